@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/zsy619/yyhertz/framework/mvc/codegen"
 )
@@ -12,7 +14,13 @@ import (
 func main() {
 	var (
 		projectRoot = flag.String("root", ".", "项目根目录")
-		genType     = flag.String("type", "all", "生成类型: all, routes, docs, client")
+		genType     = flag.String("type", "all", "生成类型: all, routes, docs, client, cert")
+		certFile    = flag.String("cert", codegen.DefaultCertFile, "证书输出路径（相对项目根目录）")
+		keyFile     = flag.String("key", codegen.DefaultKeyFile, "私钥输出路径（相对项目根目录）")
+		commonName  = flag.String("cn", "localhost", "证书CN")
+		hosts       = flag.String("hosts", "localhost,127.0.0.1,::1", "证书SAN，逗号分隔的域名或IP")
+		days        = flag.Int("days", 365, "证书有效期(天)")
+		force       = flag.Bool("force", false, "覆盖已存在的证书文件")
 		help        = flag.Bool("help", false, "显示帮助信息")
 	)
 	flag.Parse()
@@ -51,6 +59,17 @@ func main() {
 		err = generator.GenerateDocs()
 	case "client":
 		err = generator.GenerateClient()
+	case "cert":
+		certGen := codegen.NewCertGenerator(absRoot)
+		certGen.CertFile = resolvePath(absRoot, *certFile)
+		certGen.KeyFile = resolvePath(absRoot, *keyFile)
+		certGen.CommonName = *commonName
+		certGen.Hosts = strings.Split(*hosts, ",")
+		certGen.ValidFor = time.Duration(*days) * 24 * time.Hour
+		certGen.Overwrite = *force
+		if err = certGen.Generate(); err == nil {
+			fmt.Printf("证书: %s\n私钥: %s\n", certGen.CertFile, certGen.KeyFile)
+		}
 	default:
 		fmt.Printf("错误: 不支持的生成类型: %s\n", *genType)
 		showHelp()
@@ -65,6 +84,14 @@ func main() {
 	fmt.Println("代码生成成功！")
 }
 
+// resolvePath 相对路径按项目根目录解析
+func resolvePath(root, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
+
 func showHelp() {
 	fmt.Println("YYHertz 代码生成工具")
 	fmt.Println()
@@ -75,7 +102,19 @@ func showHelp() {
 	fmt.Println("  -root string")
 	fmt.Println("        项目根目录 (默认: \".\")")
 	fmt.Println("  -type string")
-	fmt.Println("        生成类型: all, routes, docs, client (默认: \"all\")")
+	fmt.Println("        生成类型: all, routes, docs, client, cert (默认: \"all\")")
+	fmt.Println("  -cert string")
+	fmt.Println("        证书输出路径，仅 cert 类型 (默认: \"certs/server.crt\")")
+	fmt.Println("  -key string")
+	fmt.Println("        私钥输出路径，仅 cert 类型 (默认: \"certs/server.key\")")
+	fmt.Println("  -cn string")
+	fmt.Println("        证书CN，仅 cert 类型 (默认: \"localhost\")")
+	fmt.Println("  -hosts string")
+	fmt.Println("        证书SAN，逗号分隔，仅 cert 类型 (默认: \"localhost,127.0.0.1,::1\")")
+	fmt.Println("  -days int")
+	fmt.Println("        证书有效期(天)，仅 cert 类型 (默认: 365)")
+	fmt.Println("  -force")
+	fmt.Println("        覆盖已存在的证书文件")
 	fmt.Println("  -help")
 	fmt.Println("        显示帮助信息")
 	fmt.Println()
@@ -84,10 +123,12 @@ func showHelp() {
 	fmt.Println("  codegen -type routes")
 	fmt.Println("  codegen -type docs")
 	fmt.Println("  codegen -type client")
+	fmt.Println("  codegen -type cert -hosts localhost,dev.local,127.0.0.1 -days 30")
 	fmt.Println()
 	fmt.Println("生成类型说明:")
 	fmt.Println("  all     - 生成所有代码（路由、文档、客户端）")
 	fmt.Println("  routes  - 仅生成路由注册代码")
 	fmt.Println("  docs    - 仅生成API文档")
 	fmt.Println("  client  - 仅生成客户端SDK代码")
+	fmt.Println("  cert    - 生成本地开发用自签名证书（路径与TLS配置默认值一致）")
 }
\ No newline at end of file
@@ -161,6 +161,18 @@ func TestBasicCRUD(t *testing.T) {
 		fmt.Printf("查询到用户: %s (ID: %d)\n", user.Name, user.ID)
 	})
 
+	t.Run("测试必需查询用户不存在", func(t *testing.T) {
+		// 必需变体返回ErrNotFound，便于统一映射为404
+		user, err := config.UserMapper.SelectByIdRequired(999999)
+		assert.ErrorIs(t, err, mybatis.ErrNotFound)
+		assert.Nil(t, user)
+
+		// 标准变体返回nil
+		user, err = config.UserMapper.SelectById(999999)
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
 	t.Run("测试根据邮箱查询用户", func(t *testing.T) {
 		// 插入测试数据
 		testUser := &User{
@@ -229,6 +241,34 @@ func TestBasicCRUD(t *testing.T) {
 		fmt.Printf("更新用户成功: %s -> %s\n", originalName, updatedUser.Name)
 	})
 
+	t.Run("测试选择性更新", func(t *testing.T) {
+		testUser := &User{Name: "选择性更新", Email: "selective@example.com", Age: 33, Status: "active", Phone: "13800000000"}
+		require.NoError(t, config.DB.Create(testUser).Error)
+
+		// 仅提供名称，其余零值字段不应被覆盖
+		affected, err := config.UserMapper.UpdateSelective(&User{ID: testUser.ID, Name: "选择性更新(已更新)"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+
+		updatedUser, err := config.UserMapper.SelectById(testUser.ID)
+		require.NoError(t, err)
+		require.NotNil(t, updatedUser)
+		assert.Equal(t, "选择性更新(已更新)", updatedUser.Name)
+		assert.Equal(t, testUser.Email, updatedUser.Email)
+		assert.Equal(t, 33, updatedUser.Age)
+		assert.Equal(t, "active", updatedUser.Status)
+		assert.Equal(t, "13800000000", updatedUser.Phone)
+
+		// 没有可更新字段时不执行更新
+		affected, err = config.UserMapper.UpdateSelective(&User{ID: testUser.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), affected)
+
+		// 缺少ID时返回错误
+		_, err = config.UserMapper.UpdateSelective(&User{Name: "无ID"})
+		assert.Error(t, err)
+	})
+
 	t.Run("测试软删除用户", func(t *testing.T) {
 		// 创建测试用户
 		testUser := &User{
@@ -300,6 +340,19 @@ func TestDynamicSQL(t *testing.T) {
 		fmt.Printf("动态条件查询到 %d 个用户\n", len(users))
 	})
 
+	t.Run("测试排序字段白名单", func(t *testing.T) {
+		// 非白名单排序字段被拒绝，不会拼接进SQL
+		_, err := config.UserMapper.SelectList(&UserQuery{OrderBy: "id; DROP TABLE users"})
+		assert.ErrorIs(t, err, mybatis.ErrInvalidOrderBy)
+		assert.True(t, config.DB.Migrator().HasTable(&User{}))
+
+		users, err := config.UserMapper.SelectList(&UserQuery{OrderBy: "created_at", OrderDesc: true})
+		require.NoError(t, err)
+		for i := 1; i < len(users); i++ {
+			assert.False(t, users[i].CreatedAt.After(users[i-1].CreatedAt))
+		}
+	})
+
 	t.Run("测试关键字搜索", func(t *testing.T) {
 		// 使用上一个测试已经插入的数据
 		query := &UserQuery{
@@ -352,6 +405,148 @@ func TestDynamicSQL(t *testing.T) {
 		fmt.Printf("分页查询结果: 总数=%d, 当前页=%d, 每页=%d, 总页数=%d\n",
 			result.Total, result.Page, result.PageSize, result.TotalPages)
 	})
+
+	t.Run("测试游标分页", func(t *testing.T) {
+		var expectedIDs []int64
+		require.NoError(t, config.DB.Model(&User{}).Where("deleted_at IS NULL").Order("id").Pluck("id", &expectedIDs).Error)
+		require.NotEmpty(t, expectedIDs)
+
+		seen := make(map[int64]bool)
+		var walkedIDs []int64
+		var cursor int64
+		inserted := 0
+		for pages := 0; pages < 100; pages++ {
+			result, err := config.UserMapper.SelectCursor(&UserQuery{PageSize: 3}, cursor)
+			require.NoError(t, err)
+
+			users, ok := result.Data.([]*User)
+			require.True(t, ok)
+			assert.LessOrEqual(t, len(users), 3)
+			for _, user := range users {
+				assert.False(t, seen[user.ID], "用户 %d 重复出现", user.ID)
+				assert.Greater(t, user.ID, cursor)
+				seen[user.ID] = true
+				walkedIDs = append(walkedIDs, user.ID)
+			}
+
+			// 遍历过程中插入新数据，不应导致跳过或重复
+			if inserted < 2 {
+				inserted++
+				newUser := &User{
+					Name:   fmt.Sprintf("游标用户%d", inserted),
+					Email:  fmt.Sprintf("cursor_%d@example.com", inserted),
+					Age:    30,
+					Status: "active",
+				}
+				require.NoError(t, config.DB.Create(newUser).Error)
+				expectedIDs = append(expectedIDs, newUser.ID)
+			}
+
+			if !result.HasNext {
+				assert.Equal(t, int64(0), result.NextCursor)
+				break
+			}
+			assert.Equal(t, users[len(users)-1].ID, result.NextCursor)
+			cursor = result.NextCursor
+		}
+
+		assert.Equal(t, expectedIDs, walkedIDs)
+
+		fmt.Printf("游标分页遍历 %d 个用户\n", len(walkedIDs))
+	})
+}
+
+// TestSoftDeleteFiltering 测试列表、计数与分页的软删除过滤一致
+func TestSoftDeleteFiltering(t *testing.T) {
+	config, err := setupTestEnvironment()
+	require.NoError(t, err)
+	defer teardownTestEnvironment(config)
+
+	for i := 1; i <= 4; i++ {
+		user := &User{
+			Name:   fmt.Sprintf("软删除用户%d", i),
+			Email:  fmt.Sprintf("soft_%d@example.com", i),
+			Age:    30,
+			Status: "active",
+		}
+		require.NoError(t, config.DB.Create(user).Error)
+	}
+
+	query := &UserQuery{Name: "软删除用户", OrderBy: "id"}
+	users, err := config.UserMapper.SelectList(query)
+	require.NoError(t, err)
+	require.Len(t, users, 4)
+
+	affected, err := config.UserMapper.Delete(users[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	t.Run("列表与计数一致", func(t *testing.T) {
+		users, err := config.UserMapper.SelectList(query)
+		require.NoError(t, err)
+		count, err := config.UserMapper.SelectCount(query)
+		require.NoError(t, err)
+
+		assert.Len(t, users, 3)
+		assert.Equal(t, int64(len(users)), count)
+
+		page, err := config.UserMapper.SelectPage(&UserQuery{Name: "软删除用户", Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), page.Total)
+		assert.Len(t, page.Data.([]*User), 3)
+	})
+
+	t.Run("显式包含已删除记录", func(t *testing.T) {
+		withDeleted := &UserQuery{Name: "软删除用户", IncludeDeleted: true}
+		users, err := config.UserMapper.SelectList(withDeleted)
+		require.NoError(t, err)
+		count, err := config.UserMapper.SelectCount(withDeleted)
+		require.NoError(t, err)
+
+		assert.Len(t, users, 4)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("恢复已删除用户", func(t *testing.T) {
+		deletedID := users[0].ID
+		deleted, err := config.UserMapper.SelectById(deletedID)
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+
+		affected, err := config.UserMapper.Restore(deletedID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+
+		restored, err := config.UserMapper.SelectById(deletedID)
+		require.NoError(t, err)
+		require.NotNil(t, restored)
+		assert.Nil(t, restored.DeletedAt)
+
+		count, err := config.UserMapper.SelectCount(query)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+
+		// 未删除的用户无需恢复
+		affected, err = config.UserMapper.Restore(deletedID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), affected)
+
+		// 重新删除，供后续用例使用
+		_, err = config.UserMapper.Delete(deletedID)
+		require.NoError(t, err)
+	})
+
+	t.Run("联表查询过滤已删除用户", func(t *testing.T) {
+		withoutProfile, err := config.UserMapper.SelectUsersWithoutProfile()
+		require.NoError(t, err)
+		ids := make(map[int64]bool)
+		for _, user := range withoutProfile {
+			assert.Nil(t, user.DeletedAt, "用户 %d 已删除", user.ID)
+			ids[user.ID] = true
+		}
+		assert.False(t, ids[users[0].ID])
+		assert.True(t, ids[users[1].ID])
+	})
 }
 
 // TestBatchOperations 测试批量操作
@@ -412,6 +607,59 @@ func TestBatchOperations(t *testing.T) {
 		fmt.Printf("批量更新 %d 个用户状态成功\n", len(ids))
 	})
 
+	t.Run("测试批量更新", func(t *testing.T) {
+		testUsers := []*User{
+			{Name: "待更新1", Email: "update1@example.com", Age: 20, Status: "active"},
+			{Name: "待更新2", Email: "update2@example.com", Age: 21, Status: "active"},
+			{Name: "待更新3", Email: "update3@example.com", Age: 22, Status: "active"},
+		}
+
+		var ids []int64
+		for _, user := range testUsers {
+			require.NoError(t, config.DB.Create(user).Error)
+			ids = append(ids, user.ID)
+		}
+
+		// 批量更新多个字段
+		affected, err := config.UserMapper.BatchUpdate(&BatchUpdateRequest{
+			UserIDs: ids,
+			Updates: map[string]any{"age": 40, "status": "inactive"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(ids)), affected)
+
+		for _, id := range ids {
+			user, err := config.UserMapper.SelectById(id)
+			require.NoError(t, err)
+			assert.Equal(t, 40, user.Age)
+			assert.Equal(t, "inactive", user.Status)
+		}
+
+		// 邮箱唯一约束导致第二条语句失败，整体回滚
+		affected, err = config.UserMapper.BatchUpdate(&BatchUpdateRequest{
+			UserIDs: ids,
+			Updates: map[string]any{"age": 50, "email": "conflict@example.com"},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), affected)
+
+		for i, id := range ids {
+			user, err := config.UserMapper.SelectById(id)
+			require.NoError(t, err)
+			assert.Equal(t, 40, user.Age)
+			assert.Equal(t, testUsers[i].Email, user.Email)
+		}
+
+		// 不允许更新的字段
+		_, err = config.UserMapper.BatchUpdate(&BatchUpdateRequest{
+			UserIDs: ids,
+			Updates: map[string]any{"id": 1},
+		})
+		assert.Error(t, err)
+
+		fmt.Printf("批量更新 %d 个用户成功\n", len(ids))
+	})
+
 	t.Run("测试批量删除", func(t *testing.T) {
 		// 先创建测试数据
 		testUsers := []*User{
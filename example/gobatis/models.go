@@ -5,6 +5,8 @@ package main
 
 import (
 	"time"
+
+	"github.com/zsy619/yyhertz/framework/mybatis"
 )
 
 // User 用户实体
@@ -179,10 +181,42 @@ type ComplexQueryResult struct {
 }
 
 // AggregationResult 聚合查询结果
+// Count/Sum/Avg/Min/Max 由数据库 GROUP BY 聚合计算，数值列以Decimal接收，避免float64舍入
 type AggregationResult struct {
-	Field string `json:"field"`
-	Value any    `json:"value"`
-	Count int64  `json:"count"`
+	Field string          `json:"field"` // 分组维度
+	Value any             `json:"value"` // 分组值
+	Count int64           `json:"count"`
+	Sum   mybatis.Decimal `json:"sum"`
+	Avg   mybatis.Decimal `json:"avg"`
+	Min   mybatis.Decimal `json:"min"`
+	Max   mybatis.Decimal `json:"max"`
+}
+
+// aggregationRow GROUP BY 聚合查询的结果行，数据库返回的数值直接扫描为Decimal
+type aggregationRow struct {
+	Value string
+	Count int64
+	Sum   mybatis.Decimal
+	Avg   mybatis.Decimal
+	Min   mybatis.Decimal
+	Max   mybatis.Decimal
+}
+
+// newAggregationResults 将聚合结果行转换为指定分组维度的AggregationResult列表
+func newAggregationResults(field string, rows []*aggregationRow) []*AggregationResult {
+	results := make([]*AggregationResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &AggregationResult{
+			Field: field,
+			Value: row.Value,
+			Count: row.Count,
+			Sum:   row.Sum,
+			Avg:   row.Avg,
+			Min:   row.Min,
+			Max:   row.Max,
+		})
+	}
+	return results
 }
 
 // PaginationResult 分页结果
@@ -194,6 +228,7 @@ type PaginationResult struct {
 	TotalPages int   `json:"total_pages"`
 	HasNext    bool  `json:"has_next"`
 	HasPrev    bool  `json:"has_prev"`
+	NextCursor int64 `json:"next_cursor,omitempty"` // 游标分页的下一页游标（最后一条记录ID），无下一页时为0
 }
 
 // TableName 指定表名
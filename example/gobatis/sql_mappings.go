@@ -230,6 +230,12 @@ const (
 		WHERE id = #{id}
 	`
 	
+	RestoreSQL = `
+		UPDATE users 
+		SET deleted_at = NULL, updated_at = NOW() 
+		WHERE id = #{id} AND deleted_at IS NOT NULL
+	`
+	
 	BatchDeleteSQL = `
 		UPDATE users 
 		SET deleted_at = NOW() 
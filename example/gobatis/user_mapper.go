@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/zsy619/yyhertz/framework/mybatis"
@@ -21,6 +23,9 @@ type UserMapper interface {
 	// @Select("SELECT * FROM users WHERE id = #{id} AND deleted_at IS NULL")
 	SelectById(id int64) (*User, error)
 	
+	// SelectByIdRequired 根据ID查询用户，不存在时返回mybatis.ErrNotFound
+	SelectByIdRequired(id int64) (*User, error)
+	
 	// SelectByEmail 根据邮箱查询用户
 	// @Select("SELECT * FROM users WHERE email = #{email} AND deleted_at IS NULL")
 	SelectByEmail(email string) (*User, error)
@@ -46,6 +51,10 @@ type UserMapper interface {
 	// @Delete("DELETE FROM users WHERE id=#{id}")
 	PhysicalDelete(id int64) (int64, error)
 	
+	// Restore 恢复软删除的用户
+	// @Update("UPDATE users SET deleted_at=NULL, updated_at=NOW() WHERE id=#{id} AND deleted_at IS NOT NULL")
+	Restore(id int64) (int64, error)
+	
 	// ========== 动态SQL查询 ==========
 	
 	// SelectList 动态条件查询用户列表
@@ -57,6 +66,10 @@ type UserMapper interface {
 	// SelectPage 分页查询用户
 	SelectPage(query *UserQuery) (*PaginationResult, error)
 	
+	// SelectCursor 游标（keyset）分页查询用户，返回ID大于afterID的下一页
+	// @Select("SELECT * FROM users WHERE id > #{afterID} ORDER BY id LIMIT #{pageSize}")
+	SelectCursor(query *UserQuery, afterID int64) (*PaginationResult, error)
+	
 	// UpdateSelective 选择性更新用户
 	UpdateSelective(user *User) (int64, error)
 	
@@ -147,35 +160,17 @@ func NewUserMapper(session interface{}) UserMapper {
 
 func (m *UserMapperImpl) SelectById(id int64) (*User, error) {
 	ctx := context.Background()
-	result, err := m.simpleSession.SelectOne(ctx, "SELECT * FROM users WHERE id = ? AND deleted_at IS NULL", id)
-	if err != nil {
-		return nil, err
-	}
-	
-	if result == nil {
-		return nil, nil
-	}
-	
-	if resultMap, ok := result.(map[string]interface{}); ok {
-		return mapToUser(resultMap), nil
-	}
-	
-	return nil, nil
+	return mybatis.ScanOne[User](ctx, m.simpleSession, "SELECT * FROM users WHERE id = ? AND deleted_at IS NULL", id)
+}
+
+func (m *UserMapperImpl) SelectByIdRequired(id int64) (*User, error) {
+	ctx := context.Background()
+	return mybatis.ScanOneRequired[User](ctx, m.simpleSession, "SELECT * FROM users WHERE id = ? AND deleted_at IS NULL", id)
 }
 
 func (m *UserMapperImpl) SelectByEmail(email string) (*User, error) {
 	ctx := context.Background()
-	result, err := m.simpleSession.SelectOne(ctx, "SELECT * FROM users WHERE email = ? AND deleted_at IS NULL", email)
-	if err != nil {
-		return nil, err
-	}
-	if result == nil {
-		return nil, nil
-	}
-	if resultMap, ok := result.(map[string]interface{}); ok {
-		return mapToUser(resultMap), nil
-	}
-	return nil, nil
+	return mybatis.ScanOne[User](ctx, m.simpleSession, "SELECT * FROM users WHERE email = ? AND deleted_at IS NULL", email)
 }
 
 func (m *UserMapperImpl) SelectByIds(ids []int64) ([]*User, error) {
@@ -217,43 +212,98 @@ func (m *UserMapperImpl) PhysicalDelete(id int64) (int64, error) {
 	return m.simpleSession.Delete(ctx, "DELETE FROM users WHERE id = ?", id)
 }
 
+func (m *UserMapperImpl) Restore(id int64) (int64, error) {
+	ctx := context.Background()
+	return m.simpleSession.Update(ctx, "UPDATE users SET deleted_at=NULL, updated_at=datetime('now') WHERE id=? AND deleted_at IS NOT NULL", id)
+}
+
 // ========== 动态SQL查询实现 ==========
 
+// userOrderByColumns 用户列表允许的排序字段
+var userOrderByColumns = mybatis.OrderByWhitelist{
+	"id":         "id",
+	"name":       "name",
+	"email":      "email",
+	"age":        "age",
+	"status":     "status",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// userFilter 用户查询条件构建器
+// 列表、计数、游标与联表查询共用，默认追加软删除条件，IncludeDeleted 为true时包含已删除记录
+type userFilter struct {
+	alias      string // 表别名，联表查询时用于限定列名
+	conditions []string
+	args       []interface{}
+}
+
+// newUserFilter 根据查询参数创建条件构建器，alias 为users表在SQL中的别名（无别名时为空）
+func newUserFilter(query *UserQuery, alias string) *userFilter {
+	f := &userFilter{alias: alias}
+	if query == nil || !query.IncludeDeleted {
+		f.where(f.column("deleted_at") + " IS NULL")
+	}
+	if query == nil {
+		return f
+	}
+	
+	if query.Name != "" {
+		f.where(f.column("name")+" LIKE ?", "%"+query.Name+"%")
+	}
+	if query.Status != "" {
+		f.where(f.column("status")+" = ?", query.Status)
+	}
+	if query.AgeMin > 0 {
+		f.where(f.column("age")+" >= ?", query.AgeMin)
+	}
+	if query.AgeMax > 0 {
+		f.where(f.column("age")+" <= ?", query.AgeMax)
+	}
+	if query.Keyword != "" {
+		f.where("("+f.column("name")+" LIKE ? OR "+f.column("email")+" LIKE ?)", "%"+query.Keyword+"%", "%"+query.Keyword+"%")
+	}
+	return f
+}
+
+// column 返回带表别名的列名
+func (f *userFilter) column(name string) string {
+	if f.alias == "" {
+		return name
+	}
+	return f.alias + "." + name
+}
+
+// where 追加条件
+func (f *userFilter) where(condition string, args ...interface{}) *userFilter {
+	f.conditions = append(f.conditions, condition)
+	f.args = append(f.args, args...)
+	return f
+}
+
+// build 返回WHERE子句（无条件时为空）与参数
+func (f *userFilter) build() (string, []interface{}) {
+	if len(f.conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(f.conditions, " AND "), append([]interface{}{}, f.args...)
+}
+
 func (m *UserMapperImpl) SelectList(query *UserQuery) ([]*User, error) {
 	ctx := context.Background()
 	
 	// 构建动态SQL
-	sql := "SELECT * FROM users WHERE 1=1"
-	var args []interface{}
+	where, args := newUserFilter(query, "").build()
+	sql := "SELECT * FROM users" + where
 	
 	if query != nil {
-		if query.Name != "" {
-			sql += " AND name LIKE ?"
-			args = append(args, "%"+query.Name+"%")
-		}
-		if query.Status != "" {
-			sql += " AND status = ?"
-			args = append(args, query.Status)
-		}
-		if query.AgeMin > 0 {
-			sql += " AND age >= ?"
-			args = append(args, query.AgeMin)
-		}
-		if query.AgeMax > 0 {
-			sql += " AND age <= ?"
-			args = append(args, query.AgeMax)
+		// 排序，只允许白名单中的字段
+		orderBy, err := userOrderByColumns.Resolve(query.OrderBy, query.OrderDesc)
+		if err != nil {
+			return nil, err
 		}
-		if query.Keyword != "" {
-			sql += " AND (name LIKE ? OR email LIKE ?)"
-			args = append(args, "%"+query.Keyword+"%", "%"+query.Keyword+"%")
-		}
-		
-		// 排序
-		if query.OrderBy != "" {
-			sql += " ORDER BY " + query.OrderBy
-			if query.OrderDesc {
-				sql += " DESC"
-			}
+		if orderBy != "" {
+			sql += " " + orderBy
 		}
 		
 		// 分页
@@ -267,49 +317,15 @@ func (m *UserMapperImpl) SelectList(query *UserQuery) ([]*User, error) {
 		}
 	}
 	
-	results, err := m.simpleSession.SelectList(ctx, sql, args...)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
+	return mybatis.ScanList[User](ctx, m.simpleSession, sql, args...)
 }
 
 func (m *UserMapperImpl) SelectCount(query *UserQuery) (int64, error) {
 	ctx := context.Background()
 	
-	// 构建动态计数SQL
-	sql := "SELECT COUNT(*) as count FROM users WHERE deleted_at IS NULL"
-	var args []interface{}
-	
-	if query != nil {
-		if query.Name != "" {
-			sql += " AND name LIKE ?"
-			args = append(args, "%"+query.Name+"%")
-		}
-		if query.Status != "" {
-			sql += " AND status = ?"
-			args = append(args, query.Status)
-		}
-		if query.AgeMin > 0 {
-			sql += " AND age >= ?"
-			args = append(args, query.AgeMin)
-		}
-		if query.AgeMax > 0 {
-			sql += " AND age <= ?"
-			args = append(args, query.AgeMax)
-		}
-		if query.Keyword != "" {
-			sql += " AND (name LIKE ? OR email LIKE ?)"
-			args = append(args, "%"+query.Keyword+"%", "%"+query.Keyword+"%")
-		}
-	}
+	// 构建动态计数SQL，与SelectList使用相同的过滤条件
+	where, args := newUserFilter(query, "").build()
+	sql := "SELECT COUNT(*) as count FROM users" + where
 	
 	result, err := m.simpleSession.SelectOne(ctx, sql, args...)
 	if err != nil {
@@ -373,35 +389,133 @@ func (m *UserMapperImpl) SelectPage(query *UserQuery) (*PaginationResult, error)
 	}, nil
 }
 
+func (m *UserMapperImpl) SelectCursor(query *UserQuery, afterID int64) (*PaginationResult, error) {
+	ctx := context.Background()
+	
+	pageSize := 10
+	if query != nil && query.PageSize > 0 {
+		pageSize = query.PageSize
+	}
+	
+	// 基于ID的keyset分页，不受OFFSET扫描与中途插入数据的影响
+	where, args := newUserFilter(query, "").where("id > ?", afterID).build()
+	sql := "SELECT * FROM users" + where
+	
+	// 多取一条用于判断是否还有下一页
+	sql += " ORDER BY id LIMIT ?"
+	args = append(args, pageSize+1)
+	
+	users, err := mybatis.ScanList[User](ctx, m.simpleSession, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	
+	hasNext := len(users) > pageSize
+	if hasNext {
+		users = users[:pageSize]
+	}
+	
+	var nextCursor int64
+	if hasNext {
+		nextCursor = users[len(users)-1].ID
+	}
+	
+	return &PaginationResult{
+		Data:       users,
+		PageSize:   pageSize,
+		HasNext:    hasNext,
+		HasPrev:    afterID > 0,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// selectiveExcludedColumns 选择性更新时不允许由调用方修改的字段
+var selectiveExcludedColumns = []string{"id", "created_at", "updated_at", "deleted_at"}
+
 func (m *UserMapperImpl) UpdateSelective(user *User) (int64, error) {
-	// 简化实现，直接调用Update
-	return m.Update(user)
+	if user == nil || user.ID == 0 {
+		return 0, fmt.Errorf("user id is required for selective update")
+	}
+	
+	// 只更新非零字段，零值字段保持数据库中的原值
+	columns, values, err := mybatis.SelectiveColumns(user, selectiveExcludedColumns...)
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+	
+	setClauses := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		setClauses = append(setClauses, column+" = ?")
+	}
+	setClauses = append(setClauses, "updated_at = datetime('now')")
+	sql := "UPDATE users SET " + strings.Join(setClauses, ", ") + " WHERE id = ? AND deleted_at IS NULL"
+	
+	ctx := context.Background()
+	return m.simpleSession.Update(ctx, sql, append(values, user.ID)...)
 }
 
 // ========== 批量操作实现 ==========
 
 func (m *UserMapperImpl) BatchInsert(users []*User) (int64, error) {
 	ctx := context.Background()
-	var affected int64
+	now := time.Now()
 	
+	// 单条多行INSERT代替逐行插入，超过批大小时拆分为多条语句并在同一事务中执行
+	rows := make([][]interface{}, 0, len(users))
 	for _, user := range users {
-		id, err := m.simpleSession.Insert(ctx, 
-			"INSERT INTO users (name, email, age, status, phone, birthday, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'))",
-			user.Name, user.Email, user.Age, user.Status, user.Phone, user.Birthday)
-		if err != nil {
-			return affected, err
-		}
-		if id > 0 {
-			affected++
-		}
+		rows = append(rows, []interface{}{user.Name, user.Email, user.Age, user.Status, user.Phone, user.Birthday, now, now})
 	}
 	
-	return affected, nil
+	return m.simpleSession.BatchInsertRows(ctx, "users",
+		[]string{"name", "email", "age", "status", "phone", "birthday", "created_at", "updated_at"}, rows)
+}
+
+// batchUpdatableColumns 允许批量更新的用户字段
+var batchUpdatableColumns = map[string]bool{
+	"name":     true,
+	"email":    true,
+	"age":      true,
+	"status":   true,
+	"avatar":   true,
+	"phone":    true,
+	"birthday": true,
 }
 
 func (m *UserMapperImpl) BatchUpdate(request *BatchUpdateRequest) (int64, error) {
-	// 简化实现，返回错误表示不支持
-	return 0, fmt.Errorf("BatchUpdate not supported in simplified implementation")
+	if request == nil || len(request.UserIDs) == 0 || len(request.Updates) == 0 {
+		return 0, nil
+	}
+	
+	// 构建SET子句，字段按名称排序保证SQL稳定
+	columns := make([]string, 0, len(request.Updates))
+	for column := range request.Updates {
+		if !batchUpdatableColumns[column] {
+			return 0, fmt.Errorf("column %q is not allowed in batch update", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	
+	setClauses := make([]string, 0, len(columns)+1)
+	values := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		setClauses = append(setClauses, column+" = ?")
+		values = append(values, request.Updates[column])
+	}
+	setClauses = append(setClauses, "updated_at = datetime('now')")
+	sql := "UPDATE users SET " + strings.Join(setClauses, ", ") + " WHERE id = ? AND deleted_at IS NULL"
+	
+	// 每个用户一条语句，在同一事务中执行
+	statements := make([]mybatis.BatchStatement, 0, len(request.UserIDs))
+	for _, id := range request.UserIDs {
+		args := append(append([]interface{}{}, values...), id)
+		statements = append(statements, mybatis.BatchStatement{SQL: sql, Args: args})
+	}
+	
+	return m.simpleSession.BatchExec(context.Background(), statements)
 }
 
 func (m *UserMapperImpl) BatchDelete(ids []int64) (int64, error) {
@@ -472,8 +586,9 @@ func (m *UserMapperImpl) SelectStats() (*UserStats, error) {
 func (m *UserMapperImpl) SelectByStatus() ([]*AggregationResult, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, `
-		SELECT status as value, COUNT(*) as count 
+	rows, err := mybatis.ScanList[aggregationRow](ctx, m.simpleSession, `
+		SELECT status as value, COUNT(*) as count,
+			SUM(age) as sum, AVG(age) as avg, MIN(age) as min, MAX(age) as max
 		FROM users 
 		WHERE deleted_at IS NULL 
 		GROUP BY status
@@ -481,27 +596,13 @@ func (m *UserMapperImpl) SelectByStatus() ([]*AggregationResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	
-	aggregations := make([]*AggregationResult, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			agg := &AggregationResult{}
-			if value, ok := resultMap["value"]; ok {
-				agg.Value = value
-			}
-			if count, ok := resultMap["count"].(int64); ok {
-				agg.Count = count
-			}
-			aggregations = append(aggregations, agg)
-		}
-	}
-	return aggregations, nil
+	return newAggregationResults("status", rows), nil
 }
 
 func (m *UserMapperImpl) SelectByAgeGroup() ([]*AggregationResult, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, `
+	rows, err := mybatis.ScanList[aggregationRow](ctx, m.simpleSession, `
 		SELECT 
 			CASE 
 				WHEN age < 25 THEN '18-24'
@@ -510,7 +611,8 @@ func (m *UserMapperImpl) SelectByAgeGroup() ([]*AggregationResult, error) {
 				WHEN age < 55 THEN '45-54'
 				ELSE '55+'
 			END as value,
-			COUNT(*) as count
+			COUNT(*) as count,
+			SUM(age) as sum, AVG(age) as avg, MIN(age) as min, MAX(age) as max
 		FROM users 
 		WHERE deleted_at IS NULL 
 		GROUP BY 
@@ -525,40 +627,15 @@ func (m *UserMapperImpl) SelectByAgeGroup() ([]*AggregationResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	
-	aggregations := make([]*AggregationResult, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			agg := &AggregationResult{}
-			if value, ok := resultMap["value"]; ok {
-				agg.Value = value
-			}
-			if count, ok := resultMap["count"].(int64); ok {
-				agg.Count = count
-			}
-			aggregations = append(aggregations, agg)
-		}
-	}
-	return aggregations, nil
+	return newAggregationResults("age_group", rows), nil
 }
 
 func (m *UserMapperImpl) SelectActiveUsersInPeriod(startTime, endTime time.Time) ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE status = 'active' AND created_at BETWEEN ? AND ? AND deleted_at IS NULL",
 		startTime, endTime)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 // ========== 复杂查询实现 ==========
@@ -603,20 +680,9 @@ func (m *UserMapperImpl) SelectWithArticles(userId int64, limit int) (*ComplexQu
 func (m *UserMapperImpl) SearchUsers(keyword string, limit int) ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE (name LIKE ? OR email LIKE ?) AND deleted_at IS NULL LIMIT ?",
 		"%"+keyword+"%", "%"+keyword+"%", limit)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 func (m *UserMapperImpl) SelectSimilarUsers(userId int64, limit int) ([]*User, error) {
@@ -628,20 +694,9 @@ func (m *UserMapperImpl) SelectSimilarUsers(userId int64, limit int) ([]*User, e
 		return nil, err
 	}
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE id != ? AND status = ? AND ABS(age - ?) <= 5 AND deleted_at IS NULL LIMIT ?",
 		userId, baseUser.Status, baseUser.Age, limit)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 // ========== 特殊查询实现 ==========
@@ -649,79 +704,33 @@ func (m *UserMapperImpl) SelectSimilarUsers(userId int64, limit int) ([]*User, e
 func (m *UserMapperImpl) SelectRandomUsers(limit int) ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE deleted_at IS NULL ORDER BY RANDOM() LIMIT ?", 
 		limit)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 func (m *UserMapperImpl) SelectTopActiveUsers(limit int) ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE status = 'active' AND deleted_at IS NULL ORDER BY updated_at DESC LIMIT ?", 
 		limit)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 func (m *UserMapperImpl) SelectUsersWithoutProfile() ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, `
-		SELECT u.* FROM users u 
-		LEFT JOIN user_profiles p ON u.id = p.user_id 
-		WHERE p.user_id IS NULL AND u.deleted_at IS NULL
-	`)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
+	where, args := newUserFilter(nil, "u").where("p.user_id IS NULL").build()
+	return mybatis.ScanList[User](ctx, m.simpleSession,
+		"SELECT u.* FROM users u LEFT JOIN user_profiles p ON u.id = p.user_id"+where, args...)
 }
 
 func (m *UserMapperImpl) SelectRecentRegistrations(days int, limit int) ([]*User, error) {
 	ctx := context.Background()
 	
-	results, err := m.simpleSession.SelectList(ctx, 
+	return mybatis.ScanList[User](ctx, m.simpleSession, 
 		"SELECT * FROM users WHERE created_at >= datetime('now', '-' || ? || ' days') AND deleted_at IS NULL ORDER BY created_at DESC LIMIT ?",
 		days, limit)
-	if err != nil {
-		return nil, err
-	}
-	
-	users := make([]*User, 0)
-	for _, result := range results {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			users = append(users, mapToUser(resultMap))
-		}
-	}
-	return users, nil
 }
 
 // ========== 存储过程和函数实现 ==========
@@ -746,65 +755,3 @@ func RegisterUserMapper(session interface{}) (UserMapper, error) {
 func GetUserMapperType() reflect.Type {
 	return reflect.TypeOf((*UserMapper)(nil)).Elem()
 }
-
-// mapToUser 将map结果转换为User结构体
-func mapToUser(m map[string]interface{}) *User {
-	user := &User{}
-	
-	if id, ok := m["id"]; ok {
-		// SQLite可能返回int64或其他整数类型
-		switch v := id.(type) {
-		case int64:
-			user.ID = v
-		case int:
-			user.ID = int64(v)
-		}
-	}
-	
-	if name, ok := m["name"]; ok {
-		if nameStr, ok := name.(string); ok {
-			user.Name = nameStr
-		}
-	}
-	
-	if email, ok := m["email"]; ok {
-		if emailStr, ok := email.(string); ok {
-			user.Email = emailStr
-		}
-	}
-	
-	if age, ok := m["age"]; ok {
-		switch v := age.(type) {
-		case int:
-			user.Age = v
-		case int64:
-			user.Age = int(v)
-		}
-	}
-	
-	if status, ok := m["status"]; ok {
-		if statusStr, ok := status.(string); ok {
-			user.Status = statusStr
-		}
-	}
-	
-	if phone, ok := m["phone"]; ok {
-		if phoneStr, ok := phone.(string); ok {
-			user.Phone = phoneStr
-		}
-	}
-	
-	if createdAt, ok := m["created_at"]; ok {
-		if createdAtTime, ok := createdAt.(time.Time); ok {
-			user.CreatedAt = createdAtTime
-		}
-	}
-	
-	if updatedAt, ok := m["updated_at"]; ok {
-		if updatedAtTime, ok := updatedAt.(time.Time); ok {
-			user.UpdatedAt = updatedAtTime
-		}
-	}
-	
-	return user
-}
\ No newline at end of file
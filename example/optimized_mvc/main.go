@@ -10,6 +10,7 @@ import (
 	"github.com/zsy619/yyhertz/framework/mvc/controller"
 	"github.com/zsy619/yyhertz/framework/mvc/core"
 	"github.com/zsy619/yyhertz/framework/mvc/middleware"
+	"github.com/zsy619/yyhertz/framework/mvc/mvctest"
 )
 
 // 用户控制器示例 - 统一使用BaseController + 启用优化特性
@@ -373,31 +374,33 @@ func main() {
 	testRequests := []struct {
 		controller string
 		method     string
+		httpMethod string
+		path       string
 		desc       string
 	}{
-		{"OptimizedUserController", "GetIndex", "获取用户列表"},
-		{"OptimizedUserController", "GetShow", "获取单个用户"},
-		{"OptimizedUserController", "PostCreate", "创建用户"},
-		{"OptimizedProductController", "GetIndex", "获取产品列表"},
-		{"OptimizedProductController", "PostCreate", "创建产品"},
+		{"OptimizedUserController", "GetIndex", "GET", "/users", "获取用户列表"},
+		{"OptimizedUserController", "GetShow", "GET", "/users/1", "获取单个用户"},
+		{"OptimizedUserController", "PostCreate", "POST", "/users", "创建用户"},
+		{"OptimizedProductController", "GetIndex", "GET", "/products", "获取产品列表"},
+		{"OptimizedProductController", "PostCreate", "POST", "/products", "创建产品"},
 	}
 
 	// 执行测试请求
 	for i, req := range testRequests {
 		fmt.Printf("\n%d. %s\n", i+1, req.desc)
 
-		ctx := &context.Context{
-			Keys: make(map[string]interface{}),
-		}
+		// 使用mvctest构建完整初始化的上下文，并记录响应
+		ctx, rec := mvctest.NewContext(mvctest.NewRequest(req.httpMethod, req.path, nil))
 
 		start := time.Now()
 		err := manager.HandleRequest(ctx, req.controller, req.method)
 		duration := time.Since(start)
+		ctx.Release()
 
 		if err != nil {
 			fmt.Printf("   ❌ 请求失败: %v (耗时: %v)\n", err, duration)
 		} else {
-			fmt.Printf("   ✅ 请求成功 (耗时: %v)\n", duration)
+			fmt.Printf("   ✅ 请求成功: %d %s (耗时: %v)\n", rec.Code(), rec.BodyString(), duration)
 		}
 	}
 
@@ -35,6 +35,10 @@ func main() {
 	// app.AddStaticPath("/cdn", "./cdn")
 	// app.AddStaticPath("/images", "./storage/images")
 
+	// 单页应用：未匹配的非 /api 路径返回入口文件；目录列表默认关闭
+	// app.SetSPAFallback("./dist/index.html")
+	// app.SetDirectoryListing(true)
+
 	// 设置视图模板路径
 	app.SetViewPath("./views")
 
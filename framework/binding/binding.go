@@ -3,6 +3,7 @@
 package binding
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/go-playground/validator/v10"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
 )
 
@@ -58,6 +61,11 @@ func Default(method, contentType string) Binding {
 		return Form
 	}
 
+	// 去掉 charset、boundary 等参数
+	if idx := strings.IndexByte(contentType, ';'); idx >= 0 {
+		contentType = strings.TrimSpace(contentType[:idx])
+	}
+
 	switch contentType {
 	case "application/json":
 		return JSON
@@ -65,7 +73,7 @@ func Default(method, contentType string) Binding {
 		return XML
 	case "application/x-protobuf":
 		return ProtoBuf
-	case "application/x-msgpack":
+	case "application/x-msgpack", "application/msgpack":
 		return MsgPack
 	case "application/x-yaml", "text/yaml":
 		return YAML
@@ -127,7 +135,7 @@ func decodeJSON(body []byte, obj any) error {
 	if err := json.Unmarshal(body, obj); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // XML绑定器
@@ -149,7 +157,7 @@ func decodeXML(body []byte, obj any) error {
 	if err := xml.Unmarshal(body, obj); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // Form绑定器
@@ -163,7 +171,7 @@ func (formBinding) Bind(req *app.RequestContext, obj any) error {
 	if err := req.Bind(obj); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // Query绑定器
@@ -181,7 +189,7 @@ func (queryBinding) Bind(req *app.RequestContext, obj any) error {
 	if err := mapForm(obj, values); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // FormPost绑定器
@@ -195,7 +203,7 @@ func (formPostBinding) Bind(req *app.RequestContext, obj any) error {
 	if err := req.Bind(obj); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // FormMultipart绑定器
@@ -206,10 +214,14 @@ func (formMultipartBinding) Name() string {
 }
 
 func (formMultipartBinding) Bind(req *app.RequestContext, obj any) error {
-	if err := req.Bind(obj); err != nil {
+	form, err := req.MultipartForm()
+	if err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	if err := mapMultipart(obj, form); err != nil {
+		return err
+	}
+	return validate(obj)
 }
 
 // ProtoBuf绑定器
@@ -220,13 +232,23 @@ func (protobufBinding) Name() string {
 }
 
 func (protobufBinding) Bind(req *app.RequestContext, obj any) error {
-	// TODO: 实现protobuf绑定
-	return fmt.Errorf("protobuf binding not implemented")
+	return decodeProtoBuf(req.Request.Body(), obj)
 }
 
 func (protobufBinding) BindBody(body []byte, obj any) error {
-	// TODO: 实现protobuf绑定
-	return fmt.Errorf("protobuf binding not implemented")
+	return decodeProtoBuf(body, obj)
+}
+
+// decodeProtoBuf 解码protobuf请求体，obj 必须实现 proto.Message
+func decodeProtoBuf(body []byte, obj any) error {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf binding: %T does not implement proto.Message", obj)
+	}
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return err
+	}
+	return validate(obj)
 }
 
 // MsgPack绑定器
@@ -237,13 +259,21 @@ func (msgpackBinding) Name() string {
 }
 
 func (msgpackBinding) Bind(req *app.RequestContext, obj any) error {
-	// TODO: 实现msgpack绑定
-	return fmt.Errorf("msgpack binding not implemented")
+	return decodeMsgPack(req.Request.Body(), obj)
 }
 
 func (msgpackBinding) BindBody(body []byte, obj any) error {
-	// TODO: 实现msgpack绑定
-	return fmt.Errorf("msgpack binding not implemented")
+	return decodeMsgPack(body, obj)
+}
+
+// decodeMsgPack 解码MessagePack请求体，字段名取自 msgpack 标签，未设置时使用 json 标签
+func decodeMsgPack(body []byte, obj any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(body))
+	dec.SetCustomStructTag("json")
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	return validate(obj)
 }
 
 // YAML绑定器
@@ -265,7 +295,7 @@ func decodeYAML(body []byte, obj any) error {
 	if err := yaml.Unmarshal(body, obj); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // URI绑定器
@@ -279,7 +309,7 @@ func (uriBinding) BindUri(m map[string][]string, obj any) error {
 	if err := mapUri(obj, m); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // Header绑定器
@@ -293,11 +323,19 @@ func (headerBinding) Bind(req *app.RequestContext, obj any) error {
 	if err := mapHeader(obj, req); err != nil {
 		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return validate(obj)
 }
 
 // 辅助函数
 
+// validate 填充默认值并校验 oneof 后再执行结构体验证
+func validate(obj any) error {
+	if err := applyTagRules(obj); err != nil {
+		return err
+	}
+	return Validator.ValidateStruct(obj)
+}
+
 // mapForm 将表单数据映射到结构体
 func mapForm(ptr any, form url.Values) error {
 	return mapFormByTag(ptr, form, "form")
@@ -335,6 +373,10 @@ func (f formSource) TrySet(value reflect.Value, field reflect.StructField, tagVa
 
 // setByForm 通过表单设置值
 func setByForm(value reflect.Value, field reflect.StructField, form map[string][]string, tagValue string, opt setOptions) (isSetted bool, err error) {
+	if value.Kind() == reflect.Map {
+		return setFormMap(value, field, form, tagValue)
+	}
+
 	vs, ok := form[tagValue]
 	if !ok && !opt.isDefaultExists {
 		return false, nil
@@ -367,6 +409,48 @@ func setByForm(value reflect.Value, field reflect.StructField, form map[string][
 	}
 }
 
+// setFormMap 将 key[name]=value 形式的参数设置到map字段，值按map元素类型转换
+func setFormMap(value reflect.Value, field reflect.StructField, form map[string][]string, key string) (bool, error) {
+	mapType := value.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return false, fmt.Errorf("unsupported map key type %s", mapType.Key())
+	}
+
+	prefix := key + "["
+	var m reflect.Value
+	for k, vs := range form {
+		if len(vs) == 0 || !strings.HasPrefix(k, prefix) || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		name := k[len(prefix) : len(k)-1]
+		if name == "" || strings.ContainsAny(name, "[]") {
+			continue
+		}
+
+		elem := reflect.New(mapType.Elem()).Elem()
+		var err error
+		if elem.Kind() == reflect.Slice {
+			err = setSlice(vs, elem, field)
+		} else {
+			err = setWithProperType(vs[0], elem, field)
+		}
+		if err != nil {
+			return false, fmt.Errorf("key %q: %w", name, err)
+		}
+
+		if !m.IsValid() {
+			m = reflect.MakeMap(mapType)
+		}
+		m.SetMapIndex(reflect.ValueOf(name).Convert(mapType.Key()), elem)
+	}
+
+	if !m.IsValid() {
+		return false, nil
+	}
+	value.Set(m)
+	return true, nil
+}
+
 // setOptions 设置选项
 type setOptions struct {
 	isDefaultExists bool
@@ -551,7 +635,11 @@ func mapFormByTag2(ptr any, mapper mappingByPtr, tag string) error {
 }
 
 func tryToSetValue(value reflect.Value, field reflect.StructField, mapper mappingByPtr, inputFieldName string, opt setOptions) (bool, error) {
-	return mapper.TrySet(value, field, inputFieldName, opt)
+	ok, err := mapper.TrySet(value, field, inputFieldName, opt)
+	if err != nil {
+		return ok, fmt.Errorf("field '%s': %w", field.Name, err)
+	}
+	return ok, nil
 }
 
 func head(str, sep string) (head string, tail []string) {
@@ -0,0 +1,136 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OneOfError 字段值不在 validate:"oneof=..." 允许的取值范围内
+type OneOfError struct {
+	Field   string   // 字段名
+	Value   string   // 实际值
+	Allowed []string // 允许的取值
+}
+
+// Error 实现error接口
+func (e *OneOfError) Error() string {
+	return fmt.Sprintf("field '%s' must be one of [%s], got %q", e.Field, strings.Join(e.Allowed, " "), e.Value)
+}
+
+// applyTagRules 按 default 标签填充零值字段，再按 validate 标签中的 oneof 规则校验取值
+//
+// 可选字段同时声明 default 与 oneof 时（如 `validate:"oneof=admin user guest" default:"user"`），
+// 未传值的字段先取默认值再校验；未声明 default 的零值字段仅在包含 required 规则时校验 oneof
+func applyTagRules(obj any) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return applyStructRules(v)
+}
+
+// applyStructRules 递归处理结构体字段
+func applyStructRules(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if !value.CanSet() {
+			continue
+		}
+
+		if def, ok := field.Tag.Lookup("default"); ok && value.IsZero() {
+			if err := setDefault(value, field, def); err != nil {
+				return fmt.Errorf("field '%s': invalid default %q: %w", field.Name, def, err)
+			}
+		}
+
+		if err := checkOneOf(value, field); err != nil {
+			return err
+		}
+
+		// 嵌套结构体
+		switch {
+		case value.Kind() == reflect.Struct && value.Type() != reflect.TypeOf(time.Time{}):
+			if err := applyStructRules(value); err != nil {
+				return err
+			}
+		case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct:
+			if err := applyStructRules(value.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDefault 设置默认值，切片以逗号分隔
+func setDefault(value reflect.Value, field reflect.StructField, def string) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		if err := setDefault(elem.Elem(), field, def); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	case reflect.Slice:
+		return setSlice(strings.Split(def, ","), value, field)
+	default:
+		return setWithProperType(def, value, field)
+	}
+}
+
+// checkOneOf 校验 oneof 规则（仅支持字符串与整数字段，取值以空格分隔）
+func checkOneOf(value reflect.Value, field reflect.StructField) error {
+	rules := strings.Split(field.Tag.Get("validate"), ",")
+
+	var allowed []string
+	required := false
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "required" {
+			required = true
+		}
+		if params, ok := strings.CutPrefix(rule, "oneof="); ok {
+			allowed = strings.Fields(params)
+		}
+	}
+	if allowed == nil {
+		return nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.IsZero() && !required {
+		return nil
+	}
+
+	var actual string
+	switch value.Kind() {
+	case reflect.String:
+		actual = value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = fmt.Sprint(value.Interface())
+	default:
+		return nil
+	}
+
+	for _, candidate := range allowed {
+		if candidate == actual {
+			return nil
+		}
+	}
+	return &OneOfError{Field: field.Name, Value: actual, Allowed: allowed}
+}
@@ -0,0 +1,87 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+)
+
+// createUserRequest 同时声明默认值与取值范围的请求
+type createUserRequest struct {
+	Name    string   `json:"name"`
+	Role    string   `json:"role" validate:"oneof=admin user guest" default:"user"`
+	Level   int      `json:"level" validate:"oneof=1 2 3"`
+	Status  string   `json:"status" validate:"required,oneof=active inactive"`
+	Page    *int     `json:"page" default:"1"`
+	Tags    []string `json:"tags" default:"new,unverified"`
+	Profile struct {
+		Locale string `json:"locale" default:"zh-CN"`
+	} `json:"profile"`
+}
+
+func TestBindBody_AppliesDefaults(t *testing.T) {
+	var req createUserRequest
+	if err := JSON.BindBody([]byte(`{"name":"alice","status":"active"}`), &req); err != nil {
+		t.Fatalf("Expected request to bind, got %v", err)
+	}
+
+	if req.Role != "user" {
+		t.Errorf("Expected default role user, got %q", req.Role)
+	}
+	if req.Page == nil || *req.Page != 1 {
+		t.Errorf("Expected default page 1, got %v", req.Page)
+	}
+	if len(req.Tags) != 2 || req.Tags[0] != "new" || req.Tags[1] != "unverified" {
+		t.Errorf("Expected default tags, got %v", req.Tags)
+	}
+	if req.Profile.Locale != "zh-CN" {
+		t.Errorf("Expected nested default locale, got %q", req.Profile.Locale)
+	}
+	if req.Level != 0 {
+		t.Errorf("Expected optional level without default to stay zero, got %d", req.Level)
+	}
+
+	// 显式传入的值不被默认值覆盖
+	req = createUserRequest{}
+	if err := JSON.BindBody([]byte(`{"role":"admin","status":"inactive","level":2}`), &req); err != nil {
+		t.Fatalf("Expected request to bind, got %v", err)
+	}
+	if req.Role != "admin" || req.Level != 2 {
+		t.Errorf("Expected explicit values to be kept, got role=%q level=%d", req.Role, req.Level)
+	}
+}
+
+func TestBindBody_RejectsValuesOutsideOneOf(t *testing.T) {
+	tests := []struct {
+		name, body, field, value string
+	}{
+		{"explicit role", `{"role":"root","status":"active"}`, "Role", "root"},
+		{"integer", `{"level":5,"status":"active"}`, "Level", "5"},
+		{"required without value", `{"role":"guest"}`, "Status", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req createUserRequest
+			err := JSON.BindBody([]byte(tt.body), &req)
+
+			var oneOfErr *OneOfError
+			if !errors.As(err, &oneOfErr) {
+				t.Fatalf("Expected OneOfError, got %v", err)
+			}
+			if oneOfErr.Field != tt.field || oneOfErr.Value != tt.value {
+				t.Errorf("Expected %s=%q to be rejected, got %s=%q", tt.field, tt.value, oneOfErr.Field, oneOfErr.Value)
+			}
+		})
+	}
+}
+
+func TestApplyTagRules_DefaultOutsideOneOf(t *testing.T) {
+	// 默认值本身不在取值范围内时同样被拒绝
+	var req struct {
+		Role string `validate:"oneof=admin user" default:"guest"`
+	}
+	var oneOfErr *OneOfError
+	if err := applyTagRules(&req); !errors.As(err, &oneOfErr) {
+		t.Errorf("Expected misconfigured default to be rejected, got %v", err)
+	}
+}
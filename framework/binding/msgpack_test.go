@@ -0,0 +1,67 @@
+package binding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/zsy619/yyhertz/framework/render"
+)
+
+type msgpackOrderRequest struct {
+	ID     int64    `msgpack:"id" validate:"required"`
+	Status string   `msgpack:"status" validate:"oneof=paid shipped" default:"paid"`
+	Items  []string `msgpack:"items"`
+}
+
+func TestMsgPack_RoundTripThroughRenderAndBind(t *testing.T) {
+	order := msgpackOrderRequest{ID: 42, Status: "shipped", Items: []string{"book", "pen"}}
+
+	rendered := ut.CreateUtRequestContext("GET", "/orders/42", nil)
+	if err := render.WriteMsgPack(rendered, order); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	body := append([]byte(nil), rendered.Response.Body()...)
+
+	for _, contentType := range []string{"application/x-msgpack", "application/msgpack; charset=utf-8"} {
+		req := ut.CreateUtRequestContext("POST", "/orders", &ut.Body{Body: bytes.NewReader(body), Len: len(body)},
+			ut.Header{Key: "Content-Type", Value: contentType})
+		b := Default("POST", contentType)
+		if b.Name() != "msgpack" {
+			t.Fatalf("Expected msgpack binding for %s, got %s", contentType, b.Name())
+		}
+
+		var bound msgpackOrderRequest
+		if err := b.Bind(req, &bound); err != nil {
+			t.Fatalf("Bind failed: %v", err)
+		}
+		if bound.ID != 42 || bound.Status != "shipped" || len(bound.Items) != 2 || bound.Items[1] != "pen" {
+			t.Errorf("Unexpected bound request %+v", bound)
+		}
+	}
+}
+
+func TestMsgPack_BindBodyDefaultsAndValidation(t *testing.T) {
+	data, err := msgpack.Marshal(map[string]any{"id": 7})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var req msgpackOrderRequest
+	if err := MsgPack.BindBody(data, &req); err != nil {
+		t.Fatalf("BindBody failed: %v", err)
+	}
+	if req.Status != "paid" {
+		t.Errorf("Expected default status paid, got %q", req.Status)
+	}
+
+	data, _ = msgpack.Marshal(map[string]any{"id": 7, "status": "lost"})
+	if err := MsgPack.BindBody(data, &msgpackOrderRequest{}); err == nil {
+		t.Error("Expected oneof validation error")
+	}
+
+	if err := MsgPack.BindBody([]byte{0xc1}, &msgpackOrderRequest{}); err == nil {
+		t.Error("Expected error for malformed body")
+	}
+}
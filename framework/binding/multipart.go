@@ -0,0 +1,52 @@
+package binding
+
+import (
+	"mime/multipart"
+	"reflect"
+)
+
+var (
+	fileHeaderType      = reflect.TypeOf(multipart.FileHeader{})
+	fileHeaderPtrType   = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// mapMultipart 将multipart表单映射到结构体，文本字段与上传文件均按 form 标签匹配
+func mapMultipart(ptr any, form *multipart.Form) error {
+	return mapping(ptr, multipartSource{form: form}, "form")
+}
+
+// multipartSource multipart表单数据源
+type multipartSource struct {
+	form *multipart.Form
+}
+
+// TrySet 文件字段（*multipart.FileHeader、multipart.FileHeader、[]*multipart.FileHeader）从上传文件绑定，其余字段从文本值绑定
+func (m multipartSource) TrySet(value reflect.Value, field reflect.StructField, key string, opt setOptions) (bool, error) {
+	if isFileField(value.Type()) {
+		return true, setFileHeaders(value, m.form.File[key])
+	}
+	return setByForm(value, field, m.form.Value, key, opt)
+}
+
+// isFileField 判断字段是否为上传文件类型
+func isFileField(t reflect.Type) bool {
+	return t == fileHeaderType || t == fileHeaderPtrType || t == fileHeaderSliceType
+}
+
+// setFileHeaders 设置上传文件字段，未上传文件时保持零值
+func setFileHeaders(value reflect.Value, files []*multipart.FileHeader) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	switch value.Type() {
+	case fileHeaderPtrType:
+		value.Set(reflect.ValueOf(files[0]))
+	case fileHeaderType:
+		value.Set(reflect.ValueOf(*files[0]))
+	case fileHeaderSliceType:
+		value.Set(reflect.ValueOf(append([]*multipart.FileHeader(nil), files...)))
+	}
+	return nil
+}
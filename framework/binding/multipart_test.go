@@ -0,0 +1,114 @@
+package binding
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// uploadRequest 同时包含文本字段与上传文件的请求
+type uploadRequest struct {
+	Title    string                  `form:"title" binding:"required"`
+	Count    int                     `form:"count"`
+	Tags     []string                `form:"tags"`
+	Public   bool                    `form:"public" default:"true"`
+	Avatar   *multipart.FileHeader   `form:"avatar" binding:"required"`
+	Document multipart.FileHeader    `form:"document"`
+	Images   []*multipart.FileHeader `form:"images"`
+	Missing  *multipart.FileHeader   `form:"missing"`
+}
+
+// newMultipartBody 构造multipart请求体，files 为 字段名 -> 文件名:内容 列表
+func newMultipartBody(t *testing.T, fields map[string][]string, files map[string][][2]string) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for name, values := range fields {
+		for _, v := range values {
+			if err := w.WriteField(name, v); err != nil {
+				t.Fatalf("Failed to write field %s: %v", name, err)
+			}
+		}
+	}
+	for name, list := range files {
+		for _, f := range list {
+			part, err := w.CreateFormFile(name, f[0])
+			if err != nil {
+				t.Fatalf("Failed to create file %s: %v", name, err)
+			}
+			part.Write([]byte(f[1]))
+		}
+	}
+	w.Close()
+	return body, w.FormDataContentType()
+}
+
+// readFile 读取上传文件内容
+func readFile(t *testing.T, fh *multipart.FileHeader) string {
+	t.Helper()
+	f, err := fh.Open()
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", fh.Filename, err)
+	}
+	defer f.Close()
+	data, _ := io.ReadAll(f)
+	return string(data)
+}
+
+func TestFormMultipart_BindsFieldsAndFiles(t *testing.T) {
+	body, contentType := newMultipartBody(t,
+		map[string][]string{"title": {"report"}, "count": {"3"}, "tags": {"a", "b"}},
+		map[string][][2]string{
+			"avatar":   {{"me.png", "png-data"}},
+			"document": {{"spec.pdf", "pdf-data"}},
+			"images":   {{"1.jpg", "one"}, {"2.jpg", "two"}},
+		})
+	ctx := ut.CreateUtRequestContext("POST", "/upload", &ut.Body{Body: body, Len: body.Len()},
+		ut.Header{Key: "Content-Type", Value: contentType})
+
+	b := Default("POST", contentType)
+	if b != FormMultipart {
+		t.Fatalf("Expected multipart binding for %q, got %s", contentType, b.Name())
+	}
+
+	var req uploadRequest
+	if err := b.Bind(ctx, &req); err != nil {
+		t.Fatalf("Expected request to bind, got %v", err)
+	}
+
+	if req.Title != "report" || req.Count != 3 || !req.Public {
+		t.Errorf("Unexpected text fields: title=%q count=%d public=%v", req.Title, req.Count, req.Public)
+	}
+	if len(req.Tags) != 2 || req.Tags[0] != "a" || req.Tags[1] != "b" {
+		t.Errorf("Expected tags [a b], got %v", req.Tags)
+	}
+	if req.Avatar == nil || req.Avatar.Filename != "me.png" || readFile(t, req.Avatar) != "png-data" {
+		t.Errorf("Expected avatar me.png to be bound, got %+v", req.Avatar)
+	}
+	if req.Document.Filename != "spec.pdf" || readFile(t, &req.Document) != "pdf-data" {
+		t.Errorf("Expected document spec.pdf to be bound, got %+v", req.Document)
+	}
+	if len(req.Images) != 2 || req.Images[0].Filename != "1.jpg" || readFile(t, req.Images[1]) != "two" {
+		t.Errorf("Expected two images, got %v", req.Images)
+	}
+	if req.Missing != nil {
+		t.Errorf("Expected missing file to stay nil, got %+v", req.Missing)
+	}
+}
+
+func TestFormMultipart_ValidatesRequiredFile(t *testing.T) {
+	body, contentType := newMultipartBody(t, map[string][]string{"title": {"report"}}, nil)
+	ctx := ut.CreateUtRequestContext("POST", "/upload", &ut.Body{Body: body, Len: body.Len()},
+		ut.Header{Key: "Content-Type", Value: contentType})
+
+	var req uploadRequest
+	if err := FormMultipart.Bind(ctx, &req); err == nil {
+		t.Fatal("Expected validation error for missing avatar")
+	}
+	if req.Title != "report" {
+		t.Errorf("Expected title to be bound before validation, got %q", req.Title)
+	}
+}
@@ -0,0 +1,74 @@
+package binding
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zsy619/yyhertz/framework/render"
+)
+
+func newProtoUser(t *testing.T) *structpb.Struct {
+	t.Helper()
+	msg, err := structpb.NewStruct(map[string]any{
+		"name":   "alice",
+		"age":    30,
+		"tags":   []any{"admin", "beta"},
+		"active": true,
+	})
+	if err != nil {
+		t.Fatalf("NewStruct failed: %v", err)
+	}
+	return msg
+}
+
+func TestProtoBuf_RoundTripThroughRenderAndBind(t *testing.T) {
+	user := newProtoUser(t)
+
+	// 服务端渲染
+	rendered := ut.CreateUtRequestContext("GET", "/users/1", nil)
+	if err := render.WriteProtoBuf(rendered, user); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if ct := string(rendered.Response.Header.ContentType()); ct != "application/x-protobuf" {
+		t.Errorf("Expected application/x-protobuf, got %q", ct)
+	}
+	body := append([]byte(nil), rendered.Response.Body()...)
+
+	// 以渲染结果作为请求体绑定
+	req := ut.CreateUtRequestContext("POST", "/users", &ut.Body{Body: bytes.NewReader(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: "application/x-protobuf"})
+	b := Default("POST", string(req.Request.Header.ContentType()))
+	if b.Name() != "protobuf" {
+		t.Fatalf("Expected protobuf binding, got %s", b.Name())
+	}
+
+	var bound structpb.Struct
+	if err := b.Bind(req, &bound); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if !proto.Equal(user, &bound) {
+		t.Errorf("Expected %v, got %v", user, &bound)
+	}
+}
+
+func TestProtoBuf_BindBodyErrors(t *testing.T) {
+	user := newProtoUser(t)
+	data, err := proto.Marshal(user)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var notProto struct{ Name string }
+	if err := ProtoBuf.BindBody(data, &notProto); err == nil {
+		t.Error("Expected error binding into non proto.Message")
+	}
+
+	var bound structpb.Struct
+	if err := ProtoBuf.BindBody([]byte{0xff, 0xff, 0xff}, &bound); err == nil {
+		t.Error("Expected error for malformed protobuf body")
+	}
+}
@@ -0,0 +1,78 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// searchQuery 包含切片与map字段的查询参数
+type searchQuery struct {
+	IDs     []int               `form:"ids"`
+	Names   []string            `form:"name"`
+	Filter  map[string]string   `form:"filter"`
+	Ranges  map[string]int      `form:"range"`
+	Sort    map[string][]string `form:"sort"`
+	Keyword string              `form:"q"`
+}
+
+func TestQuery_BindsSlicesAndMaps(t *testing.T) {
+	ctx := ut.CreateUtRequestContext("GET",
+		"/search?ids=1&ids=2&ids=3&name=a&name=b&filter[status]=active&filter[role]=admin&range[min]=10&range[max]=20&sort[by]=name&sort[by]=age&q=go", nil)
+
+	var q searchQuery
+	if err := Query.Bind(ctx, &q); err != nil {
+		t.Fatalf("Expected query to bind, got %v", err)
+	}
+
+	if len(q.IDs) != 3 || q.IDs[0] != 1 || q.IDs[2] != 3 {
+		t.Errorf("Expected ids [1 2 3], got %v", q.IDs)
+	}
+	if len(q.Names) != 2 || q.Names[1] != "b" {
+		t.Errorf("Expected names [a b], got %v", q.Names)
+	}
+	if len(q.Filter) != 2 || q.Filter["status"] != "active" || q.Filter["role"] != "admin" {
+		t.Errorf("Expected filter map, got %v", q.Filter)
+	}
+	if q.Ranges["min"] != 10 || q.Ranges["max"] != 20 {
+		t.Errorf("Expected converted range map, got %v", q.Ranges)
+	}
+	if by := q.Sort["by"]; len(by) != 2 || by[0] != "name" || by[1] != "age" {
+		t.Errorf("Expected sort[by] to collect all values, got %v", q.Sort)
+	}
+	if q.Keyword != "go" {
+		t.Errorf("Expected keyword go, got %q", q.Keyword)
+	}
+}
+
+func TestQuery_MissingMapStaysNil(t *testing.T) {
+	ctx := ut.CreateUtRequestContext("GET", "/search?q=go&filters=x&filter[]=y", nil)
+
+	var q searchQuery
+	if err := Query.Bind(ctx, &q); err != nil {
+		t.Fatalf("Expected query to bind, got %v", err)
+	}
+	if q.Filter != nil || q.IDs != nil {
+		t.Errorf("Expected unset fields to stay nil, got filter=%v ids=%v", q.Filter, q.IDs)
+	}
+}
+
+func TestQuery_ConversionErrors(t *testing.T) {
+	cases := map[string]string{
+		"slice": "/search?ids=1&ids=abc",
+		"map":   "/search?range[min]=low",
+	}
+	for name, uri := range cases {
+		t.Run(name, func(t *testing.T) {
+			var q searchQuery
+			err := Query.Bind(ut.CreateUtRequestContext("GET", uri, nil), &q)
+			if err == nil {
+				t.Fatalf("Expected conversion error for %s", uri)
+			}
+			if !strings.Contains(err.Error(), "field '") {
+				t.Errorf("Expected error to name the field, got %v", err)
+			}
+		})
+	}
+}
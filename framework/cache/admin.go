@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+// AdminTokenHeader 管理接口令牌请求头，也可使用 Authorization: Bearer <token>
+const AdminTokenHeader = "X-Admin-Token"
+
+// Admin 缓存管理接口，用于查看缓存大小与命中率以及清空指定缓存
+//
+// 用法：
+//
+//	cache.NewAdmin(os.Getenv("CACHE_ADMIN_TOKEN")).Mount(app, "/admin/caches")
+type Admin struct {
+	token string
+}
+
+// NewAdmin 创建缓存管理接口，令牌为空时拒绝所有请求
+func NewAdmin(token string) *Admin {
+	return &Admin{token: token}
+}
+
+// Mount 注册管理路由：
+// GET prefix 列出所有缓存，GET prefix/:name 查看指定缓存，DELETE prefix/:name 清空指定缓存
+func (a *Admin) Mount(r route.IRoutes, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	r.GET(prefix, a.Authenticate, a.ListHandler)
+	r.GET(prefix+"/:name", a.Authenticate, a.StatsHandler)
+	r.DELETE(prefix+"/:name", a.Authenticate, a.FlushHandler)
+}
+
+// Authenticate 校验管理令牌
+func (a *Admin) Authenticate(c context.Context, ctx *app.RequestContext) {
+	token := string(ctx.GetHeader(AdminTokenHeader))
+	if token == "" {
+		token = strings.TrimPrefix(string(ctx.GetHeader("Authorization")), "Bearer ")
+	}
+	if a.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		ctx.JSON(consts.StatusUnauthorized, map[string]any{
+			"error": "Admin token required",
+			"code":  "AUTH_REQUIRED",
+		})
+		ctx.Abort()
+		return
+	}
+	ctx.Next(c)
+}
+
+// ListHandler 列出所有登记缓存的统计信息
+func (a *Admin) ListHandler(c context.Context, ctx *app.RequestContext) {
+	caches := Registered()
+	stats := make([]CacheStats, 0, len(caches))
+	for _, cache := range caches {
+		stats = append(stats, cache.GetStats())
+	}
+	ctx.JSON(consts.StatusOK, map[string]any{
+		"caches":    stats,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// StatsHandler 查看指定缓存的统计信息
+func (a *Admin) StatsHandler(c context.Context, ctx *app.RequestContext) {
+	cache, ok := a.lookup(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(consts.StatusOK, cache.GetStats())
+}
+
+// FlushHandler 清空指定缓存
+func (a *Admin) FlushHandler(c context.Context, ctx *app.RequestContext) {
+	cache, ok := a.lookup(ctx)
+	if !ok {
+		return
+	}
+	before := cache.GetStats().Size
+	cache.Clear()
+	ctx.JSON(consts.StatusOK, map[string]any{
+		"name":    cache.GetName(),
+		"flushed": before,
+	})
+}
+
+// lookup 查找路径参数指定的缓存，不存在时返回404
+func (a *Admin) lookup(ctx *app.RequestContext) (Inspector, bool) {
+	name := ctx.Param("name")
+	cache, ok := Lookup(name)
+	if !ok {
+		ctx.JSON(consts.StatusNotFound, map[string]any{
+			"error": "Cache not found",
+			"name":  name,
+		})
+		return nil, false
+	}
+	return cache, true
+}
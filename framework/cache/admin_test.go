@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_StatsAndFlush(t *testing.T) {
+	sessions := NewCacheManager[string]("SessionCache", "会话缓存")
+	Register(sessions)
+	t.Cleanup(func() { Unregister("SessionCache") })
+
+	sessions.Set("s1", "alice", 0)
+	sessions.Set("s2", "bob", 0)
+	sessions.Get("s1")
+	sessions.Get("missing")
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	NewAdmin("secret").Mount(engine, "/admin/caches")
+	auth := ut.Header{Key: AdminTokenHeader, Value: "secret"}
+
+	// 未认证的请求被拒绝
+	resp := ut.PerformRequest(engine, "GET", "/admin/caches/SessionCache", nil).Result()
+	assert.Equal(t, 401, resp.StatusCode())
+	resp = ut.PerformRequest(engine, "DELETE", "/admin/caches/SessionCache", nil,
+		ut.Header{Key: AdminTokenHeader, Value: "wrong"}).Result()
+	assert.Equal(t, 401, resp.StatusCode())
+	assert.Equal(t, 2, sessions.Size(), "rejected flush should not clear the cache")
+
+	// 查看缓存大小与命中率
+	resp = ut.PerformRequest(engine, "GET", "/admin/caches/SessionCache", nil, auth).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	var stats CacheStats
+	require.NoError(t, json.Unmarshal(resp.Body(), &stats))
+	assert.Equal(t, 2, stats.Size)
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.InDelta(t, 0.5, stats.HitRate, 0.001)
+
+	// 列表中包含登记的缓存
+	resp = ut.PerformRequest(engine, "GET", "/admin/caches", nil,
+		ut.Header{Key: "Authorization", Value: "Bearer secret"}).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Contains(t, string(resp.Body()), `"name":"SessionCache"`)
+
+	// 清空指定缓存
+	resp = ut.PerformRequest(engine, "DELETE", "/admin/caches/SessionCache", nil, auth).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.JSONEq(t, `{"name":"SessionCache","flushed":2}`, string(resp.Body()))
+	assert.Equal(t, 0, sessions.Size())
+
+	// 不存在的缓存
+	resp = ut.PerformRequest(engine, "DELETE", "/admin/caches/Unknown", nil, auth).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+}
+
+func TestAdmin_EmptyTokenDisablesEndpoint(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	NewAdmin("").Mount(engine, "/admin/caches")
+
+	resp := ut.PerformRequest(engine, "GET", "/admin/caches", nil, ut.Header{Key: AdminTokenHeader, Value: ""}).Result()
+	assert.Equal(t, 401, resp.StatusCode())
+}
+
+func TestNewInspector(t *testing.T) {
+	items := map[string]int{"a": 1, "b": 2}
+	inspector := NewInspector("QueryCache", "查询缓存",
+		func() int { return len(items) },
+		func() { items = map[string]int{} })
+
+	assert.Equal(t, 2, inspector.GetStats().Size)
+	inspector.Clear()
+	assert.Equal(t, 0, inspector.GetStats().Size)
+}
@@ -17,6 +17,9 @@ type CacheManager[T any] struct {
 	mutex sync.RWMutex
 	name  string
 	desc  string
+
+	hits   int64 // Get命中次数
+	misses int64 // Get未命中次数
 }
 
 // NewCacheManager 创建新的缓存管理器
@@ -54,6 +57,7 @@ func (c *CacheManager[T]) Get(key string) (T, bool) {
 	
 	item, exists := c.items[key]
 	if !exists {
+		c.misses++
 		var zero T
 		return zero, false
 	}
@@ -61,10 +65,12 @@ func (c *CacheManager[T]) Get(key string) (T, bool) {
 	// 检查是否过期，如果过期则删除
 	if time.Now().UnixNano() > item.Expiration {
 		delete(c.items, key)
+		c.misses++
 		var zero T
 		return zero, false
 	}
 	
+	c.hits++
 	return item.Value, true
 }
 
@@ -204,12 +210,15 @@ func (c *CacheManager[T]) CleanupExpired() int {
 
 // CacheStats 缓存统计信息
 type CacheStats struct {
-	Keys         int    `json:"keys"`
-	Size         int    `json:"size"`
-	ItemCount    int    `json:"itemCount"`
-	Name         string `json:"name"`
-	Desc         string `json:"desc"`
-	Description  string `json:"description"`
+	Keys        int     `json:"keys"`
+	Size        int     `json:"size"`
+	ItemCount   int     `json:"itemCount"`
+	Name        string  `json:"name"`
+	Desc        string  `json:"desc"`
+	Description string  `json:"description"`
+	Hits        int64   `json:"hits"`
+	Misses      int64   `json:"misses"`
+	HitRate     float64 `json:"hitRate"` // 命中率（0-1），无访问时为0
 }
 
 // GetStats 获取缓存统计信息
@@ -226,6 +235,11 @@ func (c *CacheManager[T]) GetStats() CacheStats {
 		}
 	}
 	
+	var hitRate float64
+	if total := c.hits + c.misses; total > 0 {
+		hitRate = float64(c.hits) / float64(total)
+	}
+	
 	return CacheStats{
 		Keys:        validCount,
 		Size:        validCount,
@@ -233,6 +247,9 @@ func (c *CacheManager[T]) GetStats() CacheStats {
 		Name:        c.name,
 		Desc:        c.desc,
 		Description: c.desc,
+		Hits:        c.hits,
+		Misses:      c.misses,
+		HitRate:     hitRate,
 	}
 }
 
@@ -245,11 +262,18 @@ var (
 )
 
 // InitCaches 初始化所有缓存管理器
+// 控制器编译缓存、MyBatis查询缓存、响应缓存与会话缓存由所属的包在创建时登记，见 ControllerCompilerCacheName 等
 func InitCaches() {
 	DictCache = NewCacheManager[[]map[string]any]("DictCache", "字典缓存")
 	ConfigCache = NewCacheManager[string]("ConfigCache", "配置缓存")
 	EmailCache = NewCacheManager[map[string]any]("EmailCache", "邮件配置缓存")
 	GeneralCache = NewCacheManager[any]("GeneralCache", "通用缓存")
+	
+	// 登记到缓存注册表，供管理接口查看与清空
+	Register(DictCache)
+	Register(ConfigCache)
+	Register(EmailCache)
+	Register(GeneralCache)
 }
 
 func init() {
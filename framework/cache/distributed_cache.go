@@ -228,6 +228,21 @@ func (m *MemoryDistributedCache) Clear() error {
 	return nil
 }
 
+// Len 获取未过期的缓存项数量
+func (m *MemoryDistributedCache) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now().UnixNano()
+	count := 0
+	for _, item := range m.data {
+		if item.Expiration == 0 || now <= item.Expiration {
+			count++
+		}
+	}
+	return count
+}
+
 // Exists 检查缓存是否存在
 func (m *MemoryDistributedCache) Exists(key string) (bool, error) {
 	_, exists, err := m.Get(key)
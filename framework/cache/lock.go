@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+var (
+	// ErrLockNotAcquired 锁已被其他持有者占用
+	ErrLockNotAcquired = errors.New("lock not acquired")
+	// ErrLockNotHeld 锁已过期或不属于调用方
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// unlockScript 仅当令牌匹配时删除锁，避免释放其他持有者的锁
+var unlockScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`)
+
+// renewScript 仅当令牌匹配时延长锁的过期时间
+var renewScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`)
+
+// DistributedLock 基于Redis的分布式锁，用于多实例间的定时任务、单例任务互斥
+type DistributedLock struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewDistributedLock 创建分布式锁，client 为go-redis客户端，prefix 为锁键前缀
+func NewDistributedLock(client redis.Cmdable, prefix string) *DistributedLock {
+	return &DistributedLock{client: client, prefix: prefix}
+}
+
+// NewDistributedLockFromConfig 使用数据库配置中的Redis缓存配置（Cache.RedisAddr等）创建分布式锁
+func NewDistributedLockFromConfig(cfg *config.DatabaseConfig) (*DistributedLock, error) {
+	client, err := RedisClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewDistributedLock(client, cfg.Cache.KeyPrefix+"lock:"), nil
+}
+
+// TryLock 尝试获取锁，不等待；锁被占用时返回 ErrLockNotAcquired
+func (d *DistributedLock) TryLock(key string, ttl time.Duration) (*Lock, error) {
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+	acquired, err := d.client.SetNX(context.Background(), d.prefix+key, token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		return nil, ErrLockNotAcquired
+	}
+	return &Lock{locker: d, key: key, token: token, ttl: ttl}, nil
+}
+
+// Unlock 使用令牌释放锁，令牌不匹配（非持有者或锁已过期）时返回 ErrLockNotHeld
+func (d *DistributedLock) Unlock(key, token string) error {
+	deleted, err := unlockScript.Run(context.Background(), d.client, []string{d.prefix + key}, token).Int64()
+	if err != nil {
+		return fmt.Errorf("release lock %s: %w", key, err)
+	}
+	if deleted == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Renew 使用令牌延长锁的过期时间，令牌不匹配时返回 ErrLockNotHeld
+func (d *DistributedLock) Renew(key, token string, ttl time.Duration) error {
+	renewed, err := renewScript.Run(context.Background(), d.client, []string{d.prefix + key}, token, ttlMillis(ttl)).Int64()
+	if err != nil {
+		return fmt.Errorf("renew lock %s: %w", key, err)
+	}
+	if renewed == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Lock 已获取的锁
+type Lock struct {
+	locker *DistributedLock
+	key    string
+	token  string
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	stopRenew chan struct{}
+	lost      chan struct{}
+}
+
+// Key 锁的键（不含前缀）
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token 持有者令牌
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// AutoRenew 启动自动续期，每隔ttl/3将过期时间重置为ttl，直到 Unlock
+// 返回的通道在续期失败（锁已丢失）时关闭，任务应据此停止
+func (l *Lock) AutoRenew() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lost != nil {
+		return l.lost
+	}
+	l.stopRenew = make(chan struct{})
+	l.lost = make(chan struct{})
+
+	interval := l.ttl / 3
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+	go func(stop <-chan struct{}, lost chan<- struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := l.locker.Renew(l.key, l.token, l.ttl); err != nil {
+					config.Warnf("Distributed lock %s lost: %v", l.key, err)
+					close(lost)
+					return
+				}
+			}
+		}
+	}(l.stopRenew, l.lost)
+	return l.lost
+}
+
+// Unlock 停止自动续期并释放锁
+func (l *Lock) Unlock() error {
+	l.mu.Lock()
+	if l.stopRenew != nil {
+		close(l.stopRenew)
+		l.stopRenew = nil
+	}
+	l.mu.Unlock()
+
+	return l.locker.Unlock(l.key, l.token)
+}
+
+// newLockToken 生成随机持有者令牌
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// ttlMillis 过期时间转换为毫秒，最小1毫秒
+func ttlMillis(ttl time.Duration) int64 {
+	if ms := ttl.Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
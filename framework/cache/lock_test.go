@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisClient 创建连接miniredis的go-redis客户端
+func newTestRedisClient(t *testing.T, server *miniredis.Miniredis) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestDistributedLock_MutualExclusion(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	var holders, maxHolders, acquired int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		// 每个goroutine模拟一个实例，使用独立的客户端
+		locker := NewDistributedLock(newTestRedisClient(t, server), "test:")
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				lock, err := locker.TryLock("job", time.Second)
+				if errors.Is(err, ErrLockNotAcquired) {
+					time.Sleep(time.Millisecond)
+					continue
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				current := atomic.AddInt32(&holders, 1)
+				if current > atomic.LoadInt32(&maxHolders) {
+					atomic.StoreInt32(&maxHolders, current)
+				}
+				atomic.AddInt32(&acquired, 1)
+				time.Sleep(2 * time.Millisecond)
+				atomic.AddInt32(&holders, -1)
+
+				assert.NoError(t, lock.Unlock())
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxHolders), "lock must never be held by both goroutines")
+	assert.Greater(t, atomic.LoadInt32(&acquired), int32(1))
+}
+
+func TestDistributedLock_NonOwnerCannotUnlock(t *testing.T) {
+	locker := NewDistributedLock(newTestRedisClient(t, miniredis.RunT(t)), "test:")
+
+	lock, err := locker.TryLock("report", time.Minute)
+	require.NoError(t, err)
+
+	// 其他持有者的令牌无法释放锁
+	assert.ErrorIs(t, locker.Unlock("report", "forged-token"), ErrLockNotHeld)
+	_, err = locker.TryLock("report", time.Minute)
+	assert.ErrorIs(t, err, ErrLockNotAcquired, "lock should still be held")
+
+	require.NoError(t, lock.Unlock())
+	assert.ErrorIs(t, lock.Unlock(), ErrLockNotHeld, "released lock cannot be released twice")
+
+	again, err := locker.TryLock("report", time.Minute)
+	require.NoError(t, err)
+	assert.NotEqual(t, lock.Token(), again.Token())
+}
+
+func TestDistributedLock_AutoRenew(t *testing.T) {
+	server := miniredis.RunT(t)
+	locker := NewDistributedLock(newTestRedisClient(t, server), "test:")
+
+	lock, err := locker.TryLock("renew", 60*time.Millisecond)
+	require.NoError(t, err)
+	lost := lock.AutoRenew()
+
+	// 超过原始TTL后仍持有锁，过期时间被重置
+	time.Sleep(150 * time.Millisecond)
+	server.FastForward(40 * time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	server.FastForward(40 * time.Millisecond)
+	_, err = locker.TryLock("renew", time.Minute)
+	assert.ErrorIs(t, err, ErrLockNotAcquired)
+
+	// 锁被删除后续期失败，通知调用方
+	server.Del("test:renew")
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("expected lost notification")
+	}
+}
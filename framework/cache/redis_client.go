@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+var (
+	redisClientsMu sync.Mutex
+	redisClients   = make(map[string]*redis.Client)
+)
+
+// SharedRedisClient 获取共享的go-redis客户端（自带连接池），地址、密码与库号相同时复用同一个客户端
+// 分布式锁、MyBatis二级缓存与健康检查通过该函数共用连接
+func SharedRedisClient(addr, password string, db int) *redis.Client {
+	key := addr + "|" + password + "|" + strconv.Itoa(db)
+
+	redisClientsMu.Lock()
+	defer redisClientsMu.Unlock()
+	if client, ok := redisClients[key]; ok {
+		return client
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+	redisClients[key] = client
+	return client
+}
+
+// RedisClientFromConfig 使用数据库配置中的Redis缓存配置（Cache.RedisAddr等）获取共享客户端
+func RedisClientFromConfig(cfg *config.DatabaseConfig) (*redis.Client, error) {
+	if cfg == nil || cfg.Cache.RedisAddr == "" {
+		return nil, fmt.Errorf("redis address not configured")
+	}
+	return SharedRedisClient(cfg.Cache.RedisAddr, cfg.Cache.RedisPassword, cfg.Cache.RedisDB), nil
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+)
+
+// Inspector 可在运行时查看统计信息并清空的缓存
+type Inspector interface {
+	GetName() string
+	GetStats() CacheStats
+	Clear()
+}
+
+// 框架内置缓存的登记名称，由各缓存所属的包在创建时通过 NewInspector 登记
+const (
+	ControllerCompilerCacheName = "ControllerCompilerCache" // 控制器编译缓存
+	QueryCacheName              = "MyBatisQueryCache"       // MyBatis二级查询缓存
+	ResponseCacheName           = "ResponseCache"           // 幂等中间件的响应缓存
+	SessionCacheName            = "SessionCache"            // 会话缓存
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Inspector)
+)
+
+// Register 登记缓存，同名缓存将被替换
+func Register(c Inspector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[c.GetName()] = c
+}
+
+// Unregister 移除登记的缓存
+func Unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, name)
+}
+
+// Lookup 按名称查找登记的缓存
+func Lookup(name string) (Inspector, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[name]
+	return c, ok
+}
+
+// Registered 获取所有登记的缓存，按名称排序
+func Registered() []Inspector {
+	registryMu.RLock()
+	caches := make([]Inspector, 0, len(registry))
+	for _, c := range registry {
+		caches = append(caches, c)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].GetName() < caches[j].GetName()
+	})
+	return caches
+}
+
+// funcInspector 基于函数的缓存适配器
+type funcInspector struct {
+	name  string
+	desc  string
+	size  func() int
+	clear func()
+}
+
+// NewInspector 将任意缓存（如控制器编译缓存、MyBatis查询缓存、会话存储）适配为Inspector
+func NewInspector(name, desc string, size func() int, clear func()) Inspector {
+	return &funcInspector{name: name, desc: desc, size: size, clear: clear}
+}
+
+// GetName 获取缓存名称
+func (f *funcInspector) GetName() string {
+	return f.name
+}
+
+// GetStats 获取缓存统计信息（适配器不统计命中率）
+func (f *funcInspector) GetStats() CacheStats {
+	size := f.size()
+	return CacheStats{
+		Keys:        size,
+		Size:        size,
+		ItemCount:   size,
+		Name:        f.name,
+		Desc:        f.desc,
+		Description: f.desc,
+	}
+}
+
+// Clear 清空缓存
+func (f *funcInspector) Clear() {
+	f.clear()
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -8,13 +10,23 @@ import (
 type AppConfig struct {
 	// 应用基础配置
 	App struct {
-		Name        string `mapstructure:"name" yaml:"name" json:"name"`
-		Version     string `mapstructure:"version" yaml:"version" json:"version"`
-		Environment string `mapstructure:"environment" yaml:"environment" json:"environment"` // dev, test, prod
-		Debug       bool   `mapstructure:"debug" yaml:"debug" json:"debug"`
-		Port        int    `mapstructure:"port" yaml:"port" json:"port"`
-		Host        string `mapstructure:"host" yaml:"host" json:"host"`
-		Timezone    string `mapstructure:"timezone" yaml:"timezone" json:"timezone"`
+		Name          string `mapstructure:"name" yaml:"name" json:"name"`
+		Version       string `mapstructure:"version" yaml:"version" json:"version"`
+		Environment   string `mapstructure:"environment" yaml:"environment" json:"environment"` // dev, test, prod
+		Debug         bool   `mapstructure:"debug" yaml:"debug" json:"debug"`
+		Port          int    `mapstructure:"port" yaml:"port" json:"port"`
+		Host          string `mapstructure:"host" yaml:"host" json:"host"`
+		Timezone      string `mapstructure:"timezone" yaml:"timezone" json:"timezone"`
+		ConfigAudit   bool   `mapstructure:"config_audit" yaml:"config_audit" json:"config_audit"`       // 启动时输出脱敏后的有效配置
+		StrictRouting bool   `mapstructure:"strict_routing" yaml:"strict_routing" json:"strict_routing"` // 重复注册路由时启动失败
+
+		CaseInsensitiveRouting bool `mapstructure:"case_insensitive_routing" yaml:"case_insensitive_routing" json:"case_insensitive_routing"` // 路径不区分大小写
+		RedirectCanonicalPath  bool `mapstructure:"redirect_canonical_path" yaml:"redirect_canonical_path" json:"redirect_canonical_path"`    // 不区分大小写时重定向到规范路径
+
+		ShutdownTimeout string `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout" json:"shutdown_timeout"` // 优雅关闭时等待进行中请求的最长时间
+
+		SlowRequestThreshold string `mapstructure:"slow_request_threshold" yaml:"slow_request_threshold" json:"slow_request_threshold"` // 慢请求阈值，超过时以Warn级别记录（0表示不检测）
+		LogSlowRequestsOnly  bool   `mapstructure:"log_slow_requests_only" yaml:"log_slow_requests_only" json:"log_slow_requests_only"` // 仅记录慢请求和服务端错误请求
 	} `mapstructure:"app" yaml:"app" json:"app"`
 
 	// 日志配置
@@ -93,6 +105,13 @@ func (c AppConfig) SetDefaults(v *viper.Viper) {
 	v.SetDefault("app.port", 8888)
 	v.SetDefault("app.host", "0.0.0.0")
 	v.SetDefault("app.timezone", "Asia/Shanghai")
+	v.SetDefault("app.config_audit", false)
+	v.SetDefault("app.strict_routing", false)
+	v.SetDefault("app.case_insensitive_routing", false)
+	v.SetDefault("app.redirect_canonical_path", false)
+	v.SetDefault("app.shutdown_timeout", "10s")
+	v.SetDefault("app.slow_request_threshold", "0s")
+	v.SetDefault("app.log_slow_requests_only", false)
 
 	// 日志默认配置
 	v.SetDefault("log.level", "info")
@@ -146,7 +165,13 @@ app:
   port: 8888
   host: "0.0.0.0"
   timezone: "Asia/Shanghai"
-
+  config_audit: false     # 启动时输出脱敏后的有效配置，便于排查问题
+  strict_routing: false   # 重复注册相同方法+路径的路由时启动失败（否则仅输出警告）
+  case_insensitive_routing: false  # 路径不区分大小写（/Users 可匹配 /users）
+  redirect_canonical_path: false   # 不区分大小写时重定向到注册的规范路径
+  shutdown_timeout: "10s"          # 优雅关闭时等待进行中请求的最长时间
+  slow_request_threshold: "0s"     # 慢请求阈值，超过时以Warn级别记录并附带耗时分解（0s表示不检测）
+  log_slow_requests_only: false    # 仅记录慢请求和服务端错误请求，减少日志量
 
 # 日志配置
 log:
@@ -221,3 +246,35 @@ monitor:
   timeout: 10           # 秒
 `
 }
+
+// Validate 校验应用配置，返回汇总全部不合法配置项的 *ValidationError
+func (c AppConfig) Validate() error {
+	v := newConfigValidator(AppConfigName)
+
+	v.port("app.port", c.App.Port)
+	v.duration("app.shutdown_timeout", c.App.ShutdownTimeout)
+	v.duration("app.slow_request_threshold", c.App.SlowRequestThreshold)
+
+	c.Log.validateInto(v, "log.")
+
+	v.nonNegative("middleware.cors.max_age", c.Middleware.CORS.MaxAge)
+	if c.Middleware.RateLimit.Enable {
+		v.positive("middleware.rate_limit.rate", c.Middleware.RateLimit.Rate)
+		v.positive("middleware.rate_limit.burst", c.Middleware.RateLimit.Burst)
+		v.oneOf("middleware.rate_limit.strategy", c.Middleware.RateLimit.Strategy, "token_bucket", "sliding_window")
+	}
+
+	v.optionalOneOf("services.email.provider", c.Services.Email.Provider, "smtp", "sendgrid", "ses")
+	v.port("services.email.port", c.Services.Email.Port)
+	v.optionalOneOf("services.storage.provider", c.Services.Storage.Provider, "local", "s3", "oss")
+	if strings.EqualFold(c.Services.Storage.Provider, "s3") || strings.EqualFold(c.Services.Storage.Provider, "oss") {
+		v.required("services.storage.bucket", c.Services.Storage.Bucket)
+	}
+
+	if c.Monitor.Enable {
+		v.positive("monitor.interval", c.Monitor.Interval)
+		v.positive("monitor.timeout", c.Monitor.Timeout)
+	}
+
+	return v.err()
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -366,3 +369,63 @@ logging:
   log_error_events: true                     # 记录错误事件
 `
 }
+
+// Validate 校验认证配置，返回汇总全部不合法配置项的 *ValidationError
+func (c AuthConfig) Validate() error {
+	v := newConfigValidator(AuthConfigName)
+
+	if c.CAS.Enabled {
+		v.required("cas.host", c.CAS.Host)
+		v.oneOf("cas.version", c.CAS.Version, "1.0", "2.0", "3.0")
+	}
+
+	// JWT
+	v.optionalOneOf("jwt.signing_method", c.JWT.SigningMethod,
+		"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512")
+	switch strings.ToUpper(c.JWT.SigningMethod) {
+	case "HS256", "HS384", "HS512":
+		v.required("jwt.secret", c.JWT.Secret)
+	case "RS256", "RS384", "RS512", "ES256", "ES384", "ES512":
+		v.required("jwt.private_key_path", c.JWT.PrivateKeyPath)
+	}
+	v.nonNegative("jwt.token_ttl", c.JWT.TokenTTL)
+	v.nonNegative("jwt.refresh_ttl", c.JWT.RefreshTTL)
+
+	// Session
+	v.nonNegative("session.max_age", c.Session.MaxAge)
+	v.optionalOneOf("session.same_site", c.Session.SameSite, "Strict", "Lax", "None")
+	if strings.EqualFold(c.Session.SameSite, "None") && !c.Session.Secure {
+		v.addf("session.secure", "session.same_site 为 None 时必须启用 secure")
+	}
+	v.optionalOneOf("session.store", c.Session.Store, "cookie", "memory", "redis")
+
+	// OAuth
+	names := make([]string, 0, len(c.OAuth.Providers))
+	for name := range c.OAuth.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		provider := c.OAuth.Providers[name]
+		if !provider.Enable {
+			continue
+		}
+		prefix := "oauth.providers." + name + "."
+		v.required(prefix+"client_id", provider.ClientID)
+		v.required(prefix+"auth_url", provider.AuthURL)
+		v.required(prefix+"token_url", provider.TokenURL)
+	}
+
+	// 安全
+	v.nonNegative("security.password_policy.min_length", c.Security.PasswordPolicy.MinLength)
+	v.nonNegative("security.login_attempts.max_attempts", c.Security.LoginAttempts.MaxAttempts)
+	v.nonNegative("security.login_attempts.lockout_time", c.Security.LoginAttempts.LockoutTime)
+	if c.Security.TwoFactorAuth.Enable {
+		v.positive("security.two_factor_auth.secret_length", c.Security.TwoFactorAuth.SecretLength)
+	}
+
+	v.optionalOneOf("logging.level", c.Logging.Level, "debug", "info", "warn", "error")
+	v.optionalOneOf("logging.format", c.Logging.Format, "json", "text")
+
+	return v.err()
+}
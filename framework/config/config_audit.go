@@ -0,0 +1,214 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// MaskedValue 敏感字段脱敏后的占位值
+const MaskedValue = "******"
+
+// sensitiveKeyPatterns 敏感字段名匹配规则（按字段名的小写形式匹配）
+var sensitiveKeyPatterns = []string{
+	"password",
+	"passwd",
+	"pass_phrase",
+	"passphrase",
+	"secret",
+	"token",
+	"dsn",
+	"private",
+	"credential",
+}
+
+// sensitiveKeySuffixes 以这些后缀结尾的字段视为密钥（如 secret_key、ticket_key、key_data）
+var sensitiveKeySuffixes = []string{
+	"_key",
+	"key_data",
+}
+
+// IsSensitiveConfigKey 判断配置字段名是否属于敏感字段
+func IsSensitiveConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	if lower == "key" {
+		return true
+	}
+	for _, pattern := range sensitiveKeyPatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskConfig 将配置结构体转换为键值映射，并对敏感字段进行脱敏
+// 字段名优先使用 mapstructure 标签，与配置文件中的键保持一致
+func MaskConfig(config any) map[string]any {
+	masked, ok := maskValue(reflect.ValueOf(config)).(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+	return masked
+}
+
+// DumpConfig 导出指定配置的有效值（YAML格式，敏感字段已脱敏）
+func DumpConfig[T ConfigInterface](config T) (string, error) {
+	manager := GetViperConfigManager(config)
+	effective, err := manager.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	return DumpMaskedConfig(*effective)
+}
+
+// DumpMaskedConfig 将配置结构体脱敏后序列化为YAML
+func DumpMaskedConfig(config any) (string, error) {
+	data, err := yaml.Marshal(MaskConfig(config))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// AuditConfigs 输出全部有效配置（敏感字段已脱敏），由应用启动（App.Run）时显式调用
+// 仅当 app.config_audit 为 true 时生效
+func AuditConfigs() {
+	if !GetConfigBool(AppConfig{}, "app.config_audit") {
+		return
+	}
+
+	audits := []struct {
+		name string
+		dump func() (string, error)
+	}{
+		{AppConfigName, func() (string, error) { return DumpConfig(AppConfig{}) }},
+		{TemplateConfigName, func() (string, error) { return DumpConfig(TemplateConfig{}) }},
+		{AuthConfigName, func() (string, error) { return DumpConfig(AuthConfig{}) }},
+		{LogConfigName, func() (string, error) { return DumpConfig(LogConfig{}) }},
+		{DatabaseConfigName, func() (string, error) { return DumpConfig(DatabaseConfig{}) }},
+		{RedisConfigName, func() (string, error) { return DumpConfig(RedisConfig{}) }},
+		{SessionConfigName, func() (string, error) { return DumpConfig(SessionConfig{}) }},
+		{TLSConfigName, func() (string, error) { return DumpConfig(TLSServerConfig{}) }},
+		{MyBatisConfigName, func() (string, error) { return DumpConfig(MyBatisConfig{}) }},
+		{MVCConfigName, func() (string, error) { return DumpConfig(MVCConfig{}) }},
+		{MiddlewareConfigName, func() (string, error) { return DumpConfig(MiddlewareConfig{}) }},
+	}
+
+	for _, audit := range audits {
+		content, err := audit.dump()
+		if err != nil {
+			WithField("config_name", audit.name).WithError(err).Warn("配置审计导出失败")
+			continue
+		}
+		WithField("config_name", audit.name).Infof("有效配置:\n%s", content)
+	}
+}
+
+// maskValue 递归转换配置值，敏感字段替换为 MaskedValue
+func maskValue(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		result := make(map[string]any)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key := configFieldKey(field)
+			if key == "-" {
+				continue
+			}
+			if isSensitiveField(key, v.Field(i)) {
+				result[key] = maskScalar(v.Field(i))
+				continue
+			}
+			result[key] = maskValue(v.Field(i))
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]any, v.Len())
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			key := fmt.Sprint(k.Interface())
+			if isSensitiveField(key, v.MapIndex(k)) {
+				result[key] = maskScalar(v.MapIndex(k))
+				continue
+			}
+			result[key] = maskValue(v.MapIndex(k))
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		result := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = maskValue(v.Index(i))
+		}
+		return result
+	default:
+		if !v.IsValid() {
+			return nil
+		}
+		return v.Interface()
+	}
+}
+
+// isSensitiveField 判断字段是否需要脱敏
+// 仅对字符串值脱敏，数值/布尔（如 token_ttl、secret_length）及嵌套结构保持原样
+func isSensitiveField(key string, v reflect.Value) bool {
+	if !IsSensitiveConfigKey(key) {
+		return false
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// maskScalar 对敏感值脱敏，空值保持为空以便区分"未配置"
+func maskScalar(v reflect.Value) any {
+	if !v.IsValid() || v.IsZero() {
+		return ""
+	}
+	return MaskedValue
+}
+
+// configFieldKey 获取字段在配置文件中的键名
+func configFieldKey(field reflect.StructField) string {
+	for _, tagName := range []string{"mapstructure", "yaml", "json"} {
+		if tag := field.Tag.Get(tagName); tag != "" {
+			name := strings.Split(tag, ",")[0]
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return strings.ToLower(field.Name)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskConfig_DatabaseConfig(t *testing.T) {
+	var dbConfig DatabaseConfig
+	dbConfig.Primary.Driver = "mysql"
+	dbConfig.Primary.Host = "127.0.0.1"
+	dbConfig.Primary.Port = 3306
+	dbConfig.Primary.Username = "root"
+	dbConfig.Primary.Password = "super-secret-password"
+	dbConfig.Primary.DSN = "root:super-secret-password@tcp(127.0.0.1:3306)/app"
+
+	masked := MaskConfig(dbConfig)
+	primary, ok := masked["primary"].(map[string]any)
+	require.True(t, ok)
+
+	assert.Equal(t, MaskedValue, primary["password"])
+	assert.Equal(t, MaskedValue, primary["dsn"])
+	assert.Equal(t, "mysql", primary["driver"])
+	assert.Equal(t, "127.0.0.1", primary["host"])
+	assert.Equal(t, 3306, primary["port"])
+	assert.Equal(t, "root", primary["username"])
+
+	// 未配置的敏感字段保持为空
+	replica, ok := masked["replica"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "", replica["password"])
+}
+
+func TestDumpMaskedConfig_TLSConfig(t *testing.T) {
+	var tlsConfig TLSServerConfig
+	tlsConfig.Certificate.CertFile = "./certs/server.crt"
+	tlsConfig.Certificate.KeyFile = "./certs/server.key"
+	tlsConfig.Certificate.PassPhrase = "my-pass-phrase"
+	tlsConfig.Session.TicketKey = "ticket-key-value"
+	tlsConfig.Version.MinVersion = "1.2"
+
+	content, err := DumpMaskedConfig(tlsConfig)
+	require.NoError(t, err)
+
+	assert.NotContains(t, content, "my-pass-phrase")
+	assert.NotContains(t, content, "ticket-key-value")
+	assert.Contains(t, content, "pass_phrase:")
+	assert.Contains(t, content, MaskedValue)
+	assert.Contains(t, content, "./certs/server.crt")
+	assert.Contains(t, content, "./certs/server.key")
+	assert.Contains(t, content, "1.2")
+}
+
+func TestIsSensitiveConfigKey(t *testing.T) {
+	sensitive := []string{"password", "pass_phrase", "dsn", "secret_key", "ticket_key", "key_data", "client_secret"}
+	for _, key := range sensitive {
+		assert.True(t, IsSensitiveConfigKey(key), key)
+	}
+
+	plain := []string{"host", "port", "cert_file", "key_file", "username", "driver"}
+	for _, key := range plain {
+		assert.False(t, IsSensitiveConfigKey(key), key)
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultEnvPrefix 默认环境变量前缀，如 YYHERTZ_PRIMARY_HOST 覆盖 primary.host
+const DefaultEnvPrefix = "YYHERTZ"
+
+// envKeyReplacer 配置键到环境变量名的转换：层级分隔符与连字符均转为下划线
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// SetEnvPrefix 设置环境变量前缀，需在初始化前调用
+func (gcm *ViperConfigManager[T]) SetEnvPrefix(prefix string) *ViperConfigManager[T] {
+	gcm.mu.Lock()
+	defer gcm.mu.Unlock()
+
+	gcm.envPrefix = prefix
+	return gcm
+}
+
+// EnvKey 返回覆盖指定配置键的环境变量名，如 primary.max_open_conns -> YYHERTZ_PRIMARY_MAX_OPEN_CONNS
+func (gcm *ViperConfigManager[T]) EnvKey(key string) string {
+	name := strings.ToUpper(envKeyReplacer.Replace(key))
+	if gcm.envPrefix == "" {
+		return name
+	}
+	return strings.ToUpper(gcm.envPrefix) + "_" + name
+}
+
+// bindEnvKeys 为配置结构体的全部键绑定环境变量
+// AutomaticEnv 只作用于已有默认值或配置文件中出现的键，绑定后未设置默认值的字段同样可以被环境变量覆盖
+func (gcm *ViperConfigManager[T]) bindEnvKeys(v *viper.Viper) {
+	for _, key := range configKeys(reflect.TypeOf(gcm.config), "") {
+		_ = v.BindEnv(key)
+	}
+}
+
+// configKeys 按 mapstructure 标签展开结构体的配置键，切片与映射作为整体
+func configKeys(t reflect.Type, prefix string) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		if prefix == "" {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "squash") {
+			keys = append(keys, configKeys(field.Type, prefix)...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = strings.ToLower(name)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		keys = append(keys, configKeys(field.Type, name)...)
+	}
+	return keys
+}
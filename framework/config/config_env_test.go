@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEnvTestManager(t *testing.T) *ViperConfigManager[DatabaseConfig] {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "database.yaml")
+	content := "primary:\n  host: file-host\n  max_open_conns: 10\nreplica:\n  hosts:\n    - file-replica\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return NewViperConfigManager(DatabaseConfig{}).SetConfigFile(path)
+}
+
+func TestViperConfigManager_EnvOverridesFileAndDefaults(t *testing.T) {
+	t.Setenv("YYHERTZ_PRIMARY_HOST", "env-host")
+	t.Setenv("YYHERTZ_PRIMARY_MAX_OPEN_CONNS", "50")
+	t.Setenv("YYHERTZ_PRIMARY_SSL_MODE", "require")
+	t.Setenv("YYHERTZ_PRIMARY_SSL_CERT", "/etc/ssl/db.pem")
+	t.Setenv("YYHERTZ_REPLICA_HOSTS", "replica-1,replica-2")
+
+	manager := newEnvTestManager(t)
+	require.NoError(t, manager.Initialize())
+	cfg, err := manager.GetConfig()
+	require.NoError(t, err)
+
+	// 覆盖配置文件中的值
+	assert.Equal(t, "env-host", cfg.Primary.Host)
+	assert.Equal(t, 50, cfg.Primary.MaxOpenConns)
+	// 覆盖默认值
+	assert.Equal(t, "require", cfg.Primary.SSLMode)
+	// 既无默认值也未出现在配置文件中的键
+	assert.Equal(t, "/etc/ssl/db.pem", cfg.Primary.SSLCert)
+	// 切片以逗号分隔
+	assert.Equal(t, []string{"replica-1", "replica-2"}, cfg.Replica.Hosts)
+
+	assert.Equal(t, "env-host", manager.GetString("primary.host"))
+	// 未设置环境变量的键保持原值
+	assert.Equal(t, "mysql", cfg.Replica.Driver)
+}
+
+func TestViperConfigManager_CustomEnvPrefix(t *testing.T) {
+	t.Setenv("YYHERTZ_PRIMARY_HOST", "default-prefix-host")
+	t.Setenv("MYAPP_PRIMARY_HOST", "custom-prefix-host")
+
+	manager := newEnvTestManager(t).SetEnvPrefix("myapp")
+	require.NoError(t, manager.Initialize())
+	cfg, err := manager.GetConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "custom-prefix-host", cfg.Primary.Host)
+	assert.Equal(t, []string{"file-replica"}, cfg.Replica.Hosts)
+}
+
+func TestViperConfigManager_EnvKey(t *testing.T) {
+	manager := NewViperConfigManager(DatabaseConfig{})
+	assert.Equal(t, "YYHERTZ_PRIMARY_HOST", manager.EnvKey("primary.host"))
+	assert.Equal(t, "YYHERTZ_PRIMARY_MAX_OPEN_CONNS", manager.EnvKey("primary.max_open_conns"))
+	assert.Equal(t, "YYHERTZ_GORM_TABLE_PREFIX", manager.EnvKey("gorm.table-prefix"))
+
+	manager.SetEnvPrefix("")
+	assert.Equal(t, "PRIMARY_HOST", manager.EnvKey("primary.host"))
+}
+
+func TestConfigKeys(t *testing.T) {
+	type inner struct {
+		Value string `mapstructure:"value"`
+	}
+	type sample struct {
+		Name    string            `mapstructure:"name"`
+		Tags    []string          `mapstructure:"tags"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Nested  inner             `mapstructure:"nested"`
+		Pointer *inner            `mapstructure:"pointer"`
+		Embed   inner             `mapstructure:",squash"`
+		Ignored string            `mapstructure:"-"`
+		NoTag   int
+	}
+
+	assert.Equal(t, []string{"name", "tags", "labels", "nested.value", "pointer.value", "value", "notag"},
+		configKeys(reflect.TypeOf(sample{}), ""))
+}
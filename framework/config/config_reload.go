@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+
+	"github.com/spf13/viper"
+)
+
+// SetConfigFile 指定配置文件路径，需在初始化前调用
+func (gcm *ViperConfigManager[T]) SetConfigFile(path string) *ViperConfigManager[T] {
+	gcm.mu.Lock()
+	defer gcm.mu.Unlock()
+
+	gcm.configFile = path
+	return gcm
+}
+
+// SetValidator 设置热加载时的校验函数，校验失败的配置不会生效
+// 未设置时，配置类型实现了 Validate() error 则使用该方法校验
+func (gcm *ViperConfigManager[T]) SetValidator(validator func(config *T) error) *ViperConfigManager[T] {
+	gcm.mu.Lock()
+	defer gcm.mu.Unlock()
+
+	gcm.validator = validator
+	return gcm
+}
+
+// OnChange 订阅配置变更，配置重新加载且内容发生变化时以旧值与新值回调
+func (gcm *ViperConfigManager[T]) OnChange(listener func(oldConfig, newConfig *T)) {
+	gcm.mu.Lock()
+	defer gcm.mu.Unlock()
+
+	gcm.listeners = append(gcm.listeners, listener)
+}
+
+// Reload 重新读取配置文件，解析并校验通过后生效，内容变化时通知订阅者
+// 读取、解析或校验失败时返回错误并保留当前配置
+func (gcm *ViperConfigManager[T]) Reload() error {
+	gcm.ensureInitialized()
+
+	gcm.mu.Lock()
+	configFile := gcm.viper.ConfigFileUsed()
+	if configFile == "" {
+		gcm.mu.Unlock()
+		return fmt.Errorf("配置文件未找到: %s", gcm.configName)
+	}
+
+	var oldConfig T
+	if err := gcm.viper.Unmarshal(&oldConfig); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("解析当前配置失败: %w", err)
+	}
+
+	// 先在独立实例中解析与校验，通过后再应用
+	if info, err := os.Stat(configFile); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("读取配置文件失败: %w", err)
+	} else if info.Size() == 0 {
+		// 编辑器保存过程中可能出现空文件
+		gcm.mu.Unlock()
+		return fmt.Errorf("配置文件为空: %s", configFile)
+	}
+	candidate := viper.New()
+	gcm.configureViper(candidate)
+	candidate.SetConfigFile(configFile)
+	if err := candidate.ReadInConfig(); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	var newConfig T
+	if err := candidate.Unmarshal(&newConfig); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("解析配置失败: %w", err)
+	}
+	if err := gcm.validate(&newConfig); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("配置校验失败: %w", err)
+	}
+
+	if err := gcm.viper.ReadInConfig(); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	// 重新从当前实例解析，保留通过 Set 设置的值
+	if err := gcm.viper.Unmarshal(&newConfig); err != nil {
+		gcm.mu.Unlock()
+		return fmt.Errorf("解析配置失败: %w", err)
+	}
+	listeners := append([]func(oldConfig, newConfig *T){}, gcm.listeners...)
+	gcm.mu.Unlock()
+
+	log.Printf("配置文件重新加载成功 - file: %s", configFile)
+	if reflect.DeepEqual(oldConfig, newConfig) {
+		return nil
+	}
+	for _, listener := range listeners {
+		gcm.notify(listener, &oldConfig, &newConfig)
+	}
+	return nil
+}
+
+// validate 校验配置
+func (gcm *ViperConfigManager[T]) validate(config *T) error {
+	if gcm.validator != nil {
+		return gcm.validator(config)
+	}
+	if validator, ok := any(config).(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// notify 通知订阅者，订阅者panic不影响其他订阅者
+func (gcm *ViperConfigManager[T]) notify(listener func(oldConfig, newConfig *T), oldConfig, newConfig *T) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("配置变更回调异常 - config_name: %s, error: %v", gcm.configName, r)
+		}
+	}()
+	listener(oldConfig, newConfig)
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDatabaseConfig 以临时文件加重命名的方式原子写入数据库配置
+func writeDatabaseConfig(t *testing.T, path string, maxOpenConns int) {
+	t.Helper()
+	content := fmt.Sprintf("primary:\n  driver: sqlite\n  max_open_conns: %d\n", maxOpenConns)
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0644))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func newReloadTestManager(t *testing.T, maxOpenConns int) (*ViperConfigManager[DatabaseConfig], string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "database.yaml")
+	writeDatabaseConfig(t, path, maxOpenConns)
+
+	manager := NewViperConfigManager(DatabaseConfig{}).SetConfigFile(path)
+	require.NoError(t, manager.Initialize())
+	return manager, path
+}
+
+func TestViperConfigManager_ReloadNotifiesChanges(t *testing.T) {
+	manager, path := newReloadTestManager(t, 10)
+
+	var calls int
+	var oldValue, newValue int
+	manager.OnChange(func(oldConfig, newConfig *DatabaseConfig) {
+		calls++
+		oldValue, newValue = oldConfig.Primary.MaxOpenConns, newConfig.Primary.MaxOpenConns
+	})
+
+	// 内容未变化时不通知
+	require.NoError(t, manager.Reload())
+	assert.Equal(t, 0, calls)
+
+	writeDatabaseConfig(t, path, 20)
+	require.NoError(t, manager.Reload())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 10, oldValue)
+	assert.Equal(t, 20, newValue)
+
+	cfg, err := manager.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.Primary.MaxOpenConns)
+}
+
+func TestViperConfigManager_ReloadRejectsInvalidConfig(t *testing.T) {
+	manager, path := newReloadTestManager(t, 10)
+	manager.SetValidator(func(config *DatabaseConfig) error {
+		if config.Primary.MaxOpenConns <= 0 {
+			return errors.New("max_open_conns must be positive")
+		}
+		return nil
+	})
+
+	var calls int
+	manager.OnChange(func(oldConfig, newConfig *DatabaseConfig) { calls++ })
+
+	writeDatabaseConfig(t, path, -1)
+	assert.Error(t, manager.Reload())
+	assert.Equal(t, 0, calls)
+
+	cfg, err := manager.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.Primary.MaxOpenConns, "invalid config is not applied")
+
+	require.NoError(t, os.WriteFile(path, []byte("primary: [broken"), 0644))
+	assert.Error(t, manager.Reload())
+	assert.Equal(t, 0, calls)
+}
+
+func TestViperConfigManager_WatchConfigFiresCallback(t *testing.T) {
+	manager, path := newReloadTestManager(t, 10)
+
+	type change struct{ old, new int }
+	changes := make(chan change, 4)
+	manager.OnChange(func(oldConfig, newConfig *DatabaseConfig) {
+		changes <- change{oldConfig.Primary.MaxOpenConns, newConfig.Primary.MaxOpenConns}
+	})
+	manager.WatchConfig()
+
+	writeDatabaseConfig(t, path, 30)
+	select {
+	case got := <-changes:
+		assert.Equal(t, change{10, 30}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change callback was not fired")
+	}
+}
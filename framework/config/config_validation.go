@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FieldError 单个配置项的校验错误
+type FieldError struct {
+	Field   string // 配置键，如 primary.driver
+	Message string // 错误描述
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError 配置校验错误，汇总全部不合法的配置项
+type ValidationError struct {
+	ConfigName string
+	Fields     []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s 配置校验失败，共 %d 项:", e.ConfigName, len(e.Fields))
+	for _, field := range e.Fields {
+		b.WriteString("\n  - ")
+		b.WriteString(field.Error())
+	}
+	return b.String()
+}
+
+// HasField 是否包含指定配置键的校验错误
+func (e *ValidationError) HasField(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// configValidator 配置校验辅助，逐项检查并收集错误
+type configValidator struct {
+	configName string
+	fields     []*FieldError
+}
+
+func newConfigValidator(configName string) *configValidator {
+	return &configValidator{configName: configName}
+}
+
+// addf 记录一项校验错误
+func (v *configValidator) addf(field, format string, args ...any) {
+	v.fields = append(v.fields, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// oneOf 检查枚举值（不区分大小写）
+func (v *configValidator) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return
+		}
+	}
+	if value == "" {
+		v.addf(field, "不能为空，可选值: %s", strings.Join(allowed, ", "))
+		return
+	}
+	v.addf(field, "不支持的值 %q，可选值: %s", value, strings.Join(allowed, ", "))
+}
+
+// optionalOneOf 未设置时跳过，否则检查枚举值
+func (v *configValidator) optionalOneOf(field, value string, allowed ...string) {
+	if value != "" {
+		v.oneOf(field, value, allowed...)
+	}
+}
+
+// duration 检查时间间隔字符串，如 30s、5m；未设置时跳过
+func (v *configValidator) duration(field, value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		v.addf(field, "无效的时间间隔 %q，示例: 500ms, 30s, 5m", value)
+		return 0
+	}
+	if d < 0 {
+		v.addf(field, "时间间隔不能为负数: %s", value)
+	}
+	return d
+}
+
+// required 检查必填项
+func (v *configValidator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf(field, "不能为空")
+	}
+}
+
+// positive 检查正整数
+func (v *configValidator) positive(field string, value int) {
+	if value <= 0 {
+		v.addf(field, "必须大于0，当前为 %d", value)
+	}
+}
+
+// nonNegative 检查非负整数
+func (v *configValidator) nonNegative(field string, value int) {
+	if value < 0 {
+		v.addf(field, "不能为负数，当前为 %d", value)
+	}
+}
+
+// port 检查端口号，0 表示未设置
+func (v *configValidator) port(field string, value int) {
+	if value < 0 || value > 65535 {
+		v.addf(field, "端口号必须在 0-65535 之间，当前为 %d", value)
+	}
+}
+
+// err 返回汇总的校验错误，全部通过时返回nil
+func (v *configValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{ConfigName: v.configName, Fields: v.fields}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultsOf 返回仅包含默认值的配置
+func defaultsOf[T ConfigInterface](t *testing.T, config T) T {
+	t.Helper()
+	v := viper.New()
+	config.SetDefaults(v)
+	var out T
+	require.NoError(t, v.Unmarshal(&out))
+	return out
+}
+
+// requireInvalidFields 断言校验失败且包含全部指定配置键
+func requireInvalidFields(t *testing.T, err error, fields ...string) *ValidationError {
+	t.Helper()
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "expected *ValidationError, got %v", err)
+	for _, field := range fields {
+		assert.True(t, validationErr.HasField(field), "missing error for %s in:\n%s", field, validationErr)
+	}
+	return validationErr
+}
+
+func TestValidate_DefaultsAreValid(t *testing.T) {
+	configs := map[string]interface{ Validate() error }{
+		AppConfigName:      defaultsOf(t, AppConfig{}),
+		AuthConfigName:     defaultsOf(t, AuthConfig{}),
+		DatabaseConfigName: defaultsOf(t, DatabaseConfig{}),
+		LogConfigName:      defaultsOf(t, LogConfig{}),
+		MyBatisConfigName:  defaultsOf(t, MyBatisConfig{}),
+		RedisConfigName:    defaultsOf(t, RedisConfig{}),
+		SessionConfigName:  defaultsOf(t, SessionConfig{}),
+		TemplateConfigName: defaultsOf(t, TemplateConfig{}),
+		TLSConfigName:      defaultsOf(t, TLSServerConfig{}),
+	}
+	for name, cfg := range configs {
+		assert.NoError(t, cfg.Validate(), name)
+	}
+}
+
+func TestDatabaseConfig_Validate(t *testing.T) {
+	cfg := defaultsOf(t, DatabaseConfig{})
+	cfg.Primary.Driver = "mysq"
+	cfg.Primary.SSLMode = "on"
+	cfg.Primary.ConnMaxLifetime = "1 hour"
+	cfg.Primary.MaxOpenConns = 5
+	cfg.Primary.MaxIdleConns = 10
+	cfg.Replica.Enable = true
+	cfg.Replica.LoadBalancingStrategy = "fastest"
+
+	err := requireInvalidFields(t, cfg.Validate(),
+		"primary.driver",
+		"primary.ssl_mode",
+		"primary.conn_max_lifetime",
+		"primary.max_idle_conns",
+		"replica.hosts",
+		"replica.load_balancing_strategy",
+	)
+	assert.Len(t, err.Fields, 6)
+	assert.Contains(t, err.Error(), `primary.driver: 不支持的值 "mysq"，可选值: mysql, postgres, sqlite, sqlserver`)
+
+	// 启用从库并提供地址后通过
+	cfg = defaultsOf(t, DatabaseConfig{})
+	cfg.Replica.Enable = true
+	cfg.Replica.Hosts = []string{"replica-1:3306"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestRedisConfig_Validate(t *testing.T) {
+	cfg := defaultsOf(t, RedisConfig{})
+	cfg.Cluster.Enable = true
+	cfg.Performance.MinRetryBackoff = "1s"
+	cfg.Performance.MaxRetryBackoff = "500ms"
+	cfg.Cache.Serializer = "xml"
+
+	requireInvalidFields(t, cfg.Validate(), "cluster.nodes", "performance.min_retry_backoff", "cache.serializer")
+}
+
+func TestSessionConfig_Validate(t *testing.T) {
+	cfg := defaultsOf(t, SessionConfig{})
+	cfg.Cookie.SameSite = "none"
+	cfg.Cookie.Secure = false
+	cfg.Session.Store.Type = "redis"
+	cfg.Session.Store.Redis.Addr = ""
+	cfg.Cookie.Encrypt.Enable = true
+	cfg.Cookie.Encrypt.SecretKey = "short"
+
+	requireInvalidFields(t, cfg.Validate(), "cookie.secure", "session.store.redis.addr", "cookie.encrypt.secret_key")
+}
+
+func TestTLSServerConfig_Validate(t *testing.T) {
+	cfg := defaultsOf(t, TLSServerConfig{})
+	cfg.Version.MinVersion = "1.3"
+	cfg.Version.MaxVersion = "1.2"
+	cfg.ClientAuth.Mode = "RequireAndVerifyClientCert"
+	cfg.Cipher.Suites = []string{"TLS_FAKE_SUITE"}
+
+	requireInvalidFields(t, cfg.Validate(), "version.min_version", "client_auth.ca_file", "cipher.suites")
+}
+
+func TestAppConfig_ValidateIncludesLog(t *testing.T) {
+	cfg := defaultsOf(t, AppConfig{})
+	cfg.App.Port = 70000
+	cfg.App.ShutdownTimeout = "soon"
+	cfg.Log.Level = "verbose"
+	cfg.Middleware.RateLimit.Enable = true
+	cfg.Middleware.RateLimit.Strategy = "leaky"
+
+	requireInvalidFields(t, cfg.Validate(), "app.port", "app.shutdown_timeout", "log.level", "middleware.rate_limit.strategy")
+}
+
+func TestViperConfigManager_ReloadUsesValidate(t *testing.T) {
+	manager, path := newReloadTestManager(t, 10)
+
+	require.NoError(t, os.WriteFile(path, []byte("primary:\n  driver: oracle\n"), 0644))
+	err := manager.Reload()
+	requireInvalidFields(t, err, "primary.driver")
+
+	cfg, err := manager.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "sqlite", cfg.Primary.Driver)
+}
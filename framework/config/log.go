@@ -279,4 +279,31 @@ output_config:
     topic: "yyhertz-logs"
     client_id: "yyhertz-logger"
 `
-}
\ No newline at end of file
+}
+
+// Validate 校验日志配置，返回汇总全部不合法配置项的 *ValidationError
+func (c LogConfig) Validate() error {
+	v := newConfigValidator(LogConfigName)
+	c.validateInto(v, "")
+	return v.err()
+}
+
+// validateInto 将日志配置的校验结果记录到 v，prefix 为嵌入其他配置时的键前缀
+func (c LogConfig) validateInto(v *configValidator, prefix string) {
+	v.optionalOneOf(prefix+"level", string(c.Level),
+		string(LogLevelDebug), string(LogLevelInfo), string(LogLevelWarn),
+		string(LogLevelError), string(LogLevelFatal), string(LogLevelPanic))
+	v.optionalOneOf(prefix+"format", string(c.Format),
+		string(LogFormatJSON), string(LogFormatText), string(LogFormatBeego), string(LogFormatLog4Go),
+		string(LogFormatLogstash), string(LogFormatSyslog), string(LogFormatFluentd),
+		string(LogFormatCloudWatch), string(LogFormatApplicationInsights))
+	if c.EnableFile {
+		v.required(prefix+"file_path", c.FilePath)
+	}
+	v.nonNegative(prefix+"max_size", c.MaxSize)
+	v.nonNegative(prefix+"max_age", c.MaxAge)
+	v.nonNegative(prefix+"max_backups", c.MaxBackups)
+	if err := c.ValidateConfig(); err != nil {
+		v.addf(prefix+"output_config", "%v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -159,3 +161,38 @@ plugins:
     support_methods_arguments: true             # 支持方法参数
 `
 }
+
+// Validate 校验MyBatis配置，返回汇总全部不合法配置项的 *ValidationError
+func (c MyBatisConfig) Validate() error {
+	v := newConfigValidator(MyBatisConfigName)
+
+	if c.Basic.Enable {
+		v.required("basic.mapper_locations", c.Basic.MapperLocations)
+	}
+
+	if c.Cache.Enable {
+		v.oneOf("cache.type", c.Cache.Type, "memory", "redis")
+		if strings.EqualFold(c.Cache.Type, "redis") {
+			v.required("cache.redis_addr", c.Cache.RedisAddr)
+		}
+	}
+	v.nonNegative("cache.ttl", c.Cache.TTL)
+	v.nonNegative("cache.max_size", c.Cache.MaxSize)
+	v.nonNegative("cache.redis_db", c.Cache.RedisDB)
+
+	v.optionalOneOf("logging.level", c.Logging.Level, "debug", "info", "warn", "error")
+	v.nonNegative("logging.slow_query", c.Logging.SlowQuery)
+
+	v.optionalOneOf("performance.auto_mapping_behavior", c.Performance.AutoMappingBehavior, "NONE", "PARTIAL", "FULL")
+
+	v.nonNegative("transaction.default_timeout", c.Transaction.DefaultTimeout)
+	v.optionalOneOf("transaction.isolation_level", c.Transaction.IsolationLevel,
+		"DEFAULT", "READ_UNCOMMITTED", "READ_COMMITTED", "REPEATABLE_READ", "SERIALIZABLE")
+
+	if c.Plugins.PageHelper.Enable {
+		v.oneOf("plugins.page_helper.helper_dialect", c.Plugins.PageHelper.HelperDialect,
+			"mysql", "mariadb", "postgres", "postgresql", "sqlite", "sqlserver", "oracle")
+	}
+
+	return v.err()
+}
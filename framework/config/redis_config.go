@@ -347,3 +347,76 @@ development:
 #    performance.write_timeout = "1s"
 `
 }
+
+// Validate 校验Redis配置，返回汇总全部不合法配置项的 *ValidationError
+func (c RedisConfig) Validate() error {
+	v := newConfigValidator(RedisConfigName)
+
+	switch {
+	case c.Cluster.Enable && c.Sentinel.Enable:
+		v.addf("cluster.enable", "不能与 sentinel.enable 同时启用")
+	case c.Cluster.Enable:
+		if len(c.Cluster.Nodes) == 0 {
+			v.addf("cluster.nodes", "启用集群(cluster.enable)时至少需要一个节点地址")
+		}
+	case c.Sentinel.Enable:
+		if len(c.Sentinel.Addresses) == 0 {
+			v.addf("sentinel.addresses", "启用哨兵(sentinel.enable)时至少需要一个哨兵地址")
+		}
+		v.required("sentinel.master_name", c.Sentinel.MasterName)
+	default:
+		v.required("primary.host", c.Primary.Host)
+	}
+	v.port("primary.port", c.Primary.Port)
+	v.nonNegative("primary.database", c.Primary.Database)
+
+	// 连接池
+	v.nonNegative("pool.max_idle", c.Pool.MaxIdle)
+	v.nonNegative("pool.max_active", c.Pool.MaxActive)
+	if c.Pool.MaxActive > 0 && c.Pool.MaxIdle > c.Pool.MaxActive {
+		v.addf("pool.max_idle", "不能大于 max_active(%d)，当前为 %d", c.Pool.MaxActive, c.Pool.MaxIdle)
+	}
+	v.duration("pool.idle_timeout", c.Pool.IdleTimeout)
+	v.duration("pool.max_conn_timeout", c.Pool.MaxConnTimeout)
+
+	v.duration("sentinel.dial_timeout", c.Sentinel.DialTimeout)
+	v.duration("sentinel.read_timeout", c.Sentinel.ReadTimeout)
+	v.duration("sentinel.write_timeout", c.Sentinel.WriteTimeout)
+
+	// 性能
+	v.duration("performance.dial_timeout", c.Performance.DialTimeout)
+	v.duration("performance.read_timeout", c.Performance.ReadTimeout)
+	v.duration("performance.write_timeout", c.Performance.WriteTimeout)
+	v.duration("performance.pool_timeout", c.Performance.PoolTimeout)
+	v.duration("performance.idle_check_frequency", c.Performance.IdleCheckFrequency)
+	v.nonNegative("performance.max_retries", c.Performance.MaxRetries)
+	minBackoff := v.duration("performance.min_retry_backoff", c.Performance.MinRetryBackoff)
+	maxBackoff := v.duration("performance.max_retry_backoff", c.Performance.MaxRetryBackoff)
+	if minBackoff > 0 && maxBackoff > 0 && minBackoff > maxBackoff {
+		v.addf("performance.min_retry_backoff", "不能大于 max_retry_backoff(%s)，当前为 %s", maxBackoff, minBackoff)
+	}
+
+	// TLS
+	if c.TLS.Enable && (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		v.addf("tls.key_file", "tls.cert_file 与 tls.key_file 需同时设置")
+	}
+
+	v.duration("monitoring.slow_threshold", c.Monitoring.SlowThreshold)
+	v.duration("monitoring.stats_period", c.Monitoring.StatsPeriod)
+
+	// 缓存与Session
+	v.duration("cache.default_ttl", c.Cache.DefaultTTL)
+	v.optionalOneOf("cache.serializer", c.Cache.Serializer, "json", "msgpack", "gob")
+	v.duration("session.default_ttl", c.Session.DefaultTTL)
+	v.duration("session.gc_interval", c.Session.GCInterval)
+	v.optionalOneOf("session.serializer", c.Session.Serializer, "json", "msgpack", "gob")
+
+	// 限流
+	v.duration("rate_limit.default_window", c.RateLimit.DefaultWindow)
+	v.duration("rate_limit.cleanup_period", c.RateLimit.CleanupPeriod)
+	if c.RateLimit.Enable {
+		v.positive("rate_limit.default_limit", c.RateLimit.DefaultLimit)
+	}
+
+	return v.err()
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -357,3 +359,59 @@ development:
 #    session.security.csrf_protection = true // 启用CSRF保护
 `
 }
+
+// Validate 校验Session配置，返回汇总全部不合法配置项的 *ValidationError
+func (c SessionConfig) Validate() error {
+	v := newConfigValidator(SessionConfigName)
+	sameSite := []string{"Strict", "Lax", "None"}
+
+	// Cookie
+	v.required("cookie.name", c.Cookie.Name)
+	v.optionalOneOf("cookie.same_site", c.Cookie.SameSite, sameSite...)
+	if strings.EqualFold(c.Cookie.SameSite, "None") && !c.Cookie.Secure {
+		v.addf("cookie.secure", "cookie.same_site 为 None 时必须启用 secure")
+	}
+	if c.Cookie.Sign.Enable {
+		v.required("cookie.sign.secret", c.Cookie.Sign.Secret)
+	}
+	if c.Cookie.Encrypt.Enable {
+		v.oneOf("cookie.encrypt.algorithm", c.Cookie.Encrypt.Algorithm, "AES256", "AES128")
+		keyLen := map[string]int{"AES256": 32, "AES128": 16}[strings.ToUpper(c.Cookie.Encrypt.Algorithm)]
+		if keyLen > 0 && len(c.Cookie.Encrypt.SecretKey) < keyLen {
+			v.addf("cookie.encrypt.secret_key", "%s 至少需要 %d 字节密钥，当前为 %d", c.Cookie.Encrypt.Algorithm, keyLen, len(c.Cookie.Encrypt.SecretKey))
+		}
+	}
+	if c.Cookie.Compress.Enable {
+		v.oneOf("cookie.compress.algorithm", c.Cookie.Compress.Algorithm, "gzip", "deflate")
+		if c.Cookie.Compress.Level < 1 || c.Cookie.Compress.Level > 9 {
+			v.addf("cookie.compress.level", "压缩级别必须在 1-9 之间，当前为 %d", c.Cookie.Compress.Level)
+		}
+	}
+
+	// Session
+	v.required("session.name", c.Session.Name)
+	v.nonNegative("session.max_age", c.Session.MaxAge)
+	v.optionalOneOf("session.cookie.same_site", c.Session.Cookie.SameSite, sameSite...)
+	if strings.EqualFold(c.Session.Cookie.SameSite, "None") && !c.Session.Cookie.Secure {
+		v.addf("session.cookie.secure", "session.cookie.same_site 为 None 时必须启用 secure")
+	}
+	v.oneOf("session.store.type", c.Session.Store.Type, "cookie", "memory", "redis", "file")
+	switch strings.ToLower(c.Session.Store.Type) {
+	case "cookie":
+		v.required("session.secret", c.Session.Secret)
+	case "redis":
+		v.required("session.store.redis.addr", c.Session.Store.Redis.Addr)
+	case "file":
+		v.required("session.store.file.dir", c.Session.Store.File.Dir)
+	}
+	v.duration("session.store.memory.gc_interval", c.Session.Store.Memory.GCInterval)
+	v.duration("session.cleanup.interval", c.Session.Cleanup.Interval)
+	if c.Session.Cleanup.Enable && c.Session.Cleanup.Interval == "" {
+		v.addf("session.cleanup.interval", "启用过期清理(session.cleanup.enable)时不能为空")
+	}
+
+	v.optionalOneOf("middleware.session.error_handling", c.Middleware.Session.ErrorHandling, "ignore", "log", "panic")
+	v.duration("monitoring.report_interval", c.Monitoring.ReportInterval)
+
+	return v.err()
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/viper"
 )
 
@@ -209,3 +211,35 @@ development:
     open_browser: false          # 自动打开浏览器
 `
 }
+
+// Validate 校验模板配置，返回汇总全部不合法配置项的 *ValidationError
+func (c TemplateConfig) Validate() error {
+	v := newConfigValidator(TemplateConfigName)
+
+	v.required("engine.type", c.Engine.Type)
+	v.required("engine.directory", c.Engine.Directory)
+	if len(c.Engine.Delimiters) != 0 && len(c.Engine.Delimiters) != 2 {
+		v.addf("engine.delimiters", "需要左右两个分隔符，当前为 %d 个", len(c.Engine.Delimiters))
+	}
+
+	if c.Cache.Enable {
+		v.oneOf("cache.type", c.Cache.Type, "memory", "redis", "file")
+		if strings.EqualFold(c.Cache.Type, "file") {
+			v.required("cache.cache_dir", c.Cache.CacheDir)
+		}
+	}
+	v.nonNegative("cache.ttl", c.Cache.TTL)
+	v.nonNegative("cache.max_size", c.Cache.MaxSize)
+
+	if c.Static.Enable {
+		v.required("static.root", c.Static.Root)
+	}
+	v.nonNegative("static.cache_duration", c.Static.CacheDuration)
+
+	if c.Components.Enable {
+		v.required("components.directory", c.Components.Directory)
+	}
+	v.port("development.dev_server.port", c.Development.DevServer.Port)
+
+	return v.err()
+}
@@ -16,6 +16,7 @@ type TLSManager struct {
 	config      *TLSServerConfig
 	tlsConfig   *tls.Config
 	certWatcher *CertWatcher
+	ticketKeys  *ticketKeyRotator
 }
 
 // TLSServerConfig TLS服务器配置
@@ -91,6 +92,7 @@ type TLSServerConfig struct {
 		TicketsEnabled    bool   `mapstructure:"tickets_enabled" yaml:"tickets_enabled" json:"tickets_enabled"`
 		TicketKey         string `mapstructure:"ticket_key" yaml:"ticket_key" json:"ticket_key"`
 		TicketKeyRotation bool   `mapstructure:"ticket_key_rotation" yaml:"ticket_key_rotation" json:"ticket_key_rotation"`
+		TicketKeyInterval int    `mapstructure:"ticket_key_interval" yaml:"ticket_key_interval" json:"ticket_key_interval"` // 秒
 		TicketLifetime    int    `mapstructure:"ticket_lifetime" yaml:"ticket_lifetime" json:"ticket_lifetime"` // 秒
 		CacheSize         int    `mapstructure:"cache_size" yaml:"cache_size" json:"cache_size"`
 		CacheTTL          int    `mapstructure:"cache_ttl" yaml:"cache_ttl" json:"cache_ttl"` // 秒
@@ -198,6 +200,7 @@ func DefaultTLSServerConfig() *TLSServerConfig {
 	// 会话配置
 	config.Session.TicketsEnabled = true
 	config.Session.TicketKeyRotation = true
+	config.Session.TicketKeyInterval = 3600
 	config.Session.TicketLifetime = 3600
 	config.Session.CacheSize = 1000
 	config.Session.CacheTTL = 300
@@ -223,6 +226,10 @@ func NewTLSManager(config *TLSServerConfig) (*TLSManager, error) {
 		if config.AutoManagement.Enable {
 			manager.startCertWatcher()
 		}
+
+		if config.Session.TicketsEnabled && config.Session.TicketKeyRotation {
+			manager.startTicketKeyRotation(time.Duration(config.Session.TicketKeyInterval) * time.Second)
+		}
 	}
 
 	log.Printf("TLS管理器初始化完成: enabled=%v, auto_reload=%v, min_version=%s, max_version=%s",
@@ -246,7 +253,7 @@ func (m *TLSManager) loadTLSConfig() error {
 	tlsConfig := &tls.Config{
 		Certificates:             []tls.Certificate{cert},
 		PreferServerCipherSuites: m.config.Cipher.PreferServer,
-		NextProtos:               m.config.ALPN.NextProtos,
+		NextProtos:               m.config.EnabledNextProtos(),
 		SessionTicketsDisabled:   !m.config.Session.TicketsEnabled,
 	}
 
@@ -295,6 +302,11 @@ func (m *TLSManager) loadTLSConfig() error {
 		tlsConfig.SetSessionTicketKeys([][32]byte{[32]byte(key)})
 	}
 
+	// 证书重载后沿用轮换中的密钥，已签发的票据仍可恢复
+	if m.ticketKeys != nil {
+		m.ticketKeys.apply(tlsConfig)
+	}
+
 	m.tlsConfig = tlsConfig
 
 	log.Printf("TLS配置加载成功: cert_file=%s, key_file=%s, client_auth=%s, cipher_count=%d",
@@ -306,6 +318,26 @@ func (m *TLSManager) loadTLSConfig() error {
 	return nil
 }
 
+// EnabledNextProtos 返回ALPN协商的协议列表：按 alpn.next_protos 的顺序（服务端优先级），
+// h2 与 http/1.1 分别受 h2_enabled、http1_enabled 控制，已启用但未列出的协议追加在末尾
+func (c *TLSServerConfig) EnabledNextProtos() []string {
+	enabled := map[string]bool{
+		"h2":       c.ALPN.H2Enabled,
+		"http/1.1": c.ALPN.HTTP1Enabled,
+	}
+
+	protos := make([]string, 0, len(c.ALPN.NextProtos)+len(enabled))
+	seen := make(map[string]bool)
+	for _, proto := range append(append([]string(nil), c.ALPN.NextProtos...), "h2", "http/1.1") {
+		if on, known := enabled[proto]; (known && !on) || seen[proto] || proto == "" {
+			continue
+		}
+		seen[proto] = true
+		protos = append(protos, proto)
+	}
+	return protos
+}
+
 // GetTLSConfig 获取TLS配置
 func (m *TLSManager) GetTLSConfig() *tls.Config {
 	return m.tlsConfig
@@ -383,13 +415,17 @@ func (w *CertWatcher) watch(manager *TLSManager) {
 	}
 }
 
-// Stop 停止证书监视器
+// Stop 停止证书监视器与票据密钥轮换
 func (m *TLSManager) Stop() {
 	if m.certWatcher != nil {
 		close(m.certWatcher.stopChan)
 		m.certWatcher = nil
 		GetGlobalLogger().Info("证书监视器已停止")
 	}
+	if m.ticketKeys != nil {
+		m.ticketKeys.stop()
+		GetGlobalLogger().Info("会话票据密钥轮换已停止")
+	}
 }
 
 // parseTLSVersion 解析TLS版本
@@ -453,6 +489,8 @@ func parseCipherSuites(suites []string) ([]uint16, error) {
 		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
 		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
 		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":          tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":        tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
 	}
 
 	for _, suite := range suites {
@@ -548,6 +586,7 @@ func (c TLSServerConfig) SetDefaults(v *viper.Viper) {
 	v.SetDefault("session.tickets_enabled", true)
 	v.SetDefault("session.ticket_key", "")
 	v.SetDefault("session.ticket_key_rotation", false)
+	v.SetDefault("session.ticket_key_interval", 3600)
 	v.SetDefault("session.ticket_lifetime", 86400)
 	v.SetDefault("session.cache_size", 1000)
 	v.SetDefault("session.cache_ttl", 3600)
@@ -660,6 +699,7 @@ session:
   tickets_enabled: true                      # 启用会话票据
   ticket_key: ""                             # 会话票据密钥
   ticket_key_rotation: false                 # 启用票据密钥轮换
+  ticket_key_interval: 3600                  # 票据密钥轮换间隔(秒)
   ticket_lifetime: 86400                     # 会话票据生存时间(秒)
   cache_size: 1000                           # 会话缓存大小
   cache_ttl: 3600                            # 会话缓存TTL(秒)
@@ -701,3 +741,67 @@ monitoring:
   stats_interval: 60                         # 统计信息更新间隔(秒)
 `
 }
+
+// Validate 校验TLS配置，返回汇总全部不合法配置项的 *ValidationError
+func (c TLSServerConfig) Validate() error {
+	v := newConfigValidator(TLSConfigName)
+
+	if c.Basic.Enable {
+		if c.Certificate.CertFile == "" && c.Certificate.CertData == "" {
+			v.addf("certificate.cert_file", "启用TLS(basic.enable)时需要设置 cert_file 或 cert_data")
+		}
+		if c.Certificate.KeyFile == "" && c.Certificate.KeyData == "" {
+			v.addf("certificate.key_file", "启用TLS(basic.enable)时需要设置 key_file 或 key_data")
+		}
+	}
+	v.nonNegative("certificate.validity_period", c.Certificate.ValidityPeriod)
+
+	var minVersion, maxVersion uint16
+	if c.Version.MinVersion != "" {
+		var err error
+		if minVersion, err = parseTLSVersion(c.Version.MinVersion); err != nil {
+			v.addf("version.min_version", "%v，可选值: 1.0, 1.1, 1.2, 1.3", err)
+		}
+	}
+	if c.Version.MaxVersion != "" {
+		var err error
+		if maxVersion, err = parseTLSVersion(c.Version.MaxVersion); err != nil {
+			v.addf("version.max_version", "%v，可选值: 1.0, 1.1, 1.2, 1.3", err)
+		}
+	}
+	if minVersion != 0 && maxVersion != 0 && minVersion > maxVersion {
+		v.addf("version.min_version", "不能高于 max_version(%s)，当前为 %s", c.Version.MaxVersion, c.Version.MinVersion)
+	}
+
+	if _, err := parseCipherSuites(c.Cipher.Suites); err != nil {
+		v.addf("cipher.suites", "%v", err)
+	}
+
+	if c.ClientAuth.Mode != "" {
+		if mode, err := parseClientAuth(c.ClientAuth.Mode); err != nil {
+			v.addf("client_auth.mode", "%v", err)
+		} else if mode >= tls.VerifyClientCertIfGiven && c.ClientAuth.CAFile == "" && c.ClientAuth.CAData == "" {
+			v.addf("client_auth.ca_file", "客户端认证模式 %s 需要设置 ca_file 或 ca_data", c.ClientAuth.Mode)
+		}
+	}
+	v.nonNegative("client_auth.verify_depth", c.ClientAuth.VerifyDepth)
+
+	if c.AutoManagement.Enable {
+		v.positive("auto_management.reload_interval", c.AutoManagement.ReloadInterval)
+	}
+	if c.Session.TicketKey != "" && len(c.Session.TicketKey) != 32 {
+		v.addf("session.ticket_key", "会话票据密钥长度必须为32字节，当前为 %d", len(c.Session.TicketKey))
+	}
+	if c.Session.TicketsEnabled && c.Session.TicketKeyRotation {
+		v.positive("session.ticket_key_interval", c.Session.TicketKeyInterval)
+	}
+	if c.HSTS.Enable {
+		v.positive("hsts.max_age", c.HSTS.MaxAge)
+	}
+
+	v.nonNegative("performance.read_timeout", c.Performance.ReadTimeout)
+	v.nonNegative("performance.write_timeout", c.Performance.WriteTimeout)
+	v.nonNegative("performance.idle_timeout", c.Performance.IdleTimeout)
+
+	return v.err()
+}
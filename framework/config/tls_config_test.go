@@ -0,0 +1,200 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert 在临时目录生成自签名证书，返回证书与私钥文件路径
+func writeSelfSignedCert(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+// negotiatedProtocol 使用指定ALPN协议列表与服务端握手，返回协商结果
+func negotiatedProtocol(t *testing.T, serverConfig *tls.Config, clientProtos ...string) string {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	server := tls.Server(serverConn, serverConfig)
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Handshake() }()
+
+	client := tls.Client(clientConn, &tls.Config{InsecureSkipVerify: true, NextProtos: clientProtos})
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-errCh)
+	return client.ConnectionState().NegotiatedProtocol
+}
+
+func TestTLSServerConfig_EnabledNextProtos(t *testing.T) {
+	cfg := defaultsOf(t, TLSServerConfig{})
+	assert.Equal(t, []string{"h2", "http/1.1"}, cfg.EnabledNextProtos())
+
+	cfg.ALPN.H2Enabled = false
+	assert.Equal(t, []string{"http/1.1"}, cfg.EnabledNextProtos())
+
+	cfg.ALPN.H2Enabled = true
+	cfg.ALPN.HTTP1Enabled = false
+	assert.Equal(t, []string{"h2"}, cfg.EnabledNextProtos())
+
+	// next_protos 决定顺序，启用但未列出的协议追加在末尾，自定义协议保留
+	cfg.ALPN.HTTP1Enabled = true
+	cfg.ALPN.NextProtos = []string{"http/1.1", "acme-tls/1"}
+	assert.Equal(t, []string{"http/1.1", "acme-tls/1", "h2"}, cfg.EnabledNextProtos())
+
+	cfg.ALPN.NextProtos = nil
+	cfg.ALPN.H2Enabled = false
+	cfg.ALPN.HTTP1Enabled = false
+	assert.Empty(t, cfg.EnabledNextProtos())
+}
+
+func TestTLSManager_NegotiatesConfiguredProtocol(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+	newManager := func(h2, http1 bool) *tls.Config {
+		cfg := defaultsOf(t, TLSServerConfig{})
+		cfg.Basic.Enable = true
+		cfg.Certificate.CertFile = certFile
+		cfg.Certificate.KeyFile = keyFile
+		cfg.ALPN.H2Enabled = h2
+		cfg.ALPN.HTTP1Enabled = http1
+		manager, err := NewTLSManager(&cfg)
+		require.NoError(t, err)
+		return manager.GetTLSConfig()
+	}
+
+	enabled := newManager(true, true)
+	assert.Equal(t, "h2", negotiatedProtocol(t, enabled, "h2", "http/1.1"))
+	assert.Equal(t, "http/1.1", negotiatedProtocol(t, enabled, "http/1.1"))
+
+	// 关闭h2后h2客户端协商为HTTP/1.1
+	h2Disabled := newManager(false, true)
+	assert.Equal(t, []string{"http/1.1"}, h2Disabled.NextProtos)
+	assert.Equal(t, "http/1.1", negotiatedProtocol(t, h2Disabled, "h2", "http/1.1"))
+}
+
+// newTicketTestManager 创建启用TLS的管理器，由测试控制票据密钥轮换
+func newTicketTestManager(t *testing.T, rotation bool) *TLSManager {
+	t.Helper()
+	certFile, keyFile := writeSelfSignedCert(t)
+	cfg := defaultsOf(t, TLSServerConfig{})
+	cfg.Basic.Enable = true
+	cfg.Certificate.CertFile = certFile
+	cfg.Certificate.KeyFile = keyFile
+	cfg.Session.TicketKeyRotation = rotation
+	manager, err := NewTLSManager(&cfg)
+	require.NoError(t, err)
+	t.Cleanup(manager.Stop)
+	return manager
+}
+
+// resumedHandshake 通过本地TCP连接握手（共享会话缓存），返回本次连接是否恢复了会话
+func resumedHandshake(t *testing.T, serverConfig *tls.Config, cache tls.ClientSessionCache) bool {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer conn.Close()
+		// TLS 1.3 的会话票据在握手后下发，客户端读取数据时才会保存
+		_, err = conn.Write([]byte("x"))
+		errCh <- err
+	}()
+
+	client, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{InsecureSkipVerify: true, ServerName: "localhost", ClientSessionCache: cache})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Read(make([]byte, 1))
+	require.NoError(t, err)
+	require.NoError(t, <-errCh)
+	return client.ConnectionState().DidResume
+}
+
+func TestTicketKeyWindow(t *testing.T) {
+	assert.Equal(t, 2, ticketKeyWindow(3600, time.Hour))
+	assert.Equal(t, 3, ticketKeyWindow(5400, time.Hour))
+	assert.Equal(t, 2, ticketKeyWindow(0, time.Hour))
+	assert.Equal(t, maxSessionTicketKeys, ticketKeyWindow(86400, time.Minute))
+}
+
+func TestTLSManager_RotatesTicketKeysOnInterval(t *testing.T) {
+	manager := newTicketTestManager(t, false)
+	assert.Error(t, manager.RotateSessionTicketKeys(), "rotation disabled")
+
+	manager.startTicketKeyRotation(20 * time.Millisecond)
+	initial := manager.ticketKeys.currentKeys()
+	require.Len(t, initial, 1)
+
+	assert.Eventually(t, func() bool {
+		keys := manager.ticketKeys.currentKeys()
+		return len(keys) == manager.ticketKeys.window && keys[0] != initial[0]
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// 停止后不再轮换
+	manager.Stop()
+	stopped := manager.ticketKeys.currentKeys()
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, stopped, manager.ticketKeys.currentKeys())
+}
+
+func TestTLSManager_ResumesSessionAcrossRotation(t *testing.T) {
+	manager := newTicketTestManager(t, true)
+	require.NotNil(t, manager.ticketKeys)
+	serverConfig := manager.GetTLSConfig()
+	cache := tls.NewLRUClientSessionCache(8)
+
+	assert.False(t, resumedHandshake(t, serverConfig, cache))
+	assert.True(t, resumedHandshake(t, serverConfig, cache))
+
+	// 轮换一次后旧密钥仍在窗口内，票据可恢复
+	require.NoError(t, manager.RotateSessionTicketKeys())
+	assert.True(t, resumedHandshake(t, serverConfig, cache))
+
+	// 旧密钥全部移出窗口后无法恢复
+	for i := 0; i < manager.ticketKeys.window; i++ {
+		require.NoError(t, manager.RotateSessionTicketKeys())
+	}
+	assert.Len(t, manager.ticketKeys.currentKeys(), manager.ticketKeys.window)
+	assert.False(t, resumedHandshake(t, serverConfig, cache))
+}
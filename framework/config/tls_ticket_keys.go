@@ -0,0 +1,150 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// maxSessionTicketKeys 轮换时最多保留的票据密钥数量（含当前密钥）
+const maxSessionTicketKeys = 8
+
+// ticketKeyRotator 会话票据密钥轮换器
+// 新密钥用于签发票据，保留的旧密钥仅用于解密，使轮换前签发的票据仍可恢复会话
+type ticketKeyRotator struct {
+	mu       sync.Mutex
+	keys     [][32]byte
+	window   int
+	interval time.Duration
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// ticketKeyWindow 根据票据生存时间计算需保留的密钥数量，覆盖票据的整个有效期
+func ticketKeyWindow(lifetime int, interval time.Duration) int {
+	window := 2
+	if interval > 0 && lifetime > 0 {
+		lifetimeDuration := time.Duration(lifetime) * time.Second
+		window = int((lifetimeDuration+interval-1)/interval) + 1
+	}
+	if window < 2 {
+		window = 2
+	}
+	if window > maxSessionTicketKeys {
+		window = maxSessionTicketKeys
+	}
+	return window
+}
+
+// newSessionTicketKey 生成随机票据密钥
+func newSessionTicketKey() ([32]byte, error) {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return key, fmt.Errorf("生成会话票据密钥失败: %w", err)
+	}
+	return key, nil
+}
+
+// rotate 生成新密钥置于首位，超出窗口的旧密钥被淘汰
+func (r *ticketKeyRotator) rotate() error {
+	key, err := newSessionTicketKey()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := append([][32]byte{key}, r.keys...)
+	if len(keys) > r.window {
+		keys = keys[:r.window]
+	}
+	r.keys = keys
+	return nil
+}
+
+// apply 将当前密钥集合设置到TLS配置
+func (r *ticketKeyRotator) apply(tlsConfig *tls.Config) {
+	if tlsConfig == nil {
+		return
+	}
+	r.mu.Lock()
+	keys := append([][32]byte(nil), r.keys...)
+	r.mu.Unlock()
+	if len(keys) > 0 {
+		tlsConfig.SetSessionTicketKeys(keys)
+	}
+}
+
+// currentKeys 返回当前密钥集合的副本
+func (r *ticketKeyRotator) currentKeys() [][32]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][32]byte(nil), r.keys...)
+}
+
+// stop 停止轮换
+func (r *ticketKeyRotator) stop() {
+	r.stopOnce.Do(func() { close(r.stopChan) })
+}
+
+// startTicketKeyRotation 启动会话票据密钥轮换，配置了 ticket_key 时作为首个密钥
+func (m *TLSManager) startTicketKeyRotation(interval time.Duration) {
+	if m.ticketKeys != nil || interval <= 0 {
+		return
+	}
+
+	rotator := &ticketKeyRotator{
+		window:   ticketKeyWindow(m.config.Session.TicketLifetime, interval),
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+	if key := m.config.Session.TicketKey; len(key) == 32 {
+		rotator.keys = [][32]byte{[32]byte([]byte(key))}
+	} else if err := rotator.rotate(); err != nil {
+		GetGlobalLogger().WithFields(map[string]any{
+			"error": err.Error(),
+		}).Error("初始化会话票据密钥失败")
+		return
+	}
+
+	m.ticketKeys = rotator
+	rotator.apply(m.tlsConfig)
+
+	go m.watchTicketKeys(rotator)
+
+	log.Printf("会话票据密钥轮换启动: interval=%s, window=%d", interval, rotator.window)
+}
+
+// watchTicketKeys 按间隔轮换票据密钥
+func (m *TLSManager) watchTicketKeys(rotator *ticketKeyRotator) {
+	ticker := time.NewTicker(rotator.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.RotateSessionTicketKeys(); err != nil {
+				GetGlobalLogger().WithFields(map[string]any{
+					"error": err.Error(),
+				}).Error("轮换会话票据密钥失败")
+			}
+		case <-rotator.stopChan:
+			return
+		}
+	}
+}
+
+// RotateSessionTicketKeys 立即轮换会话票据密钥，未启用轮换时返回错误
+func (m *TLSManager) RotateSessionTicketKeys() error {
+	if m.ticketKeys == nil {
+		return fmt.Errorf("会话票据密钥轮换未启用")
+	}
+	if err := m.ticketKeys.rotate(); err != nil {
+		return err
+	}
+	m.ticketKeys.apply(m.GetTLSConfig())
+	return nil
+}
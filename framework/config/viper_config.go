@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -56,8 +55,13 @@ type ViperConfigManager[T ConfigInterface] struct {
 	configName  string
 	configType  string
 	envPrefix   string
+	configFile  string // 显式指定的配置文件路径，优先于 configName 与 configPaths
 	initialized bool
 	mu          sync.RWMutex
+
+	validator func(config *T) error           // 热加载时的校验函数
+	listeners []func(oldConfig, newConfig *T) // 配置变更订阅者
+	watching  bool                            // 是否已开始监听配置文件
 }
 
 // 全局泛型配置管理器存储
@@ -78,7 +82,7 @@ func NewViperConfigManager[T ConfigInterface](config T) *ViperConfigManager[T] {
 		configPaths: []string{"./conf"},
 		configName:  configName,
 		configType:  "yaml",
-		envPrefix:   "YYHERTZ",
+		envPrefix:   DefaultEnvPrefix,
 		initialized: false,
 	}
 }
@@ -129,22 +133,7 @@ func (gcm *ViperConfigManager[T]) initializeInternal() error {
 		return nil
 	}
 
-	// 设置配置文件名和类型
-	gcm.viper.SetConfigName(gcm.configName)
-	gcm.viper.SetConfigType(gcm.configType)
-
-	// 添加配置文件搜索路径
-	for _, path := range gcm.configPaths {
-		gcm.viper.AddConfigPath(path)
-	}
-
-	// 设置环境变量前缀
-	gcm.viper.SetEnvPrefix(gcm.envPrefix)
-	gcm.viper.AutomaticEnv()
-	gcm.viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
-	// 使用配置结构体设置默认值
-	gcm.config.SetDefaults(gcm.viper)
+	gcm.configureViper(gcm.viper)
 
 	// 尝试读取配置文件
 	if err := gcm.viper.ReadInConfig(); err != nil {
@@ -167,6 +156,30 @@ func (gcm *ViperConfigManager[T]) initializeInternal() error {
 	return nil
 }
 
+// configureViper 设置配置文件、环境变量与默认值
+func (gcm *ViperConfigManager[T]) configureViper(v *viper.Viper) {
+	// 设置配置文件名和类型
+	if gcm.configFile != "" {
+		v.SetConfigFile(gcm.configFile)
+	} else {
+		v.SetConfigName(gcm.configName)
+		// 添加配置文件搜索路径
+		for _, path := range gcm.configPaths {
+			v.AddConfigPath(path)
+		}
+	}
+	v.SetConfigType(gcm.configType)
+
+	// 设置环境变量前缀，配置键 a.b_c 对应环境变量 <PREFIX>_A_B_C
+	v.SetEnvPrefix(gcm.envPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	gcm.bindEnvKeys(v)
+
+	// 使用配置结构体设置默认值
+	gcm.config.SetDefaults(v)
+}
+
 // createDefaultConfigFile 创建默认配置文件
 func (gcm *ViperConfigManager[T]) createDefaultConfigFile() error {
 	// 使用第一个配置路径，或默认使用 ./conf
@@ -511,7 +524,7 @@ func (gcm *ViperConfigManager[T]) IsSet(key string) bool {
 	return gcm.viper.IsSet(key)
 }
 
-// WatchConfig 监听配置文件变化
+// WatchConfig 监听配置文件变化，变更内容校验通过后生效并通知 OnChange 订阅者
 func (gcm *ViperConfigManager[T]) WatchConfig() {
 	gcm.mu.Lock()
 	defer gcm.mu.Unlock()
@@ -520,17 +533,28 @@ func (gcm *ViperConfigManager[T]) WatchConfig() {
 		// 直接调用内部初始化方法，避免重复加锁
 		gcm.initializeInternal()
 	}
+	if gcm.watching {
+		return
+	}
 
-	gcm.viper.WatchConfig()
-	gcm.viper.OnConfigChange(func(e fsnotify.Event) {
+	configFile := gcm.viper.ConfigFileUsed()
+	if configFile == "" {
+		log.Printf("未找到配置文件，无法监听 - config_name: %s", gcm.configName)
+		return
+	}
+
+	// 使用独立的viper实例监听文件，避免未经校验的内容直接覆盖当前配置
+	watcher := viper.New()
+	watcher.SetConfigFile(configFile)
+	watcher.SetConfigType(gcm.configType)
+	watcher.OnConfigChange(func(e fsnotify.Event) {
 		log.Printf("配置文件发生变化，重新加载 - file: %s, operation: %s", e.Name, e.Op.String())
-		if err := gcm.viper.ReadInConfig(); err != nil {
-			log.Printf("重新加载配置文件失败 - error: %s", err.Error())
-			_, _ = gcm.GetConfig()
-		} else {
-			log.Printf("配置文件重新加载成功 - file: %s", gcm.viper.ConfigFileUsed())
+		if err := gcm.Reload(); err != nil {
+			log.Printf("重新加载配置文件失败，保留当前配置 - error: %s", err.Error())
 		}
 	})
+	watcher.WatchConfig()
+	gcm.watching = true
 }
 
 // ConfigFileUsed 获取当前使用的配置文件路径
@@ -0,0 +1,225 @@
+// Package event 进程内领域事件总线
+//
+// 通过类型化的Topic发布与订阅事件，支持同步与异步订阅者：
+//
+//	var UserCreated = event.NewTopic[*User]("user.created")
+//
+//	UserCreated.Subscribe(bus, func(ctx context.Context, u *User) error { return audit(u) })
+//	UserCreated.SubscribeAsync(bus, func(ctx context.Context, u *User) error { return sendWelcomeEmail(u) })
+//
+//	err := UserCreated.Publish(ctx, bus, user)
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// ErrBusClosed 事件总线已关闭
+var ErrBusClosed = errors.New("event: bus is closed")
+
+// Handler 类型化事件处理函数
+type Handler[T any] func(ctx context.Context, payload T) error
+
+// AsyncErrorHandler 异步订阅者错误处理函数
+type AsyncErrorHandler func(topic string, err error)
+
+// subscriber 订阅者
+type subscriber struct {
+	id      uint64
+	async   bool
+	handler func(ctx context.Context, payload any) error
+}
+
+// Bus 进程内事件总线
+// 同步订阅者在Publish调用方的goroutine中按订阅顺序执行，异步订阅者在独立goroutine中执行；
+// 任一订阅者panic都会被隔离为错误，不影响其他订阅者
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]*subscriber
+	types       map[string]reflect.Type
+	nextID      uint64
+	closed      bool
+	pending     sync.WaitGroup
+	onAsyncErr  AsyncErrorHandler
+}
+
+// NewBus 创建事件总线
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]*subscriber),
+		types:       make(map[string]reflect.Type),
+		onAsyncErr: func(topic string, err error) {
+			config.Errorf("Event subscriber for %s failed: %v", topic, err)
+		},
+	}
+}
+
+// 默认事件总线
+var defaultBus = NewBus()
+
+// Default 获取默认事件总线
+func Default() *Bus {
+	return defaultBus
+}
+
+// SetAsyncErrorHandler 设置异步订阅者的错误处理函数（默认记录错误日志）
+func (b *Bus) SetAsyncErrorHandler(handler AsyncErrorHandler) *Bus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onAsyncErr = handler
+	return b
+}
+
+// SubscriberCount 获取主题的订阅者数量
+func (b *Bus) SubscriberCount(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[topic])
+}
+
+// Shutdown 关闭事件总线，拒绝新的发布并等待进行中的异步订阅者执行完毕
+// ctx到期时停止等待并返回ctx的错误
+func (b *Bus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// subscribe 登记订阅者，返回取消订阅函数
+func (b *Bus) subscribe(topic string, payloadType reflect.Type, async bool, handler func(ctx context.Context, payload any) error) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if existing, ok := b.types[topic]; ok && existing != payloadType {
+		panic(fmt.Sprintf("event: topic %s already registered with payload type %s, got %s", topic, existing, payloadType))
+	}
+	b.types[topic] = payloadType
+
+	b.nextID++
+	sub := &subscriber{id: b.nextID, async: async, handler: handler}
+	b.subscribers[topic] = append(b.subscribers[topic], sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			subs := b.subscribers[topic]
+			for i, s := range subs {
+				if s.id == sub.id {
+					b.subscribers[topic] = append(subs[:i:i], subs[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// publish 分发事件，返回同步订阅者的错误
+func (b *Bus) publish(ctx context.Context, topic string, payload any) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBusClosed
+	}
+	subs := append([]*subscriber(nil), b.subscribers[topic]...)
+	onAsyncErr := b.onAsyncErr
+	// 在持有读锁时登记异步任务，保证Shutdown能等待到本次发布的全部异步订阅者
+	for _, sub := range subs {
+		if sub.async {
+			b.pending.Add(1)
+		}
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.async {
+			go func(sub *subscriber) {
+				defer b.pending.Done()
+				// 异步订阅者不受发布方请求上下文取消的影响
+				if err := invoke(context.WithoutCancel(ctx), sub, payload); err != nil && onAsyncErr != nil {
+					onAsyncErr(topic, err)
+				}
+			}(sub)
+			continue
+		}
+		if err := invoke(ctx, sub, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// invoke 执行订阅者，panic转换为错误
+func invoke(ctx context.Context, sub *subscriber, payload any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event: subscriber panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return sub.handler(ctx, payload)
+}
+
+// Topic 类型化事件主题，payload类型在编译期确定
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic 创建事件主题
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name 主题名称
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribe 添加同步订阅者，返回取消订阅函数
+func (t Topic[T]) Subscribe(bus *Bus, handler Handler[T]) func() {
+	return bus.subscribe(t.name, t.payloadType(), false, t.wrap(handler))
+}
+
+// SubscribeAsync 添加异步订阅者，返回取消订阅函数
+func (t Topic[T]) SubscribeAsync(bus *Bus, handler Handler[T]) func() {
+	return bus.subscribe(t.name, t.payloadType(), true, t.wrap(handler))
+}
+
+// Publish 发布事件，返回同步订阅者的错误（多个错误合并返回）
+func (t Topic[T]) Publish(ctx context.Context, bus *Bus, payload T) error {
+	return bus.publish(ctx, t.name, payload)
+}
+
+// payloadType 获取事件载荷类型
+func (t Topic[T]) payloadType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// wrap 将类型化处理函数转换为总线内部的处理函数
+func (t Topic[T]) wrap(handler Handler[T]) func(ctx context.Context, payload any) error {
+	return func(ctx context.Context, payload any) error {
+		value, _ := payload.(T)
+		return handler(ctx, value)
+	}
+}
@@ -0,0 +1,149 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userCreated struct {
+	ID    int64
+	Email string
+}
+
+var testUserCreated = NewTopic[userCreated]("user.created")
+
+func TestBus_PublishReachesAllSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	var audited, mailed []int64
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error {
+		audited = append(audited, e.ID)
+		return nil
+	})
+	testUserCreated.SubscribeAsync(bus, func(ctx context.Context, e userCreated) error {
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		mailed = append(mailed, e.ID)
+		return nil
+	})
+	assert.Equal(t, 2, bus.SubscriberCount("user.created"))
+
+	require.NoError(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 1, Email: "a@example.com"}))
+	wg.Wait()
+
+	assert.Equal(t, []int64{1}, audited)
+	assert.Equal(t, []int64{1}, mailed)
+}
+
+func TestBus_PanickingSubscriberIsIsolated(t *testing.T) {
+	bus := NewBus()
+	asyncErrs := make(chan error, 1)
+	bus.SetAsyncErrorHandler(func(topic string, err error) { asyncErrs <- err })
+
+	var calls atomic.Int32
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error {
+		panic("boom")
+	})
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error {
+		calls.Add(1)
+		return nil
+	})
+	testUserCreated.SubscribeAsync(bus, func(ctx context.Context, e userCreated) error {
+		panic("async boom")
+	})
+
+	err := testUserCreated.Publish(context.Background(), bus, userCreated{ID: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Equal(t, int32(1), calls.Load(), "subscriber after a panicking one should still run")
+
+	select {
+	case asyncErr := <-asyncErrs:
+		assert.Contains(t, asyncErr.Error(), "async boom")
+	case <-time.After(time.Second):
+		t.Fatal("async panic was not reported")
+	}
+}
+
+func TestBus_SyncErrorsAreJoined(t *testing.T) {
+	bus := NewBus()
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error { return errA })
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error { return errB })
+
+	err := testUserCreated.Publish(context.Background(), bus, userCreated{ID: 3})
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := NewBus()
+	var calls atomic.Int32
+	unsubscribe := testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error {
+		calls.Add(1)
+		return nil
+	})
+
+	require.NoError(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 4}))
+	unsubscribe()
+	unsubscribe()
+	require.NoError(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 5}))
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, bus.SubscriberCount("user.created"))
+}
+
+func TestBus_TopicTypeMismatchPanics(t *testing.T) {
+	bus := NewBus()
+	testUserCreated.Subscribe(bus, func(ctx context.Context, e userCreated) error { return nil })
+
+	assert.Panics(t, func() {
+		NewTopic[string]("user.created").Subscribe(bus, func(ctx context.Context, s string) error { return nil })
+	})
+}
+
+func TestBus_ShutdownDrainsAsyncSubscribers(t *testing.T) {
+	bus := NewBus()
+	var finished atomic.Bool
+	testUserCreated.SubscribeAsync(bus, func(ctx context.Context, e userCreated) error {
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+
+	require.NoError(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 6}))
+	require.NoError(t, bus.Shutdown(context.Background()))
+	assert.True(t, finished.Load(), "shutdown should wait for in-flight async subscribers")
+
+	// 关闭后拒绝发布
+	assert.ErrorIs(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 7}), ErrBusClosed)
+}
+
+func TestBus_ShutdownHonorsContext(t *testing.T) {
+	bus := NewBus()
+	release := make(chan struct{})
+	defer close(release)
+	testUserCreated.SubscribeAsync(bus, func(ctx context.Context, e userCreated) error {
+		<-release
+		return nil
+	})
+
+	require.NoError(t, testUserCreated.Publish(context.Background(), bus, userCreated{ID: 8}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Shutdown(ctx), context.DeadlineExceeded)
+}
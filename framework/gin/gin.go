@@ -372,6 +372,11 @@ func (c *Context) BindJSON(obj any) error {
 	return c.MustBindWith(obj, binding.JSON)
 }
 
+// BindQuery 绑定查询参数，支持 ids=1&ids=2 绑定切片、filter[a]=x 绑定map，失败时返回400
+func (c *Context) BindQuery(obj any) error {
+	return c.MustBindWith(obj, binding.Query)
+}
+
 // ShouldBindJSON 应该绑定JSON
 func (c *Context) ShouldBindJSON(obj any) error {
 	return c.ShouldBindWith(obj, binding.JSON)
@@ -0,0 +1,43 @@
+package gin
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// listQuery 切片与map查询参数
+type listQuery struct {
+	IDs    []int             `form:"ids"`
+	Filter map[string]string `form:"filter"`
+}
+
+func TestContext_BindQuery(t *testing.T) {
+	c := &Context{RequestContext: ut.CreateUtRequestContext("GET", "/items?ids=1&ids=2&filter[a]=x", nil)}
+
+	var q listQuery
+	if err := c.BindQuery(&q); err != nil {
+		t.Fatalf("Expected query to bind, got %v", err)
+	}
+	if len(q.IDs) != 2 || q.IDs[1] != 2 || q.Filter["a"] != "x" {
+		t.Errorf("Unexpected binding result %+v", q)
+	}
+	if c.IsAborted() {
+		t.Error("Expected context not to be aborted")
+	}
+}
+
+func TestContext_BindQueryConversionError(t *testing.T) {
+	c := &Context{RequestContext: ut.CreateUtRequestContext("GET", "/items?ids=1&ids=two", nil)}
+
+	var q listQuery
+	if err := c.BindQuery(&q); err == nil {
+		t.Fatal("Expected conversion error")
+	}
+	if code := c.Response.StatusCode(); code != 400 {
+		t.Errorf("Expected status 400, got %d", code)
+	}
+	if !c.IsAborted() || len(c.Errors) != 1 {
+		t.Errorf("Expected context aborted with error, aborted=%v errors=%v", c.IsAborted(), c.Errors)
+	}
+}
@@ -0,0 +1,237 @@
+// Package health 提供存活（liveness）与就绪（readiness）健康检查
+//
+// 存活检查只反映进程自身是否可用；就绪检查会探测主库、从库、Redis等外部依赖，
+// 任一依赖不可用时返回503，便于负载均衡器或Kubernetes摘除实例：
+//
+//	checker := health.NewChecker()
+//	checker.AddReadiness("primary", health.DBProbe(db))
+//	checker.AddReadiness("redis", health.RedisProbe("localhost:6379", "", 0))
+//	checker.Mount(app)
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"gorm.io/gorm"
+)
+
+// 依赖状态
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Probe 依赖探测函数，返回nil表示依赖可用
+type Probe func(ctx context.Context) error
+
+// CheckResult 单个依赖的检查结果
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report 健康检查报告
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	Timestamp string                 `json:"timestamp"`
+}
+
+// Healthy 是否全部依赖可用
+func (r *Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// namedProbe 带名称的探测函数
+type namedProbe struct {
+	name  string
+	probe Probe
+}
+
+// Checker 健康检查器
+type Checker struct {
+	mu        sync.RWMutex
+	liveness  []namedProbe
+	readiness []namedProbe
+	timeout   time.Duration
+	livePath  string
+	readyPath string
+}
+
+// NewChecker 创建健康检查器，默认单个探测超时2秒
+func NewChecker() *Checker {
+	return &Checker{
+		timeout:   2 * time.Second,
+		livePath:  "/health/live",
+		readyPath: "/health/ready",
+	}
+}
+
+// SetTimeout 设置单个探测的超时时间
+func (c *Checker) SetTimeout(timeout time.Duration) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timeout = timeout
+	return c
+}
+
+// SetPaths 设置存活与就绪检查的路由路径
+func (c *Checker) SetPaths(livePath, readyPath string) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.livePath = livePath
+	c.readyPath = readyPath
+	return c
+}
+
+// AddLiveness 添加存活探测（应只检查进程自身，不应依赖外部服务）
+func (c *Checker) AddLiveness(name string, probe Probe) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, namedProbe{name: name, probe: probe})
+	return c
+}
+
+// AddReadiness 添加就绪探测（数据库、缓存等外部依赖）
+func (c *Checker) AddReadiness(name string, probe Probe) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, namedProbe{name: name, probe: probe})
+	return c
+}
+
+// Liveness 执行存活检查
+func (c *Checker) Liveness(ctx context.Context) *Report {
+	c.mu.RLock()
+	probes := append([]namedProbe(nil), c.liveness...)
+	c.mu.RUnlock()
+	return c.run(ctx, probes)
+}
+
+// Readiness 执行就绪检查，存活探测同样计入就绪结果
+func (c *Checker) Readiness(ctx context.Context) *Report {
+	c.mu.RLock()
+	probes := append(append([]namedProbe(nil), c.liveness...), c.readiness...)
+	c.mu.RUnlock()
+	return c.run(ctx, probes)
+}
+
+// run 并发执行探测并汇总结果
+func (c *Checker) run(ctx context.Context, probes []namedProbe) *Report {
+	c.mu.RLock()
+	timeout := c.timeout
+	c.mu.RUnlock()
+
+	report := &Report{
+		Status:    StatusUp,
+		Checks:    make(map[string]CheckResult, len(probes)),
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, p := range probes {
+		wg.Add(1)
+		go func(p namedProbe) {
+			defer wg.Done()
+			result := runProbe(ctx, p.probe, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[p.name] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(p)
+	}
+	wg.Wait()
+	return report
+}
+
+// runProbe 在超时时间内执行单个探测
+func runProbe(ctx context.Context, probe Probe, timeout time.Duration) CheckResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("probe panic: %v", r)
+			}
+		}()
+		done <- probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: StatusUp, Duration: time.Since(start).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessHandler 存活检查处理器
+func (c *Checker) LivenessHandler() app.HandlerFunc {
+	return c.handler(c.Liveness)
+}
+
+// ReadinessHandler 就绪检查处理器
+func (c *Checker) ReadinessHandler() app.HandlerFunc {
+	return c.handler(c.Readiness)
+}
+
+// handler 输出检查报告，全部可用时返回200，否则返回503
+func (c *Checker) handler(check func(ctx context.Context) *Report) app.HandlerFunc {
+	return func(ctx context.Context, rc *app.RequestContext) {
+		report := check(ctx)
+		code := consts.StatusOK
+		if !report.Healthy() {
+			code = consts.StatusServiceUnavailable
+		}
+		rc.JSON(code, report)
+	}
+}
+
+// Mount 注册存活与就绪检查路由
+func (c *Checker) Mount(r route.IRoutes) {
+	c.mu.RLock()
+	livePath, readyPath := c.livePath, c.readyPath
+	c.mu.RUnlock()
+
+	r.GET(livePath, c.LivenessHandler())
+	r.GET(readyPath, c.ReadinessHandler())
+}
+
+// DBProbe 数据库探测，使用连接池的PingContext
+func DBProbe(db *gorm.DB) Probe {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("database not configured")
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
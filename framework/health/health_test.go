@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+func openHealthTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db
+}
+
+// closedHealthTestDB 返回连接池已关闭、Ping必然失败的数据库
+func closedHealthTestDB(t *testing.T) *gorm.DB {
+	db := openHealthTestDB(t)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	return db
+}
+
+// newProbeRedisClient 创建连接指定地址的go-redis客户端
+func newProbeRedisClient(t *testing.T, addr string) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// unreachableAddr 返回一个没有服务监听的地址
+func unreachableAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return addr
+}
+
+func performHealth(t *testing.T, checker *Checker, path string) (int, Report) {
+	engine := route.NewEngine(hertzconfig.NewOptions(nil))
+	checker.Mount(engine)
+
+	resp := ut.PerformRequest(engine, "GET", path, nil).Result()
+	var report Report
+	require.NoError(t, json.Unmarshal(resp.Body(), &report))
+	return resp.StatusCode(), report
+}
+
+func TestChecker_ReadinessAllUp(t *testing.T) {
+	checker := NewChecker().
+		AddReadiness("primary", DBProbe(openHealthTestDB(t))).
+		AddReadiness("replica_0", DBProbe(openHealthTestDB(t))).
+		AddReadiness("redis", RedisProbe(newProbeRedisClient(t, miniredis.RunT(t).Addr())))
+
+	code, report := performHealth(t, checker, "/health/ready")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, StatusUp, report.Status)
+	require.Len(t, report.Checks, 3)
+	for name, result := range report.Checks {
+		assert.Equal(t, StatusUp, result.Status, name)
+	}
+}
+
+func TestChecker_ReadinessDependencyDown(t *testing.T) {
+	checker := NewChecker().
+		SetTimeout(500*time.Millisecond).
+		AddReadiness("primary", DBProbe(openHealthTestDB(t))).
+		AddReadiness("replica_0", DBProbe(closedHealthTestDB(t))).
+		AddReadiness("redis", RedisProbe(newProbeRedisClient(t, unreachableAddr(t))))
+
+	code, report := performHealth(t, checker, "/health/ready")
+	assert.Equal(t, 503, code)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["primary"].Status)
+	assert.Equal(t, StatusDown, report.Checks["replica_0"].Status)
+	assert.NotEmpty(t, report.Checks["replica_0"].Error)
+	assert.Equal(t, StatusDown, report.Checks["redis"].Status)
+	assert.NotEmpty(t, report.Checks["redis"].Error)
+
+	// 存活检查不探测外部依赖
+	code, report = performHealth(t, checker, "/health/live")
+	assert.Equal(t, 200, code)
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Empty(t, report.Checks)
+}
+
+func TestChecker_ProbeTimeout(t *testing.T) {
+	checker := NewChecker().SetTimeout(20 * time.Millisecond)
+	checker.AddReadiness("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	report := checker.Readiness(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Contains(t, report.Checks["slow"].Error, "deadline exceeded")
+}
+
+func TestNewCheckerFromConfig(t *testing.T) {
+	cfg := &config.DatabaseConfig{}
+	cfg.Cache.Enable = true
+	cfg.Cache.Type = "redis"
+	cfg.Cache.RedisAddr = miniredis.RunT(t).Addr()
+
+	checker := NewCheckerFromConfig(cfg, openHealthTestDB(t), openHealthTestDB(t))
+	report := checker.Readiness(context.Background())
+	assert.True(t, report.Healthy())
+	assert.Len(t, report.Checks, 3)
+	assert.Contains(t, report.Checks, "primary")
+	assert.Contains(t, report.Checks, "replica_0")
+	assert.Contains(t, report.Checks, "redis")
+}
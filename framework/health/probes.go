@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	frameworkcache "github.com/zsy619/yyhertz/framework/cache"
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// RedisProbe Redis探测，通过go-redis客户端发送 PING
+func RedisProbe(client redis.Cmdable) Probe {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// NewCheckerFromConfig 根据数据库配置创建健康检查器
+// primary与replicas分别注册为 primary、replica_<序号> 就绪探测；缓存类型为redis时使用共享的Redis客户端注册 redis 就绪探测
+func NewCheckerFromConfig(cfg *config.DatabaseConfig, primary *gorm.DB, replicas ...*gorm.DB) *Checker {
+	checker := NewChecker()
+	if primary != nil {
+		checker.AddReadiness("primary", DBProbe(primary))
+	}
+	for i, replica := range replicas {
+		checker.AddReadiness(fmt.Sprintf("replica_%d", i), DBProbe(replica))
+	}
+
+	if cfg != nil && cfg.Cache.Enable && strings.EqualFold(cfg.Cache.Type, "redis") {
+		client := frameworkcache.SharedRedisClient(cfg.Cache.RedisAddr, cfg.Cache.RedisPassword, cfg.Cache.RedisDB)
+		checker.AddReadiness("redis", RedisProbe(client))
+	}
+	return checker
+}
@@ -6,12 +6,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/zsy619/yyhertz/framework/config"
 )
 
+// 请求上下文中的存储键
+const (
+	LocaleKey = "__yyhertz_locale"      // 本次请求解析得到的语言
+	BundleKey = "__yyhertz_i18n_bundle" // 本次请求使用的消息包
+)
+
+// Params 命名占位符参数，消息中的 {name} 替换为对应的值，count 同时用于选择复数形式
+type Params map[string]any
+
 // I18n 国际化管理器
 type I18n struct {
 	defaultLocale string
@@ -29,18 +42,33 @@ func NewI18n(defaultLocale string) *I18n {
 	}
 }
 
-// LoadMessages 加载消息文件
+// LoadMessages 加载消息文件，支持 JSON 与 YAML（.yaml/.yml）格式
+// 嵌套的键以"."连接；复数消息使用 zero/one/other 子键，如 items.one、items.other
 func (i *I18n) LoadMessages(locale, filePath string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	var messages map[string]string
-	if err := json.Unmarshal(data, &messages); err != nil {
-		return err
+	var messages map[string]any
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &messages)
+	default:
+		err = json.Unmarshal(data, &messages)
+	}
+	if err != nil {
+		return fmt.Errorf("parse messages %s: %w", filePath, err)
 	}
 
+	flat := make(map[string]string)
+	flattenMessages("", messages, flat)
+	i.AddMessages(locale, flat)
+	return nil
+}
+
+// AddMessages 添加消息，已存在的键被覆盖
+func (i *I18n) AddMessages(locale string, messages map[string]string) {
 	i.mutex.Lock()
 	defer i.mutex.Unlock()
 
@@ -51,39 +79,243 @@ func (i *I18n) LoadMessages(locale, filePath string) error {
 	for key, value := range messages {
 		i.messages[locale][key] = value
 	}
+}
 
-	return nil
+// flattenMessages 将嵌套的消息展开为"."连接的键
+func flattenMessages(prefix string, value any, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			flattenMessages(joinKey(prefix, key), child, out)
+		}
+	case map[any]any:
+		for key, child := range v {
+			flattenMessages(joinKey(prefix, fmt.Sprint(key)), child, out)
+		}
+	case nil:
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
 }
 
-// T 翻译函数
+// T 翻译函数，使用当前语言
 func (i *I18n) T(key string, args ...any) string {
+	return i.Translate(i.GetLocale(), key, args...)
+}
+
+// Translate 使用指定语言翻译
+// 依次尝试 locale、其基础语言（zh-TW -> zh）与默认语言，均未找到时返回键名
+// args 为单个 Params 时替换命名占位符，否则按 fmt.Sprintf 格式化；复数形式根据 count（或第一个整数参数）选择
+func (i *I18n) Translate(locale, key string, args ...any) string {
 	i.mutex.RLock()
 	defer i.mutex.RUnlock()
 
-	// 尝试当前语言
-	if messages, exists := i.messages[i.currentLocale]; exists {
-		if msg, exists := messages[key]; exists {
-			if len(args) > 0 {
-				return fmt.Sprintf(msg, args...)
+	count, hasCount := pluralCount(args)
+	for _, candidate := range i.fallbackChain(locale) {
+		messages, exists := i.messages[candidate]
+		if !exists {
+			continue
+		}
+		if msg, exists := lookupMessage(messages, key, count, hasCount); exists {
+			return formatMessage(msg, args)
+		}
+	}
+
+	// 返回键名作为后备
+	return key
+}
+
+// fallbackChain 语言回退链：locale -> 基础语言 -> 默认语言 -> 默认语言的基础语言
+func (i *I18n) fallbackChain(locale string) []string {
+	chain := make([]string, 0, 4)
+	add := func(l string) {
+		if l == "" {
+			return
+		}
+		for _, existing := range chain {
+			if existing == l {
+				return
 			}
-			return msg
 		}
+		chain = append(chain, l)
 	}
+	add(locale)
+	add(baseLanguage(locale))
+	add(i.defaultLocale)
+	add(baseLanguage(i.defaultLocale))
+	return chain
+}
 
-	// 尝试默认语言
-	if i.currentLocale != i.defaultLocale {
-		if messages, exists := i.messages[i.defaultLocale]; exists {
-			if msg, exists := messages[key]; exists {
-				if len(args) > 0 {
-					return fmt.Sprintf(msg, args...)
-				}
-				return msg
+// lookupMessage 查找消息，键不存在时按数量查找复数形式
+func lookupMessage(messages map[string]string, key string, count int64, hasCount bool) (string, bool) {
+	if hasCount {
+		forms := []string{"other"}
+		switch count {
+		case 0:
+			forms = []string{"zero", "other"}
+		case 1:
+			forms = []string{"one", "other"}
+		}
+		for _, form := range forms {
+			if msg, exists := messages[key+"."+form]; exists {
+				return msg, true
 			}
 		}
 	}
+	msg, exists := messages[key]
+	return msg, exists
+}
 
-	// 返回键名作为后备
-	return key
+// pluralCount 从参数中获取用于选择复数形式的数量
+func pluralCount(args []any) (int64, bool) {
+	if len(args) == 1 {
+		if params, ok := args[0].(Params); ok {
+			value, exists := params["count"]
+			if !exists {
+				return 0, false
+			}
+			return toInt64(value)
+		}
+	}
+	for _, arg := range args {
+		if n, ok := toInt64(arg); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// formatMessage 替换命名占位符或按 fmt.Sprintf 格式化
+func formatMessage(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+	if len(args) == 1 {
+		if params, ok := args[0].(Params); ok {
+			pairs := make([]string, 0, len(params)*2)
+			for name, value := range params {
+				pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+			}
+			return strings.NewReplacer(pairs...).Replace(msg)
+		}
+	}
+	if !strings.Contains(msg, "%") {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// MatchLocale 根据 Accept-Language 请求头选择已加载的语言，无匹配时返回默认语言
+// 按q值从高到低匹配；完全匹配（不区分大小写）优先，其次匹配相同基础语言的语言
+func (i *I18n) MatchLocale(acceptLanguage string) string {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+		for locale := range i.messages {
+			if strings.EqualFold(locale, tag) {
+				return locale
+			}
+		}
+		base := baseLanguage(tag)
+		if locale, exists := i.messagesWithBase(base); exists {
+			return locale
+		}
+	}
+	return i.defaultLocale
+}
+
+// messagesWithBase 查找基础语言相同的已加载语言，结果按名称排序保证稳定
+func (i *I18n) messagesWithBase(base string) (string, bool) {
+	var matches []string
+	for locale := range i.messages {
+		if strings.EqualFold(baseLanguage(locale), base) {
+			matches = append(matches, locale)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	sort.Strings(matches)
+	return matches[0], true
+}
+
+// parseAcceptLanguage 解析 Accept-Language，按q值降序返回语言标签，q=0的标签被忽略
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: strings.ReplaceAll(tag, "_", "-"), q: q})
+		}
+	}
+	sort.SliceStable(tags, func(a, b int) bool {
+		return tags[a].q > tags[b].q
+	})
+
+	result := make([]string, len(tags))
+	for idx, t := range tags {
+		result[idx] = t.tag
+	}
+	return result
+}
+
+// baseLanguage 获取语言标签的基础语言，如 zh-CN -> zh
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return base
 }
 
 // SetLocale 设置当前语言
@@ -100,9 +332,19 @@ func (i *I18n) GetLocale() string {
 	return i.currentLocale
 }
 
+// DefaultLocale 获取默认语言
+func (i *I18n) DefaultLocale() string {
+	return i.defaultLocale
+}
+
 // 全局实例
 var globalI18n = NewI18n("en")
 
+// Default 获取全局国际化管理器
+func Default() *I18n {
+	return globalI18n
+}
+
 // T 全局翻译函数
 func T(key string, args ...any) string {
 	return globalI18n.T(key, args...)
@@ -118,16 +360,22 @@ func LoadMessages(locale, filePath string) error {
 	return globalI18n.LoadMessages(locale, filePath)
 }
 
-// LoadMessagesFromDir 从目录加载所有消息文件
+// LoadMessagesFromDir 从目录加载所有消息文件（文件名即语言，如 zh-CN.yaml）
 func LoadMessagesFromDir(dir string) error {
+	return globalI18n.LoadMessagesFromDir(dir)
+}
+
+// LoadMessagesFromDir 从目录加载所有消息文件（文件名即语言，如 zh-CN.yaml）
+func (i *I18n) LoadMessagesFromDir(dir string) error {
 	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if !info.IsDir() && strings.HasSuffix(path, ".json") {
-			locale := strings.TrimSuffix(info.Name(), ".json")
-			if err := LoadMessages(locale, path); err != nil {
+		ext := filepath.Ext(info.Name())
+		if !info.IsDir() && (ext == ".json" || ext == ".yaml" || ext == ".yml") {
+			locale := strings.TrimSuffix(info.Name(), ext)
+			if err := i.LoadMessages(locale, path); err != nil {
 				config.Errorf("Failed to load messages for locale %s: %v", locale, err)
 			} else {
 				config.Infof("Loaded messages for locale: %s", locale)
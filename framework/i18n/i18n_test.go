@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBundle 从临时目录加载英文(YAML)与简体中文(JSON)两种语言
+func newTestBundle(t *testing.T) *I18n {
+	dir := t.TempDir()
+	en := `
+greeting: "Hello, {name}!"
+welcome: "Welcome"
+items:
+  zero: "No items"
+  one: "One item"
+  other: "{count} items"
+user:
+  points: "%s has %d points"
+`
+	zh := `{
+  "greeting": "你好，{name}！",
+  "items": {"other": "{count} 件商品"},
+  "user": {"points": "%s 有 %d 积分"}
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en.yaml"), []byte(en), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zh-CN.json"), []byte(zh), 0o644))
+
+	bundle := NewI18n("en")
+	require.NoError(t, bundle.LoadMessagesFromDir(dir))
+	return bundle
+}
+
+func TestTranslate(t *testing.T) {
+	bundle := newTestBundle(t)
+
+	assert.Equal(t, "Hello, Alice!", bundle.Translate("en", "greeting", Params{"name": "Alice"}))
+	assert.Equal(t, "你好，Alice！", bundle.Translate("zh-CN", "greeting", Params{"name": "Alice"}))
+	assert.Equal(t, "Bob 有 3 积分", bundle.Translate("zh-CN", "user.points", "Bob", 3))
+
+	// 复数形式
+	assert.Equal(t, "No items", bundle.Translate("en", "items", Params{"count": 0}))
+	assert.Equal(t, "One item", bundle.Translate("en", "items", Params{"count": 1}))
+	assert.Equal(t, "5 items", bundle.Translate("en", "items", Params{"count": 5}))
+	assert.Equal(t, "1 件商品", bundle.Translate("zh-CN", "items", Params{"count": 1}))
+}
+
+func TestTranslate_Fallback(t *testing.T) {
+	bundle := newTestBundle(t)
+
+	// 中文缺少的键回退到默认语言
+	assert.Equal(t, "Welcome", bundle.Translate("zh-CN", "welcome"))
+	// 未加载的语言回退到默认语言
+	assert.Equal(t, "Hello, Alice!", bundle.Translate("fr-FR", "greeting", Params{"name": "Alice"}))
+	// 所有语言均缺少时返回键名
+	assert.Equal(t, "missing.key", bundle.Translate("zh-CN", "missing.key"))
+}
+
+func TestMatchLocale(t *testing.T) {
+	bundle := newTestBundle(t)
+
+	cases := map[string]string{
+		"":                        "en",
+		"zh-CN,zh;q=0.9,en;q=0.8": "zh-CN",
+		"zh-cn":                   "zh-CN",
+		"zh-TW":                   "zh-CN", // 基础语言相同
+		"fr-FR,en-US;q=0.7":       "en",
+		"fr-FR, de;q=0.5":         "en",
+		"en;q=0.5, zh-CN;q=0.9":   "zh-CN",
+		"zh-CN;q=0, en-GB":        "en",
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, bundle.MatchLocale(header), "Accept-Language: %q", header)
+	}
+}
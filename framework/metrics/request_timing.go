@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// 请求来源
+const (
+	RequestSourceManager    = "manager"    // 优化控制器管理器（OptimizedControllerManager）
+	RequestSourceDispatcher = "dispatcher" // 标准MVC分发器（App路由）
+)
+
+// RequestTimings 请求耗时统计注册表
+// 优化控制器管理器与标准分发器共用同一注册表，无论使用哪种控制器风格，总数与平均耗时保持一致
+type RequestTimings struct {
+	mu      sync.Mutex
+	sources map[string]*requestTiming
+}
+
+// requestTiming 单个来源的耗时统计
+type requestTiming struct {
+	count int64
+	total time.Duration
+}
+
+// RequestTimingSnapshot 请求耗时统计快照
+type RequestTimingSnapshot struct {
+	TotalRequests       int64            // 总请求数
+	TotalDuration       time.Duration    // 总耗时
+	AverageResponseTime time.Duration    // 平均响应时间
+	Requests            map[string]int64 // 按来源统计的请求数
+}
+
+// NewRequestTimings 创建请求耗时统计注册表
+func NewRequestTimings() *RequestTimings {
+	return &RequestTimings{sources: make(map[string]*requestTiming)}
+}
+
+// Record 记录一次请求耗时
+func (rt *RequestTimings) Record(source string, duration time.Duration) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	timing, exists := rt.sources[source]
+	if !exists {
+		timing = &requestTiming{}
+		rt.sources[source] = timing
+	}
+	timing.count++
+	timing.total += duration
+}
+
+// Snapshot 获取统计快照
+func (rt *RequestTimings) Snapshot() RequestTimingSnapshot {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	snapshot := RequestTimingSnapshot{Requests: make(map[string]int64, len(rt.sources))}
+	for source, timing := range rt.sources {
+		snapshot.Requests[source] = timing.count
+		snapshot.TotalRequests += timing.count
+		snapshot.TotalDuration += timing.total
+	}
+	if snapshot.TotalRequests > 0 {
+		snapshot.AverageResponseTime = snapshot.TotalDuration / time.Duration(snapshot.TotalRequests)
+	}
+	return snapshot
+}
+
+// Reset 清空统计
+func (rt *RequestTimings) Reset() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.sources = make(map[string]*requestTiming)
+}
+
+// 全局请求耗时注册表
+var globalRequestTimings = NewRequestTimings()
+
+// RequestTimingRegistry 获取全局请求耗时注册表
+func RequestTimingRegistry() *RequestTimings {
+	return globalRequestTimings
+}
+
+// RecordRequest 记录全局请求耗时
+func RecordRequest(source string, duration time.Duration) {
+	globalRequestTimings.Record(source, duration)
+}
+
+// GetRequestTimings 获取全局请求耗时统计
+func GetRequestTimings() RequestTimingSnapshot {
+	return globalRequestTimings.Snapshot()
+}
+
+// ResetRequestTimings 清空全局请求耗时统计
+func ResetRequestTimings() {
+	globalRequestTimings.Reset()
+}
@@ -6,7 +6,10 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/zsy619/yyhertz/framework/mvc/context"
 )
 
 // ParameterValidator 参数验证器
@@ -23,11 +26,49 @@ type ValidationRule interface {
 
 // ValidationError 验证错误
 type ValidationError struct {
-	Field   string      // 字段名
-	Tag     string      // 验证标签
-	Value   interface{} // 实际值
-	Param   string      // 验证参数
-	Message string      // 错误消息
+	Field   string      `json:"field"`           // 字段名（优先使用json标签名）
+	Tag     string      `json:"rule"`            // 验证标签
+	Value   interface{} `json:"-"`               // 实际值（可能包含敏感信息，不输出）
+	Param   string      `json:"param,omitempty"` // 验证参数
+	Message string      `json:"message"`         // 错误消息
+}
+
+// ValidationErrors 结构体验证的全部错误，每个字段记录第一个未通过的规则
+type ValidationErrors []*ValidationError
+
+var (
+	validationMessages   = make(map[string]string)
+	validationMessagesMu sync.RWMutex
+)
+
+// SetValidationMessage 设置验证标签的自定义错误消息，支持 {field} 与 {param} 占位符，message为空时恢复默认消息
+//
+//	binding.SetValidationMessage("required", "{field}不能为空")
+//	binding.SetValidationMessage("min", "{field}不能少于{param}")
+func SetValidationMessage(tag, message string) {
+	validationMessagesMu.Lock()
+	defer validationMessagesMu.Unlock()
+	if message == "" {
+		delete(validationMessages, tag)
+		return
+	}
+	validationMessages[tag] = message
+}
+
+// validationMessage 获取验证标签的错误消息，未设置自定义消息时使用规则返回的默认消息
+func validationMessage(tag, field, param, fallback string) string {
+	validationMessagesMu.RLock()
+	message, ok := validationMessages[tag]
+	validationMessagesMu.RUnlock()
+	if !ok {
+		return fallback
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(message)
+}
+
+// init 注册默认结构体验证器，供 Context.BindAndValidate 使用
+func init() {
+	context.SetStructValidator(NewParameterValidator())
 }
 
 // NewParameterValidator 创建参数验证器
@@ -82,7 +123,7 @@ func (pv *ParameterValidator) ValidateValue(value interface{}, tags map[string]s
 					Tag:     tag,
 					Value:   value,
 					Param:   param,
-					Message: err.Error(),
+					Message: validationMessage(tag, "", param, err.Error()),
 				}
 			}
 		}
@@ -90,7 +131,7 @@ func (pv *ParameterValidator) ValidateValue(value interface{}, tags map[string]s
 	return nil
 }
 
-// ValidateStruct 验证结构体
+// ValidateStruct 验证结构体，收集所有字段的验证错误，存在错误时返回ValidationErrors
 func (pv *ParameterValidator) ValidateStruct(s interface{}) error {
 	v := reflect.ValueOf(s)
 	if v.Kind() == reflect.Ptr {
@@ -101,6 +142,7 @@ func (pv *ParameterValidator) ValidateStruct(s interface{}) error {
 		return fmt.Errorf("expected struct, got %s", v.Kind())
 	}
 
+	var errs ValidationErrors
 	t := v.Type()
 	for i := 0; i < v.NumField(); i++ {
 		field := t.Field(i)
@@ -118,19 +160,19 @@ func (pv *ParameterValidator) ValidateStruct(s interface{}) error {
 		}
 
 		// 验证字段
-		if err := pv.validateField(field.Name, fieldValue.Interface(), validateTag); err != nil {
-			if validationErr, ok := err.(*ValidationError); ok {
-				validationErr.Field = field.Name
-			}
-			return err
+		if err := pv.validateField(fieldName(field), fieldValue.Interface(), validateTag); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
-// validateField 验证字段
-func (pv *ParameterValidator) validateField(fieldName string, value interface{}, validateTag string) error {
+// validateField 验证字段，返回第一个未通过的规则
+func (pv *ParameterValidator) validateField(fieldName string, value interface{}, validateTag string) *ValidationError {
 	rules := strings.Split(validateTag, ",")
 
 	for _, rule := range rules {
@@ -140,12 +182,7 @@ func (pv *ParameterValidator) validateField(fieldName string, value interface{},
 		}
 
 		// 解析规则和参数
-		parts := strings.Split(rule, "=")
-		ruleName := parts[0]
-		param := ""
-		if len(parts) > 1 {
-			param = parts[1]
-		}
+		ruleName, param, _ := strings.Cut(rule, "=")
 
 		// 执行验证
 		if validator, exists := pv.rules[ruleName]; exists {
@@ -155,7 +192,7 @@ func (pv *ParameterValidator) validateField(fieldName string, value interface{},
 					Tag:     ruleName,
 					Value:   value,
 					Param:   param,
-					Message: err.Error(),
+					Message: validationMessage(ruleName, fieldName, param, err.Error()),
 				}
 			}
 		}
@@ -164,6 +201,14 @@ func (pv *ParameterValidator) validateField(fieldName string, value interface{},
 	return nil
 }
 
+// fieldName 获取字段在响应中的名称，优先使用json标签
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
 // RegisterRule 注册验证规则
 func (pv *ParameterValidator) RegisterRule(name string, rule ValidationRule) {
 	pv.rules[name] = rule
@@ -519,6 +564,24 @@ func (ve ValidationError) Error() string {
 	return fmt.Sprintf("validation failed: %s", ve.Message)
 }
 
+// Error 实现error接口
+func (ve ValidationErrors) Error() string {
+	messages := make([]string, len(ve))
+	for i, err := range ve {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap 支持 errors.As 获取单个 *ValidationError
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i, err := range ve {
+		errs[i] = err
+	}
+	return errs
+}
+
 // CustomValidationRule 自定义验证规则接口
 type CustomValidationRule interface {
 	ValidationRule
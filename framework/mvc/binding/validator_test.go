@@ -0,0 +1,164 @@
+package binding
+
+import (
+	"bytes"
+	stdcontext "context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+// signupRequest 验证测试请求
+type signupRequest struct {
+	Name     string `json:"name" validate:"required,min=2"`
+	Email    string `json:"email,omitempty" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Age      int    `validate:"min=18"`
+	Nickname string `json:"nickname"`
+}
+
+func TestParameterValidator_ValidateStructCollectsAllErrors(t *testing.T) {
+	SetValidationMessage("email", "{field}格式不正确")
+	SetValidationMessage("min", "{field}不能少于{param}")
+	t.Cleanup(func() {
+		SetValidationMessage("email", "")
+		SetValidationMessage("min", "")
+	})
+
+	err := NewParameterValidator().ValidateStruct(signupRequest{Name: "Tom", Email: "bad", Password: "short", Age: 10})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got %T: %v", err, err)
+	}
+	want := []struct{ field, rule, param, message string }{
+		{"email", "email", "", "email格式不正确"},
+		{"password", "min", "8", "password不能少于8"},
+		{"Age", "min", "18", "Age不能少于18"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if errs[i].Field != w.field || errs[i].Tag != w.rule || errs[i].Param != w.param || errs[i].Message != w.message {
+			t.Errorf("Error %d: expected %+v, got %+v", i, w, *errs[i])
+		}
+	}
+
+	// errors.As 可以取得单个字段错误
+	var first *ValidationError
+	if !errors.As(err, &first) || first.Field != "email" {
+		t.Errorf("Expected first field error for email, got %v", first)
+	}
+
+	// 响应中不包含字段值
+	data, _ := json.Marshal(errs[1])
+	if string(data) != `{"field":"password","rule":"min","param":"8","message":"password不能少于8"}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+}
+
+func TestParameterValidator_ValidateStructDefaultMessages(t *testing.T) {
+	err := NewParameterValidator().ValidateStruct(&signupRequest{Name: "Tom", Email: "tom@example.com", Password: "secret-password", Age: 20})
+	if err != nil {
+		t.Fatalf("Expected valid request, got %v", err)
+	}
+
+	err = NewParameterValidator().ValidateStruct(signupRequest{Age: 20})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("Expected 3 required errors, got %v", err)
+	}
+	for _, e := range errs {
+		if e.Tag != "required" || e.Message != "field is required" {
+			t.Errorf("Expected default required message, got %+v", *e)
+		}
+	}
+}
+
+// newBindEngine 创建调用 BindAndValidate 的测试引擎，后续处理器执行时记录绑定结果
+func newBindEngine(got **signupRequest) *route.Engine {
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.POST("/signup", func(c stdcontext.Context, rc *app.RequestContext) {
+		ctx := context.NewContext(rc)
+		var req signupRequest
+		if err := ctx.BindAndValidate(&req); err != nil {
+			return
+		}
+		rc.Set("signup", &req)
+	}, func(c stdcontext.Context, rc *app.RequestContext) {
+		req, _ := rc.Get("signup")
+		*got, _ = req.(*signupRequest)
+		rc.String(201, "created")
+	})
+	return engine
+}
+
+func TestContext_BindAndValidate(t *testing.T) {
+	var got *signupRequest
+	body := `{"name":"Tom","email":"tom@example.com","password":"secret-password","Age":20}`
+	resp := ut.PerformRequest(newBindEngine(&got), "POST", "/signup",
+		&ut.Body{Body: bytes.NewBufferString(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: "application/json"}).Result()
+
+	if resp.StatusCode() != 201 {
+		t.Fatalf("Expected status 201, got %d: %s", resp.StatusCode(), resp.Body())
+	}
+	if got == nil || got.Name != "Tom" || got.Email != "tom@example.com" || got.Age != 20 {
+		t.Errorf("Expected bound request, got %+v", got)
+	}
+}
+
+func TestContext_BindAndValidateFailureShortCircuits(t *testing.T) {
+	var got *signupRequest
+	body := `{"name":"Tom","email":"bad","password":"short","Age":20}`
+	resp := ut.PerformRequest(newBindEngine(&got), "POST", "/signup",
+		&ut.Body{Body: bytes.NewBufferString(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: "application/json"}).Result()
+
+	if resp.StatusCode() != 422 {
+		t.Fatalf("Expected status 422, got %d: %s", resp.StatusCode(), resp.Body())
+	}
+	if got != nil {
+		t.Errorf("Expected handler chain to be aborted, got %+v", got)
+	}
+
+	var result struct {
+		Code   string `json:"code"`
+		Errors []struct {
+			Field string `json:"field"`
+			Rule  string `json:"rule"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		t.Fatalf("Failed to decode response %s: %v", resp.Body(), err)
+	}
+	if result.Code != "VALIDATION_FAILED" || len(result.Errors) != 2 {
+		t.Fatalf("Unexpected response %s", resp.Body())
+	}
+	if result.Errors[0].Field != "email" || result.Errors[1].Field != "password" || result.Errors[1].Rule != "min" {
+		t.Errorf("Unexpected field errors %+v", result.Errors)
+	}
+}
+
+func TestContext_BindAndValidateBindFailure(t *testing.T) {
+	var got *signupRequest
+	body := `{"name":`
+	resp := ut.PerformRequest(newBindEngine(&got), "POST", "/signup",
+		&ut.Body{Body: bytes.NewBufferString(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: "application/json"}).Result()
+
+	if resp.StatusCode() != 400 {
+		t.Fatalf("Expected status 400, got %d: %s", resp.StatusCode(), resp.Body())
+	}
+	if got != nil {
+		t.Errorf("Expected handler chain to be aborted, got %+v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 与 TLSServerConfig.Certificate 默认路径保持一致
+const (
+	DefaultCertFile = "certs/server.crt"
+	DefaultKeyFile  = "certs/server.key"
+)
+
+// CertGenerator 本地开发用自签名证书生成器
+type CertGenerator struct {
+	CertFile   string        // 证书输出路径
+	KeyFile    string        // 私钥输出路径
+	CommonName string        // 证书CN
+	Hosts      []string      // SAN列表，IP地址写入IPAddresses，其余写入DNSNames
+	ValidFor   time.Duration // 有效期
+	Overwrite  bool          // 是否覆盖已存在的文件
+}
+
+// NewCertGenerator 创建证书生成器，默认输出到项目根目录下与TLS配置一致的路径
+func NewCertGenerator(projectRoot string) *CertGenerator {
+	return &CertGenerator{
+		CertFile:   filepath.Join(projectRoot, DefaultCertFile),
+		KeyFile:    filepath.Join(projectRoot, DefaultKeyFile),
+		CommonName: "localhost",
+		Hosts:      []string{"localhost", "127.0.0.1", "::1"},
+		ValidFor:   365 * 24 * time.Hour,
+	}
+}
+
+// Generate 生成自签名证书与私钥并写入文件
+func (g *CertGenerator) Generate() error {
+	if g.CertFile == "" || g.KeyFile == "" {
+		return fmt.Errorf("证书与私钥路径不能为空")
+	}
+	if g.ValidFor <= 0 {
+		return fmt.Errorf("证书有效期必须大于0")
+	}
+	if !g.Overwrite {
+		for _, file := range []string{g.CertFile, g.KeyFile} {
+			if _, err := os.Stat(file); err == nil {
+				return fmt.Errorf("文件已存在: %s（使用覆盖选项重新生成）", file)
+			}
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("生成私钥失败: %v", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return fmt.Errorf("生成证书序列号失败: %v", err)
+	}
+
+	notBefore := time.Now().Add(-time.Minute)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: g.CommonName, Organization: []string{"YYHertz Development"}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(g.ValidFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	for _, host := range g.Hosts {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("创建证书失败: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("编码私钥失败: %v", err)
+	}
+
+	if err := writePEM(g.CertFile, "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+	return writePEM(g.KeyFile, "PRIVATE KEY", keyDER, 0600)
+}
+
+// writePEM 以PEM格式写入文件，必要时创建目录
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("写入文件失败 %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCertGenerator_Generate(t *testing.T) {
+	root := t.TempDir()
+	gen := NewCertGenerator(root)
+	gen.CommonName = "dev.local"
+	gen.Hosts = []string{"dev.local", " api.dev.local ", "127.0.0.1", "::1", ""}
+	gen.ValidFor = 30 * 24 * time.Hour
+
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if gen.CertFile != filepath.Join(root, "certs", "server.crt") || gen.KeyFile != filepath.Join(root, "certs", "server.key") {
+		t.Errorf("unexpected output paths: %s, %s", gen.CertFile, gen.KeyFile)
+	}
+
+	data, err := os.ReadFile(gen.CertFile)
+	if err != nil {
+		t.Fatalf("read cert: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Fatalf("cert file is not a PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+
+	if cert.Subject.CommonName != "dev.local" {
+		t.Errorf("CommonName = %q, want dev.local", cert.Subject.CommonName)
+	}
+	if len(cert.DNSNames) != 2 || cert.DNSNames[0] != "dev.local" || cert.DNSNames[1] != "api.dev.local" {
+		t.Errorf("DNSNames = %v", cert.DNSNames)
+	}
+	if len(cert.IPAddresses) != 2 || !cert.IPAddresses[0].Equal(net.ParseIP("127.0.0.1")) || !cert.IPAddresses[1].Equal(net.ParseIP("::1")) {
+		t.Errorf("IPAddresses = %v", cert.IPAddresses)
+	}
+	if validity := cert.NotAfter.Sub(cert.NotBefore); validity != 30*24*time.Hour {
+		t.Errorf("validity = %v, want 720h", validity)
+	}
+	for _, host := range []string{"dev.local", "api.dev.local", "127.0.0.1"} {
+		if err := cert.VerifyHostname(host); err != nil {
+			t.Errorf("VerifyHostname(%q) error = %v", host, err)
+		}
+	}
+
+	// 生成的证书与私钥可直接被TLS配置加载
+	if _, err := tls.LoadX509KeyPair(gen.CertFile, gen.KeyFile); err != nil {
+		t.Errorf("LoadX509KeyPair() error = %v", err)
+	}
+	if info, err := os.Stat(gen.KeyFile); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("key file mode = %v, err = %v", info.Mode().Perm(), err)
+	}
+}
+
+func TestCertGenerator_Overwrite(t *testing.T) {
+	gen := NewCertGenerator(t.TempDir())
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	first, _ := os.ReadFile(gen.CertFile)
+
+	if err := gen.Generate(); err == nil {
+		t.Fatal("expected error when files already exist")
+	}
+
+	gen.Overwrite = true
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() with Overwrite error = %v", err)
+	}
+	second, _ := os.ReadFile(gen.CertFile)
+	if string(first) == string(second) {
+		t.Error("expected certificate to be regenerated")
+	}
+}
+
+func TestCertGenerator_InvalidValidity(t *testing.T) {
+	gen := NewCertGenerator(t.TempDir())
+	gen.ValidFor = 0
+	if err := gen.Generate(); err == nil {
+		t.Fatal("expected error for zero validity")
+	}
+}
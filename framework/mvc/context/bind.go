@@ -0,0 +1,83 @@
+package context
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// StructValidator 结构体验证器，BindAndValidate 在绑定后调用
+type StructValidator interface {
+	ValidateStruct(obj any) error
+}
+
+var (
+	structValidatorMu sync.RWMutex
+	structValidator   StructValidator
+)
+
+// SetStructValidator 设置 BindAndValidate 使用的结构体验证器
+// binding 包初始化时注册 ParameterValidator（按 validate 标签验证），未注册时只绑定不验证
+func SetStructValidator(v StructValidator) {
+	structValidatorMu.Lock()
+	structValidator = v
+	structValidatorMu.Unlock()
+}
+
+// BindAndValidate 按Content-Type将请求参数绑定到obj并执行结构体验证
+// 绑定失败时响应400，验证失败时响应结构化的422错误；两种情况均中止处理链并返回错误，调用方直接返回即可
+//
+//	var req CreateUserRequest
+//	if err := ctx.BindAndValidate(&req); err != nil {
+//		return
+//	}
+func (ctx *Context) BindAndValidate(obj any) error {
+	if ctx.Request == nil {
+		return errors.New("request context is nil")
+	}
+
+	if err := ctx.Request.Bind(obj); err != nil {
+		ctx.JSON(400, map[string]any{
+			"error":   "Invalid request",
+			"code":    "BIND_FAILED",
+			"message": err.Error(),
+		})
+		ctx.abortChain()
+		return err
+	}
+
+	structValidatorMu.RLock()
+	validator := structValidator
+	structValidatorMu.RUnlock()
+	if validator == nil {
+		return nil
+	}
+	if err := validator.ValidateStruct(obj); err != nil {
+		ctx.AbortWithValidationErrors(err)
+		return err
+	}
+	return nil
+}
+
+// AbortWithValidationErrors 输出结构化的验证错误响应（422 Unprocessable Entity）并中止处理链
+// err为字段错误列表（如 binding.ValidationErrors）时原样输出，其他错误只输出 message
+func (ctx *Context) AbortWithValidationErrors(err error) {
+	var errs any = err
+	if reflect.ValueOf(err).Kind() != reflect.Slice {
+		errs = []map[string]string{{"message": err.Error()}}
+	}
+	ctx.JSON(422, map[string]any{
+		"error":  "Validation failed",
+		"code":   "VALIDATION_FAILED",
+		"errors": errs,
+	})
+	ctx.abortChain()
+}
+
+// abortChain 中止当前Context与底层请求上下文的处理链
+func (ctx *Context) abortChain() {
+	ctx.Abort()
+	if ctx.Request != nil {
+		ctx.Request.Abort()
+	}
+}
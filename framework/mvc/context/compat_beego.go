@@ -1,7 +1,15 @@
 package context
 
 import (
-	"github.com/cloudwego/hertz/pkg/protocol"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/zsy619/yyhertz/framework/render"
 )
 
 // InputData Beego风格输入数据结构
@@ -14,11 +22,17 @@ type OutputData struct {
 	ctx *Context
 }
 
-// Cookie 设置Cookie (Output兼容性方法)
+// Cookie 设置Cookie (Output兼容性方法)，需要 SameSite 或 Partitioned 时使用 SetCookie
 func (o *OutputData) Cookie(name, value string, maxAge int, path, domain string, secure, httpOnly bool) {
-	if o.ctx.Request != nil {
-		o.ctx.Request.SetCookie(name, value, maxAge, path, domain, protocol.CookieSameSiteDefaultMode, secure, httpOnly)
-	}
+	o.ctx.SetCookie(http.Cookie{
+		Name:     name,
+		Value:    value,
+		MaxAge:   maxAge,
+		Path:     path,
+		Domain:   domain,
+		Secure:   secure,
+		HttpOnly: httpOnly,
+	})
 }
 
 // Header 设置响应头 (Output兼容性方法)
@@ -56,6 +70,73 @@ func (o *OutputData) SetStatus(code int) {
 	o.Status(code)
 }
 
+// Download 以附件形式下载文件 (Output兼容性方法)，filename 为空时使用文件本身的名称
+// 支持单区间Range请求（206 Partial Content）用于断点续传，文件内容以流的方式分块输出；
+// 携带If-Range且文件已变更时忽略Range返回完整文件
+func (o *OutputData) Download(file string, filename ...string) {
+	ctx := o.ctx
+	if ctx.Request == nil {
+		return
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		ctx.String(404, "File not found")
+		return
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		f.Close()
+		ctx.String(404, "File not found")
+		return
+	}
+
+	name := filepath.Base(file)
+	if len(filename) > 0 && filename[0] != "" {
+		name = filename[0]
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(file))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := &ctx.Request.Response.Header
+	header.SetContentType(contentType)
+	header.Set("Content-Disposition", render.ContentDisposition(name))
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Last-Modified", info.ModTime().UTC().Format(http.TimeFormat))
+	etag := FileETag(info)
+	header.Set("ETag", etag)
+
+	size := info.Size()
+	rangeHeader := ctx.Header("Range")
+	if !IfRangeMatches(ctx.Header("If-Range"), info.ModTime(), etag) {
+		rangeHeader = ""
+	}
+	start, length, err := parseByteRange(rangeHeader, size)
+	if errors.Is(err, errRangeNotSatisfiable) {
+		f.Close()
+		header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		ctx.Request.SetStatusCode(416)
+		return
+	}
+
+	status := 200
+	if err == nil && rangeHeader != "" {
+		status = 206
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+	} else {
+		// 无Range或Range格式无法识别时返回完整文件
+		start, length = 0, size
+	}
+
+	ctx.Request.SetStatusCode(status)
+	ctx.Request.Response.SetBodyStream(fileBody{
+		Reader: io.NewSectionReader(f, start, length),
+		Closer: f,
+	}, int(length))
+}
+
 // Param 获取路由参数 (Input兼容性方法)
 func (i *InputData) Param(key string) string {
 	return i.ctx.Params.ByName(key)
@@ -0,0 +1,153 @@
+package context
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// SetCookie 按 http.Cookie 设置响应Cookie，支持 SameSite、Secure、HttpOnly、MaxAge、Expires 与 Partitioned（CHIPS）属性
+// MaxAge 小于0时输出 Max-Age=0 删除Cookie；Partitioned 要求 Secure，未设置时自动补上；同名Cookie覆盖之前的设置
+//
+//	ctx.SetCookie(http.Cookie{Name: "sid", Value: token, Path: "/", MaxAge: 3600,
+//		HttpOnly: true, Secure: true, SameSite: http.SameSiteNoneMode, Partitioned: true})
+func (ctx *Context) SetCookie(cookie http.Cookie) error {
+	if ctx.Request == nil {
+		return errors.New("request context is nil")
+	}
+	if cookie.Partitioned {
+		cookie.Secure = true
+	}
+	if err := cookie.Valid(); err != nil {
+		return err
+	}
+
+	header := &ctx.Request.Response.Header
+	header.DelCookie(cookie.Name)
+	header.Add("Set-Cookie", cookie.String())
+	return nil
+}
+
+// SetCookie 按 http.Cookie 设置响应Cookie (Output兼容性方法)
+func (o *OutputData) SetCookie(cookie http.Cookie) error {
+	return o.ctx.SetCookie(cookie)
+}
+
+// ============= 签名与加密Cookie =============
+
+var (
+	// ErrInvalidCookie Cookie不存在、格式错误、签名不匹配或解密失败
+	ErrInvalidCookie = errors.New("invalid or tampered cookie")
+
+	errEmptyCookieSecret = errors.New("cookie secret is empty")
+)
+
+// SetSignedCookie 设置HMAC-SHA256签名的Cookie，值以明文（base64url）传输但无法被篡改
+// options 可选传入一个 http.Cookie 作为属性模板（Path、MaxAge、SameSite等），其 Name 与 Value 会被忽略；未传入时 Path 为 "/" 且 HttpOnly
+func (ctx *Context) SetSignedCookie(name, value, secret string, options ...http.Cookie) error {
+	if secret == "" {
+		return errEmptyCookieSecret
+	}
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(value))
+	return ctx.SetCookie(cookieFromOptions(name, encoded+"."+signCookie(name, encoded, secret), options))
+}
+
+// GetSignedCookie 读取并验证签名Cookie，签名不匹配时返回 ErrInvalidCookie
+func (ctx *Context) GetSignedCookie(name, secret string) (string, error) {
+	if secret == "" {
+		return "", errEmptyCookieSecret
+	}
+	raw := ctx.requestCookie(name)
+	encoded, signature, ok := strings.Cut(raw, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signCookie(name, encoded, secret))) {
+		return "", ErrInvalidCookie
+	}
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(value), nil
+}
+
+// SetEncryptedCookie 设置AES-256-GCM加密的Cookie，值对客户端不可见且无法被篡改
+// 密钥由 secret 经SHA-256派生，Cookie名称作为附加认证数据，防止密文被挪用到其他Cookie
+func (ctx *Context) SetEncryptedCookie(name, value, secret string, options ...http.Cookie) error {
+	aead, err := cookieAEAD(secret)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return ctx.SetCookie(cookieFromOptions(name, base64.RawURLEncoding.EncodeToString(sealed), options))
+}
+
+// GetEncryptedCookie 读取并解密加密Cookie，密文被修改或密钥不匹配时返回 ErrInvalidCookie
+func (ctx *Context) GetEncryptedCookie(name, secret string) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(ctx.requestCookie(name))
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	aead, err := cookieAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCookie
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(value), nil
+}
+
+// requestCookie 读取请求中的Cookie值
+func (ctx *Context) requestCookie(name string) string {
+	if ctx.Request == nil {
+		return ""
+	}
+	return string(ctx.Request.Cookie(name))
+}
+
+// cookieFromOptions 以可选模板构造Cookie
+func cookieFromOptions(name, value string, options []http.Cookie) http.Cookie {
+	cookie := http.Cookie{Path: "/", HttpOnly: true}
+	if len(options) > 0 {
+		cookie = options[0]
+	}
+	cookie.Name = name
+	cookie.Value = value
+	return cookie
+}
+
+// signCookie 计算Cookie签名，签名覆盖名称以防止值被挪用到其他Cookie
+func signCookie(name, encoded, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(name))
+	mac.Write([]byte{'='})
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// cookieAEAD 由secret派生AES-256-GCM实例
+func cookieAEAD(secret string) (cipher.AEAD, error) {
+	if secret == "" {
+		return nil, errEmptyCookieSecret
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,185 @@
+package context
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setCookies 返回响应中的全部 Set-Cookie 头
+func setCookies(ctx *Context) []string {
+	var cookies []string
+	ctx.Request.Response.Header.VisitAllCookie(func(_, value []byte) {
+		cookies = append(cookies, string(value))
+	})
+	return cookies
+}
+
+func TestContext_SetCookie(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie http.Cookie
+		want   string
+	}{
+		{
+			"session cookie",
+			http.Cookie{Name: "sid", Value: "abc"},
+			"sid=abc",
+		},
+		{
+			"lax http only",
+			http.Cookie{Name: "sid", Value: "abc", Path: "/", MaxAge: 3600, HttpOnly: true, SameSite: http.SameSiteLaxMode},
+			"sid=abc; Path=/; Max-Age=3600; HttpOnly; SameSite=Lax",
+		},
+		{
+			"strict secure with domain",
+			http.Cookie{Name: "token", Value: "t1", Domain: "example.com", Secure: true, SameSite: http.SameSiteStrictMode},
+			"token=t1; Domain=example.com; Secure; SameSite=Strict",
+		},
+		{
+			"partitioned implies secure",
+			http.Cookie{Name: "__Host-embed", Value: "1", Path: "/", SameSite: http.SameSiteNoneMode, Partitioned: true},
+			"__Host-embed=1; Path=/; Secure; SameSite=None; Partitioned",
+		},
+		{
+			"negative max age deletes",
+			http.Cookie{Name: "sid", Value: "", Path: "/", MaxAge: -1},
+			"sid=; Path=/; Max-Age=0",
+		},
+		{
+			"expires",
+			http.Cookie{Name: "sid", Value: "abc", Expires: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+			"sid=abc; Expires=Wed, 02 Jan 2030 03:04:05 GMT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+			defer ctx.Release()
+
+			require.NoError(t, ctx.SetCookie(tt.cookie))
+			assert.Equal(t, []string{tt.want}, setCookies(ctx))
+		})
+	}
+}
+
+func TestContext_SetCookieReplacesSameName(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+	defer ctx.Release()
+
+	require.NoError(t, ctx.SetCookie(http.Cookie{Name: "sid", Value: "old"}))
+	require.NoError(t, ctx.SetCookie(http.Cookie{Name: "lang", Value: "zh"}))
+	require.NoError(t, ctx.SetCookie(http.Cookie{Name: "sid", Value: "new", SameSite: http.SameSiteStrictMode}))
+
+	assert.ElementsMatch(t, []string{"lang=zh", "sid=new; SameSite=Strict"}, setCookies(ctx))
+	assert.Contains(t, string(ctx.Request.Response.Header.Header()), "Set-Cookie: sid=new; SameSite=Strict\r\n")
+}
+
+func TestContext_SetCookieInvalid(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+	defer ctx.Release()
+
+	assert.Error(t, ctx.SetCookie(http.Cookie{Name: "bad name", Value: "x"}))
+	assert.Empty(t, setCookies(ctx))
+}
+
+func TestOutputData_Cookie(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+	defer ctx.Release()
+
+	ctx.Output.Cookie("sid", "abc", 60, "/", "", true, true)
+	require.NoError(t, ctx.Output.SetCookie(http.Cookie{Name: "theme", Value: "dark", SameSite: http.SameSiteLaxMode}))
+
+	assert.ElementsMatch(t, []string{
+		"sid=abc; Path=/; Max-Age=60; HttpOnly; Secure",
+		"theme=dark; SameSite=Lax",
+	}, setCookies(ctx))
+}
+
+// responseCookieValue 从响应的 Set-Cookie 头中取出指定Cookie的值
+func responseCookieValue(t *testing.T, ctx *Context, name string) string {
+	t.Helper()
+	for _, raw := range setCookies(ctx) {
+		cookie, err := http.ParseSetCookie(raw)
+		require.NoError(t, err)
+		if cookie.Name == name {
+			return cookie.Value
+		}
+	}
+	t.Fatalf("cookie %s not set", name)
+	return ""
+}
+
+// requestWithCookie 创建携带指定Cookie的请求上下文
+func requestWithCookie(name, value string) *Context {
+	return NewContext(ut.CreateUtRequestContext("GET", "/", nil, ut.Header{Key: "Cookie", Value: name + "=" + value}))
+}
+
+// tamper 修改Cookie值中的一个字符
+func tamper(value string, index int) string {
+	b := []byte(value)
+	if b[index] == 'A' {
+		b[index] = 'B'
+	} else {
+		b[index] = 'A'
+	}
+	return string(b)
+}
+
+func TestContext_SignedCookie(t *testing.T) {
+	const secret = "s3cr3t"
+
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+	defer ctx.Release()
+	require.NoError(t, ctx.SetSignedCookie("uid", "42;admin=false", secret))
+	assert.Contains(t, setCookies(ctx)[0], "; Path=/; HttpOnly")
+	signed := responseCookieValue(t, ctx, "uid")
+
+	value, err := requestWithCookie("uid", signed).GetSignedCookie("uid", secret)
+	require.NoError(t, err)
+	assert.Equal(t, "42;admin=false", value)
+
+	// 修改值、修改签名、更换密钥、挪用到其他Cookie均被拒绝
+	_, err = requestWithCookie("uid", tamper(signed, 0)).GetSignedCookie("uid", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("uid", tamper(signed, len(signed)-1)).GetSignedCookie("uid", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("uid", signed).GetSignedCookie("uid", "other")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("role", signed).GetSignedCookie("role", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("uid", "unsigned").GetSignedCookie("uid", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+
+	assert.Error(t, ctx.SetSignedCookie("uid", "42", ""))
+}
+
+func TestContext_EncryptedCookie(t *testing.T) {
+	const secret = "s3cr3t"
+
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/", nil))
+	defer ctx.Release()
+	require.NoError(t, ctx.SetEncryptedCookie("session", "user=42", secret,
+		http.Cookie{Path: "/app", MaxAge: 60, Secure: true, SameSite: http.SameSiteStrictMode}))
+	assert.Contains(t, setCookies(ctx)[0], "; Path=/app; Max-Age=60; Secure; SameSite=Strict")
+	sealed := responseCookieValue(t, ctx, "session")
+	assert.NotContains(t, sealed, "user")
+
+	value, err := requestWithCookie("session", sealed).GetEncryptedCookie("session", secret)
+	require.NoError(t, err)
+	assert.Equal(t, "user=42", value)
+
+	_, err = requestWithCookie("session", tamper(sealed, len(sealed)/2)).GetEncryptedCookie("session", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("session", sealed).GetEncryptedCookie("session", "other")
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = requestWithCookie("token", sealed).GetEncryptedCookie("token", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+	_, err = NewContext(ut.CreateUtRequestContext("GET", "/", nil)).GetEncryptedCookie("session", secret)
+	assert.ErrorIs(t, err, ErrInvalidCookie)
+}
@@ -0,0 +1,245 @@
+package context
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/protocol/http1/resp"
+
+	"github.com/zsy619/yyhertz/framework/render"
+)
+
+// ============= 渲染与流式响应 =============
+
+// Render 使用渲染器输出响应
+func (ctx *Context) Render(code int, r render.Render) {
+	if ctx.Request == nil {
+		return
+	}
+	ctx.Request.SetStatusCode(code)
+	if err := r.Render(ctx.Request); err != nil {
+		ctx.AddError(err)
+	}
+}
+
+// SecureJSON 输出带防JSON劫持前缀的JSON响应，前缀默认为 while(1);，可通过 App.SetSecureJSONPrefix 修改
+func (ctx *Context) SecureJSON(code int, obj any) {
+	ctx.Render(code, render.SecureJSON{Data: obj})
+}
+
+// SecureJSONWithPrefix 使用指定前缀输出SecureJSON响应，prefix 为空时使用默认前缀
+func (ctx *Context) SecureJSONWithPrefix(code int, prefix string, obj any) {
+	ctx.Render(code, render.SecureJSON{Prefix: prefix, Data: obj})
+}
+
+// ProtoBuf 以 application/x-protobuf 输出protobuf消息，obj 必须实现 proto.Message
+func (ctx *Context) ProtoBuf(code int, obj any) {
+	ctx.Render(code, render.ProtoBuf{Data: obj})
+}
+
+// MsgPack 以 application/x-msgpack 输出MessagePack编码的数据
+func (ctx *Context) MsgPack(code int, obj any) {
+	ctx.Render(code, render.MsgPack{Data: obj})
+}
+
+// CSV 以CSV附件形式输出数据，data 为结构体切片或map切片
+func (ctx *Context) CSV(code int, filename string, data any) {
+	ctx.Render(code, render.CSV{Filename: filename, Data: data})
+}
+
+// SSEvent 写入一个Server-Sent Events事件并立即刷新
+// data 为字符串时原样输出，其余类型序列化为JSON
+func (ctx *Context) SSEvent(event string, data any) error {
+	if ctx.Request == nil {
+		return nil
+	}
+	ctx.prepareStream("text/event-stream")
+
+	payload, err := encodeSSEvent(event, data)
+	if err != nil {
+		return err
+	}
+	if _, err := ctx.Request.Write(payload); err != nil {
+		return err
+	}
+	return ctx.Request.Flush()
+}
+
+// Stream 持续调用step写入流式响应，每次调用后刷新
+// step 返回false或客户端断开（请求上下文取消）时停止，返回值表示是否因客户端断开而结束
+func (ctx *Context) Stream(step func(w io.Writer) bool) bool {
+	if ctx.Request == nil {
+		return false
+	}
+	ctx.prepareStream("")
+
+	done := ctx.clientGone()
+	for {
+		select {
+		case <-done:
+			return true
+		default:
+			keepOpen := step(ctx.Writer)
+			if err := ctx.Request.Flush(); err != nil {
+				// 刷新失败通常意味着连接已断开
+				return true
+			}
+			if !keepOpen {
+				return false
+			}
+		}
+	}
+}
+
+// prepareStream 设置流式响应头，并在存在底层连接时切换为分块写入
+func (ctx *Context) prepareStream(contentType string) {
+	header := &ctx.Request.Response.Header
+	if contentType != "" && !strings.HasPrefix(string(header.ContentType()), contentType) {
+		header.SetContentType(contentType)
+	}
+	if contentType == "text/event-stream" {
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no")
+	}
+
+	if ctx.Request.Response.GetHijackWriter() == nil && ctx.Request.GetWriter() != nil {
+		ctx.Request.Response.HijackWriter(resp.NewChunkedBodyWriter(&ctx.Request.Response, ctx.Request.GetWriter()))
+	}
+}
+
+// clientGone 返回客户端断开的通知通道
+func (ctx *Context) clientGone() <-chan struct{} {
+	if ctx.Context != nil {
+		return ctx.Context.Done()
+	}
+	return nil
+}
+
+// encodeSSEvent 按SSE协议编码事件：event行、逐行data、空行结尾
+func encodeSSEvent(event string, data any) ([]byte, error) {
+	var body string
+	switch v := data.(type) {
+	case string:
+		body = v
+	case []byte:
+		body = string(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encode sse data: %w", err)
+		}
+		body = string(encoded)
+	}
+
+	var sb strings.Builder
+	if event != "" {
+		sb.WriteString("event: ")
+		sb.WriteString(strings.ReplaceAll(event, "\n", ""))
+		sb.WriteString("\n")
+	}
+	for _, line := range strings.Split(body, "\n") {
+		sb.WriteString("data: ")
+		sb.WriteString(strings.TrimSuffix(line, "\r"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return []byte(sb.String()), nil
+}
+
+// ============= 文件下载 =============
+
+// errRangeNotSatisfiable Range请求超出文件范围
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// fileBody 文件响应体，读取完成后由Hertz关闭文件
+type fileBody struct {
+	io.Reader
+	io.Closer
+}
+
+// parseByteRange 解析单区间Range请求头，返回起始位置与长度
+// 未携带Range时返回完整范围；多区间或格式无法识别时返回错误，调用方应返回完整文件
+func parseByteRange(header string, size int64) (int64, int64, error) {
+	if header == "" {
+		return 0, size, nil
+	}
+	if !strings.HasPrefix(header, "bytes=") {
+		return 0, 0, fmt.Errorf("invalid range: %s", header)
+	}
+
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes="))
+	if strings.Contains(spec, ",") {
+		return 0, 0, fmt.Errorf("multiple ranges not supported: %s", header)
+	}
+
+	startStr, endStr, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid range: %s", header)
+	}
+	startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+	// 后缀区间：bytes=-N 表示最后N个字节
+	if startStr == "" {
+		suffix, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || suffix < 0 {
+			return 0, 0, fmt.Errorf("invalid range: %s", header)
+		}
+		if suffix == 0 || size == 0 {
+			return 0, 0, errRangeNotSatisfiable
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("invalid range: %s", header)
+	}
+	if start >= size {
+		return 0, 0, errRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, fmt.Errorf("invalid range: %s", header)
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end - start + 1, nil
+}
+
+// FileETag 根据文件修改时间与大小生成强ETag
+func FileETag(info os.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+// IfRangeMatches 判断If-Range条件是否成立，成立时才应处理Range请求
+// If-Range为空时成立；为ETag时需与强ETag完全一致（弱ETag不成立）；为日期时需与修改时间（精确到秒）一致
+func IfRangeMatches(ifRange string, modTime time.Time, etag string) bool {
+	ifRange = strings.TrimSpace(ifRange)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return !strings.HasPrefix(ifRange, "W/") && ifRange == etag
+	}
+	t, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	return modTime.Truncate(time.Second).Equal(t)
+}
@@ -0,0 +1,270 @@
+package context
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/render"
+	"github.com/zsy619/yyhertz/framework/response"
+)
+
+// readSSEFrames 按空行切分SSE帧
+func readSSEFrames(t *testing.T, body string) [][]string {
+	t.Helper()
+
+	var frames [][]string
+	var current []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(current) > 0 {
+				frames = append(frames, current)
+			}
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	require.NoError(t, scanner.Err())
+	require.Empty(t, current, "最后一个事件必须以空行结尾")
+	return frames
+}
+
+func TestContext_SSEvent(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/events", nil))
+	defer ctx.Release()
+
+	require.NoError(t, ctx.SSEvent("message", "hello"))
+	require.NoError(t, ctx.SSEvent("user", map[string]any{"id": 1}))
+	require.NoError(t, ctx.SSEvent("", "line1\nline2"))
+
+	assert.Equal(t, "text/event-stream", string(ctx.Request.Response.Header.ContentType()))
+	assert.Equal(t, "no-cache", string(ctx.Request.Response.Header.Peek("Cache-Control")))
+
+	body := string(ctx.Request.Response.Body())
+	assert.True(t, strings.HasSuffix(body, "\n\n"))
+
+	frames := readSSEFrames(t, body)
+	require.Len(t, frames, 3)
+	assert.Equal(t, []string{"event: message", "data: hello"}, frames[0])
+	assert.Equal(t, []string{"event: user", `data: {"id":1}`}, frames[1])
+	assert.Equal(t, []string{"data: line1", "data: line2"}, frames[2])
+}
+
+func TestContext_Stream(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/stream", nil))
+	defer ctx.Release()
+
+	count := 0
+	clientGone := ctx.Stream(func(w io.Writer) bool {
+		count++
+		_, _ = io.WriteString(w, "chunk\n")
+		return count < 3
+	})
+
+	assert.False(t, clientGone)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, "chunk\nchunk\nchunk\n", string(ctx.Request.Response.Body()))
+}
+
+func TestContext_StreamStopsOnClientDisconnect(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := NewContextWithContext(ut.CreateUtRequestContext("GET", "/stream", nil), parent)
+	defer ctx.Release()
+
+	count := 0
+	clientGone := ctx.Stream(func(w io.Writer) bool {
+		count++
+		if count == 2 {
+			cancel()
+		}
+		return true
+	})
+
+	assert.True(t, clientGone)
+	assert.Equal(t, 2, count)
+}
+
+// writeDownloadFile 创建下载测试文件
+func writeDownloadFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "report.txt")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	return file
+}
+
+func TestOutput_DownloadFull(t *testing.T) {
+	file := writeDownloadFile(t, "0123456789abcdef")
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/download", nil))
+	defer ctx.Release()
+
+	ctx.Output.Download(file, "报表.txt")
+
+	resp := &ctx.Request.Response
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.True(t, strings.HasPrefix(string(resp.Header.ContentType()), "text/plain"))
+	assert.Equal(t, 16, resp.Header.ContentLength())
+	assert.Equal(t, "bytes", string(resp.Header.Peek("Accept-Ranges")))
+	assert.Contains(t, string(resp.Header.Peek("Content-Disposition")), "filename*=UTF-8''")
+	assert.Equal(t, "0123456789abcdef", string(resp.Body()))
+}
+
+func TestOutput_DownloadRange(t *testing.T) {
+	file := writeDownloadFile(t, "0123456789abcdef")
+
+	tests := []struct {
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"bytes=2-5", 206, "2345", "bytes 2-5/16"},
+		{"bytes=10-", 206, "abcdef", "bytes 10-15/16"},
+		{"bytes=-3", 206, "def", "bytes 13-15/16"},
+		{"bytes=8-100", 206, "89abcdef", "bytes 8-15/16"},
+		{"bytes=20-30", 416, "", "bytes */16"},
+		{"bytes=0-1,4-5", 200, "0123456789abcdef", ""},
+	}
+
+	for _, tt := range tests {
+		ctx := NewContext(ut.CreateUtRequestContext("GET", "/download", nil,
+			ut.Header{Key: "Range", Value: tt.rangeHeader}))
+		ctx.Output.Download(file)
+
+		resp := &ctx.Request.Response
+		assert.Equal(t, tt.status, resp.StatusCode(), tt.rangeHeader)
+		assert.Equal(t, tt.body, string(resp.Body()), tt.rangeHeader)
+		assert.Equal(t, tt.contentRange, string(resp.Header.Peek("Content-Range")), tt.rangeHeader)
+		ctx.Release()
+	}
+}
+
+func TestOutput_DownloadIfRange(t *testing.T) {
+	file := writeDownloadFile(t, "0123456789abcdef")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	etag := FileETag(info)
+
+	tests := []struct {
+		ifRange string
+		status  int
+		body    string
+	}{
+		{etag, 206, "2345"},
+		{modTime.UTC().Format(http.TimeFormat), 206, "2345"},
+		{`"stale"`, 200, "0123456789abcdef"},
+		{"W/" + etag, 200, "0123456789abcdef"},
+		{modTime.Add(-time.Minute).UTC().Format(http.TimeFormat), 200, "0123456789abcdef"},
+	}
+
+	for _, tt := range tests {
+		ctx := NewContext(ut.CreateUtRequestContext("GET", "/download", nil,
+			ut.Header{Key: "Range", Value: "bytes=2-5"},
+			ut.Header{Key: "If-Range", Value: tt.ifRange}))
+		ctx.Output.Download(file)
+
+		resp := &ctx.Request.Response
+		assert.Equal(t, tt.status, resp.StatusCode(), tt.ifRange)
+		assert.Equal(t, tt.body, string(resp.Body()), tt.ifRange)
+		assert.Equal(t, etag, string(resp.Header.Peek("ETag")), tt.ifRange)
+		ctx.Release()
+	}
+}
+
+func TestOutput_DownloadMissingFile(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/download", nil))
+	defer ctx.Release()
+
+	ctx.Output.Download(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Equal(t, 404, ctx.Request.Response.StatusCode())
+}
+
+func TestContext_SecureJSON(t *testing.T) {
+	// decodeSecureJSON 校验前缀并解析剩余的JSON
+	decodeSecureJSON := func(t *testing.T, ctx *Context, prefix string) map[string]any {
+		t.Helper()
+		body := string(ctx.Request.Response.Body())
+		require.True(t, strings.HasPrefix(body, prefix), "body %q should start with %q", body, prefix)
+		var data map[string]any
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(body, prefix)), &data))
+		return data
+	}
+
+	t.Run("default prefix", func(t *testing.T) {
+		ctx := NewContext(ut.CreateUtRequestContext("GET", "/secure", nil))
+		defer ctx.Release()
+
+		ctx.SecureJSON(http.StatusOK, map[string]any{"name": "yyhertz"})
+		assert.Equal(t, http.StatusOK, ctx.Request.Response.StatusCode())
+		assert.Equal(t, "application/json; charset=utf-8", string(ctx.Request.Response.Header.ContentType()))
+		assert.Equal(t, "yyhertz", decodeSecureJSON(t, ctx, render.DefaultSecureJSONPrefix)["name"])
+	})
+
+	t.Run("explicit prefix", func(t *testing.T) {
+		ctx := NewContext(ut.CreateUtRequestContext("GET", "/secure", nil))
+		defer ctx.Release()
+
+		ctx.SecureJSONWithPrefix(http.StatusCreated, ")]}',\n", map[string]any{"id": 1})
+		assert.Equal(t, http.StatusCreated, ctx.Request.Response.StatusCode())
+		assert.Equal(t, float64(1), decodeSecureJSON(t, ctx, ")]}',\n")["id"])
+	})
+
+	t.Run("configured default prefix", func(t *testing.T) {
+		render.SetSecureJSONPrefix("for(;;);")
+		defer render.SetSecureJSONPrefix("")
+
+		ctx := NewContext(ut.CreateUtRequestContext("GET", "/secure", nil))
+		defer ctx.Release()
+
+		ctx.SecureJSON(http.StatusOK, map[string]any{"ok": true})
+		assert.Equal(t, true, decodeSecureJSON(t, ctx, "for(;;);")["ok"])
+
+		// 空前缀恢复默认值
+		render.SetSecureJSONPrefix("")
+		assert.Equal(t, render.DefaultSecureJSONPrefix, render.SecureJSONPrefix())
+	})
+}
+
+func TestContext_SuccessAndFail(t *testing.T) {
+	decode := func(t *testing.T, ctx *Context) map[string]any {
+		t.Helper()
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(ctx.Request.Response.Body(), &body))
+		return body
+	}
+
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users/1", nil))
+	ctx.Success(map[string]any{"id": 1})
+	assert.Equal(t, http.StatusOK, ctx.Request.Response.StatusCode())
+	assert.Equal(t, map[string]any{"code": float64(0), "message": "success", "data": map[string]any{"id": float64(1)}}, decode(t, ctx))
+	ctx.Release()
+
+	ctx = NewContext(ut.CreateUtRequestContext("GET", "/users/2", nil))
+	ctx.Fail(http.StatusNotFound, "user not found")
+	assert.Equal(t, http.StatusNotFound, ctx.Request.Response.StatusCode())
+	assert.Equal(t, map[string]any{"code": float64(404), "message": "user not found", "data": nil}, decode(t, ctx))
+	ctx.Release()
+
+	// 自定义字段名（由应用写入请求上下文）
+	ctx = NewContext(ut.CreateUtRequestContext("GET", "/users", nil))
+	defer ctx.Release()
+	ctx.Request.Set(response.EnvelopeContextKey,
+		response.NormalizeEnvelopeConfig(response.EnvelopeConfig{CodeField: "status", MessageField: "msg", DataField: "result"}))
+	ctx.Success([]string{"a"})
+	assert.Equal(t, map[string]any{"status": float64(0), "msg": "success", "result": []any{"a"}}, decode(t, ctx))
+}
@@ -0,0 +1,29 @@
+package context
+
+import (
+	"github.com/zsy619/yyhertz/framework/response"
+)
+
+// Success 以统一响应包装输出成功结果，字段名等配置见 response.EnvelopeConfig
+// 不需要包装的接口直接使用 JSON
+func (ctx *Context) Success(data any) {
+	ctx.JSON(ctx.envelopeConfig().SuccessEnvelope(data))
+}
+
+// Fail 以统一响应包装输出失败结果，data 字段为 null
+// code 为HTTP错误码（400-599）时同时作为HTTP状态码
+func (ctx *Context) Fail(code int, message string) {
+	ctx.JSON(ctx.envelopeConfig().FailEnvelope(code, message))
+}
+
+// envelopeConfig 获取请求所属应用的响应包装配置（App.SetResponseEnvelope），未配置时使用默认配置
+func (ctx *Context) envelopeConfig() response.EnvelopeConfig {
+	if ctx.Request != nil {
+		if cfg, ok := ctx.Request.Get(response.EnvelopeContextKey); ok {
+			if envelope, ok := cfg.(response.EnvelopeConfig); ok {
+				return envelope
+			}
+		}
+	}
+	return response.DefaultEnvelopeConfig()
+}
@@ -0,0 +1,32 @@
+package context
+
+import (
+	"github.com/zsy619/yyhertz/framework/i18n"
+)
+
+// i18nBundle 获取本次请求使用的消息包，未经过国际化中间件时使用全局实例
+func (ctx *Context) i18nBundle() *i18n.I18n {
+	if ctx.Request != nil {
+		if value, exists := ctx.Request.Get(i18n.BundleKey); exists {
+			if bundle, ok := value.(*i18n.I18n); ok {
+				return bundle
+			}
+		}
+	}
+	return i18n.Default()
+}
+
+// Locale 获取本次请求的语言，未经过国际化中间件时返回消息包的当前语言
+func (ctx *Context) Locale() string {
+	if ctx.Request != nil {
+		if locale := ctx.Request.GetString(i18n.LocaleKey); locale != "" {
+			return locale
+		}
+	}
+	return ctx.i18nBundle().GetLocale()
+}
+
+// T 按请求语言翻译，参数规则见 i18n.I18n.Translate
+func (ctx *Context) T(key string, args ...any) string {
+	return ctx.i18nBundle().Translate(ctx.Locale(), key, args...)
+}
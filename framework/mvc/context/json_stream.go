@@ -0,0 +1,135 @@
+package context
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// jsonStreamFlushEvery JSONStream每写入多少个元素刷新一次
+const jsonStreamFlushEvery = 100
+
+// JSONStream 以JSON数组的形式逐个写入元素，不在内存中缓冲完整结果
+// items 可以是任意元素类型的通道（读取至关闭）或 iter.Seq[T]；元素使用json.Encoder编码，每100个元素刷新一次。
+// 客户端断开（请求上下文取消）时停止读取并返回上下文错误，通道的生产者应同样监听该上下文以免阻塞；
+// 编码失败时数组不完整，返回编码错误
+func (ctx *Context) JSONStream(code int, items any) error {
+	if ctx.Request == nil {
+		return nil
+	}
+
+	each, err := jsonStreamIterator(items)
+	if err != nil {
+		return err
+	}
+
+	ctx.Request.SetStatusCode(code)
+	ctx.prepareStream("application/json; charset=utf-8")
+
+	w := bufio.NewWriter(ctx.Writer)
+	encoder := json.NewEncoder(w)
+	done := ctx.clientGone()
+
+	w.WriteByte('[')
+	count := 0
+	var encodeErr error
+	each(done, func(item any) bool {
+		if count > 0 {
+			w.WriteByte(',')
+		}
+		if encodeErr = encoder.Encode(item); encodeErr != nil {
+			return false
+		}
+		count++
+		if count%jsonStreamFlushEvery == 0 {
+			if encodeErr = ctx.flushJSONStream(w); encodeErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if encodeErr != nil {
+		return fmt.Errorf("json stream: element %d: %w", count, encodeErr)
+	}
+
+	select {
+	case <-done:
+		return ctx.Context.Err()
+	default:
+	}
+	w.WriteByte(']')
+	return ctx.flushJSONStream(w)
+}
+
+// flushJSONStream 将缓冲区写入响应并刷新
+func (ctx *Context) flushJSONStream(w *bufio.Writer) error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return ctx.Request.Flush()
+}
+
+// jsonStreamIterator 将通道或iter.Seq统一为遍历函数，done关闭时停止遍历
+func jsonStreamIterator(items any) (func(done <-chan struct{}, yield func(any) bool), error) {
+	if seq, ok := items.(iter.Seq[any]); ok {
+		return func(done <-chan struct{}, yield func(any) bool) {
+			for item := range seq {
+				if isDone(done) || !yield(item) {
+					return
+				}
+			}
+		}, nil
+	}
+
+	value := reflect.ValueOf(items)
+	switch {
+	case !value.IsValid():
+		return nil, errors.New("json stream: items is nil")
+	case value.Kind() == reflect.Chan && value.Type().ChanDir()&reflect.RecvDir != 0:
+		return func(done <-chan struct{}, yield func(any) bool) {
+			cases := []reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: value},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+			}
+			for {
+				chosen, item, ok := reflect.Select(cases)
+				if chosen == 1 || !ok || !yield(item.Interface()) {
+					return
+				}
+			}
+		}, nil
+	case isSeqType(value.Type()):
+		return func(done <-chan struct{}, yield func(any) bool) {
+			yieldType := value.Type().In(0)
+			value.Call([]reflect.Value{reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+				keepGoing := !isDone(done) && yield(args[0].Interface())
+				return []reflect.Value{reflect.ValueOf(keepGoing)}
+			})})
+		}, nil
+	default:
+		return nil, fmt.Errorf("json stream: unsupported items type %T, want a channel or iter.Seq", items)
+	}
+}
+
+// isSeqType 判断类型是否为 func(yield func(T) bool)
+func isSeqType(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+	yield := t.In(0)
+	return yield.Kind() == reflect.Func && yield.NumIn() == 1 &&
+		yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool
+}
+
+// isDone 非阻塞地检查done是否已关闭，nil通道视为未关闭
+func isDone(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
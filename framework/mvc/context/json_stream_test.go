@@ -0,0 +1,115 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestContext_JSONStreamChannel(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users", nil))
+	defer ctx.Release()
+
+	users := []streamedUser{{1, "alice"}, {2, "bob"}, {3, "<carol>"}}
+	ch := make(chan streamedUser)
+	go func() {
+		defer close(ch)
+		for _, u := range users {
+			ch <- u
+		}
+	}()
+
+	require.NoError(t, ctx.JSONStream(200, ch))
+
+	resp := &ctx.Request.Response
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.True(t, strings.HasPrefix(string(resp.Header.ContentType()), "application/json"))
+	require.True(t, json.Valid(resp.Body()), string(resp.Body()))
+
+	var decoded []streamedUser
+	require.NoError(t, json.Unmarshal(resp.Body(), &decoded))
+	assert.Equal(t, users, decoded)
+}
+
+func TestContext_JSONStreamSeq(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/numbers", nil))
+	defer ctx.Release()
+
+	// 超过刷新间隔，验证多次刷新后输出仍然完整
+	numbers := make([]int, 2*jsonStreamFlushEvery+50)
+	for i := range numbers {
+		numbers[i] = i * i
+	}
+	require.NoError(t, ctx.JSONStream(200, slices.Values(numbers)))
+
+	var decoded []int
+	require.NoError(t, json.Unmarshal(ctx.Request.Response.Body(), &decoded))
+	assert.Equal(t, numbers, decoded)
+}
+
+func TestContext_JSONStreamEmptyAndAny(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/empty", nil))
+	ch := make(chan int)
+	close(ch)
+	require.NoError(t, ctx.JSONStream(200, ch))
+	assert.Equal(t, "[]", string(ctx.Request.Response.Body()))
+	ctx.Release()
+
+	ctx = NewContext(ut.CreateUtRequestContext("GET", "/mixed", nil))
+	defer ctx.Release()
+	var seq iter.Seq[any] = slices.Values([]any{1, "two", map[string]any{"three": 3.0}, nil})
+	require.NoError(t, ctx.JSONStream(201, seq))
+
+	assert.Equal(t, 201, ctx.Request.Response.StatusCode())
+	var decoded []any
+	require.NoError(t, json.Unmarshal(ctx.Request.Response.Body(), &decoded))
+	assert.Equal(t, []any{1.0, "two", map[string]any{"three": 3.0}, nil}, decoded)
+}
+
+func TestContext_JSONStreamStopsOnClientDisconnect(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := NewContextWithContext(ut.CreateUtRequestContext("GET", "/users", nil), parent)
+	defer ctx.Release()
+
+	produced := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			produced++
+			if i == 3 {
+				cancel()
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	err := ctx.JSONStream(200, iter.Seq[int](seq))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 4, produced, "客户端断开后应停止遍历")
+}
+
+func TestContext_JSONStreamErrors(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users", nil))
+	defer ctx.Release()
+
+	assert.Error(t, ctx.JSONStream(200, []int{1, 2}))
+	assert.Error(t, ctx.JSONStream(200, nil))
+
+	err := ctx.JSONStream(200, slices.Values([]float64{1, math.Inf(1)}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "element 1")
+}
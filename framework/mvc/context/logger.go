@@ -0,0 +1,59 @@
+package context
+
+import (
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/sirupsen/logrus"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// LogFieldsKey RequestContext 中请求级日志字段的键
+const LogFieldsKey = "log_fields"
+
+// RequestLogFields 获取请求级日志字段：request_id、trace_id 及通过 WithLogFields 添加的字段
+func RequestLogFields(c *app.RequestContext) map[string]any {
+	fields := make(map[string]any)
+	if c == nil {
+		return fields
+	}
+	if value, exists := c.Get(LogFieldsKey); exists {
+		if scoped, ok := value.(map[string]any); ok {
+			for k, v := range scoped {
+				fields[k] = v
+			}
+		}
+	}
+	if requestID := c.GetString("request_id"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	if traceID := c.GetString("trace_id"); traceID != "" {
+		fields["trace_id"] = traceID
+	}
+	return fields
+}
+
+// WithLogFields 添加请求级日志字段，之后通过 Logger 输出的日志与请求完成日志均携带这些字段
+func (ctx *Context) WithLogFields(fields map[string]any) *Context {
+	if ctx.Request == nil || len(fields) == 0 {
+		return ctx
+	}
+	// 复制后写回，避免修改已被读取的字段集合
+	merged := make(map[string]any, len(fields))
+	if value, exists := ctx.Request.Get(LogFieldsKey); exists {
+		if scoped, ok := value.(map[string]any); ok {
+			for k, v := range scoped {
+				merged[k] = v
+			}
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	ctx.Request.Set(LogFieldsKey, merged)
+	return ctx
+}
+
+// Logger 获取绑定了请求级字段（request_id、trace_id 及 WithLogFields 添加的字段）的日志记录器
+func (ctx *Context) Logger() *logrus.Entry {
+	return config.WithFields(RequestLogFields(ctx.Request))
+}
@@ -0,0 +1,46 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+func TestContext_LoggerCarriesRequestFields(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	defer hook.Reset()
+
+	c := ut.CreateUtRequestContext("POST", "/orders", nil)
+	c.Set("request_id", "req-1")
+	c.Set("trace_id", "trace-1")
+
+	// 请求早期（如鉴权中间件）添加字段
+	early := NewContext(c)
+	early.WithLogFields(map[string]any{"user_id": 42})
+	early.Release()
+
+	// 同一请求稍后的处理器输出日志
+	ctx := NewContext(c)
+	defer ctx.Release()
+	ctx.WithLogFields(map[string]any{"order_id": "o-9"})
+	ctx.Logger().Info("order created")
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, "order created", entry.Message)
+	assert.Equal(t, "req-1", entry.Data["request_id"])
+	assert.Equal(t, "trace-1", entry.Data["trace_id"])
+	assert.Equal(t, 42, entry.Data["user_id"])
+	assert.Equal(t, "o-9", entry.Data["order_id"])
+}
+
+func TestRequestLogFields_WithoutScopedFields(t *testing.T) {
+	c := ut.CreateUtRequestContext("GET", "/", nil)
+	assert.Empty(t, RequestLogFields(c))
+	assert.Empty(t, RequestLogFields(nil))
+}
@@ -0,0 +1,85 @@
+package context
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+)
+
+// NDJSON请求体的MIME类型
+const (
+	MIMENDJSON  = "application/x-ndjson"
+	MIMENDJSON2 = "application/ndjson"
+)
+
+// ErrNotNDJSON 请求体不是NDJSON格式
+var ErrNotNDJSON = errors.New("ndjson: request content type is not application/x-ndjson")
+
+// NDJSONError NDJSON逐行处理错误，Line为出错的行号（从1开始）
+type NDJSONError struct {
+	Line int
+	Err  error
+}
+
+// Error 实现error接口
+func (e *NDJSONError) Error() string {
+	return fmt.Sprintf("ndjson: line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *NDJSONError) Unwrap() error {
+	return e.Err
+}
+
+// BindNDJSON 逐行读取 application/x-ndjson 请求体，将每行解码为T后调用fn
+// 请求体以流的方式读取，不会一次性缓冲全部记录；未设置Content-Type时按NDJSON处理
+func BindNDJSON[T any](ctx *Context, fn func(item T) error) error {
+	if ctx.Request == nil {
+		return nil
+	}
+
+	if contentType := string(ctx.Request.Request.Header.ContentType()); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != MIMENDJSON && mediaType != MIMENDJSON2) {
+			return ErrNotNDJSON
+		}
+	}
+
+	var body io.Reader
+	if ctx.Request.Request.IsBodyStream() {
+		body = ctx.Request.Request.BodyStream()
+	} else {
+		body = bytes.NewReader(ctx.Request.Request.Body())
+	}
+	return DecodeNDJSON(body, fn)
+}
+
+// DecodeNDJSON 从reader逐行解码NDJSON，空行会被跳过
+// 解码失败或fn返回错误时停止读取，并返回带行号的NDJSONError
+func DecodeNDJSON[T any](r io.Reader, fn func(item T) error) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return &NDJSONError{Line: line, Err: readErr}
+		}
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var item T
+			if err := json.Unmarshal(data, &item); err != nil {
+				return &NDJSONError{Line: line, Err: err}
+			}
+			if err := fn(item); err != nil {
+				return &NDJSONError{Line: line, Err: err}
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
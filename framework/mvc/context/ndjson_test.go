@@ -0,0 +1,76 @@
+package context
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ndjsonRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newNDJSONContext(body, contentType string) *Context {
+	c := ut.CreateUtRequestContext("POST", "/import", &ut.Body{Body: strings.NewReader(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: contentType})
+	return NewContext(c)
+}
+
+func TestBindNDJSON(t *testing.T) {
+	body := "{\"id\":1,\"name\":\"alice\"}\n{\"id\":2,\"name\":\"bob\"}\n\n{\"id\":3,\"name\":\"carol\"}"
+	ctx := newNDJSONContext(body, "application/x-ndjson; charset=utf-8")
+	defer ctx.Release()
+
+	var records []ndjsonRecord
+	require.NoError(t, BindNDJSON(ctx, func(item ndjsonRecord) error {
+		records = append(records, item)
+		return nil
+	}))
+	assert.Equal(t, []ndjsonRecord{{1, "alice"}, {2, "bob"}, {3, "carol"}}, records)
+}
+
+func TestBindNDJSON_ReportsLineNumber(t *testing.T) {
+	body := "{\"id\":1}\n{\"id\":2}\n{\"id\":\"three\"}\n{\"id\":4}\n"
+	ctx := newNDJSONContext(body, MIMENDJSON)
+	defer ctx.Release()
+
+	var ids []int
+	err := BindNDJSON(ctx, func(item ndjsonRecord) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+
+	var ndjsonErr *NDJSONError
+	require.True(t, errors.As(err, &ndjsonErr))
+	assert.Equal(t, 3, ndjsonErr.Line)
+	assert.Contains(t, err.Error(), "line 3")
+	assert.Equal(t, []int{1, 2}, ids, "解码失败后应停止处理")
+}
+
+func TestBindNDJSON_CallbackErrorAndContentType(t *testing.T) {
+	errStop := errors.New("duplicate record")
+	ctx := newNDJSONContext("{\"id\":1}\n{\"id\":1}\n", MIMENDJSON)
+	defer ctx.Release()
+
+	seen := map[int]bool{}
+	err := BindNDJSON(ctx, func(item ndjsonRecord) error {
+		if seen[item.ID] {
+			return errStop
+		}
+		seen[item.ID] = true
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	var ndjsonErr *NDJSONError
+	require.True(t, errors.As(err, &ndjsonErr))
+	assert.Equal(t, 2, ndjsonErr.Line)
+
+	jsonCtx := newNDJSONContext("[]", "application/json")
+	defer jsonCtx.Release()
+	assert.ErrorIs(t, BindNDJSON(jsonCtx, func(ndjsonRecord) error { return nil }), ErrNotNDJSON)
+}
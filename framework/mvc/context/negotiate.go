@@ -0,0 +1,199 @@
+package context
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zsy619/yyhertz/framework/render"
+)
+
+// 常用响应MIME类型
+const (
+	MIMEJSON  = "application/json"
+	MIMEXML   = "application/xml"
+	MIMEXML2  = "text/xml"
+	MIMEYAML  = "application/x-yaml"
+	MIMEYAML2 = "application/yaml"
+	MIMEHTML  = "text/html"
+	MIMEPlain = "text/plain"
+
+	MIMEPROTOBUF = "application/x-protobuf"
+)
+
+// ResponseFormatKey 协商得到的响应格式在请求上下文中的存储键
+const ResponseFormatKey = "__yyhertz_response_format"
+
+// SetResponseFormat 记录本次请求选定的响应格式（MIME类型）
+// 格式保存在底层请求上下文上，同一请求内的中间件、控制器与错误处理器均可读取
+func (ctx *Context) SetResponseFormat(format string) {
+	if ctx.Request == nil {
+		return
+	}
+	ctx.Request.Set(ResponseFormatKey, format)
+}
+
+// ResponseFormat 获取本次请求协商得到的响应格式，未协商时返回空字符串
+func (ctx *Context) ResponseFormat() string {
+	if ctx.Request == nil {
+		return ""
+	}
+	return ctx.Request.GetString(ResponseFormatKey)
+}
+
+// NegotiateFormat 根据Accept请求头从offered中选择最合适的响应格式并记录
+// 未携带Accept或无匹配时返回offered的第一项
+func (ctx *Context) NegotiateFormat(offered ...string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+
+	format := offered[0]
+	if accept := ctx.Header("Accept"); accept != "" {
+		if matched := matchAccept(accept, offered); matched != "" {
+			format = matched
+		}
+	}
+
+	ctx.SetResponseFormat(format)
+	return format
+}
+
+var (
+	negotiateDefaultMu sync.RWMutex
+	negotiateDefault   = MIMEJSON
+)
+
+// SetNegotiateDefault 设置Negotiate在未携带Accept或无匹配时使用的格式（JSON、XML或YAML），不支持的格式恢复为JSON
+func SetNegotiateDefault(format string) {
+	if !isNegotiable(format) {
+		format = MIMEJSON
+	}
+	negotiateDefaultMu.Lock()
+	negotiateDefault = format
+	negotiateDefaultMu.Unlock()
+}
+
+// NegotiateDefault 获取Negotiate的默认格式
+func NegotiateDefault() string {
+	negotiateDefaultMu.RLock()
+	defer negotiateDefaultMu.RUnlock()
+	return negotiateDefault
+}
+
+// Negotiate 根据Accept请求头（支持q值）以JSON、XML或YAML输出数据，data 为protobuf消息时还可输出protobuf
+// 未携带Accept或无匹配时使用 NegotiateDefault 返回的格式
+func (ctx *Context) Negotiate(code int, data any) {
+	offered := []string{NegotiateDefault()}
+	for _, format := range []string{MIMEJSON, MIMEXML, MIMEXML2, MIMEYAML, MIMEYAML2} {
+		if format != offered[0] {
+			offered = append(offered, format)
+		}
+	}
+	if _, ok := data.(proto.Message); ok {
+		offered = append(offered, MIMEPROTOBUF)
+	}
+
+	ctx.NegotiateFormat(offered...)
+	if ctx.Request != nil {
+		ctx.Request.Response.Header.Add("Vary", "Accept")
+	}
+	ctx.RenderFormat(code, data)
+}
+
+// isNegotiable 是否为Negotiate支持的格式
+func isNegotiable(format string) bool {
+	switch format {
+	case MIMEJSON, MIMEXML, MIMEXML2, MIMEYAML, MIMEYAML2:
+		return true
+	}
+	return false
+}
+
+// RenderFormat 按协商得到的响应格式输出数据，未协商时默认输出JSON
+// 协商为protobuf但data不是protobuf消息时输出JSON
+func (ctx *Context) RenderFormat(code int, data any) {
+	format := ctx.ResponseFormat()
+	if _, ok := data.(proto.Message); format == MIMEPROTOBUF && !ok {
+		format = MIMEJSON
+	}
+
+	switch format {
+	case MIMEPROTOBUF:
+		ctx.Render(code, render.ProtoBuf{Data: data})
+	case MIMEXML, MIMEXML2:
+		ctx.Render(code, render.XML{Data: data})
+	case MIMEYAML, MIMEYAML2:
+		ctx.Render(code, render.YAML{Data: data})
+	case MIMEPlain:
+		ctx.Render(code, render.String{Format: "%v", Data: []any{data}})
+	default:
+		ctx.Render(code, render.JSON{Data: data})
+	}
+}
+
+// acceptRange Accept头中的单个媒体范围
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// matchAccept 按质量因子从高到低匹配offered中的格式
+func matchAccept(accept string, offered []string) string {
+	ranges := parseAccept(accept)
+	for _, r := range ranges {
+		if r.quality <= 0 {
+			continue
+		}
+		for _, offer := range offered {
+			if mediaTypeMatches(r.mediaType, offer) {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+// parseAccept 解析Accept请求头，按质量因子降序排列
+func parseAccept(accept string) []acceptRange {
+	parts := strings.Split(accept, ",")
+	ranges := make([]acceptRange, 0, len(parts))
+	for _, part := range parts {
+		segments := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(segments[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range segments[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges
+}
+
+// mediaTypeMatches 判断Accept中的媒体范围是否匹配给定格式（支持 */* 与 type/*）
+func mediaTypeMatches(mediaRange, offer string) bool {
+	offer = strings.ToLower(offer)
+	if mediaRange == "*/*" || mediaRange == offer {
+		return true
+	}
+	if strings.HasSuffix(mediaRange, "/*") {
+		return strings.HasPrefix(offer, strings.TrimSuffix(mediaRange, "*"))
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/zsy619/yyhertz/framework/render"
+)
+
+// negotiate 以指定Accept请求头调用Negotiate，返回Content-Type与响应体
+func negotiate(accept string) (string, string) {
+	var headers []ut.Header
+	if accept != "" {
+		headers = append(headers, ut.Header{Key: "Accept", Value: accept})
+	}
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users/1", nil, headers...))
+	defer ctx.Release()
+
+	ctx.Negotiate(200, render.H{"name": "alice"})
+	return string(ctx.Request.Response.Header.ContentType()), string(ctx.Request.Response.Body())
+}
+
+func TestContext_Negotiate(t *testing.T) {
+	const (
+		jsonBody = `{"name":"alice"}`
+		xmlBody  = `<response><name>alice</name></response>`
+		yamlBody = "name: alice\n"
+	)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"no accept", "", MIMEJSON, jsonBody},
+		{"wildcard", "*/*", MIMEJSON, jsonBody},
+		{"json", "application/json", MIMEJSON, jsonBody},
+		{"xml", "application/xml", MIMEXML, xmlBody},
+		{"text xml", "text/xml", MIMEXML, xmlBody},
+		{"yaml", "application/x-yaml", MIMEYAML, yamlBody},
+		{"yaml registered type", "application/yaml", MIMEYAML, yamlBody},
+		{"quality order", "application/json;q=0.5, application/x-yaml;q=0.9, application/xml;q=0.7", MIMEYAML, yamlBody},
+		{"browser", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", MIMEXML, xmlBody},
+		{"refused format", "application/json;q=0, application/xml;q=0.1", MIMEXML, xmlBody},
+		{"unsupported", "image/png", MIMEJSON, jsonBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body := negotiate(tt.accept)
+			assert.Contains(t, contentType, tt.contentType)
+			assert.Equal(t, tt.body, body)
+		})
+	}
+}
+
+func TestContext_NegotiateDefault(t *testing.T) {
+	SetNegotiateDefault(MIMEYAML)
+	defer SetNegotiateDefault(MIMEJSON)
+
+	contentType, body := negotiate("")
+	assert.Contains(t, contentType, MIMEYAML)
+	assert.Equal(t, "name: alice\n", body)
+
+	// 通配符匹配默认格式，显式指定时仍按Accept选择
+	contentType, _ = negotiate("*/*")
+	assert.Contains(t, contentType, MIMEYAML)
+	contentType, _ = negotiate("application/json")
+	assert.Contains(t, contentType, MIMEJSON)
+
+	SetNegotiateDefault("text/csv")
+	assert.Equal(t, MIMEJSON, NegotiateDefault(), "unsupported default should fall back to JSON")
+}
+
+func TestContext_NegotiateSetsVary(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users/1", nil, ut.Header{Key: "Accept", Value: "application/xml"}))
+	defer ctx.Release()
+
+	ctx.Negotiate(201, render.H{"name": "alice"})
+	assert.Equal(t, 201, ctx.Request.Response.StatusCode())
+	assert.Equal(t, "Accept", string(ctx.Request.Response.Header.Peek("Vary")))
+	assert.Equal(t, MIMEXML, ctx.ResponseFormat())
+}
+
+func TestContext_NegotiateProtoBuf(t *testing.T) {
+	ctx := NewContext(ut.CreateUtRequestContext("GET", "/users/1", nil,
+		ut.Header{Key: "Accept", Value: "application/x-protobuf, application/json;q=0.5"}))
+	defer ctx.Release()
+
+	ctx.Negotiate(200, wrapperspb.String("alice"))
+	assert.Equal(t, MIMEPROTOBUF, ctx.ResponseFormat())
+	assert.Equal(t, MIMEPROTOBUF, string(ctx.Request.Response.Header.ContentType()))
+
+	var decoded wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(ctx.Request.Response.Body(), &decoded))
+	assert.Equal(t, "alice", decoded.GetValue())
+
+	// 非protobuf消息不参与protobuf协商
+	contentType, body := negotiate("application/x-protobuf")
+	assert.Contains(t, contentType, MIMEJSON)
+	assert.Equal(t, `{"name":"alice"}`, body)
+
+	ctx = NewContext(ut.CreateUtRequestContext("GET", "/users/1", nil))
+	defer ctx.Release()
+	ctx.SetResponseFormat(MIMEPROTOBUF)
+	ctx.RenderFormat(200, render.H{"name": "alice"})
+	assert.Contains(t, string(ctx.Request.Response.Header.ContentType()), MIMEJSON)
+}
@@ -0,0 +1,173 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ============= 流式代理 =============
+
+// ProxyStreamClient ProxyStream请求远程URL时使用的HTTP客户端
+var ProxyStreamClient = http.DefaultClient
+
+// proxyResponseHeaders 从上游响应透传给客户端的响应头
+var proxyResponseHeaders = []string{
+	"Content-Range",
+	"Accept-Ranges",
+	"ETag",
+	"Last-Modified",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Cache-Control",
+	"Expires",
+}
+
+// DataFromReader 将reader的内容以流的方式写入响应，不会缓冲到内存
+// contentLength 小于0时以分块方式输出；reader实现io.Closer时在输出完成后关闭
+func (ctx *Context) DataFromReader(code int, contentLength int64, contentType string, reader io.Reader, extraHeaders map[string]string) {
+	if ctx.Request == nil {
+		return
+	}
+
+	header := &ctx.Request.Response.Header
+	if contentType != "" {
+		header.SetContentType(contentType)
+	}
+	for key, value := range extraHeaders {
+		header.Set(key, value)
+	}
+	if contentLength < 0 {
+		contentLength = -1
+	}
+	ctx.Request.SetStatusCode(code)
+	ctx.Request.Response.SetBodyStream(reader, int(contentLength))
+}
+
+// ProxyStream 将远程对象或reader以流的方式代理给客户端，适用于转发对象存储（S3等）中的文件
+//
+// source 为URL字符串时请求该地址，并将客户端的Range、If-Range请求头转发给上游，
+// 上游的状态码（200/206/416等）与Content-Range、ETag等响应头原样返回；
+// contentType 为空、contentLength 小于0时使用上游的值。
+// source 为io.Reader时直接输出；若为io.ReadSeeker且contentLength已知，则在本地处理单区间Range请求，
+// headers 中的ETag、Last-Modified用于If-Range判断。
+// headers 为额外的响应头，最后设置；上游请求失败时返回502及错误
+func (ctx *Context) ProxyStream(source any, contentType string, contentLength int64, headers map[string]string) error {
+	if ctx.Request == nil {
+		return nil
+	}
+
+	switch src := source.(type) {
+	case string:
+		return ctx.proxyURL(src, contentType, contentLength, headers)
+	case io.Reader:
+		ctx.proxyReader(src, contentType, contentLength, headers)
+		return nil
+	default:
+		return fmt.Errorf("proxy stream: unsupported source type %T", source)
+	}
+}
+
+// proxyURL 请求远程地址并转发响应
+func (ctx *Context) proxyURL(url string, contentType string, contentLength int64, headers map[string]string) error {
+	// 响应体在处理函数返回后才输出，不能使用随请求结束而取消的上下文
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		ctx.Request.SetStatusCode(http.StatusBadGateway)
+		return fmt.Errorf("proxy stream: %w", err)
+	}
+	for _, key := range []string{"Range", "If-Range"} {
+		if value := ctx.Header(key); value != "" {
+			req.Header.Set(key, value)
+		}
+	}
+
+	upstream, err := ProxyStreamClient.Do(req)
+	if err != nil {
+		ctx.Request.SetStatusCode(http.StatusBadGateway)
+		return fmt.Errorf("proxy stream: %w", err)
+	}
+
+	if contentType == "" {
+		contentType = upstream.Header.Get("Content-Type")
+	}
+	if contentLength < 0 {
+		contentLength = upstream.ContentLength
+	}
+
+	extra := make(map[string]string, len(proxyResponseHeaders)+len(headers))
+	for _, key := range proxyResponseHeaders {
+		if value := upstream.Header.Get(key); value != "" {
+			extra[key] = value
+		}
+	}
+	for key, value := range headers {
+		extra[key] = value
+	}
+	ctx.DataFromReader(upstream.StatusCode, contentLength, contentType, upstream.Body, extra)
+	return nil
+}
+
+// proxyReader 输出reader，可寻址时处理Range请求
+func (ctx *Context) proxyReader(reader io.Reader, contentType string, contentLength int64, headers map[string]string) {
+	seeker, seekable := reader.(io.ReadSeeker)
+	if !seekable || contentLength < 0 {
+		ctx.DataFromReader(http.StatusOK, contentLength, contentType, reader, headers)
+		return
+	}
+
+	extra := make(map[string]string, len(headers)+2)
+	extra["Accept-Ranges"] = "bytes"
+	for key, value := range headers {
+		extra[key] = value
+	}
+
+	rangeHeader := ctx.Header("Range")
+	if !ctx.proxyIfRangeMatches(headers) {
+		rangeHeader = ""
+	}
+	start, length, err := parseByteRange(rangeHeader, contentLength)
+	if errors.Is(err, errRangeNotSatisfiable) {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		extra["Content-Range"] = fmt.Sprintf("bytes */%d", contentLength)
+		ctx.DataFromReader(http.StatusRequestedRangeNotSatisfiable, 0, contentType, http.NoBody, extra)
+		return
+	}
+	if err != nil || rangeHeader == "" {
+		// 无Range或Range格式无法识别时返回完整内容
+		ctx.DataFromReader(http.StatusOK, contentLength, contentType, reader, extra)
+		return
+	}
+
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		// 无法定位时退回完整内容
+		ctx.DataFromReader(http.StatusOK, contentLength, contentType, reader, extra)
+		return
+	}
+	var body io.Reader = io.LimitReader(reader, length)
+	if closer, ok := reader.(io.Closer); ok {
+		body = fileBody{Reader: body, Closer: closer}
+	}
+	extra["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, contentLength)
+	ctx.DataFromReader(http.StatusPartialContent, length, contentType, body, extra)
+}
+
+// proxyIfRangeMatches 根据响应头中的ETag与Last-Modified判断If-Range条件
+func (ctx *Context) proxyIfRangeMatches(headers map[string]string) bool {
+	var etag string
+	var modTime time.Time
+	for key, value := range headers {
+		switch http.CanonicalHeaderKey(key) {
+		case "Etag":
+			etag = value
+		case "Last-Modified":
+			modTime, _ = http.ParseTime(value)
+		}
+	}
+	return IfRangeMatches(ctx.Header("If-Range"), modTime, etag)
+}
@@ -0,0 +1,156 @@
+package context
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackingReader 记录是否被关闭的只读数据源
+type trackingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func newProxyContext(headers ...ut.Header) *Context {
+	return NewContext(ut.CreateUtRequestContext("GET", "/objects/report.csv", nil, headers...))
+}
+
+func TestContext_ProxyStreamReader(t *testing.T) {
+	ctx := newProxyContext()
+	defer ctx.Release()
+
+	source := &trackingReader{Reader: strings.NewReader("id,name\n1,alice\n")}
+	require.NoError(t, ctx.ProxyStream(source, "text/csv", 16, map[string]string{
+		"Content-Disposition": "attachment; filename=report.csv",
+		"Cache-Control":       "private, max-age=60",
+	}))
+
+	resp := &ctx.Request.Response
+	require.True(t, resp.IsBodyStream(), "响应体应以流的方式输出")
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "text/csv", string(resp.Header.ContentType()))
+	assert.Equal(t, 16, resp.Header.ContentLength())
+	assert.Equal(t, "attachment; filename=report.csv", string(resp.Header.Peek("Content-Disposition")))
+	assert.Equal(t, "private, max-age=60", string(resp.Header.Peek("Cache-Control")))
+	assert.Empty(t, string(resp.Header.Peek("Accept-Ranges")), "不可寻址的数据源不支持Range")
+	assert.Equal(t, "id,name\n1,alice\n", string(resp.Body()))
+
+	resp.ResetBody()
+	assert.True(t, source.closed, "输出完成后应关闭数据源")
+}
+
+func TestContext_ProxyStreamReaderUnknownLength(t *testing.T) {
+	ctx := newProxyContext(ut.Header{Key: "Range", Value: "bytes=0-1"})
+	defer ctx.Release()
+
+	source := io.MultiReader(strings.NewReader("chunk-1 "), strings.NewReader("chunk-2"))
+	require.NoError(t, ctx.ProxyStream(source, "application/octet-stream", -1, nil))
+
+	resp := &ctx.Request.Response
+	require.True(t, resp.IsBodyStream())
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "chunk-1 chunk-2", string(resp.Body()))
+}
+
+func TestContext_ProxyStreamReaderRange(t *testing.T) {
+	const content = "0123456789abcdef"
+	lastModified := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	headers := map[string]string{"ETag": `"v1"`, "Last-Modified": lastModified}
+
+	tests := []struct {
+		name         string
+		rangeHeader  string
+		ifRange      string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"区间", "bytes=2-5", "", 206, "2345", "bytes 2-5/16"},
+		{"后缀区间", "bytes=-3", "", 206, "def", "bytes 13-15/16"},
+		{"超出范围", "bytes=20-30", "", 416, "", "bytes */16"},
+		{"多区间", "bytes=0-1,4-5", "", 200, content, ""},
+		{"If-Range匹配ETag", "bytes=2-5", `"v1"`, 206, "2345", "bytes 2-5/16"},
+		{"If-Range匹配日期", "bytes=2-5", lastModified, 206, "2345", "bytes 2-5/16"},
+		{"If-Range已变更", "bytes=2-5", `"v0"`, 200, content, ""},
+	}
+
+	for _, tt := range tests {
+		reqHeaders := []ut.Header{{Key: "Range", Value: tt.rangeHeader}}
+		if tt.ifRange != "" {
+			reqHeaders = append(reqHeaders, ut.Header{Key: "If-Range", Value: tt.ifRange})
+		}
+		ctx := newProxyContext(reqHeaders...)
+		source := &struct {
+			io.ReadSeeker
+		}{strings.NewReader(content)}
+		require.NoError(t, ctx.ProxyStream(source, "text/plain", int64(len(content)), headers), tt.name)
+
+		resp := &ctx.Request.Response
+		assert.Equal(t, tt.status, resp.StatusCode(), tt.name)
+		assert.Equal(t, tt.body, string(resp.Body()), tt.name)
+		assert.Equal(t, tt.contentRange, string(resp.Header.Peek("Content-Range")), tt.name)
+		assert.Equal(t, "bytes", string(resp.Header.Peek("Accept-Ranges")), tt.name)
+		assert.Equal(t, `"v1"`, string(resp.Header.Peek("ETag")), tt.name)
+		ctx.Release()
+	}
+}
+
+func TestContext_ProxyStreamURL(t *testing.T) {
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var gotRange string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("ETag", `"object-v1"`)
+		w.Header().Set("X-Amz-Request-Id", "internal")
+		http.ServeContent(w, r, "clip.mp4", modTime, strings.NewReader("0123456789abcdef"))
+	}))
+	defer upstream.Close()
+
+	ctx := newProxyContext(ut.Header{Key: "Range", Value: "bytes=4-7"})
+	defer ctx.Release()
+
+	require.NoError(t, ctx.ProxyStream(upstream.URL+"/bucket/clip.mp4", "", -1, map[string]string{
+		"Cache-Control": "public, max-age=3600",
+	}))
+
+	resp := &ctx.Request.Response
+	require.True(t, resp.IsBodyStream())
+	assert.Equal(t, "bytes=4-7", gotRange, "Range请求头应转发给上游")
+	assert.Equal(t, 206, resp.StatusCode())
+	assert.Equal(t, "video/mp4", string(resp.Header.ContentType()))
+	assert.Equal(t, 4, resp.Header.ContentLength())
+	assert.Equal(t, "bytes 4-7/16", string(resp.Header.Peek("Content-Range")))
+	assert.Equal(t, "bytes", string(resp.Header.Peek("Accept-Ranges")))
+	assert.Equal(t, `"object-v1"`, string(resp.Header.Peek("ETag")))
+	assert.Equal(t, "public, max-age=3600", string(resp.Header.Peek("Cache-Control")))
+	assert.Empty(t, string(resp.Header.Peek("X-Amz-Request-Id")), "上游内部响应头不应透传")
+	assert.Equal(t, "4567", string(resp.Body()))
+}
+
+func TestContext_ProxyStreamURLUnavailable(t *testing.T) {
+	upstream := httptest.NewServer(http.NotFoundHandler())
+	url := upstream.URL
+	upstream.Close()
+
+	ctx := newProxyContext()
+	defer ctx.Release()
+
+	assert.Error(t, ctx.ProxyStream(url+"/bucket/missing", "", -1, nil))
+	assert.Equal(t, 502, ctx.Request.Response.StatusCode())
+
+	assert.Error(t, ctx.ProxyStream(42, "", -1, nil))
+}
@@ -0,0 +1,15 @@
+package context
+
+// 请求ID的请求头与在 RequestContext 中的存储键
+const (
+	RequestIDHeader = "X-Request-ID"
+	RequestIDKey    = "request_id"
+)
+
+// RequestID 获取本次请求的请求ID，由请求ID中间件读取 X-Request-ID 请求头或生成；未设置时返回空字符串
+func (ctx *Context) RequestID() string {
+	if ctx.Request == nil {
+		return ""
+	}
+	return ctx.Request.GetString(RequestIDKey)
+}
@@ -0,0 +1,20 @@
+package context
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/orm"
+)
+
+// Tx 获取事务中间件为本次请求开启的事务，未启用事务中间件时返回nil
+func (ctx *Context) Tx() *gorm.DB {
+	if ctx.Request == nil {
+		return nil
+	}
+	if value, exists := ctx.Request.Get(orm.RequestTransactionKey); exists {
+		if tx, ok := value.(*gorm.DB); ok {
+			return tx
+		}
+	}
+	return nil
+}
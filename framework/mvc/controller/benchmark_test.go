@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	"github.com/zsy619/yyhertz/framework/metrics"
 	"github.com/zsy619/yyhertz/framework/mvc/core"
 	mvcContext "github.com/zsy619/yyhertz/framework/mvc/context"
 )
@@ -255,7 +258,14 @@ func TestControllerCompiler(t *testing.T) {
 			t.Errorf("Method %s not found in compiled controller", methodName)
 		}
 	}
-	
+
+	// 嵌入的BaseController提升的方法不应作为动作编译
+	for _, methodName := range []string{"GetHeader", "GetClientIP", "GetMethodMapping"} {
+		if _, exists := compiled.Methods[methodName]; exists {
+			t.Errorf("Promoted method %s should not be compiled", methodName)
+		}
+	}
+
 	// 测试缓存
 	compiled2, err := compiler.Compile(controller)
 	if err != nil {
@@ -267,6 +277,60 @@ func TestControllerCompiler(t *testing.T) {
 	}
 }
 
+// listMixin 提供通用动作的嵌入组件
+type listMixin struct {
+	Called string
+}
+
+// GetList 被外层控制器覆盖的动作
+func (m *listMixin) GetList() {
+	m.Called = "mixin"
+}
+
+// GetExport 未被覆盖的嵌入动作
+func (m *listMixin) GetExport() {
+	m.Called = "mixin"
+}
+
+// OverrideController 覆盖嵌入组件动作的控制器
+type OverrideController struct {
+	core.BaseController
+	listMixin
+	Called string
+}
+
+// GetList 覆盖嵌入组件的同名动作
+func (oc *OverrideController) GetList() {
+	oc.Called = "override"
+}
+
+// TestControllerCompiler_OverriddenEmbeddedAction 控制器覆盖嵌入字段的动作时应编译外层方法
+func TestControllerCompiler_OverriddenEmbeddedAction(t *testing.T) {
+	compiler := NewControllerCompiler(DefaultCompilerConfig())
+	controller := &OverrideController{}
+
+	compiled, err := compiler.Compile(controller)
+	if err != nil {
+		t.Fatalf("Compilation failed: %v", err)
+	}
+
+	method, exists := compiled.Methods["GetList"]
+	if !exists {
+		t.Fatal("Overridden action GetList should be compiled")
+	}
+	if _, exists := compiled.Methods["GetExport"]; exists {
+		t.Error("Promoted action GetExport should not be compiled")
+	}
+
+	ctx := mvcContext.NewContext(ut.CreateUtRequestContext("GET", "/override/list", nil))
+	if err := method.Handler(ctx, controller); err != nil {
+		t.Fatalf("Handler failed: %v", err)
+	}
+	if controller.Called != "override" {
+		t.Errorf("Expected the outer GetList to run, got %q", controller.Called)
+	}
+}
+
 // TestLifecycleManager 生命周期管理器测试
 func TestLifecycleManager(t *testing.T) {
 	config := DefaultCompilerConfig()
@@ -299,9 +363,12 @@ func TestLifecycleManager(t *testing.T) {
 		return nil
 	})
 	
-	_, err = lifecycleManager.CreateController(controllerType, ctx)
-	if err != nil {
-		t.Fatalf("Failed to create controller with hook: %v", err)
+	// 复用池中的实例不会触发创建钩子，池空后新建的实例才会触发
+	for i := 0; i < 2 && !hookCalled; i++ {
+		_, err = lifecycleManager.CreateController(controllerType, ctx)
+		if err != nil {
+			t.Fatalf("Failed to create controller with hook: %v", err)
+		}
 	}
 	
 	if !hookCalled {
@@ -358,6 +425,51 @@ func TestOptimizedControllerManager(t *testing.T) {
 	}
 }
 
+// TestRequestTimingSharedRegistry 优化管理器与标准分发器共用请求计时
+func TestRequestTimingSharedRegistry(t *testing.T) {
+	metrics.ResetRequestTimings()
+	defer metrics.ResetRequestTimings()
+	
+	// 优化控制器管理器路径
+	manager := NewOptimizedControllerManager(DefaultCompilerConfig())
+	if manager.timings != metrics.RequestTimingRegistry() {
+		t.Fatal("Expected manager to record into the shared request timing registry")
+	}
+	if err := manager.RegisterController(NewBenchmarkController()); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+	ctx := &mvcContext.Context{
+		Keys: make(map[string]interface{}),
+	}
+	if err := manager.HandleRequest(ctx, "BenchmarkController", "GetIndex"); err != nil {
+		t.Fatalf("Request handling failed: %v", err)
+	}
+	
+	// 标准MVC分发器路径
+	app := core.NewApp()
+	app.Router(NewBenchmarkController(), "GetIndex", "GET:/timing/index")
+	resp := ut.PerformRequest(app.Engine, "GET", "/timing/index", nil).Result()
+	if resp.StatusCode() != 200 {
+		t.Fatalf("Expected 200 from dispatcher, got %d", resp.StatusCode())
+	}
+	
+	timings := metrics.GetRequestTimings()
+	if timings.Requests[metrics.RequestSourceManager] != 1 {
+		t.Errorf("Expected 1 manager request, got %d", timings.Requests[metrics.RequestSourceManager])
+	}
+	if timings.Requests[metrics.RequestSourceDispatcher] != 1 {
+		t.Errorf("Expected 1 dispatcher request, got %d", timings.Requests[metrics.RequestSourceDispatcher])
+	}
+	if timings.TotalRequests != 2 {
+		t.Errorf("Expected 2 total requests, got %d", timings.TotalRequests)
+	}
+	
+	// 管理器统计与共用注册表一致
+	if stats := manager.GetStats(); stats.TotalRequests != timings.TotalRequests {
+		t.Errorf("Expected manager stats to report %d requests, got %d", timings.TotalRequests, stats.TotalRequests)
+	}
+}
+
 // TestParameterBinding 参数绑定测试
 func TestParameterBinding(t *testing.T) {
 	// 这里需要实际的HTTP上下文来测试参数绑定
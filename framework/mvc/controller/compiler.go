@@ -1,12 +1,15 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/zsy619/yyhertz/framework/mvc/binding"
 	"github.com/zsy619/yyhertz/framework/mvc/context"
 )
 
@@ -131,12 +134,13 @@ func (cc *ControllerCompiler) compileController(controller interface{}, controll
 		CreatedAt: time.Now(),
 	}
 
-	// 编译所有公开方法
-	for i := 0; i < controllerType.NumMethod(); i++ {
-		method := controllerType.Method(i)
+	// 编译所有公开方法，从指针类型收集以包含指针接收者的方法
+	ptrType := reflect.PtrTo(controllerType)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
 		
-		// 跳过非公开方法和基础方法
-		if !method.IsExported() || cc.isBaseMethod(method.Name) {
+		// 跳过非公开方法、基础方法以及嵌入的基础控制器提升的方法
+		if !method.IsExported() || cc.isBaseMethod(method.Name) || isPromotedMethod(controllerType, method.Name) {
 			continue
 		}
 
@@ -202,8 +206,12 @@ func (cc *ControllerCompiler) createOptimizedHandler(method reflect.Method, bind
 			return fmt.Errorf("parameter binding failed: %w", err)
 		}
 
-		// 2. 参数验证
+		// 2. 参数验证，未通过时响应422并列出每个字段的错误
 		if err := validator.ValidateParameters(params); err != nil {
+			var validationErrs binding.ValidationErrors
+			if errors.As(err, &validationErrs) {
+				writeValidationErrors(ctx, validationErrs)
+			}
 			return fmt.Errorf("parameter validation failed: %w", err)
 		}
 
@@ -227,6 +235,15 @@ func (cc *ControllerCompiler) createOptimizedHandler(method reflect.Method, bind
 	}
 }
 
+// writeValidationErrors 输出结构化的验证错误响应（422 Unprocessable Entity）
+func writeValidationErrors(ctx *context.Context, errs binding.ValidationErrors) {
+	ctx.JSON(422, map[string]interface{}{
+		"error":  "Validation failed",
+		"code":   "VALIDATION_FAILED",
+		"errors": errs,
+	})
+}
+
 // handleMethodResult 处理方法返回值
 func (cc *ControllerCompiler) handleMethodResult(ctx *context.Context, results []reflect.Value) error {
 	if len(results) == 0 {
@@ -303,9 +320,10 @@ func (cc *ControllerCompiler) extractMetadata(controllerType reflect.Type) *Cont
 	}
 
 	// 提取方法列表
-	for i := 0; i < controllerType.NumMethod(); i++ {
-		method := controllerType.Method(i)
-		if method.IsExported() {
+	ptrType := reflect.PtrTo(controllerType)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
+		if method.IsExported() && !isPromotedMethod(controllerType, method.Name) {
 			metadata.Methods = append(metadata.Methods, method.Name)
 		}
 	}
@@ -341,6 +359,45 @@ func (cc *ControllerCompiler) isBaseMethod(methodName string) bool {
 	return false
 }
 
+// isPromotedMethod 判断方法是否仅由嵌入字段（如 core.BaseController）提升而来
+// 控制器自身声明的同名方法（覆盖嵌入字段的动作）不属于提升方法
+func isPromotedMethod(controllerType reflect.Type, methodName string) bool {
+	if controllerType.Kind() != reflect.Struct || isDeclaredMethod(controllerType, methodName) {
+		return false
+	}
+	for i := 0; i < controllerType.NumField(); i++ {
+		field := controllerType.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() != reflect.Ptr {
+			fieldType = reflect.PtrTo(fieldType)
+		}
+		if _, ok := fieldType.MethodByName(methodName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeclaredMethod 判断方法是否由类型自身（值或指针接收者）声明
+// 提升方法在方法表中对应编译器生成的包装函数，其源文件为 <autogenerated>
+func isDeclaredMethod(t reflect.Type, methodName string) bool {
+	for _, typ := range []reflect.Type{reflect.PtrTo(t), t} {
+		method, ok := typ.MethodByName(methodName)
+		if !ok {
+			continue
+		}
+		if fn := runtime.FuncForPC(method.Func.Pointer()); fn != nil {
+			if file, _ := fn.FileLine(fn.Entry()); file != "<autogenerated>" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getFromCache 从缓存获取编译结果
 func (cc *ControllerCompiler) getFromCache(controllerName string) (*CompiledController, bool) {
 	if value, exists := cc.cache.Load(controllerName); exists {
@@ -354,6 +411,24 @@ func (cc *ControllerCompiler) GetCompiledController(controllerName string) (*Com
 	return cc.getFromCache(controllerName)
 }
 
+// CacheSize 获取编译缓存中的控制器数量
+func (cc *ControllerCompiler) CacheSize() int {
+	size := 0
+	cc.cache.Range(func(key, value interface{}) bool {
+		size++
+		return true
+	})
+	return size
+}
+
+// ClearCache 清空编译缓存，控制器将在下次编译时重新生成
+func (cc *ControllerCompiler) ClearCache() {
+	cc.cache.Range(func(key, value interface{}) bool {
+		cc.cache.Delete(key)
+		return true
+	})
+}
+
 // PrecompileAll 预编译所有已注册的控制器
 func (cc *ControllerCompiler) PrecompileAll(controllers []interface{}) error {
 	for _, controller := range controllers {
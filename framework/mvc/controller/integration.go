@@ -3,9 +3,12 @@ package controller
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/zsy619/yyhertz/framework/cache"
+	"github.com/zsy619/yyhertz/framework/metrics"
 	"github.com/zsy619/yyhertz/framework/mvc/context"
 )
 
@@ -16,6 +19,9 @@ type OptimizedControllerManager struct {
 	controllers    sync.Map                // 已注册的控制器
 	config         *CompilerConfig         // 配置
 	stats          *PerformanceStats       // 性能统计
+	timings        *metrics.RequestTimings // 请求耗时（与标准分发器共用）
+	routes         *RouteMatcher           // 由GetMethodMapping构建的路由匹配器
+	middleware     map[string]context.HandlerFunc // 命名中间件
 	mu             sync.RWMutex           // 读写锁
 }
 
@@ -36,11 +42,18 @@ func NewOptimizedControllerManager(config *CompilerConfig) *OptimizedControllerM
 		config = DefaultCompilerConfig()
 	}
 
+	compiler := NewControllerCompiler(config)
+	// 登记编译缓存，供缓存管理接口查看与清空
+	cache.Register(cache.NewInspector(cache.ControllerCompilerCacheName, "控制器编译缓存", compiler.CacheSize, compiler.ClearCache))
+
 	return &OptimizedControllerManager{
-		compiler:         NewControllerCompiler(config),
+		compiler:         compiler,
 		lifecycleManager: NewLifecycleManager(config),
 		config:          config,
 		stats:           &PerformanceStats{},
+		timings:         metrics.RequestTimingRegistry(),
+		routes:          NewRouteMatcher(),
+		middleware:      make(map[string]context.HandlerFunc),
 	}
 }
 
@@ -64,6 +77,14 @@ func (ocm *OptimizedControllerManager) RegisterController(controller interface{}
 	// 更新统计信息
 	ocm.stats.updateCompilationTime(compilationTime)
 
+	// 注册方法映射中的路由
+	if err := ocm.registerRoutes(controllerName, controller); err != nil {
+		return fmt.Errorf("failed to register routes for controller %s: %w", controllerName, err)
+	}
+
+	// 合并控制器级与方法级中间件
+	attachMiddleware(controller, compiled)
+
 	// 存储编译后的控制器
 	ocm.controllers.Store(controllerName, compiled)
 
@@ -75,9 +96,7 @@ func (ocm *OptimizedControllerManager) RegisterController(controller interface{}
 func (ocm *OptimizedControllerManager) HandleRequest(ctx *context.Context, controllerName, methodName string) error {
 	startTime := time.Now()
 	defer func() {
-		responseTime := time.Since(startTime)
-		ocm.stats.updateResponseTime(responseTime)
-		ocm.stats.updateTotalRequests(1)
+		ocm.timings.Record(metrics.RequestSourceManager, time.Since(startTime))
 	}()
 
 	// 获取编译后的控制器
@@ -92,6 +111,26 @@ func (ocm *OptimizedControllerManager) HandleRequest(ctx *context.Context, contr
 		return fmt.Errorf("method not found: %s.%s", controllerName, methodName)
 	}
 
+	// 解析控制器级与方法级中间件
+	middlewares, err := ocm.resolveMiddleware(compiledMethod.Middleware)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", controllerName, methodName, err)
+	}
+	if len(middlewares) == 0 {
+		return ocm.invoke(ctx, compiledController, compiledMethod)
+	}
+
+	// 中间件依次执行，中止后不再调用控制器方法
+	var invokeErr error
+	ctx.SetHandlers(append(middlewares, func(ctx *context.Context) {
+		invokeErr = ocm.invoke(ctx, compiledController, compiledMethod)
+	}))
+	ctx.Next()
+	return invokeErr
+}
+
+// invoke 创建控制器实例并执行编译后的方法
+func (ocm *OptimizedControllerManager) invoke(ctx *context.Context, compiledController *CompiledController, compiledMethod *CompiledMethod) error {
 	// 创建控制器实例
 	instance, err := ocm.lifecycleManager.CreateController(compiledController.Type, ctx)
 	if err != nil {
@@ -113,6 +152,110 @@ func (ocm *OptimizedControllerManager) HandleRequest(ctx *context.Context, contr
 	return nil
 }
 
+// RegisterMiddleware 登记命名中间件，供控制器GetMiddleware()与GetMethodMiddleware()按名称引用
+func (ocm *OptimizedControllerManager) RegisterMiddleware(name string, handler context.HandlerFunc) {
+	ocm.mu.Lock()
+	ocm.middleware[name] = handler
+	ocm.mu.Unlock()
+}
+
+// resolveMiddleware 按名称解析中间件，引用未登记的中间件时返回错误
+func (ocm *OptimizedControllerManager) resolveMiddleware(names []string) ([]context.HandlerFunc, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ocm.mu.RLock()
+	defer ocm.mu.RUnlock()
+
+	handlers := make([]context.HandlerFunc, 0, len(names)+1)
+	for _, name := range names {
+		handler, ok := ocm.middleware[name]
+		if !ok {
+			return nil, fmt.Errorf("middleware not registered: %s", name)
+		}
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
+// attachMiddleware 为每个编译后的方法合并控制器级中间件（GetMiddleware）与方法级中间件（GetMethodMiddleware），
+// 控制器级在前，重复的名称只保留第一次出现
+func attachMiddleware(controller interface{}, compiled *CompiledController) {
+	var controllerLevel []string
+	if namer, ok := controller.(interface{ GetMiddleware() []string }); ok {
+		controllerLevel = namer.GetMiddleware()
+	}
+	var methodLevel map[string][]string
+	if namer, ok := controller.(interface{ GetMethodMiddleware() map[string][]string }); ok {
+		methodLevel = namer.GetMethodMiddleware()
+	}
+
+	for methodName, method := range compiled.Methods {
+		method.Middleware = mergeMiddleware(method.Middleware, controllerLevel, methodLevel[methodName])
+	}
+}
+
+// mergeMiddleware 按顺序合并中间件名称并去重
+func mergeMiddleware(lists ...[]string) []string {
+	var merged []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				merged = append(merged, name)
+			}
+		}
+	}
+	return merged
+}
+
+// Dispatch 按HTTP方法与请求路径分发请求，提取的路径参数追加到ctx.Params
+func (ocm *OptimizedControllerManager) Dispatch(ctx *context.Context, httpMethod, path string) error {
+	match, ok := ocm.routes.Match(httpMethod, path)
+	if !ok {
+		return fmt.Errorf("%w: %s %s", ErrRouteNotFound, httpMethod, path)
+	}
+
+	ctx.Params = append(ctx.Params, match.Params...)
+	ctx.FullPath = match.Target.Pattern
+	return ocm.HandleRequest(ctx, match.Target.Controller, match.Target.Method)
+}
+
+// MatchRoute 匹配请求路径对应的控制器方法
+func (ocm *OptimizedControllerManager) MatchRoute(httpMethod, path string) (*RouteMatch, bool) {
+	return ocm.routes.Match(httpMethod, path)
+}
+
+// registerRoutes 将控制器GetMethodMapping中 "GET:/users/{id}" 形式的映射添加到路由匹配器
+func (ocm *OptimizedControllerManager) registerRoutes(controllerName string, controller interface{}) error {
+	mapper, ok := controller.(interface{ GetMethodMapping() map[string]string })
+	if !ok {
+		return nil
+	}
+
+	mapping := mapper.GetMethodMapping()
+	methodNames := make([]string, 0, len(mapping))
+	for methodName := range mapping {
+		methodNames = append(methodNames, methodName)
+	}
+	// 按方法名排序，使冲突报告稳定
+	sort.Strings(methodNames)
+
+	for _, methodName := range methodNames {
+		httpMethod, pattern, ok := ParseRouteMapping(mapping[methodName])
+		if !ok {
+			continue
+		}
+		target := RouteTarget{Controller: controllerName, Method: methodName}
+		if err := ocm.routes.Add(httpMethod, pattern, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getCompiledController 获取编译后的控制器
 func (ocm *OptimizedControllerManager) getCompiledController(controllerName string) (*CompiledController, error) {
 	if value, exists := ocm.controllers.Load(controllerName); exists {
@@ -135,13 +278,16 @@ func (ocm *OptimizedControllerManager) PrecompileAll() error {
 }
 
 // GetStats 获取性能统计
+// 请求数与平均响应时间来自共用的请求耗时注册表，包含标准分发器处理的请求
 func (ocm *OptimizedControllerManager) GetStats() *PerformanceStats {
+	timings := ocm.timings.Snapshot()
+
 	ocm.stats.mu.RLock()
 	defer ocm.stats.mu.RUnlock()
 
 	return &PerformanceStats{
-		TotalRequests:       ocm.stats.TotalRequests,
-		AverageResponseTime: ocm.stats.AverageResponseTime,
+		TotalRequests:       timings.TotalRequests,
+		AverageResponseTime: timings.AverageResponseTime,
 		CacheHitRate:       ocm.stats.CacheHitRate,
 		CompilationTime:    ocm.stats.CompilationTime,
 		ControllerInstances: ocm.stats.ControllerInstances,
@@ -167,7 +313,7 @@ func (ocm *OptimizedControllerManager) GetDetailedStats() map[string]interface{}
 			"methods_count": len(compiled.Methods),
 			"created_at":    compiled.CreatedAt,
 			"metadata":      compiled.Metadata,
-			"pool_stats":    compiled.Pool.Stats(),
+			"pool_stats":    ocm.lifecycleManager.PoolStats(compiled.Type),
 		}
 		return true
 	})
@@ -209,25 +355,6 @@ func (ocm *OptimizedControllerManager) Shutdown() error {
 
 // PerformanceStats 方法实现
 
-// updateTotalRequests 更新总请求数
-func (ps *PerformanceStats) updateTotalRequests(delta int64) {
-	ps.mu.Lock()
-	ps.TotalRequests += delta
-	ps.mu.Unlock()
-}
-
-// updateResponseTime 更新响应时间
-func (ps *PerformanceStats) updateResponseTime(duration time.Duration) {
-	ps.mu.Lock()
-	// 简单的移动平均算法
-	if ps.AverageResponseTime == 0 {
-		ps.AverageResponseTime = duration
-	} else {
-		ps.AverageResponseTime = (ps.AverageResponseTime + duration) / 2
-	}
-	ps.mu.Unlock()
-}
-
 // updateCompilationTime 更新编译时间
 func (ps *PerformanceStats) updateCompilationTime(duration time.Duration) {
 	ps.mu.Lock()
@@ -261,6 +388,12 @@ type OptimizedController interface {
 	GetMiddleware() []string
 }
 
+// MethodMiddlewareProvider 可选接口，声明方法级中间件（方法名 -> 中间件名称列表），
+// 与GetMethodMapping并列，例如 {"PostCreate": {"rateLimit"}}
+type MethodMiddlewareProvider interface {
+	GetMethodMiddleware() map[string][]string
+}
+
 // ============= BaseOptimizedController 已完全移除 =============
 // 
 // BaseOptimizedController 已完全合并到 framework/mvc/core.BaseController 中
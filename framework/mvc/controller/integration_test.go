@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	"github.com/zsy619/yyhertz/framework/cache"
+	mvcContext "github.com/zsy619/yyhertz/framework/mvc/context"
+	"github.com/zsy619/yyhertz/framework/mvc/core"
+)
+
+// methodMiddlewareController 仅PostCreate声明方法级限流中间件的测试控制器
+type methodMiddlewareController struct {
+	core.BaseController
+}
+
+// middlewareCalls 记录中间件与方法的执行顺序
+var middlewareCalls []string
+
+func (mc *methodMiddlewareController) GetIndex() error {
+	middlewareCalls = append(middlewareCalls, "GetIndex")
+	return nil
+}
+
+func (mc *methodMiddlewareController) PostCreate() error {
+	middlewareCalls = append(middlewareCalls, "PostCreate")
+	return nil
+}
+
+func (mc *methodMiddlewareController) GetMethodMapping() map[string]string {
+	return map[string]string{
+		"GetIndex":   "GET:/users",
+		"PostCreate": "POST:/users",
+	}
+}
+
+func (mc *methodMiddlewareController) GetMiddleware() []string {
+	return []string{"logging"}
+}
+
+func (mc *methodMiddlewareController) GetMethodMiddleware() map[string][]string {
+	return map[string][]string{
+		"PostCreate": {"logging", "rateLimit"},
+	}
+}
+
+func TestOptimizedControllerManager_MethodMiddleware(t *testing.T) {
+	manager := NewOptimizedControllerManager(nil)
+	manager.RegisterMiddleware("logging", func(ctx *mvcContext.Context) {
+		middlewareCalls = append(middlewareCalls, "logging")
+	})
+	// 每个请求允许一次，之后中止
+	allowed := 1
+	manager.RegisterMiddleware("rateLimit", func(ctx *mvcContext.Context) {
+		middlewareCalls = append(middlewareCalls, "rateLimit")
+		if allowed == 0 {
+			ctx.Abort()
+			return
+		}
+		allowed--
+	})
+	if err := manager.RegisterController(&methodMiddlewareController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+
+	dispatch := func(method, path string) string {
+		middlewareCalls = nil
+		if err := manager.Dispatch(&mvcContext.Context{Keys: make(map[string]interface{})}, method, path); err != nil {
+			t.Fatalf("Dispatch %s %s failed: %v", method, path, err)
+		}
+		return strings.Join(middlewareCalls, ",")
+	}
+
+	// 同级方法只执行控制器级中间件
+	for i := 0; i < 3; i++ {
+		if got := dispatch("GET", "/users"); got != "logging,GetIndex" {
+			t.Errorf("Expected logging,GetIndex, got %s", got)
+		}
+	}
+
+	// 方法级中间件在控制器级之后执行，重复的名称只执行一次
+	if got := dispatch("POST", "/users"); got != "logging,rateLimit,PostCreate" {
+		t.Errorf("Expected logging,rateLimit,PostCreate, got %s", got)
+	}
+	// 超出限流时中止，控制器方法不再执行
+	if got := dispatch("POST", "/users"); got != "logging,rateLimit" {
+		t.Errorf("Expected request to be rate limited, got %s", got)
+	}
+}
+
+func TestOptimizedControllerManager_UnregisteredMiddleware(t *testing.T) {
+	manager := NewOptimizedControllerManager(nil)
+	manager.RegisterMiddleware("logging", func(ctx *mvcContext.Context) {})
+	if err := manager.RegisterController(&methodMiddlewareController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+
+	err := manager.Dispatch(&mvcContext.Context{Keys: make(map[string]interface{})}, "POST", "/users")
+	if err == nil || !strings.Contains(err.Error(), "rateLimit") {
+		t.Errorf("Expected unregistered rateLimit middleware error, got %v", err)
+	}
+}
+
+// accountCreateRequest 注册请求
+type accountCreateRequest struct {
+	Name     string `json:"name" validate:"required,min=2"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// accountController 带请求结构体参数的测试控制器
+type accountController struct {
+	core.BaseController
+}
+
+func (ac *accountController) PostCreate(req accountCreateRequest) error {
+	middlewareCalls = append(middlewareCalls, "PostCreate")
+	return nil
+}
+
+func TestOptimizedControllerManager_StructuredValidationErrors(t *testing.T) {
+	manager := NewOptimizedControllerManager(nil)
+	if err := manager.RegisterController(&accountController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+	middlewareCalls = nil
+
+	body := `{"name":"A","email":"not-an-email","password":"short"}`
+	ctx := mvcContext.NewContext(ut.CreateUtRequestContext("POST", "/accounts",
+		&ut.Body{Body: bytes.NewBufferString(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: "application/json"}))
+	defer ctx.Release()
+
+	if err := manager.HandleRequest(ctx, "accountController", "PostCreate"); err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if len(middlewareCalls) != 0 {
+		t.Errorf("Expected action not to run, got %v", middlewareCalls)
+	}
+
+	resp := &ctx.Request.Response
+	if resp.StatusCode() != 422 {
+		t.Fatalf("Expected 422, got %d", resp.StatusCode())
+	}
+
+	var result struct {
+		Code   string `json:"code"`
+		Errors []struct {
+			Field   string `json:"field"`
+			Rule    string `json:"rule"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		t.Fatalf("Invalid JSON body %s: %v", resp.Body(), err)
+	}
+	if result.Code != "VALIDATION_FAILED" {
+		t.Errorf("Expected VALIDATION_FAILED, got %s", result.Code)
+	}
+
+	var got []string
+	for _, e := range result.Errors {
+		if e.Message == "" {
+			t.Errorf("Expected message for field %s", e.Field)
+		}
+		got = append(got, e.Field+":"+e.Rule)
+	}
+	if strings.Join(got, ",") != "name:min,email:email,password:min" {
+		t.Errorf("Expected every violated field, got %v", got)
+	}
+}
+
+func TestOptimizedControllerManager_RegistersCompilerCache(t *testing.T) {
+	manager := NewOptimizedControllerManager(nil)
+	if err := manager.RegisterController(&accountController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+
+	inspector, ok := cache.Lookup(cache.ControllerCompilerCacheName)
+	if !ok {
+		t.Fatal("Expected compiler cache to be registered")
+	}
+	if size := inspector.GetStats().Size; size != 1 {
+		t.Errorf("Expected 1 compiled controller, got %d", size)
+	}
+
+	inspector.Clear()
+	if size := inspector.GetStats().Size; size != 0 {
+		t.Errorf("Expected flushed compiler cache to be empty, got %d", size)
+	}
+	if _, ok := manager.compiler.GetCompiledController("accountController"); ok {
+		t.Error("Expected compiled controller to be removed")
+	}
+}
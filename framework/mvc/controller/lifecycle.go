@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/zsy619/yyhertz/framework/config"
 	mvcContext "github.com/zsy619/yyhertz/framework/mvc/context"
 )
 
 // LifecycleManager 控制器生命周期管理器
 type LifecycleManager struct {
-	pools       sync.Map                    // 控制器池映射
+	pools       sync.Map                    // 控制器池映射（reflect.Type -> *ControllerPool）
 	config      *CompilerConfig            // 配置
 	hooks       map[LifecycleHook][]HookFunc // 生命周期钩子
 	metrics     *LifecycleMetrics          // 生命周期指标
@@ -38,21 +40,35 @@ type LifecycleMetrics struct {
 	CreatedCount   int64         // 创建数量
 	DestroyedCount int64         // 销毁数量
 	ActiveCount    int64         // 活跃数量
+	PoolHits       int64         // 从池中复用实例的次数
+	PoolMisses     int64         // 池中无可用实例而新建的次数
 	PoolHitRate    float64       // 池命中率
 	AverageLifetime time.Duration // 平均生命周期
 	mu             sync.RWMutex  // 指标锁
 }
 
-// ControllerPool 控制器池
+// ControllerPool 控制器池，基于sync.Pool按控制器类型复用实例
+// maxSize为空闲实例数的软上限，超出时归还的实例被销毁；空闲超过maxIdleTime的实例在取出或清理时被销毁。
+// sync.Pool可能在GC时回收空闲实例，因此空闲数为近似值
+
 type ControllerPool struct {
-	factory    ControllerFactory           // 控制器工厂
-	pool       chan interface{}            // 控制器池
-	controllerType reflect.Type            // 控制器类型
-	maxSize    int                        // 最大池大小
-	created    int64                      // 已创建数量
-	borrowed   int64                      // 已借出数量
-	returned   int64                      // 已归还数量
-	mu         sync.RWMutex               // 池锁
+	factory        ControllerFactory            // 控制器工厂
+	pool           sync.Pool                    // 空闲实例
+	controllerType reflect.Type                 // 控制器类型
+	maxSize        int                          // 空闲实例数软上限（<=0表示不限制）
+	maxIdleTime    time.Duration                // 最大空闲时间（<=0表示不过期）
+	onEvict        func(controller interface{}) // 实例被丢弃时的回调
+	idle           int64                        // 空闲实例数（近似值）
+	created        int64                        // 已创建数量
+	borrowed       int64                        // 已借出数量
+	returned       int64                        // 已归还数量
+	evicted        int64                        // 已丢弃数量
+}
+
+// pooledController 池中的空闲实例
+type pooledController struct {
+	controller interface{}
+	idleSince  time.Time
 }
 
 // ControllerFactory 控制器工厂接口
@@ -94,7 +110,6 @@ func NewLifecycleManager(config *CompilerConfig) *LifecycleManager {
 // NewControllerPool 创建控制器池
 func NewControllerPool(controllerType reflect.Type, maxSize int) *ControllerPool {
 	return &ControllerPool{
-		pool:           make(chan interface{}, maxSize),
 		controllerType: controllerType,
 		maxSize:        maxSize,
 	}
@@ -108,26 +123,31 @@ func NewDefaultControllerFactory(controllerType reflect.Type, lifecycle *Lifecyc
 	}
 }
 
-// CreateController 创建控制器实例
+// CreateController 创建控制器实例，启用池化（PoolSize>0）时优先复用池中的实例
 func (lm *LifecycleManager) CreateController(controllerType reflect.Type, ctx *mvcContext.Context) (*ControllerInstance, error) {
+	pooling := lm.config != nil && lm.config.PoolSize > 0
+
 	// 尝试从池中获取
-	if pool, exists := lm.getPool(controllerType); exists {
+	if pooling {
+		pool := lm.getOrCreatePool(controllerType)
 		if controller := pool.Get(); controller != nil {
 			instance := &ControllerInstance{
 				Controller: controller,
 				LastUsed:   time.Now(),
 				Pooled:     true,
 			}
-			
+
 			// 初始化控制器
 			if err := lm.initController(controller, ctx); err != nil {
 				pool.Put(controller) // 归还到池
 				return nil, fmt.Errorf("failed to initialize controller: %w", err)
 			}
-			
+
+			lm.metrics.updatePool(true)
 			lm.metrics.updateActive(1)
 			return instance, nil
 		}
+		lm.metrics.updatePool(false)
 	}
 
 	// 创建新实例
@@ -135,13 +155,16 @@ func (lm *LifecycleManager) CreateController(controllerType reflect.Type, ctx *m
 	if err != nil {
 		return nil, err
 	}
+	if pooling {
+		atomic.AddInt64(&lm.getOrCreatePool(controllerType).created, 1)
+	}
 
 	instance := &ControllerInstance{
 		Controller: controller,
 		CreatedAt:  time.Now(),
 		LastUsed:   time.Now(),
 		UsageCount: 0,
-		Pooled:     false,
+		Pooled:     pooling,
 	}
 
 	lm.metrics.updateCreated(1)
@@ -211,14 +234,16 @@ func (lm *LifecycleManager) ReturnController(instance *ControllerInstance) error
 
 	// 归还到池
 	if pool, exists := lm.getPool(controllerType); exists {
-		// 重置控制器状态
+		// 重置控制器状态（Data、ActionName等），避免泄漏到下一个请求
 		if resettable, ok := instance.Controller.(ControllerResettable); ok {
 			resettable.Reset()
 		}
 
-		pool.Put(instance.Controller)
-		lm.metrics.updateActive(-1)
-		return nil
+		if pool.Put(instance.Controller) {
+			lm.metrics.updateActive(-1)
+			return nil
+		}
+		// 超出池容量，销毁实例
 	}
 
 	// 池不存在，直接销毁
@@ -278,7 +303,7 @@ func (lm *LifecycleManager) executeHooks(hook LifecycleHook, controller interfac
 
 // getPool 获取控制器池
 func (lm *LifecycleManager) getPool(controllerType reflect.Type) (*ControllerPool, bool) {
-	if value, exists := lm.pools.Load(controllerType.Name()); exists {
+	if value, exists := lm.pools.Load(controllerType); exists {
 		return value.(*ControllerPool), true
 	}
 	return nil, false
@@ -286,22 +311,40 @@ func (lm *LifecycleManager) getPool(controllerType reflect.Type) (*ControllerPoo
 
 // getOrCreatePool 获取或创建控制器池
 func (lm *LifecycleManager) getOrCreatePool(controllerType reflect.Type) *ControllerPool {
-	typeName := controllerType.Name()
-	
-	if value, exists := lm.pools.Load(typeName); exists {
+	if value, exists := lm.pools.Load(controllerType); exists {
 		return value.(*ControllerPool)
 	}
 
 	pool := NewControllerPool(controllerType, lm.config.PoolSize)
 	pool.factory = NewDefaultControllerFactory(controllerType, lm)
-	
-	lm.pools.Store(typeName, pool)
-	return pool
+	pool.maxIdleTime = lm.config.MaxIdleTime
+	pool.onEvict = func(controller interface{}) {
+		// 空闲实例归还时已从活跃数中扣除，销毁时会再次扣除，因此先补回
+		lm.metrics.updateActive(1)
+		if err := lm.destroyController(controller); err != nil {
+			config.Errorf("Failed to destroy evicted controller: %v", err)
+		}
+	}
+
+	value, _ := lm.pools.LoadOrStore(controllerType, pool)
+	return value.(*ControllerPool)
+}
+
+// PoolStats 获取指定控制器类型的池统计信息
+func (lm *LifecycleManager) PoolStats(controllerType reflect.Type) map[string]interface{} {
+	if pool, exists := lm.getPool(controllerType); exists {
+		return pool.Stats()
+	}
+	return map[string]interface{}{}
 }
 
 // startCleanupRoutine 启动清理协程
 func (lm *LifecycleManager) startCleanupRoutine() {
-	ticker := time.NewTicker(5 * time.Minute) // 每5分钟清理一次
+	interval := 5 * time.Minute // 默认每5分钟清理一次
+	if lm.config != nil && lm.config.MaxIdleTime > 0 && lm.config.MaxIdleTime < interval {
+		interval = lm.config.MaxIdleTime
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -316,56 +359,106 @@ func (lm *LifecycleManager) startCleanupRoutine() {
 func (lm *LifecycleManager) cleanup() {
 	lm.pools.Range(func(key, value interface{}) bool {
 		pool := value.(*ControllerPool)
-		pool.cleanup(lm.config.MaxIdleTime)
+		pool.cleanup()
 		return true
 	})
 }
 
 // ControllerPool 方法实现
 
-// Get 从池中获取控制器
+// Get 从池中获取控制器，池为空时返回nil让调用方创建新实例
 func (cp *ControllerPool) Get() interface{} {
-	select {
-	case controller := <-cp.pool:
-		cp.mu.Lock()
-		cp.borrowed++
-		cp.mu.Unlock()
-		return controller
-	default:
-		// 池为空，返回nil让调用方创建新实例
-		return nil
+	for {
+		item, _ := cp.pool.Get().(*pooledController)
+		if item == nil {
+			// 池已空（包括被GC回收的实例），校正空闲计数
+			atomic.StoreInt64(&cp.idle, 0)
+			return nil
+		}
+		cp.decIdle()
+
+		if cp.expired(item) {
+			cp.evict(item.controller)
+			continue
+		}
+		atomic.AddInt64(&cp.borrowed, 1)
+		return item.controller
+	}
+}
+
+// Put 将控制器放回池中，超出软上限时返回false，由调用方销毁实例
+func (cp *ControllerPool) Put(controller interface{}) bool {
+	if cp.maxSize > 0 && atomic.LoadInt64(&cp.idle) >= int64(cp.maxSize) {
+		return false
+	}
+	atomic.AddInt64(&cp.idle, 1)
+	atomic.AddInt64(&cp.returned, 1)
+	cp.pool.Put(&pooledController{controller: controller, idleSince: time.Now()})
+	return true
+}
+
+// cleanup 清理池中空闲超时的控制器，返回清理数量
+func (cp *ControllerPool) cleanup() int {
+	if cp.maxIdleTime <= 0 {
+		return 0
+	}
+
+	var keep []*pooledController
+	evicted := 0
+	for i := atomic.LoadInt64(&cp.idle); i > 0; i-- {
+		item, _ := cp.pool.Get().(*pooledController)
+		if item == nil {
+			break
+		}
+		cp.decIdle()
+		if cp.expired(item) {
+			cp.evict(item.controller)
+			evicted++
+			continue
+		}
+		keep = append(keep, item)
+	}
+
+	// 未过期的实例放回池中，保留原空闲时间
+	for _, item := range keep {
+		atomic.AddInt64(&cp.idle, 1)
+		cp.pool.Put(item)
 	}
+	return evicted
 }
 
-// Put 将控制器放回池中
-func (cp *ControllerPool) Put(controller interface{}) {
-	select {
-	case cp.pool <- controller:
-		cp.mu.Lock()
-		cp.returned++
-		cp.mu.Unlock()
-	default:
-		// 池已满，丢弃控制器
+// expired 空闲实例是否超过最大空闲时间
+func (cp *ControllerPool) expired(item *pooledController) bool {
+	return cp.maxIdleTime > 0 && time.Since(item.idleSince) > cp.maxIdleTime
+}
+
+// evict 丢弃空闲实例
+func (cp *ControllerPool) evict(controller interface{}) {
+	atomic.AddInt64(&cp.evicted, 1)
+	if cp.onEvict != nil {
+		cp.onEvict(controller)
 	}
 }
 
-// cleanup 清理池中的过期控制器
-func (cp *ControllerPool) cleanup(maxIdleTime time.Duration) {
-	// 这里可以实现更复杂的清理逻辑
-	// 例如检查控制器的最后使用时间等
+// decIdle 空闲计数减一，不小于0
+func (cp *ControllerPool) decIdle() {
+	for {
+		idle := atomic.LoadInt64(&cp.idle)
+		if idle <= 0 || atomic.CompareAndSwapInt64(&cp.idle, idle, idle-1) {
+			return
+		}
+	}
 }
 
 // Stats 获取池统计信息
 func (cp *ControllerPool) Stats() map[string]interface{} {
-	cp.mu.RLock()
-	defer cp.mu.RUnlock()
-
 	return map[string]interface{}{
-		"pool_size":  len(cp.pool),
-		"max_size":   cp.maxSize,
-		"created":    cp.created,
-		"borrowed":   cp.borrowed,
-		"returned":   cp.returned,
+		"pool_size": atomic.LoadInt64(&cp.idle),
+		"max_size":  cp.maxSize,
+		"created":   atomic.LoadInt64(&cp.created),
+		"borrowed":  atomic.LoadInt64(&cp.borrowed),
+		"returned":  atomic.LoadInt64(&cp.returned),
+		"evicted":   atomic.LoadInt64(&cp.evicted),
 	}
 }
 
@@ -402,6 +495,18 @@ func (lm *LifecycleMetrics) updateDestroyed(delta int64) {
 	lm.mu.Unlock()
 }
 
+// updatePool 更新池命中统计
+func (lm *LifecycleMetrics) updatePool(hit bool) {
+	lm.mu.Lock()
+	if hit {
+		lm.PoolHits++
+	} else {
+		lm.PoolMisses++
+	}
+	lm.PoolHitRate = float64(lm.PoolHits) / float64(lm.PoolHits+lm.PoolMisses)
+	lm.mu.Unlock()
+}
+
 // updateActive 更新活跃数量
 func (lm *LifecycleMetrics) updateActive(delta int64) {
 	lm.mu.Lock()
@@ -419,6 +524,8 @@ func (lm *LifecycleManager) GetMetrics() *LifecycleMetrics {
 		CreatedCount:   lm.metrics.CreatedCount,
 		DestroyedCount: lm.metrics.DestroyedCount,
 		ActiveCount:    lm.metrics.ActiveCount,
+		PoolHits:       lm.metrics.PoolHits,
+		PoolMisses:     lm.metrics.PoolMisses,
 		PoolHitRate:    lm.metrics.PoolHitRate,
 		AverageLifetime: lm.metrics.AverageLifetime,
 	}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zsy619/yyhertz/framework/mvc/core"
+)
+
+// pooledTestController 池化测试控制器
+type pooledTestController struct {
+	core.BaseController
+}
+
+func TestLifecycleManager_PoolReusesInstancesWithoutLeakingState(t *testing.T) {
+	lm := NewLifecycleManager(&CompilerConfig{PoolSize: 4, MaxIdleTime: time.Minute})
+	controllerType := reflect.TypeOf((*pooledTestController)(nil)).Elem()
+
+	const workers, requests = 8, 50
+	var (
+		mu     sync.Mutex
+		seen   = make(map[*pooledTestController]bool)
+		leaked []string
+		wg     sync.WaitGroup
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < requests; i++ {
+				instance, err := lm.CreateController(controllerType, nil)
+				if err != nil {
+					t.Errorf("Failed to create controller: %v", err)
+					return
+				}
+				ctrl := instance.Controller.(*pooledTestController)
+
+				// 上一个请求写入的状态必须已被重置
+				if len(ctrl.Data) != 0 || ctrl.ActionName != "" {
+					mu.Lock()
+					leaked = append(leaked, fmt.Sprintf("%v %q", ctrl.Data, ctrl.ActionName))
+					mu.Unlock()
+				}
+
+				if ctrl.Data == nil {
+					ctrl.Data = make(map[string]any)
+				}
+				ctrl.Data["request"] = fmt.Sprintf("%d-%d", worker, i)
+				ctrl.ActionName = "GetIndex"
+
+				mu.Lock()
+				seen[ctrl] = true
+				mu.Unlock()
+
+				if err := lm.ReturnController(instance); err != nil {
+					t.Errorf("Failed to return controller: %v", err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(leaked) > 0 {
+		t.Fatalf("State leaked between requests: %v", leaked)
+	}
+
+	metrics := lm.GetMetrics()
+	total := int64(workers * requests)
+	if metrics.PoolHits+metrics.PoolMisses != total {
+		t.Errorf("Expected %d pool lookups, got %d hits + %d misses", total, metrics.PoolHits, metrics.PoolMisses)
+	}
+	if metrics.PoolHits == 0 || metrics.CreatedCount >= total || int64(len(seen)) >= total {
+		t.Errorf("Expected instances to be reused, got %d hits, %d created, %d distinct instances",
+			metrics.PoolHits, metrics.CreatedCount, len(seen))
+	}
+	if metrics.PoolHitRate <= 0 || metrics.PoolHitRate > 1 {
+		t.Errorf("Unexpected pool hit rate %v", metrics.PoolHitRate)
+	}
+	if metrics.ActiveCount != 0 {
+		t.Errorf("Expected no active instances after all requests, got %d", metrics.ActiveCount)
+	}
+}
+
+func TestControllerPool_SoftCapAndIdleEviction(t *testing.T) {
+	pool := NewControllerPool(reflect.TypeOf((*pooledTestController)(nil)).Elem(), 1)
+	pool.maxIdleTime = 20 * time.Millisecond
+	var evicted []interface{}
+	pool.onEvict = func(controller interface{}) { evicted = append(evicted, controller) }
+
+	first, second := &pooledTestController{}, &pooledTestController{}
+	if !pool.Put(first) {
+		t.Fatal("Expected first controller to be pooled")
+	}
+	if pool.Put(second) {
+		t.Error("Expected put beyond the soft cap to be rejected")
+	}
+
+	// 空闲超时的实例不再被复用
+	time.Sleep(40 * time.Millisecond)
+	if got := pool.Get(); got != nil {
+		t.Errorf("Expected idle controller to be evicted, got %v", got)
+	}
+	if len(evicted) > 1 {
+		t.Errorf("Expected at most one eviction, got %d", len(evicted))
+	}
+
+	// 清理也会丢弃空闲超时的实例
+	pool.Put(first)
+	time.Sleep(40 * time.Millisecond)
+	pool.cleanup()
+	if got := pool.Get(); got != nil {
+		t.Errorf("Expected cleanup to evict idle controller, got %v", got)
+	}
+}
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+// ErrRouteNotFound 没有与请求路径匹配的路由
+var ErrRouteNotFound = errors.New("route not found")
+
+// RouteTarget 路由目标（控制器与方法）
+type RouteTarget struct {
+	Controller string // 控制器名称
+	Method     string // 方法名称
+	Pattern    string // 注册的路由模式
+}
+
+// RouteMatch 路由匹配结果
+type RouteMatch struct {
+	Target RouteTarget
+	Params context.Params
+}
+
+// RouteMatcher 基于前缀树的路由匹配器
+// 路由模式支持静态段、参数段（{id} 或 :id）以及位于末尾的通配段（*path 或 {path...}）；
+// 同一位置静态段优先于参数段，参数段优先于通配段，匹配失败时回溯尝试其他分支
+type RouteMatcher struct {
+	root *routeNode
+	mu   sync.RWMutex
+}
+
+// routeNode 前缀树节点，每个节点对应一个路径段
+type routeNode struct {
+	static    map[string]*routeNode  // 静态子节点
+	param     *routeNode             // 参数子节点
+	paramName string                 // 参数名称
+	catchAll  *routeNode             // 通配子节点
+	catchName string                 // 通配参数名称
+	targets   map[string]RouteTarget // HTTP方法 -> 路由目标（ANY表示任意方法）
+}
+
+// NewRouteMatcher 创建路由匹配器
+func NewRouteMatcher() *RouteMatcher {
+	return &RouteMatcher{root: newRouteNode()}
+}
+
+// newRouteNode 创建空节点
+func newRouteNode() *routeNode {
+	return &routeNode{static: make(map[string]*routeNode)}
+}
+
+// ParseRouteMapping 解析 "GET:/users/{id}" 形式的方法映射，未指定HTTP方法时为ANY
+func ParseRouteMapping(spec string) (method, pattern string, ok bool) {
+	if strings.HasPrefix(spec, "/") {
+		return "ANY", spec, true
+	}
+	method, pattern, found := strings.Cut(spec, ":")
+	if !found || !strings.HasPrefix(pattern, "/") {
+		return "", "", false
+	}
+	method = strings.ToUpper(method)
+	if method == "*" {
+		method = "ANY"
+	}
+	return method, pattern, true
+}
+
+// Add 添加路由，同一方法下模式等价的路由（如 /users/{id} 与 /users/{uid}）视为冲突
+func (m *RouteMatcher) Add(method, pattern string, target RouteTarget) error {
+	method = strings.ToUpper(method)
+	target.Pattern = pattern
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node := m.root
+	segments := splitRoutePath(pattern)
+	for i, segment := range segments {
+		switch {
+		case isCatchAllSegment(segment):
+			if i != len(segments)-1 {
+				return fmt.Errorf("route %s: wildcard must be the last segment", pattern)
+			}
+			name := catchAllName(segment)
+			if node.catchAll == nil {
+				node.catchAll = newRouteNode()
+				node.catchName = name
+			} else if node.catchName != name {
+				return fmt.Errorf("route %s: wildcard *%s conflicts with *%s", pattern, name, node.catchName)
+			}
+			node = node.catchAll
+		case isParamSegment(segment):
+			name := paramName(segment)
+			if node.param == nil {
+				node.param = newRouteNode()
+				node.paramName = name
+			} else if node.paramName != name {
+				// 参数名不同不影响匹配，按节点上的参数名提取会导致取值错误，因此视为冲突
+				return fmt.Errorf("route %s: parameter {%s} conflicts with {%s}", pattern, name, node.paramName)
+			}
+			node = node.param
+		default:
+			child, ok := node.static[segment]
+			if !ok {
+				child = newRouteNode()
+				node.static[segment] = child
+			}
+			node = child
+		}
+	}
+
+	if node.targets == nil {
+		node.targets = make(map[string]RouteTarget)
+	}
+	if existing, ok := node.targets[method]; ok {
+		return fmt.Errorf("route %s %s: already registered by %s.%s", method, pattern, existing.Controller, existing.Method)
+	}
+	node.targets[method] = target
+	return nil
+}
+
+// Match 匹配请求路径，返回路由目标与提取的路径参数
+func (m *RouteMatcher) Match(method, path string) (*RouteMatch, bool) {
+	method = strings.ToUpper(method)
+	segments := splitRoutePath(path)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var params context.Params
+	target, ok := m.root.match(method, segments, &params)
+	if !ok {
+		return nil, false
+	}
+	return &RouteMatch{Target: target, Params: params}, true
+}
+
+// Routes 获取所有已注册的路由（"METHOD pattern"，已排序）
+func (m *RouteMatcher) Routes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var routes []string
+	m.root.walk(func(method string, target RouteTarget) {
+		routes = append(routes, method+" "+target.Pattern)
+	})
+	sort.Strings(routes)
+	return routes
+}
+
+// match 递归匹配，依次尝试静态、参数与通配分支
+func (n *routeNode) match(method string, segments []string, params *context.Params) (RouteTarget, bool) {
+	if len(segments) == 0 {
+		if target, ok := n.target(method); ok {
+			return target, true
+		}
+		// 通配段可以匹配空路径（/files/ 匹配 /files/*path）
+		if n.catchAll != nil {
+			if target, ok := n.catchAll.target(method); ok {
+				*params = append(*params, context.Param{Key: n.catchName, Value: ""})
+				return target, true
+			}
+		}
+		return RouteTarget{}, false
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if child, ok := n.static[segment]; ok {
+		if target, ok := child.match(method, rest, params); ok {
+			return target, true
+		}
+	}
+
+	if n.param != nil {
+		mark := len(*params)
+		*params = append(*params, context.Param{Key: n.paramName, Value: segment})
+		if target, ok := n.param.match(method, rest, params); ok {
+			return target, true
+		}
+		*params = (*params)[:mark]
+	}
+
+	if n.catchAll != nil {
+		if target, ok := n.catchAll.target(method); ok {
+			*params = append(*params, context.Param{Key: n.catchName, Value: strings.Join(segments, "/")})
+			return target, true
+		}
+	}
+	return RouteTarget{}, false
+}
+
+// target 获取节点上与方法匹配的路由目标，精确方法优先于ANY
+func (n *routeNode) target(method string) (RouteTarget, bool) {
+	if n.targets == nil {
+		return RouteTarget{}, false
+	}
+	if target, ok := n.targets[method]; ok {
+		return target, true
+	}
+	target, ok := n.targets["ANY"]
+	return target, ok
+}
+
+// walk 遍历所有路由目标
+func (n *routeNode) walk(fn func(method string, target RouteTarget)) {
+	for method, target := range n.targets {
+		fn(method, target)
+	}
+	for _, child := range n.static {
+		child.walk(fn)
+	}
+	if n.param != nil {
+		n.param.walk(fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walk(fn)
+	}
+}
+
+// splitRoutePath 拆分路径段，忽略首尾及重复的斜杠
+func splitRoutePath(path string) []string {
+	parts := strings.Split(path, "/")
+	segments := parts[:0]
+	for _, part := range parts {
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+// isParamSegment 是否为参数段：{id} 或 :id
+func isParamSegment(segment string) bool {
+	return strings.HasPrefix(segment, ":") ||
+		(strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"))
+}
+
+// paramName 获取参数段的名称
+func paramName(segment string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(segment, ":"), "{"), "}")
+}
+
+// isCatchAllSegment 是否为通配段：*path 或 {path...}
+func isCatchAllSegment(segment string) bool {
+	return strings.HasPrefix(segment, "*") || strings.HasSuffix(segment, "...}")
+}
+
+// catchAllName 获取通配段的名称
+func catchAllName(segment string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(segment, "*"), "{"), "...}")
+	if name == "" {
+		return "path"
+	}
+	return name
+}
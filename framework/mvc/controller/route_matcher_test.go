@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	mvcContext "github.com/zsy619/yyhertz/framework/mvc/context"
+	"github.com/zsy619/yyhertz/framework/mvc/core"
+)
+
+// newTestRouteMatcher 创建包含静态、参数与通配路由的匹配器
+func newTestRouteMatcher(t testing.TB) *RouteMatcher {
+	matcher := NewRouteMatcher()
+	routes := []struct{ spec, method string }{
+		{"GET:/users", "GetIndex"},
+		{"GET:/users/new", "GetNew"},
+		{"GET:/users/{id}", "GetShow"},
+		{"PUT:/users/{id}", "PutUpdate"},
+		{"GET:/users/{id}/orders/:orderId", "GetOrder"},
+		{"GET:/files/*path", "GetFile"},
+		{"GET:/files/readme", "GetReadme"},
+		{"/health", "Health"},
+	}
+	for _, route := range routes {
+		method, pattern, ok := ParseRouteMapping(route.spec)
+		if !ok {
+			t.Fatalf("Failed to parse route mapping %s", route.spec)
+		}
+		if err := matcher.Add(method, pattern, RouteTarget{Controller: "UserController", Method: route.method}); err != nil {
+			t.Fatalf("Failed to add route %s: %v", route.spec, err)
+		}
+	}
+	return matcher
+}
+
+func TestRouteMatcher_Match(t *testing.T) {
+	matcher := newTestRouteMatcher(t)
+
+	tests := []struct {
+		name, method, path string
+		want               string
+		params             map[string]string
+	}{
+		{"static", "GET", "/users", "GetIndex", nil},
+		{"trailing slash", "GET", "/users/", "GetIndex", nil},
+		{"static wins over param", "GET", "/users/new", "GetNew", nil},
+		{"param", "GET", "/users/42", "GetShow", map[string]string{"id": "42"}},
+		{"method specific", "PUT", "/users/42", "PutUpdate", map[string]string{"id": "42"}},
+		{"nested params", "GET", "/users/42/orders/7", "GetOrder", map[string]string{"id": "42", "orderId": "7"}},
+		{"wildcard", "GET", "/files/docs/guide.md", "GetFile", map[string]string{"path": "docs/guide.md"}},
+		{"static wins over wildcard", "GET", "/files/readme", "GetReadme", nil},
+		{"any method", "POST", "/health", "Health", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := matcher.Match(tt.method, tt.path)
+			if !ok {
+				t.Fatalf("Expected %s %s to match", tt.method, tt.path)
+			}
+			if match.Target.Method != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, match.Target.Method)
+			}
+			for key, value := range tt.params {
+				if got := match.Params.ByName(key); got != value {
+					t.Errorf("Expected param %s=%s, got %q", key, value, got)
+				}
+			}
+			if len(match.Params) != len(tt.params) {
+				t.Errorf("Expected %d params, got %v", len(tt.params), match.Params)
+			}
+		})
+	}
+
+	for _, miss := range [][2]string{{"DELETE", "/users/42"}, {"GET", "/users/42/profile"}, {"GET", "/missing"}} {
+		if _, ok := matcher.Match(miss[0], miss[1]); ok {
+			t.Errorf("Expected %s %s not to match", miss[0], miss[1])
+		}
+	}
+}
+
+func TestRouteMatcher_AmbiguousRoutes(t *testing.T) {
+	matcher := NewRouteMatcher()
+	_ = matcher.Add("GET", "/users/{id}", RouteTarget{Controller: "UserController", Method: "GetShow"})
+	_ = matcher.Add("GET", "/users/{id}/posts", RouteTarget{Controller: "UserController", Method: "GetPosts"})
+	_ = matcher.Add("GET", "/users/me/settings", RouteTarget{Controller: "UserController", Method: "GetSettings"})
+
+	// 相同模式重复注册
+	if err := matcher.Add("GET", "/users/{id}", RouteTarget{Controller: "AdminController", Method: "GetShow"}); err == nil {
+		t.Error("Expected duplicate route to be rejected")
+	}
+	// 参数名不同的等价模式
+	if err := matcher.Add("GET", "/users/{uid}/posts", RouteTarget{Controller: "PostController", Method: "GetList"}); err == nil {
+		t.Error("Expected equivalent route with different parameter name to be rejected")
+	}
+	// 通配段必须位于末尾
+	if err := matcher.Add("GET", "/static/*path/raw", RouteTarget{}); err == nil {
+		t.Error("Expected wildcard in the middle to be rejected")
+	}
+
+	// 静态分支匹配失败时回溯到参数分支
+	match, ok := matcher.Match("GET", "/users/me/posts")
+	if !ok || match.Target.Method != "GetPosts" || match.Params.ByName("id") != "me" {
+		t.Errorf("Expected backtracking to GetPosts with id=me, got %+v", match)
+	}
+	match, ok = matcher.Match("GET", "/users/me/settings")
+	if !ok || match.Target.Method != "GetSettings" || len(match.Params) != 0 {
+		t.Errorf("Expected static GetSettings without params, got %+v", match)
+	}
+}
+
+// routedController 带方法映射的测试控制器
+type routedController struct {
+	core.BaseController
+}
+
+// routedCalls 记录被分发的方法
+var routedCalls []string
+
+func (rc *routedController) GetIndex() error {
+	routedCalls = append(routedCalls, "GetIndex")
+	return nil
+}
+
+func (rc *routedController) GetShow() error {
+	routedCalls = append(routedCalls, "GetShow")
+	return nil
+}
+
+func (rc *routedController) GetMethodMapping() map[string]string {
+	return map[string]string{
+		"GetIndex": "GET:/users",
+		"GetShow":  "GET:/users/{id}",
+	}
+}
+
+func TestOptimizedControllerManager_Dispatch(t *testing.T) {
+	manager := NewOptimizedControllerManager(nil)
+	if err := manager.RegisterController(&routedController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+	routedCalls = nil
+
+	ctx := &mvcContext.Context{Keys: make(map[string]interface{})}
+	if err := manager.Dispatch(ctx, "GET", "/users/42"); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if ctx.Params.ByName("id") != "42" || ctx.FullPath != "/users/{id}" {
+		t.Errorf("Expected id=42 and full path /users/{id}, got %v %s", ctx.Params, ctx.FullPath)
+	}
+
+	if err := manager.Dispatch(&mvcContext.Context{Keys: make(map[string]interface{})}, "GET", "/users"); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if strings.Join(routedCalls, ",") != "GetShow,GetIndex" {
+		t.Errorf("Expected GetShow then GetIndex, got %v", routedCalls)
+	}
+
+	err := manager.Dispatch(&mvcContext.Context{Keys: make(map[string]interface{})}, "POST", "/users")
+	if !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf("Expected ErrRouteNotFound, got %v", err)
+	}
+}
+
+// linearRoute 线性匹配的基准实现
+type linearRoute struct {
+	method   string
+	segments []string
+	target   RouteTarget
+}
+
+// matchLinear 逐条比较所有路由
+func matchLinear(routes []linearRoute, method, path string) (RouteTarget, bool) {
+	segments := splitRoutePath(path)
+	for _, route := range routes {
+		if route.method != method || len(route.segments) != len(segments) {
+			continue
+		}
+		matched := true
+		for i, segment := range route.segments {
+			if !isParamSegment(segment) && segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route.target, true
+		}
+	}
+	return RouteTarget{}, false
+}
+
+// benchmarkRoutes 生成指定数量的资源路由
+func benchmarkRoutes(resources int) []linearRoute {
+	var routes []linearRoute
+	for i := 0; i < resources; i++ {
+		for _, pattern := range []string{"/api/res%d", "/api/res%d/{id}", "/api/res%d/{id}/items/{itemId}"} {
+			p := fmt.Sprintf(pattern, i)
+			routes = append(routes, linearRoute{method: "GET", segments: splitRoutePath(p), target: RouteTarget{Pattern: p}})
+		}
+	}
+	return routes
+}
+
+func BenchmarkRouteMatcher_Trie(b *testing.B) {
+	matcher := NewRouteMatcher()
+	for _, route := range benchmarkRoutes(100) {
+		if err := matcher.Add(route.method, "/"+strings.Join(route.segments, "/"), route.target); err != nil {
+			b.Fatalf("Failed to add route: %v", err)
+		}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, ok := matcher.Match("GET", "/api/res99/42/items/7"); !ok {
+			b.Fatal("Expected route to match")
+		}
+	}
+}
+
+func BenchmarkRouteMatcher_Linear(b *testing.B) {
+	routes := benchmarkRoutes(100)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, ok := matchLinear(routes, "GET", "/api/res99/42/items/7"); !ok {
+			b.Fatal("Expected route to match")
+		}
+	}
+}
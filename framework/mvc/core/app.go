@@ -8,16 +8,23 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	hertzlogrus "github.com/hertz-contrib/logger/logrus"
 
 	"github.com/zsy619/yyhertz/framework/config"
+	"github.com/zsy619/yyhertz/framework/metrics"
+	_ "github.com/zsy619/yyhertz/framework/mvc/binding" // 注册 Context.BindAndValidate 默认结构体验证器
 	contextenhanced "github.com/zsy619/yyhertz/framework/mvc/context"
 	"github.com/zsy619/yyhertz/framework/mvc/middleware"
+	"github.com/zsy619/yyhertz/framework/render"
+	"github.com/zsy619/yyhertz/framework/response"
+	"github.com/zsy619/yyhertz/framework/scheduler"
 )
 
 var (
@@ -47,6 +54,29 @@ type App struct {
 	startTime     time.Time
 	address       string
 	loggerManager *config.LoggerManager
+	routes        *RouteRegistry // 路由注册表，用于检测重复路由
+	corsPolicies  *RouteCORSRegistry // 路由级跨域策略（命名空间/路由组）
+	envelope      atomic.Pointer[response.EnvelopeConfig] // 统一响应包装配置（SetResponseEnvelope）
+
+	caseInsensitive   bool // 路径不区分大小写
+	redirectCanonical bool // 不区分大小写时重定向到规范路径
+	autoHead          bool // 未注册HEAD路由时按GET路由响应HEAD请求
+
+	staticMu      sync.RWMutex
+	staticMounts  map[string]*staticMount // 静态文件挂载点（规范化URL前缀 -> 挂载点）
+	staticListing bool                    // 目录列表
+	spa           *spaFallback            // 单页应用回退
+	spaRegistered bool                    // 是否已注册回退处理器
+
+	shutdownMu      sync.Mutex
+	shutdownHooks   []namedShutdownHook // 关闭钩子
+	shutdownOnce    sync.Once
+	shutdownTimeout time.Duration // 优雅关闭超时时间
+
+	engineHandlers   app.HandlersChain           // Hertz引擎内置中间件
+	globalMiddleware []*middlewareEntry          // 全局中间件（按优先级排序）
+	namedMiddleware  map[string]*middlewareEntry // 命名中间件
+	middlewareSeq    int                         // 中间件登记序号
 }
 
 // GetAppInstance 获取单例应用实例
@@ -76,8 +106,29 @@ func NewAppWithLogConfig(logConfig *config.LogConfig) *App {
 		host = "0.0.0.0"
 	}
 
-	// 创建Hertz服务器实例
-	h := server.Default(server.WithHostPorts(host + ":" + strconv.Itoa(port)))
+	// 优雅关闭超时时间
+	shutdownTimeout := defaultShutdownTimeout
+	if value := config.GetAppConfigString("app.shutdown_timeout"); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil {
+			shutdownTimeout = timeout
+		}
+	}
+
+	// TLS配置（证书、ALPN、h2c与HSTS）
+	tlsConfig, err := config.GetTLSConfig()
+	if err != nil {
+		config.Warnf("Failed to load TLS config: %v", err)
+		tlsConfig = nil
+	}
+	tlsOptions, tlsManager := serverTLSOptions(tlsConfig)
+
+	// 创建Hertz服务器实例，路径存在但方法不匹配时返回405
+	h := server.Default(append([]hertzconfig.Option{
+		server.WithHostPorts(host + ":" + strconv.Itoa(port)),
+		server.WithExitWaitTime(shutdownTimeout),
+		server.WithHandleMethodNotAllowed(true),
+	}, tlsOptions...)...)
+	registerHTTP2(h)
 
 	// 初始化全局日志管理器
 	loggerManager := config.InitGlobalLogger(logConfig)
@@ -89,13 +140,24 @@ func NewAppWithLogConfig(logConfig *config.LogConfig) *App {
 		startTime:     time.Now(),                       // 记录应用启动时间
 		address:       fmt.Sprintf("%s:%d", host, port), // 应用监听地址
 		loggerManager: loggerManager,                    // 日志管理器
+		routes:        NewRouteRegistry(config.GetAppConfigBool("app.strict_routing")),
+		corsPolicies:  NewRouteCORSRegistry(),
+
+		caseInsensitive:   config.GetAppConfigBool("app.case_insensitive_routing"),
+		redirectCanonical: config.GetAppConfigBool("app.redirect_canonical_path"),
+		autoHead:          true,
+		shutdownTimeout:   shutdownTimeout,
+		staticMounts:      make(map[string]*staticMount),
+
+		engineHandlers:  append(app.HandlersChain(nil), h.Handlers...),
+		namedMiddleware: make(map[string]*middlewareEntry),
 	}
 
 	// 配置视图路径
 	app.SetViewPath("./views")
 	// 注册默认静态路径
 	for urlPath, _ := range app.StaticPaths {
-		app.Static(urlPath, ".")
+		app.serveStatic(urlPath)
 	}
 
 	// 配置增强的日志中间件
@@ -104,16 +166,37 @@ func NewAppWithLogConfig(logConfig *config.LogConfig) *App {
 		EnableResponseBody: false,
 		SkipPaths:          []string{"/health", "/ping"},
 		MaxBodySize:        512,
+		SlowOnly:           config.GetAppConfigBool("app.log_slow_requests_only"),
+	}
+	if value := config.GetAppConfigString("app.slow_request_threshold"); value != "" {
+		if threshold, err := time.ParseDuration(value); err == nil {
+			loggerConfig.SlowThreshold = threshold
+		}
+	}
+
+	// 添加基础全局中间件（路径大小写策略与HEAD请求处理需位于首位）
+	app.UseWithPriority("routing.case", MiddlewarePriorityRouting, app.caseInsensitiveRoutingMiddleware()).
+		UseWithPriority("routing.head", MiddlewarePriorityRouting, app.autoHeadMiddleware()).
+		UseWithPriority("response.envelope", MiddlewarePriorityRouting, app.envelopeMiddleware()).
+		UseWithPriority("recovery", MiddlewarePriorityRecovery, middleware.RecoveryMiddleware()).
+		UseWithPriority("tracing", MiddlewarePriorityTracing, middleware.TracingMiddleware()).
+		UseWithPriority("logger", MiddlewarePriorityLogger, middleware.LoggerMiddlewareWithConfig(loggerConfig)).
+		UseWithPriority("cors", MiddlewarePriorityCORS, app.corsMiddleware()).
+		UseWithPriority("ratelimit", MiddlewarePriorityRateLimit, middleware.RateLimitMiddleware(100, time.Minute))
+
+	// 启用TLS时为HTTPS响应附带HSTS头，关闭时停止证书监视器
+	if tlsManager != nil {
+		if tlsConfig.HSTS.Enable {
+			app.UseWithPriority("hsts", MiddlewarePrioritySecurity, middleware.HSTSMiddleware(tlsConfig))
+		}
+		app.ManageTLS(tlsManager)
 	}
 
-	// 添加基础全局中间件
-	app.Use(
-		middleware.RecoveryMiddleware(),
-		middleware.TracingMiddleware(),
-		middleware.LoggerMiddlewareWithConfig(loggerConfig),
-		middleware.CORSMiddleware(),
-		middleware.RateLimitMiddleware(100, time.Minute),
-	)
+	// 关闭时停止全局任务调度器（未启动时不执行任何操作）
+	app.ManageScheduler(scheduler.GetGlobalScheduler())
+
+	// 405响应附带Allow头
+	app.Hertz.NoMethod(app.methodNotAllowedHandler())
 
 	// 设置基础路由
 	app.setupBasicRoutes()
@@ -124,7 +207,7 @@ func NewAppWithLogConfig(logConfig *config.LogConfig) *App {
 // setupBasicRoutes 设置基础路由
 func (app *App) setupBasicRoutes() {
 	// 健康检查路由
-	app.GET("/health", func(c context.Context, ctx *RequestContext) {
+	app.registerRoute("GET", "/health", "builtin.health", func(c context.Context, ctx *RequestContext) {
 		ctx.JSON(consts.StatusOK, map[string]string{
 			"status":    "ok",
 			"timestamp": time.Now().Format(time.RFC3339),
@@ -132,7 +215,7 @@ func (app *App) setupBasicRoutes() {
 	})
 
 	// ping路由
-	app.GET("/ping", func(c context.Context, ctx *RequestContext) {
+	app.registerRoute("GET", "/ping", "builtin.ping", func(c context.Context, ctx *RequestContext) {
 		ctx.JSON(consts.StatusOK, map[string]string{"message": "pong"})
 	})
 }
@@ -161,18 +244,18 @@ func (app *App) SetStaticPath(path string) {
 	// 只有当路径不存在或者发生变化时才注册
 	if existing, exists := app.StaticPaths[urlPath]; !exists || existing != path {
 		app.StaticPaths[urlPath] = path
-		// Hertz的Static方法需要相对路径，urlPath为"/static"，path为"./static"时
-		// 会导致路径变成"/static/static"，所以我们传递"."让它映射到当前目录下的路径
-		app.Static(urlPath, ".")
+		// 静态文件以当前目录为根，urlPath为"/static"时映射到"./static"，避免路径变成"/static/static"
+		app.serveStatic(urlPath)
 	}
 }
 
-// SetStaticPaths 设置多个静态文件路径映射
+// SetStaticPaths 设置多个静态文件路径映射，替换已有映射（未包含的URL路径返回404）
 func (app *App) SetStaticPaths(pathMap map[string]string) {
+	app.removeStaticMounts(pathMap)
 	app.StaticPaths = make(map[string]string)
 	for urlPath, localPath := range pathMap {
 		app.StaticPaths[urlPath] = localPath
-		app.Static(urlPath, ".")
+		app.serveStatic(urlPath)
 	}
 }
 
@@ -182,7 +265,7 @@ func (app *App) AddStaticPath(urlPath, localPath string) {
 		app.StaticPaths = make(map[string]string)
 	}
 	app.StaticPaths[urlPath] = localPath
-	app.Static(urlPath, ".")
+	app.serveStatic(urlPath)
 }
 
 // GetStaticPath 获取默认静态文件路径（向后兼容）
@@ -202,19 +285,14 @@ func (app *App) GetStaticPaths() map[string]string {
 	return app.StaticPaths
 }
 
-// Use 添加中间件
-func (app *App) Use(middleware ...HandlerFunc) {
-	for _, m := range middleware {
-		app.Hertz.Use(m)
-	}
-}
-
-// Run 启动服务器
+// Run 启动服务器，收到SIGINT/SIGTERM后优雅关闭（见Shutdown）
 func (app *App) Run(addr ...string) {
 	if len(addr) > 0 {
 		app.address = addr[0]
 	}
-	app.Hertz.Spin()
+	// 输出脱敏后的有效配置（由 app.config_audit 控制）
+	config.AuditConfigs()
+	app.waitForShutdown()
 }
 
 // ============= 日志方法 =============
@@ -297,6 +375,14 @@ func (app *App) AutoRoutersPrefix(prefix string, ctrls ...IController) *App {
 	return app
 }
 
+// AutoRoutersPrefixWithMiddleware 自动注册控制器路由，并在每个路由处理函数之前附加中间件
+func (app *App) AutoRoutersPrefixWithMiddleware(prefix string, middlewares []HandlerFunc, ctrls ...IController) *App {
+	for _, ctrl := range ctrls {
+		app.registerAutoRoutes(prefix, ctrl, middlewares...)
+	}
+	return app
+}
+
 // AutoRouter 自动注册单个控制器
 func (app *App) AutoRouter(ctrl IController) *App {
 	return app.AutoRouterPrefix("", ctrl)
@@ -304,7 +390,7 @@ func (app *App) AutoRouter(ctrl IController) *App {
 
 // 注册单个控制器（无routes时自动注册，有routes时手动注册）
 func (app *App) AutoRouterPrefix(prefix string, ctrl IController) *App {
-	app.registerManualRoutes(prefix, ctrl)
+	app.registerManualRoutes(prefix, nil, ctrl)
 	return app
 }
 
@@ -318,14 +404,23 @@ func (app *App) RouterPrefix(prefix string, ctrl IController, routes ...string)
 	if len(routes) == 0 {
 		return app
 	}
-	app.registerManualRoutes(prefix, ctrl, routes...)
+	app.registerManualRoutes(prefix, nil, ctrl, routes...)
+	return app
+}
+
+// RouterPrefixWithMiddleware 手动注册控制器路由，并在每个路由处理函数之前附加中间件
+func (app *App) RouterPrefixWithMiddleware(prefix string, middlewares []HandlerFunc, ctrl IController, routes ...string) *App {
+	if len(routes) == 0 {
+		return app
+	}
+	app.registerManualRoutes(prefix, middlewares, ctrl, routes...)
 	return app
 }
 
 // ============= 向后兼容的别名方法 =============
 
-// registerAutoRoutes 自动注册控制器路由
-func (app *App) registerAutoRoutes(basePath string, controller IController) {
+// registerAutoRoutes 自动注册控制器路由，middlewares位于控制器命名中间件之前
+func (app *App) registerAutoRoutes(basePath string, controller IController, middlewares ...HandlerFunc) {
 	// 确保控制器实例正确设置（提前初始化）
 	if method := reflect.ValueOf(controller).MethodByName("SetControllerInstance"); method.IsValid() {
 		method.Call([]reflect.Value{reflect.ValueOf(controller)})
@@ -410,13 +505,13 @@ func (app *App) registerAutoRoutes(basePath string, controller IController) {
 		// 创建处理函数
 		handler := app.createControllerHandler(controller, method)
 
-		// 注册路由
-		app.registerRoute(httpMethod, routePath, handler)
+		// 注册路由（控制器与方法的命名中间件位于处理函数之前）
+		app.registerControllerRoute(httpMethod, routePath, controllerName+"."+methodName, controller, methodName, middlewares, handler)
 	}
 }
 
-// registerManualRoutes 手动注册路由
-func (app *App) registerManualRoutes(basePath string, controller IController, routes ...string) {
+// registerManualRoutes 手动注册路由，middlewares位于控制器命名中间件之前
+func (app *App) registerManualRoutes(basePath string, middlewares []HandlerFunc, controller IController, routes ...string) {
 	t := reflect.TypeOf(controller)                       // 返回 *controllers.UserController
 	controllerName := strings.TrimPrefix(t.String(), "*") // 得到 "controllers.UserController"
 	controllerName = strings.TrimSuffix(controllerName, "Controller")
@@ -459,8 +554,8 @@ func (app *App) registerManualRoutes(basePath string, controller IController, ro
 		// 创建处理函数
 		handler := app.createMethodHandler(controller, methodName)
 
-		// 注册路由
-		app.registerRoute(httpMethod, routePath, handler)
+		// 注册路由（控制器与方法的命名中间件位于处理函数之前）
+		app.registerControllerRoute(httpMethod, routePath, controllerName+"."+methodName, controller, methodName, middlewares, handler)
 	}
 }
 
@@ -483,6 +578,11 @@ func (app *App) getControllerName(controller IController) string {
 // createControllerHandler 创建控制器处理函数
 func (app *App) createControllerHandler(controller IController, method reflect.Method) HandlerFunc {
 	return func(ctx context.Context, c *RequestContext) {
+		startTime := time.Now()
+		defer func() {
+			metrics.RecordRequest(metrics.RequestSourceDispatcher, time.Since(startTime))
+		}()
+
 		// 确保控制器实例正确设置（关键修复）
 		if method := reflect.ValueOf(controller).MethodByName("SetControllerInstance"); method.IsValid() {
 			method.Call([]reflect.Value{reflect.ValueOf(controller)})
@@ -525,6 +625,11 @@ func (app *App) createControllerHandler(controller IController, method reflect.M
 // createMethodHandler 创建方法处理函数
 func (app *App) createMethodHandler(controller IController, methodName string) HandlerFunc {
 	return func(ctx context.Context, c *RequestContext) {
+		startTime := time.Now()
+		defer func() {
+			metrics.RecordRequest(metrics.RequestSourceDispatcher, time.Since(startTime))
+		}()
+
 		// 初始化控制器
 		enhancedCtx := contextenhanced.NewContext(c)
 		controllerName := app.getControllerName(controller)
@@ -588,25 +693,79 @@ func (app *App) setControllerContext(controller IController, ctx *RequestContext
 }
 
 // registerRoute 注册路由到应用
-func (app *App) registerRoute(method, path string, handler HandlerFunc) {
-	switch strings.ToUpper(method) {
-	case "GET":
-		app.GET(path, handler)
-	case "POST":
-		app.POST(path, handler)
-	case "PUT":
-		app.PUT(path, handler)
-	case "DELETE":
-		app.DELETE(path, handler)
-	case "PATCH":
-		app.PATCH(path, handler)
-	case "HEAD":
-		app.HEAD(path, handler)
-	case "OPTIONS":
-		app.OPTIONS(path, handler)
-	default:
-		app.Any(path, handler)
+// 严格路由模式下重复注册将panic，使启动失败；否则记录警告并保留先注册的路由
+func (app *App) registerRoute(method, path, source string, handlers ...HandlerFunc) {
+	app.registerRouteWithMiddleware(method, path, source, app.routeMiddlewareNames(handlers), handlers...)
+}
+
+// registerRouteWithMiddleware 注册路由并记录中间件链名称（用于路由列表）
+func (app *App) registerRouteWithMiddleware(method, path, source string, middleware []string, handlers ...HandlerFunc) {
+	methods, err := app.ReserveRoute(method, path, source)
+	if err != nil {
+		panic(err)
+	}
+	if len(methods) == 0 {
+		return
+	}
+
+	chain := toHandlersChain(handlers)
+	for _, m := range methods {
+		app.Handle(m, path, chain...)
+		app.routes.SetMiddleware(m, path, middleware)
 	}
 
 	app.LogInfof("Route registered: %s %s", method, path)
 }
+
+// ReserveRoute 在路由注册表中登记路由，返回可注册到引擎的HTTP方法
+// 严格路由模式下冲突时返回DuplicateRouteError；否则记录警告并跳过冲突的方法
+func (app *App) ReserveRoute(method, path, source string) ([]string, error) {
+	methods, err := app.routes.Register(method, path, source)
+	if err != nil {
+		if app.routes.IsStrict() {
+			return nil, err
+		}
+		app.LogWarnf("Duplicate route ignored: %v", err)
+	}
+	return methods, nil
+}
+
+// SetStrictRouting 设置严格路由模式（重复注册路由时启动失败）
+func (app *App) SetStrictRouting(strict bool) *App {
+	app.routes.SetStrict(strict)
+	return app
+}
+
+// SetSecureJSONPrefix 设置SecureJSON响应的默认防劫持前缀（默认 while(1);）
+func (app *App) SetSecureJSONPrefix(prefix string) *App {
+	render.SetSecureJSONPrefix(prefix)
+	return app
+}
+
+// SetResponseEnvelope 设置本应用 Context.Success/Fail 使用的统一响应包装（字段名、成功码等）
+// 配置保存在应用上，经请求上下文传递，不影响同一进程中的其他应用
+func (app *App) SetResponseEnvelope(cfg response.EnvelopeConfig) *App {
+	cfg = response.NormalizeEnvelopeConfig(cfg)
+	app.envelope.Store(&cfg)
+	return app
+}
+
+// envelopeMiddleware 将应用的响应包装配置写入请求上下文，未设置时不写入（使用默认配置）
+func (app *App) envelopeMiddleware() HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		if cfg := app.envelope.Load(); cfg != nil {
+			ctx.Set(response.EnvelopeContextKey, *cfg)
+		}
+		ctx.Next(c)
+	}
+}
+
+// GetRouteRegistry 获取路由注册表
+func (app *App) GetRouteRegistry() *RouteRegistry {
+	return app.routes
+}
+
+// GetCORSRegistry 获取路由级跨域策略注册表
+func (app *App) GetCORSRegistry() *RouteCORSRegistry {
+	return app.corsPolicies
+}
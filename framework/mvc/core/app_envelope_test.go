@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contextenhanced "github.com/zsy619/yyhertz/framework/mvc/context"
+	"github.com/zsy619/yyhertz/framework/response"
+)
+
+// envelopeBody 请求并解析统一响应包装
+func envelopeBody(t *testing.T, app *App, path string) map[string]any {
+	resp := ut.PerformRequest(app.Engine, "GET", path, nil).Result()
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(resp.Body(), &body))
+	return body
+}
+
+func TestApp_SetResponseEnvelopePerApp(t *testing.T) {
+	success := func(c context.Context, ctx *RequestContext) {
+		contextenhanced.NewContext(ctx).Success("ok")
+	}
+
+	custom := NewApp()
+	custom.SetResponseEnvelope(response.EnvelopeConfig{CodeField: "errno", MessageField: "msg", DataField: "result"})
+	custom.GET("/envelope", success)
+
+	// 另一个应用未设置，使用默认字段名，且不受前者影响
+	other := NewApp()
+	other.GET("/envelope", success)
+
+	assert.Equal(t, map[string]any{"errno": float64(0), "msg": "success", "result": "ok"}, envelopeBody(t, custom, "/envelope"))
+	assert.Equal(t, map[string]any{"code": float64(0), "message": "success", "data": "ok"}, envelopeBody(t, other, "/envelope"))
+}
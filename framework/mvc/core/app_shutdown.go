@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/zsy619/yyhertz/framework/config"
+	"github.com/zsy619/yyhertz/framework/scheduler"
+)
+
+// defaultShutdownTimeout 默认优雅关闭超时时间
+const defaultShutdownTimeout = 10 * time.Second
+
+// ShutdownHook 关闭钩子，在服务器停止接收请求并处理完进行中的请求后执行
+type ShutdownHook func(ctx context.Context) error
+
+// namedShutdownHook 带名称的关闭钩子
+type namedShutdownHook struct {
+	name string
+	hook ShutdownHook
+}
+
+// OnShutdown 注册关闭钩子，钩子按注册的逆序执行
+func (app *App) OnShutdown(name string, hook ShutdownHook) *App {
+	app.shutdownMu.Lock()
+	defer app.shutdownMu.Unlock()
+	app.shutdownHooks = append(app.shutdownHooks, namedShutdownHook{name: name, hook: hook})
+	return app
+}
+
+// ManageTLS 关闭时停止TLS管理器的证书监视器
+func (app *App) ManageTLS(manager *config.TLSManager) *App {
+	return app.OnShutdown("tls.cert_watcher", func(ctx context.Context) error {
+		manager.Stop()
+		return nil
+	})
+}
+
+// ManageScheduler 关闭时优雅停止任务调度器，等待正在执行的任务完成（最长到ctx截止）
+func (app *App) ManageScheduler(s *scheduler.Scheduler) *App {
+	return app.OnShutdown("scheduler", func(ctx context.Context) error {
+		return s.Shutdown(ctx)
+	})
+}
+
+// SetShutdownTimeout 设置优雅关闭超时时间（等待进行中请求的最长时间）
+func (app *App) SetShutdownTimeout(timeout time.Duration) *App {
+	app.shutdownTimeout = timeout
+	app.Hertz.GetOptions().ExitWaitTimeout = timeout
+	return app
+}
+
+// Shutdown 优雅关闭应用
+// 停止接收新连接并等待进行中的请求处理完成（最长到ctx截止或关闭超时），随后依次执行关闭钩子；
+// 多次调用只执行一次
+func (app *App) Shutdown(ctx context.Context) error {
+	var err error
+	app.shutdownOnce.Do(func() {
+		app.LogInfo("Shutting down server, draining in-flight requests")
+		if shutdownErr := app.Hertz.Shutdown(ctx); shutdownErr != nil {
+			err = fmt.Errorf("server shutdown: %w", shutdownErr)
+		}
+		err = errors.Join(err, app.runShutdownHooks(ctx))
+		app.LogInfo("Server shutdown complete")
+	})
+	return err
+}
+
+// runShutdownHooks 按注册的逆序执行关闭钩子
+func (app *App) runShutdownHooks(ctx context.Context) error {
+	app.shutdownMu.Lock()
+	hooks := append([]namedShutdownHook(nil), app.shutdownHooks...)
+	app.shutdownMu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].hook(ctx); err != nil {
+			app.LogErrorf("Shutdown hook %s failed: %v", hooks[i].name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// waitForShutdown 启动服务器并阻塞，收到SIGINT/SIGTERM后优雅关闭
+func (app *App) waitForShutdown() {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.Hertz.Run()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case sig := <-signals:
+		app.LogInfof("Received signal %s", sig)
+	case err := <-errCh:
+		// 服务器异常退出时仍需释放其他资源
+		if err != nil {
+			app.LogErrorf("Server stopped: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
+		defer cancel()
+		app.shutdownOnce.Do(func() {
+			if err := app.runShutdownHooks(ctx); err != nil {
+				app.LogErrorf("Shutdown error: %v", err)
+			}
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
+	defer cancel()
+	if err := app.Shutdown(ctx); err != nil {
+		app.LogErrorf("Shutdown error: %v", err)
+	}
+}
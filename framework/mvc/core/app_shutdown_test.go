@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// newListeningTestApp 创建监听本地空闲端口的应用，返回应用与监听地址
+func newListeningTestApp(t *testing.T) (*App, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	host, oldPort := config.GetAppConfigString("app.host"), config.GetAppConfigInt("app.port")
+	config.SetConfigValue(config.AppConfig{}, "app.host", "127.0.0.1")
+	config.SetConfigValue(config.AppConfig{}, "app.port", port)
+	t.Cleanup(func() {
+		config.SetConfigValue(config.AppConfig{}, "app.host", host)
+		config.SetConfigValue(config.AppConfig{}, "app.port", oldPort)
+	})
+
+	return NewApp(), "127.0.0.1:" + strconv.Itoa(port)
+}
+
+func TestApp_ShutdownDrainsInFlightRequests(t *testing.T) {
+	app, addr := newListeningTestApp(t)
+	app.SetShutdownTimeout(5 * time.Second)
+
+	started := make(chan struct{})
+	app.registerRoute("GET", "/slow", "test.slow", func(c context.Context, ctx *RequestContext) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		ctx.String(200, "done")
+	})
+
+	var hooks []string
+	app.OnShutdown("first", func(ctx context.Context) error {
+		hooks = append(hooks, "first")
+		return nil
+	})
+	app.OnShutdown("second", func(ctx context.Context) error {
+		hooks = append(hooks, "second")
+		return errors.New("stop failed")
+	})
+
+	go app.Hertz.Run()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond, "server did not start")
+
+	// 发起慢请求
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	slowDone := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			slowDone <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slowDone <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	<-started
+
+	// 慢请求处理期间开始关闭
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- app.Shutdown(context.Background())
+	}()
+
+	// 关闭开始后拒绝新的连接
+	require.Eventually(t, func() bool {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	}, 2*time.Second, 10*time.Millisecond, "new connections should be refused during shutdown")
+
+	// 进行中的请求正常完成
+	res := <-slowDone
+	require.NoError(t, res.err)
+	assert.Equal(t, 200, res.status)
+	assert.Equal(t, "done", res.body)
+
+	err := <-shutdownDone
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "second: stop failed")
+	assert.Equal(t, []string{"second", "first"}, hooks, "hooks should run in reverse order after draining")
+
+	// 重复调用不会再次执行
+	assert.NoError(t, app.Shutdown(context.Background()))
+	assert.Len(t, hooks, 2)
+}
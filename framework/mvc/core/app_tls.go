@@ -0,0 +1,50 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/network/standard"
+	"github.com/cloudwego/hertz/pkg/protocol/suite"
+	"github.com/hertz-contrib/http2/factory"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// serverTLSOptions 根据TLS配置生成Hertz服务器选项
+// basic.enable 时使用标准库网络层挂载证书（netpoll不支持TLS，ALPN协议列表见 TLSServerConfig.EnabledNextProtos），
+// 启用h2时打开ALPN协商；alpn.h2c_enabled 时启用明文HTTP/2探测。HTTP/2 协议服务由 registerHTTP2 注册
+func serverTLSOptions(tlsConfig *config.TLSServerConfig) ([]hertzconfig.Option, *config.TLSManager) {
+	if tlsConfig == nil {
+		return nil, nil
+	}
+
+	var opts []hertzconfig.Option
+	if tlsConfig.ALPN.H2CEnabled {
+		opts = append(opts, server.WithH2C(true))
+	}
+	if !tlsConfig.Basic.Enable {
+		return opts, nil
+	}
+
+	manager, err := config.NewTLSManager(tlsConfig)
+	if err != nil {
+		config.Errorf("TLS disabled: %v", err)
+		return opts, nil
+	}
+	opts = append(opts, server.WithTLS(manager.GetTLSConfig()), server.WithTransport(standard.NewTransporter))
+	if slices.Contains(manager.GetTLSConfig().NextProtos, "h2") {
+		opts = append(opts, server.WithALPN(true))
+	}
+	return opts, manager
+}
+
+// registerHTTP2 在启用h2c或TLS ALPN协商h2时注册HTTP/2协议服务，
+// 否则Hertz只会在ALPN中声明h2，实际连接仍回退到HTTP/1.1
+func registerHTTP2(h *server.Hertz) {
+	options := h.GetOptions()
+	if options.H2C || (options.ALPN && options.TLS != nil) {
+		h.AddProtocol(suite.HTTP2, factory.NewServerFactory())
+	}
+}
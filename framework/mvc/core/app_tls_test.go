@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// writeTestCert 在临时目录生成自签名证书，返回证书与私钥文件路径
+func writeTestCert(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestServerTLSOptions(t *testing.T) {
+	// 默认配置：不启用TLS与h2c
+	opts, manager := serverTLSOptions(config.DefaultTLSServerConfig())
+	options := hertzconfig.NewOptions(opts)
+	assert.Nil(t, manager)
+	assert.Nil(t, options.TLS)
+	assert.False(t, options.H2C)
+	assert.False(t, options.ALPN)
+
+	// 明文HTTP/2
+	cfg := config.DefaultTLSServerConfig()
+	cfg.ALPN.H2CEnabled = true
+	opts, manager = serverTLSOptions(cfg)
+	options = hertzconfig.NewOptions(opts)
+	assert.Nil(t, manager)
+	assert.True(t, options.H2C)
+	assert.Nil(t, options.TLS)
+
+	// 证书加载失败时不启用TLS
+	cfg.Basic.Enable = true
+	cfg.Certificate.CertFile = "missing.pem"
+	opts, manager = serverTLSOptions(cfg)
+	options = hertzconfig.NewOptions(opts)
+	assert.Nil(t, manager)
+	assert.Nil(t, options.TLS)
+	assert.True(t, options.H2C)
+
+	opts, manager = serverTLSOptions(nil)
+	assert.Empty(t, opts)
+	assert.Nil(t, manager)
+}
+
+func TestServerTLSOptions_TLSWithALPN(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+	cfg := config.DefaultTLSServerConfig()
+	cfg.Basic.Enable = true
+	cfg.Certificate.CertFile = certFile
+	cfg.Certificate.KeyFile = keyFile
+
+	opts, manager := serverTLSOptions(cfg)
+	require.NotNil(t, manager)
+	options := hertzconfig.NewOptions(opts)
+	require.NotNil(t, options.TLS)
+	assert.Equal(t, []string{"h2", "http/1.1"}, options.TLS.NextProtos)
+	assert.True(t, options.ALPN)
+	assert.False(t, options.H2C)
+
+	// 仅HTTP/1.1时不进行ALPN分发
+	cfg.ALPN.H2Enabled = false
+	opts, _ = serverTLSOptions(cfg)
+	options = hertzconfig.NewOptions(opts)
+	require.NotNil(t, options.TLS)
+	assert.Equal(t, []string{"http/1.1"}, options.TLS.NextProtos)
+	assert.False(t, options.ALPN)
+}
+
+// startHTTP2TestServer 使用TLS配置生成的选项启动监听本地空闲端口的服务器，返回监听地址
+func startHTTP2TestServer(t *testing.T, cfg *config.TLSServerConfig) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	opts, _ := serverTLSOptions(cfg)
+	h := server.New(append([]hertzconfig.Option{server.WithHostPorts(addr)}, opts...)...)
+	registerHTTP2(h)
+	h.GET("/proto", func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(200, "ok")
+	})
+	go h.Spin()
+	t.Cleanup(func() { _ = h.Close() })
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond, "server did not start")
+	return addr
+}
+
+func TestServerTLSOptions_ServesHTTP2OverTLS(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+	cfg := config.DefaultTLSServerConfig()
+	cfg.Basic.Enable = true
+	cfg.Certificate.CertFile = certFile
+	cfg.Certificate.KeyFile = keyFile
+	addr := startHTTP2TestServer(t, cfg)
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get("https://" + addr + "/proto")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "HTTP/2.0", resp.Proto, "ALPN h2 should be served by the HTTP/2 server")
+	assert.Equal(t, "ok", string(body))
+}
+
+func TestServerTLSOptions_ServesH2C(t *testing.T) {
+	cfg := config.DefaultTLSServerConfig()
+	cfg.ALPN.H2CEnabled = true
+	addr := startHTTP2TestServer(t, cfg)
+
+	// 明文HTTP/2（prior knowledge）
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Get("http://" + addr + "/proto")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "HTTP/2.0", resp.Proto)
+	assert.Equal(t, "ok", string(body))
+
+	// HTTP/1.1请求仍可访问
+	resp, err = http.Get("http://" + addr + "/proto")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "HTTP/1.1", resp.Proto)
+}
@@ -9,12 +9,14 @@ var ReservedMethods = map[string]bool{
 	// ============= 优化控制方法 =============
 	"EnableOptimization": true, "DisableOptimization": true, "IsOptimizationEnabled": true,
 	"GetMiddleware": true, "SetMiddleware": true, "AddMiddleware": true,
+	"GetMethodMiddleware": true, "SetMethodMiddleware": true,
 
 	// ============= 基础响应方法 =============
 	"JSON": true, "String": true,
 	"JSONWithStatus": true, "StringWithStatus": true,
 	"JSONOK": true, "JSONError": true, "JSONSuccess": true,
 	"JSONPage": true, "JSONStatus": true,
+	"Negotiate": true, "NegotiateWithStatus": true,
 	"Redirect": true, "Error": true,
 
 	// ============= 模板渲染方法 =============
@@ -67,8 +67,9 @@ type BaseController struct {
 	// ============= 优化控制器特性 =============
 
 	// 优化功能控制
-	optimizationEnabled bool     // 是否启用优化特性
-	middlewareList      []string // 中间件列表，支持GetMiddleware()
+	optimizationEnabled bool                // 是否启用优化特性
+	middlewareList      []string            // 中间件列表，支持GetMiddleware()
+	methodMiddleware    map[string][]string // 方法级中间件列表，支持GetMethodMiddleware()
 
 	// 内部控制字段
 	initialized bool // 控制器名称是否已初始化（内部使用）
@@ -270,6 +271,23 @@ func (c *BaseController) AddMiddleware(middleware string) {
 	c.middlewareList = append(c.middlewareList, middleware)
 }
 
+// GetMethodMiddleware 获取方法级中间件（方法名 -> 中间件列表）
+func (c *BaseController) GetMethodMiddleware() map[string][]string {
+	result := make(map[string][]string, len(c.methodMiddleware))
+	for method, middlewares := range c.methodMiddleware {
+		result[method] = append([]string(nil), middlewares...)
+	}
+	return result
+}
+
+// SetMethodMiddleware 设置指定方法的中间件列表，与控制器级中间件合并后作用于该方法
+func (c *BaseController) SetMethodMiddleware(method string, middlewares []string) {
+	if c.methodMiddleware == nil {
+		c.methodMiddleware = make(map[string][]string)
+	}
+	c.methodMiddleware[method] = append([]string(nil), middlewares...)
+}
+
 // EnableOptimization 启用优化特性
 func (c *BaseController) EnableOptimization() {
 	c.optimizationEnabled = true
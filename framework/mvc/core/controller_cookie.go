@@ -1,9 +1,9 @@
 package core
 
 import (
-	"fmt"
-	"strings"
+	"net/http"
 
+	"github.com/zsy619/yyhertz/framework/config"
 	"github.com/zsy619/yyhertz/framework/mvc/cookie"
 )
 
@@ -41,50 +41,35 @@ func (c *BaseController) HasCookie(name string) bool {
 	return c.cookieHelper.Has(c.Ctx.RequestContext, name)
 }
 
-// SetSecureCookie 设置安全Cookie（Beego兼容）
+// SetSecureCookie 设置HMAC签名的防篡改Cookie（Beego兼容），others[0] 可指定过期时间（秒），默认1小时
 func (c *BaseController) SetSecureCookie(secret, name, value string, others ...any) {
-	// 简化实现，实际使用中可以集成更复杂的加密逻辑
-	options := &cookie.Options{
+	if c.Ctx == nil {
+		return
+	}
+
+	options := http.Cookie{
+		Path:     "/",
 		MaxAge:   3600, // 默认1小时
 		HttpOnly: true,
 		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
 	}
-
 	if len(others) > 0 {
 		if maxAge, ok := others[0].(int); ok {
 			options.MaxAge = maxAge
 		}
 	}
 
-	// 这里可以添加加密逻辑
-	encryptedValue := c.encryptCookieValue(secret, value)
-	c.SetCookie(name, encryptedValue, options)
+	if err := c.Ctx.SetSignedCookie(name, value, secret, options); err != nil {
+		config.Warnf("Failed to set secure cookie %s: %v", name, err)
+	}
 }
 
-// GetSecureCookie 获取安全Cookie（Beego兼容）
+// GetSecureCookie 读取并验证签名Cookie（Beego兼容），签名不匹配时返回false
 func (c *BaseController) GetSecureCookie(secret, name string) (string, bool) {
-	encryptedValue := c.GetCookie(name)
-	if encryptedValue == "" {
+	if c.Ctx == nil {
 		return "", false
 	}
-
-	// 这里可以添加解密逻辑
-	value, ok := c.decryptCookieValue(secret, encryptedValue)
-	return value, ok
-}
-
-// encryptCookieValue 加密Cookie值（简化实现）
-func (c *BaseController) encryptCookieValue(secret, value string) string {
-	// 简化实现：实际项目中应使用更安全的加密算法
-	return fmt.Sprintf("%s:%s", secret, value)
+	value, err := c.Ctx.GetSignedCookie(name, secret)
+	return value, err == nil
 }
-
-// decryptCookieValue 解密Cookie值（简化实现）
-func (c *BaseController) decryptCookieValue(secret, encryptedValue string) (string, bool) {
-	// 简化实现：实际项目中应使用对应的解密算法
-	parts := strings.SplitN(encryptedValue, ":", 2)
-	if len(parts) != 2 || parts[0] != secret {
-		return "", false
-	}
-	return parts[1], true
-}
\ No newline at end of file
@@ -52,6 +52,20 @@ func (c *BaseController) JSONStatus(status int, code int, message string, data a
 	c.JSONWithStatus(status, response)
 }
 
+// Negotiate 根据Accept请求头返回JSON、XML或YAML格式的数据
+func (c *BaseController) Negotiate(data any) {
+	c.NegotiateWithStatus(consts.StatusOK, data)
+}
+
+// NegotiateWithStatus 根据Accept请求头返回指定状态码的JSON、XML或YAML数据
+func (c *BaseController) NegotiateWithStatus(status int, data any) {
+	if c.Ctx == nil {
+		config.Error("Context is nil when trying to negotiate response")
+		return
+	}
+	c.Ctx.Negotiate(status, data)
+}
+
 // ============= 字符串响应方法 =============
 
 // String 返回字符串响应
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"math"
+	"reflect"
+	"runtime"
+	"sort"
+
+	hertzapp "github.com/cloudwego/hertz/pkg/app"
+)
+
+// 中间件优先级 (数字越小优先级越高，越先执行)
+const (
+	MiddlewarePriorityRouting    = -100 // 路由预处理（路径大小写策略）
+	MiddlewarePriorityRecovery   = 0    // 异常恢复
+	MiddlewarePriorityTracing    = 100  // 链路追踪
+	MiddlewarePriorityLogger     = 200  // 请求日志
+	MiddlewarePrioritySecurity   = 250  // 安全响应头（HSTS等）
+	MiddlewarePriorityCORS       = 300  // 跨域
+	MiddlewarePriorityRateLimit  = 400  // 限流
+	MiddlewarePriorityAuth       = 500  // 认证授权
+	MiddlewarePriorityDefault    = 1000 // 未指定优先级的中间件
+	MiddlewarePriorityValidation = 1500 // 参数校验
+)
+
+// 中间件作用范围
+const (
+	MiddlewareScopeEngine     = "engine"     // Hertz引擎内置中间件，始终位于最前
+	MiddlewareScopeGlobal     = "global"     // 全局中间件
+	MiddlewareScopeController = "controller" // 控制器通过SetMiddleware引用的命名中间件
+)
+
+// MiddlewareInfo 中间件链中的一项（用于调试）
+type MiddlewareInfo struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Scope    string `json:"scope"`
+}
+
+// middlewareEntry 已登记的中间件
+type middlewareEntry struct {
+	name     string
+	priority int
+	seq      int // 登记顺序，同优先级按登记顺序执行
+	handler  HandlerFunc
+}
+
+// middlewareNamer 提供命名中间件列表的控制器
+type middlewareNamer interface {
+	GetMiddleware() []string
+}
+
+// methodMiddlewareNamer 提供方法级命名中间件列表的控制器
+type methodMiddlewareNamer interface {
+	GetMethodMiddleware() map[string][]string
+}
+
+// Use 添加全局中间件，使用默认优先级，同优先级按调用顺序执行
+func (app *App) Use(middleware ...HandlerFunc) {
+	for _, m := range middleware {
+		app.UseWithPriority(handlerName(m), MiddlewarePriorityDefault, m)
+	}
+}
+
+// UseWithPriority 按指定优先级添加全局中间件
+// 全局中间件链在每次添加后按优先级重新排序；已注册的路由保留注册时的中间件链
+func (app *App) UseWithPriority(name string, priority int, handler HandlerFunc) *App {
+	app.middlewareSeq++
+	app.globalMiddleware = append(app.globalMiddleware, &middlewareEntry{
+		name:     name,
+		priority: priority,
+		seq:      app.middlewareSeq,
+		handler:  handler,
+	})
+	sortMiddleware(app.globalMiddleware)
+
+	chain := append(hertzapp.HandlersChain(nil), app.engineHandlers...)
+	for _, entry := range app.globalMiddleware {
+		chain = append(chain, entry.handler)
+	}
+	app.Hertz.Handlers = chain
+	// 不带参数调用以重建404/405处理链
+	app.Hertz.Use()
+	return app
+}
+
+// RegisterMiddleware 登记命名中间件，供控制器通过SetMiddleware/AddMiddleware按名称引用
+// 需在注册控制器路由之前调用；重复登记同名中间件将覆盖之前的登记
+func (app *App) RegisterMiddleware(name string, priority int, handler HandlerFunc) *App {
+	app.middlewareSeq++
+	app.namedMiddleware[name] = &middlewareEntry{
+		name:     name,
+		priority: priority,
+		seq:      app.middlewareSeq,
+		handler:  handler,
+	}
+	return app
+}
+
+// GetMiddlewareChain 获取解析后的中间件执行顺序
+// 未传入控制器时返回全局中间件链；传入控制器时追加该控制器引用的命名中间件
+func (app *App) GetMiddlewareChain(controller ...IController) []MiddlewareInfo {
+	chain := make([]MiddlewareInfo, 0, len(app.engineHandlers)+len(app.globalMiddleware))
+	for _, handler := range app.engineHandlers {
+		chain = append(chain, MiddlewareInfo{Name: handlerName(handler), Priority: math.MinInt, Scope: MiddlewareScopeEngine})
+	}
+	for _, entry := range app.globalMiddleware {
+		chain = append(chain, MiddlewareInfo{Name: entry.name, Priority: entry.priority, Scope: MiddlewareScopeGlobal})
+	}
+	for _, ctrl := range controller {
+		for _, entry := range app.resolveControllerMiddleware(ctrl, "") {
+			chain = append(chain, MiddlewareInfo{Name: entry.name, Priority: entry.priority, Scope: MiddlewareScopeController})
+		}
+	}
+	return chain
+}
+
+// registerControllerRoute 注册控制器路由，依次组合路由附加中间件、控制器与方法的命名中间件以及路由处理函数
+// 命名中间件以登记名称记录到路由列表
+func (app *App) registerControllerRoute(method, path, source string, controller IController, methodName string, middlewares []HandlerFunc, handler HandlerFunc) {
+	entries := app.resolveControllerMiddleware(controller, methodName)
+	handlers := make([]HandlerFunc, 0, len(middlewares)+len(entries)+1)
+	handlers = append(handlers, middlewares...)
+	names := app.routeMiddlewareNames(append(handlers, handler))
+	for _, entry := range entries {
+		handlers = append(handlers, entry.handler)
+		names = append(names, entry.name)
+	}
+	app.registerRouteWithMiddleware(method, path, source, names, append(handlers, handler)...)
+}
+
+// resolveControllerMiddleware 按优先级解析控制器级与指定方法引用的命名中间件，未登记的名称记录警告并跳过
+func (app *App) resolveControllerMiddleware(controller IController, methodName string) []*middlewareEntry {
+	var names []string
+	if namer, ok := controller.(middlewareNamer); ok {
+		names = append(names, namer.GetMiddleware()...)
+	}
+	if namer, ok := controller.(methodMiddlewareNamer); ok && methodName != "" {
+		names = append(names, namer.GetMethodMiddleware()[methodName]...)
+	}
+
+	var entries []*middlewareEntry
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		entry, ok := app.namedMiddleware[name]
+		if !ok {
+			app.LogWarnf("Middleware %s referenced by %s is not registered", name, reflect.TypeOf(controller))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sortMiddleware(entries)
+	return entries
+}
+
+// toHandlersChain 转换为Hertz处理链
+func toHandlersChain(handlers []HandlerFunc) hertzapp.HandlersChain {
+	chain := make(hertzapp.HandlersChain, 0, len(handlers))
+	for _, handler := range handlers {
+		chain = append(chain, handler)
+	}
+	return chain
+}
+
+// sortMiddleware 按优先级稳定排序，同优先级按登记顺序
+func sortMiddleware(entries []*middlewareEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].priority != entries[j].priority {
+			return entries[i].priority < entries[j].priority
+		}
+		return entries[i].seq < entries[j].seq
+	})
+}
+
+// handlerName 获取处理函数名称
+func handlerName(handler any) string {
+	if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return "anonymous"
+}
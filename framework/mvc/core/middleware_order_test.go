@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedController 测试用控制器，引用命名中间件
+type orderedController struct {
+	BaseController
+}
+
+func (c *orderedController) GetList() {
+	c.String("ok")
+}
+
+// recordMiddleware 记录执行顺序的中间件
+func recordMiddleware(order *[]string, name string) HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		*order = append(*order, name)
+		ctx.Next(c)
+	}
+}
+
+// chainNames 提取指定作用范围的中间件名称
+func chainNames(chain []MiddlewareInfo, scope string) []string {
+	var names []string
+	for _, info := range chain {
+		if info.Scope == scope {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+func TestApp_MiddlewareChainOrder(t *testing.T) {
+	app := NewApp()
+	var order []string
+
+	// 按与优先级相反的顺序添加
+	app.Use(recordMiddleware(&order, "user"))
+	app.UseWithPriority("global.auth", MiddlewarePriorityAuth, recordMiddleware(&order, "global.auth"))
+	app.UseWithPriority("metrics", MiddlewarePriorityTracing, recordMiddleware(&order, "metrics"))
+
+	app.RegisterMiddleware("validation", MiddlewarePriorityValidation, recordMiddleware(&order, "validation"))
+	app.RegisterMiddleware("logging", MiddlewarePriorityLogger, recordMiddleware(&order, "logging"))
+	app.RegisterMiddleware("auth", MiddlewarePriorityAuth, recordMiddleware(&order, "auth"))
+
+	ctrl := &orderedController{}
+	ctrl.SetMiddleware([]string{"validation", "auth", "unknown", "logging"})
+
+	chain := app.GetMiddlewareChain(ctrl)
+	globals := chainNames(chain, MiddlewareScopeGlobal)
+	require.Len(t, globals, 11)
+	assert.Equal(t, []string{"routing.case", "routing.head", "response.envelope", "recovery", "tracing", "metrics", "logger", "cors", "ratelimit", "global.auth"}, globals[:10],
+		"framework middleware should run before auth regardless of registration order")
+	assert.Contains(t, globals[10], "recordMiddleware", "Use() without priority should run last")
+	assert.Equal(t, []string{"logging", "auth", "validation"}, chainNames(chain, MiddlewareScopeController),
+		"named middleware should be sorted by priority and unknown names skipped")
+
+	// 全局中间件之后执行控制器的命名中间件
+	for i, info := range chain[1:] {
+		assert.LessOrEqual(t, scopeRank(chain[i].Scope), scopeRank(info.Scope))
+	}
+
+	// 实际执行顺序与解析结果一致
+	app.Router(ctrl, "GetList", "GET:/ordered/list")
+	resp := ut.PerformRequest(app.Engine, "GET", "/ordered/list", nil).Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, []string{"metrics", "global.auth", "user", "logging", "auth", "validation"}, order)
+}
+
+func TestApp_NoRouteChainFollowsPriority(t *testing.T) {
+	app := NewApp()
+	var order []string
+
+	app.UseWithPriority("late", MiddlewarePriorityValidation, recordMiddleware(&order, "late"))
+	app.UseWithPriority("early", MiddlewarePriorityRecovery, recordMiddleware(&order, "early"))
+	app.UseWithPriority("early.second", MiddlewarePriorityRecovery, recordMiddleware(&order, "early.second"))
+
+	// 未匹配路由的处理链同样按优先级排序
+	resp := ut.PerformRequest(app.Engine, "GET", "/missing", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+	assert.Equal(t, []string{"early", "early.second", "late"}, order)
+}
+
+// rateLimitedController 仅Create方法声明限流中间件
+type rateLimitedController struct {
+	BaseController
+}
+
+func (c *rateLimitedController) GetList() {
+	c.String("list")
+}
+
+func (c *rateLimitedController) PostCreate() {
+	c.String("created")
+}
+
+func TestApp_MethodMiddleware(t *testing.T) {
+	app := NewApp()
+	var order []string
+
+	app.RegisterMiddleware("logging", MiddlewarePriorityLogger, recordMiddleware(&order, "logging"))
+	app.RegisterMiddleware("rateLimit", MiddlewarePriorityRateLimit, func(c context.Context, ctx *RequestContext) {
+		order = append(order, "rateLimit")
+		ctx.AbortWithStatus(429)
+	})
+
+	ctrl := &rateLimitedController{}
+	ctrl.SetMiddleware([]string{"logging"})
+	ctrl.SetMethodMiddleware("PostCreate", []string{"rateLimit"})
+	app.Router(ctrl, "GetList", "GET:/limited", "PostCreate", "POST:/limited")
+
+	resp := ut.PerformRequest(app.Engine, "GET", "/limited", nil).Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, []string{"logging"}, order, "sibling actions should not be rate limited")
+
+	order = nil
+	resp = ut.PerformRequest(app.Engine, "POST", "/limited", nil).Result()
+	assert.Equal(t, 429, resp.StatusCode())
+	assert.Equal(t, []string{"logging", "rateLimit"}, order)
+}
+
+// scopeRank 作用范围的执行先后
+func scopeRank(scope string) int {
+	switch scope {
+	case MiddlewareScopeEngine:
+		return 0
+	case MiddlewareScopeGlobal:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// SetCaseInsensitiveRouting 设置路径大小写策略（默认区分大小写）
+// enabled为true时 /Users 可匹配 /users；redirect为true时重定向到注册的规范路径，否则直接按规范路径处理
+func (app *App) SetCaseInsensitiveRouting(enabled, redirect bool) *App {
+	app.caseInsensitive = enabled
+	app.redirectCanonical = redirect
+	return app
+}
+
+// IsCaseInsensitiveRouting 是否启用不区分大小写的路由匹配
+func (app *App) IsCaseInsensitiveRouting() bool {
+	return app.caseInsensitive
+}
+
+// caseInsensitiveRoutingMiddleware 路径大小写策略中间件，作为第一个全局中间件注册
+// 仅在未匹配到路由（NoRoute处理链）时生效，通过路由注册表查找规范路径，覆盖全部注册方式
+func (app *App) caseInsensitiveRoutingMiddleware() HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		if !app.caseInsensitive || len(ctx.FullPath()) > 0 {
+			return
+		}
+
+		requestPath := string(ctx.Request.URI().Path())
+		canonical, ok := app.routes.MatchFold(string(ctx.Request.Method()), requestPath)
+		if !ok || canonical == requestPath {
+			return
+		}
+
+		if app.redirectCanonical {
+			code := consts.StatusMovedPermanently
+			if method := string(ctx.Request.Method()); method != consts.MethodGet && method != consts.MethodHead {
+				code = consts.StatusPermanentRedirect
+			}
+			location := canonical
+			if query := ctx.Request.URI().QueryString(); len(query) > 0 {
+				location += "?" + string(query)
+			}
+			ctx.Redirect(code, []byte(location))
+			ctx.Abort()
+			return
+		}
+
+		// 按规范路径重新分发，重新分发后的处理链包含本中间件，因其已匹配到路由而直接放行
+		ctx.Request.URI().SetPath(canonical)
+		ctx.Params = ctx.Params[:0]
+		ctx.SetStatusCode(consts.StatusOK)
+		ctx.SetIndex(-1)
+		app.Engine.ServeHTTP(c, ctx)
+		ctx.Abort()
+	}
+}
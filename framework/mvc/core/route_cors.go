@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zsy619/yyhertz/framework/mvc/middleware"
+)
+
+// routeCORSEntry 路由前缀对应的跨域策略，policy为nil表示该子树禁止跨域
+type routeCORSEntry struct {
+	prefix  string
+	policy  *middleware.CORSPolicy
+	handler middleware.Middleware
+}
+
+// RouteCORSRegistry 路由级跨域策略注册表，命名空间/路由组的策略覆盖全局默认CORS配置
+type RouteCORSRegistry struct {
+	mu      sync.RWMutex
+	entries []routeCORSEntry // 按前缀长度降序排列，优先匹配最长前缀
+}
+
+// NewRouteCORSRegistry 创建路由级跨域策略注册表
+func NewRouteCORSRegistry() *RouteCORSRegistry {
+	return &RouteCORSRegistry{}
+}
+
+// Set 为路由前缀设置跨域策略，policy 为 nil 时表示该前缀下的路由不输出任何CORS头
+func (r *RouteCORSRegistry) Set(prefix string, policy *middleware.CORSPolicy) {
+	entry := routeCORSEntry{
+		prefix:  normalizeCORSPrefix(prefix),
+		policy:  policy,
+		handler: middleware.CORSMiddlewareWithPolicy(policy),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.entries {
+		if r.entries[i].prefix == entry.prefix {
+			r.entries[i] = entry
+			return
+		}
+	}
+	r.entries = append(r.entries, entry)
+	sort.SliceStable(r.entries, func(i, j int) bool {
+		return len(r.entries[i].prefix) > len(r.entries[j].prefix)
+	})
+}
+
+// Remove 移除路由前缀上的跨域策略，恢复使用全局默认策略
+func (r *RouteCORSRegistry) Remove(prefix string) {
+	prefix = normalizeCORSPrefix(prefix)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.entries {
+		if entry.prefix == prefix {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Match 查找请求路径对应的路由级跨域策略
+// matched 为 false 表示没有路由级策略，应使用全局默认策略
+func (r *RouteCORSRegistry) Match(path string) (policy *middleware.CORSPolicy, matched bool) {
+	if entry, ok := r.match(path); ok {
+		return entry.policy, true
+	}
+	return nil, false
+}
+
+// match 按最长前缀查找路由级策略
+func (r *RouteCORSRegistry) match(path string) (routeCORSEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		if entry.prefix == "/" || path == entry.prefix || strings.HasPrefix(path, entry.prefix+"/") {
+			return entry, true
+		}
+	}
+	return routeCORSEntry{}, false
+}
+
+// normalizeCORSPrefix 规范化路由前缀：以"/"开头且不以"/"结尾
+func normalizeCORSPrefix(prefix string) string {
+	return "/" + strings.Trim(prefix, "/")
+}
+
+// corsMiddleware 全局CORS中间件：命中路由级策略时使用该策略，否则使用默认的 CORSMiddleware
+func (app *App) corsMiddleware() HandlerFunc {
+	fallback := middleware.CORSMiddleware()
+	return func(c context.Context, ctx *RequestContext) {
+		if entry, ok := app.corsPolicies.match(string(ctx.Path())); ok {
+			entry.handler(c, ctx)
+			return
+		}
+		fallback(c, ctx)
+	}
+}
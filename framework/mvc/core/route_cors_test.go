@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zsy619/yyhertz/framework/mvc/middleware"
+)
+
+// corsController 测试用控制器
+type corsController struct {
+	BaseController
+}
+
+func (c *corsController) GetList() {
+	c.String("ok")
+}
+
+func TestRouteCORSRegistry_LongestPrefix(t *testing.T) {
+	registry := NewRouteCORSRegistry()
+	outer := &middleware.CORSPolicy{AllowOrigins: []string{"*"}}
+	inner := &middleware.CORSPolicy{AllowOrigins: []string{"https://admin.example.com"}}
+	registry.Set("/api/", outer)
+	registry.Set("/api/admin", inner)
+
+	policy, matched := registry.Match("/api/admin/users")
+	assert.True(t, matched)
+	assert.Same(t, inner, policy)
+	policy, matched = registry.Match("/api/users")
+	assert.True(t, matched)
+	assert.Same(t, outer, policy)
+	_, matched = registry.Match("/apix")
+	assert.False(t, matched, "/apix should not match the /api prefix")
+
+	registry.Remove("/api/admin")
+	policy, _ = registry.Match("/api/admin/users")
+	assert.Same(t, outer, policy)
+}
+
+func TestApp_RouteCORSPolicies(t *testing.T) {
+	app := NewApp()
+	app.GetCORSRegistry().Set("/api", &middleware.CORSPolicy{
+		AllowOrigins: []string{"https://app.example.com"},
+		AllowMethods: []string{"GET", "POST"},
+		MaxAge:       600,
+	})
+	app.GetCORSRegistry().Set("/web", nil)
+	for _, path := range []string{"/api/users", "/web/index", "/other"} {
+		app.Router(&corsController{}, "GetList", "GET:"+path)
+	}
+
+	origin := ut.Header{Key: "Origin", Value: "https://app.example.com"}
+
+	// API路由：使用路由级策略
+	resp := ut.PerformRequest(app.Engine, "GET", "/api/users", nil, origin).Result()
+	assert.Equal(t, "https://app.example.com", string(resp.Header.Peek("Access-Control-Allow-Origin")))
+	assert.Equal(t, "GET, POST", string(resp.Header.Peek("Access-Control-Allow-Methods")))
+
+	// Web路由：禁止跨域，不输出CORS头
+	resp = ut.PerformRequest(app.Engine, "GET", "/web/index", nil, origin).Result()
+	assert.Empty(t, string(resp.Header.Peek("Access-Control-Allow-Origin")))
+
+	// 未配置的路由：使用全局默认策略
+	resp = ut.PerformRequest(app.Engine, "GET", "/other", nil, origin).Result()
+	assert.Equal(t, "*", string(resp.Header.Peek("Access-Control-Allow-Origin")))
+
+	// 路由级预检请求直接返回204
+	resp = ut.PerformRequest(app.Engine, "OPTIONS", "/api/users", nil, origin).Result()
+	assert.Equal(t, 204, resp.StatusCode())
+
+	// 策略属于各自的应用，不影响其他应用
+	other := NewApp()
+	other.Router(&corsController{}, "GetList", "GET:/web/index")
+	resp = ut.PerformRequest(other.Engine, "GET", "/web/index", nil, origin).Result()
+	assert.Equal(t, "*", string(resp.Header.Peek("Access-Control-Allow-Origin")))
+}
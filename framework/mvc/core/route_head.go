@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// SetAutoHead 设置是否自动响应HEAD请求（默认开启）
+// 开启时未注册HEAD路由的路径按GET路由处理并丢弃响应体，显式注册的HEAD路由优先
+func (app *App) SetAutoHead(enabled bool) *App {
+	app.autoHead = enabled
+	return app
+}
+
+// IsAutoHead 是否自动响应HEAD请求
+func (app *App) IsAutoHead() bool {
+	return app.autoHead
+}
+
+// autoHeadMiddleware HEAD请求中间件，作为全局路由中间件注册
+// 仅在未匹配到HEAD路由（NoRoute/NoMethod处理链）时生效，按GET重新分发，响应保留状态码与响应头（包括Content-Length）
+func (app *App) autoHeadMiddleware() HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		if !app.autoHead || len(ctx.FullPath()) > 0 || !ctx.Request.Header.IsHead() {
+			return
+		}
+
+		// 按GET重新分发，重新分发后的处理链包含本中间件，因请求方法为GET而直接放行
+		ctx.Request.Header.SetMethod(consts.MethodGet)
+		ctx.Params = ctx.Params[:0]
+		ctx.SetStatusCode(consts.StatusOK)
+		ctx.SetIndex(-1)
+		app.Engine.ServeHTTP(c, ctx)
+		ctx.Request.Header.SetMethod(consts.MethodHead)
+
+		discardBody(ctx)
+		ctx.Abort()
+	}
+}
+
+// discardBody 丢弃响应体，Content-Length保持为原响应体长度
+func discardBody(ctx *RequestContext) {
+	if !ctx.Response.IsBodyStream() {
+		length := len(ctx.Response.Body())
+		ctx.Response.ResetBody()
+		ctx.Response.Header.SetContentLength(length)
+	}
+	ctx.Response.SkipBody = true
+}
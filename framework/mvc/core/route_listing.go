@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// DefaultRouteListingPath 路由列表调试端点的默认路径
+const DefaultRouteListingPath = "/debug/routes"
+
+// RouteDescriptor 已注册路由的描述（用于调试）
+type RouteDescriptor struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Handler    string   `json:"handler"`
+	Middleware []string `json:"middleware"`
+}
+
+// ListRoutes 列出引擎中已注册的全部路由（命名空间、自动路由、手动路由、注释路由等），按路径与方法排序
+// Handler优先使用路由注册表中的来源（如 user.GetList），Middleware为注册时的中间件链；
+// 未经路由注册表登记的路由按当前全局中间件链展示
+func (app *App) ListRoutes() []RouteDescriptor {
+	global := app.routeMiddlewareNames(nil)
+
+	infos := app.Routes()
+	routes := make([]RouteDescriptor, 0, len(infos))
+	for _, info := range infos {
+		route := RouteDescriptor{Method: info.Method, Path: info.Path, Handler: info.Handler, Middleware: global}
+		if source, ok := app.routes.Lookup(info.Method, info.Path); ok {
+			route.Handler = source
+		}
+		if names, ok := app.routes.Middleware(info.Method, info.Path); ok {
+			route.Middleware = names
+		}
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// EnableRouteListing 注册路由列表调试端点，以JSON返回每个路由的方法、路径、处理函数与中间件
+// 仅在开发模式（app.debug）下生效，未传入路径时使用 DefaultRouteListingPath
+func (app *App) EnableRouteListing(path ...string) *App {
+	if !config.GetAppConfigBool("app.debug") {
+		app.LogWarnf("Route listing endpoint is only available when app.debug is enabled")
+		return app
+	}
+
+	listingPath := DefaultRouteListingPath
+	if len(path) > 0 && path[0] != "" {
+		listingPath = path[0]
+	}
+
+	app.registerRoute("GET", listingPath, "builtin.routes", func(c context.Context, ctx *RequestContext) {
+		routes := app.ListRoutes()
+		ctx.JSON(consts.StatusOK, map[string]any{
+			"count":  len(routes),
+			"routes": routes,
+		})
+	})
+	return app
+}
+
+// routeMiddlewareNames 组合当前全局中间件链与路由附加的处理函数（最后一个为路由处理函数，不计入）的名称
+func (app *App) routeMiddlewareNames(handlers []HandlerFunc) []string {
+	chain := app.GetMiddlewareChain()
+	names := make([]string, 0, len(chain)+len(handlers))
+	for _, info := range chain {
+		names = append(names, info.Name)
+	}
+	for i := 0; i < len(handlers)-1; i++ {
+		names = append(names, handlerName(handlers[i]))
+	}
+	return names
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ListingController 路由列表测试控制器
+type ListingController struct {
+	BaseController
+}
+
+func (c *ListingController) GetList() {
+	c.String("list")
+}
+
+// findRoute 按方法与路径查找路由描述
+func findRoute(routes []RouteDescriptor, method, path string) (RouteDescriptor, bool) {
+	for _, route := range routes {
+		if route.Method == method && route.Path == path {
+			return route, true
+		}
+	}
+	return RouteDescriptor{}, false
+}
+
+func TestApp_ListRoutes(t *testing.T) {
+	app := NewApp()
+	var order []string
+	app.RegisterMiddleware("auth", MiddlewarePriorityAuth, recordMiddleware(&order, "auth"))
+
+	auto := &ListingController{}
+	auto.SetMiddleware([]string{"auth"})
+	app.AutoRoutersPrefix("/auto", auto)
+	app.RouterPrefixWithMiddleware("/manual", []HandlerFunc{recordMiddleware(&order, "manual")}, &ListingController{}, "GetList", "GET:/list")
+	// 直接注册到引擎的路由（未经路由注册表）
+	app.GET("/raw", func(c context.Context, ctx *RequestContext) {})
+
+	routes := app.ListRoutes()
+
+	autoRoute, ok := findRoute(routes, "GET", "/auto/listing/list")
+	require.True(t, ok, "auto route should be listed")
+	assert.Equal(t, "ListingController.GetList", autoRoute.Handler)
+	assert.Contains(t, autoRoute.Middleware, "recovery")
+	assert.Equal(t, "auth", autoRoute.Middleware[len(autoRoute.Middleware)-1], "named middleware should be listed by name")
+
+	manualRoute, ok := findRoute(routes, "GET", "/manual/list")
+	require.True(t, ok, "manual route should be listed")
+	assert.Contains(t, manualRoute.Handler, "Listing.GetList")
+	assert.Contains(t, manualRoute.Middleware[len(manualRoute.Middleware)-1], "recordMiddleware")
+
+	rawRoute, ok := findRoute(routes, "GET", "/raw")
+	require.True(t, ok, "engine route should be listed")
+	assert.Contains(t, rawRoute.Handler, "TestApp_ListRoutes")
+	assert.Equal(t, app.routeMiddlewareNames(nil), rawRoute.Middleware)
+
+	health, ok := findRoute(routes, "GET", "/health")
+	require.True(t, ok)
+	assert.Equal(t, "builtin.health", health.Handler)
+}
+
+func TestApp_EnableRouteListing(t *testing.T) {
+	app := NewApp().EnableRouteListing()
+	app.Router(&ListingController{}, "GetList", "GET:/manual/list")
+
+	resp := ut.PerformRequest(app.Engine, "GET", DefaultRouteListingPath, nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+
+	var body struct {
+		Count  int               `json:"count"`
+		Routes []RouteDescriptor `json:"routes"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body(), &body))
+	assert.Equal(t, len(body.Routes), body.Count)
+
+	for _, path := range []string{"/health", "/ping", "/manual/list", DefaultRouteListingPath} {
+		route, ok := findRoute(body.Routes, "GET", path)
+		if assert.True(t, ok, "route %s should be listed", path) {
+			assert.NotEmpty(t, route.Handler)
+			assert.NotEmpty(t, route.Middleware)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package core
+
+import (
+	"context"
+	"strings"
+)
+
+// methodNotAllowedHandler 路径已注册但HTTP方法不匹配时的处理器，作为NoMethod处理器注册
+// Hertz返回405，本处理器按路由注册表写入Allow头，列出该路径支持的方法
+func (app *App) methodNotAllowedHandler() HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		methods := app.routes.AllowedMethods(string(ctx.Path()), false)
+		if len(methods) > 0 {
+			ctx.Response.Header.Set("Allow", strings.Join(methods, ", "))
+		}
+	}
+}
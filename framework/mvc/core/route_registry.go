@@ -0,0 +1,238 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// anyMethods ANY路由展开后的HTTP方法（与Hertz的Any保持一致）
+var anyMethods = []string{
+	consts.MethodGet,
+	consts.MethodPost,
+	consts.MethodPut,
+	consts.MethodPatch,
+	consts.MethodHead,
+	consts.MethodOptions,
+	consts.MethodDelete,
+	consts.MethodConnect,
+	consts.MethodTrace,
+}
+
+// DuplicateRouteError 重复路由注册错误
+type DuplicateRouteError struct {
+	Method    string // 冲突的HTTP方法
+	Path      string // 冲突的路由路径
+	Existing  string // 已注册路由的来源
+	Duplicate string // 重复注册的来源
+}
+
+// Error 实现error接口
+func (e *DuplicateRouteError) Error() string {
+	return fmt.Sprintf("duplicate route %s %s: registered by %s, conflicts with %s",
+		e.Method, e.Path, e.Existing, e.Duplicate)
+}
+
+// RouteRegistry 路由注册表，跨注册方式（AutoRouters、Router、Namespace、注释路由）检测重复路由
+type RouteRegistry struct {
+	mu         sync.RWMutex
+	routes     map[string]string   // "METHOD path" -> 注册来源
+	paths      map[string]string   // "METHOD path" -> 注册时的原始路径
+	middleware map[string][]string // "METHOD path" -> 注册时的中间件链
+	strict     bool
+}
+
+// NewRouteRegistry 创建路由注册表
+func NewRouteRegistry(strict bool) *RouteRegistry {
+	return &RouteRegistry{
+		routes:     make(map[string]string),
+		paths:      make(map[string]string),
+		middleware: make(map[string][]string),
+		strict:     strict,
+	}
+}
+
+// SetStrict 设置严格模式
+func (r *RouteRegistry) SetStrict(strict bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strict = strict
+}
+
+// IsStrict 是否为严格模式
+func (r *RouteRegistry) IsStrict() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.strict
+}
+
+// Register 登记路由，返回未冲突（可以注册到引擎）的HTTP方法
+// 方法为ANY时展开为全部HTTP方法；冲突的方法不会被登记，并以DuplicateRouteError返回首个冲突
+func (r *RouteRegistry) Register(method, path, source string) ([]string, error) {
+	methods := ExpandRouteMethod(method)
+	key := normalizeRoutePath(path)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var dupErr *DuplicateRouteError
+	available := make([]string, 0, len(methods))
+	for _, m := range methods {
+		routeKey := m + " " + key
+		if existing, ok := r.routes[routeKey]; ok {
+			if dupErr == nil {
+				dupErr = &DuplicateRouteError{Method: m, Path: path, Existing: existing, Duplicate: source}
+			}
+			continue
+		}
+		available = append(available, m)
+	}
+
+	// 严格模式下任一方法冲突则整体拒绝
+	if dupErr != nil && r.strict {
+		return nil, dupErr
+	}
+	for _, m := range available {
+		r.routes[m+" "+key] = source
+		r.paths[m+" "+key] = path
+	}
+	if dupErr != nil {
+		return available, dupErr
+	}
+	return available, nil
+}
+
+// Lookup 查询路由的注册来源
+func (r *RouteRegistry) Lookup(method, path string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.routes[strings.ToUpper(method)+" "+normalizeRoutePath(path)]
+	return source, ok
+}
+
+// SetMiddleware 记录路由注册时的中间件链（名称列表）
+func (r *RouteRegistry) SetMiddleware(method, path string, names []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware[strings.ToUpper(method)+" "+normalizeRoutePath(path)] = names
+}
+
+// Middleware 查询路由注册时的中间件链
+func (r *RouteRegistry) Middleware(method, path string) ([]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names, ok := r.middleware[strings.ToUpper(method)+" "+normalizeRoutePath(path)]
+	return names, ok
+}
+
+// MatchFold 不区分大小写地匹配请求路径，返回按注册路由大小写还原的规范路径
+// 参数段保留请求中的原值；多个路由均匹配时优先静态段更多的路由
+func (r *RouteRegistry) MatchFold(method, requestPath string) (string, bool) {
+	prefix := strings.ToUpper(method) + " "
+	requestSegments := strings.Split(requestPath, "/")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	best, bestStatic := "", -1
+	for key, pattern := range r.paths {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		canonical, static, ok := matchRoutePath(strings.Split(pattern, "/"), requestSegments, true)
+		if !ok {
+			continue
+		}
+		if static > bestStatic || (static == bestStatic && canonical < best) {
+			best, bestStatic = canonical, static
+		}
+	}
+	return best, bestStatic >= 0
+}
+
+// AllowedMethods 返回请求路径已登记的HTTP方法（按字母序），用于405响应的Allow头
+// fold为true时不区分大小写匹配
+func (r *RouteRegistry) AllowedMethods(requestPath string, fold bool) []string {
+	requestSegments := strings.Split(requestPath, "/")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var methods []string
+	for key, pattern := range r.paths {
+		method, _, _ := strings.Cut(key, " ")
+		if seen[method] {
+			continue
+		}
+		if _, _, ok := matchRoutePath(strings.Split(pattern, "/"), requestSegments, fold); ok {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// matchRoutePath 按段匹配路由模式与请求路径，返回规范路径与匹配的静态段数量；fold为true时静态段不区分大小写
+func matchRoutePath(patternSegments, requestSegments []string, fold bool) (string, int, bool) {
+	canonical := make([]string, 0, len(requestSegments))
+	static := 0
+	for i, seg := range patternSegments {
+		if strings.HasPrefix(seg, "*") {
+			canonical = append(canonical, requestSegments[min(i, len(requestSegments)):]...)
+			return strings.Join(canonical, "/"), static, true
+		}
+		if i >= len(requestSegments) {
+			return "", 0, false
+		}
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			if requestSegments[i] == "" {
+				return "", 0, false
+			}
+			canonical = append(canonical, requestSegments[i])
+		case seg == requestSegments[i] || (fold && strings.EqualFold(seg, requestSegments[i])):
+			canonical = append(canonical, seg)
+			static++
+		default:
+			return "", 0, false
+		}
+	}
+	if len(patternSegments) != len(requestSegments) {
+		return "", 0, false
+	}
+	return strings.Join(canonical, "/"), static, true
+}
+
+// Len 已登记的路由数量（按方法计）
+func (r *RouteRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.routes)
+}
+
+// ExpandRouteMethod 将路由方法展开为具体的HTTP方法列表
+func ExpandRouteMethod(method string) []string {
+	method = strings.ToUpper(method)
+	if method == "" || method == "ANY" || method == "*" {
+		return anyMethods
+	}
+	return []string{method}
+}
+
+// normalizeRoutePath 规范化路由路径，参数名不同但结构相同的路由视为同一路由
+func normalizeRoutePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			segments[i] = ":"
+		} else if strings.HasPrefix(seg, "*") {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// duplicateRouteController 测试用控制器
+type duplicateRouteController struct {
+	BaseController
+}
+
+func (c *duplicateRouteController) GetList()  {}
+func (c *duplicateRouteController) GetOther() {}
+
+func TestRouteRegistry_DetectsDuplicates(t *testing.T) {
+	registry := NewRouteRegistry(true)
+
+	methods, err := registry.Register("GET", "/users/:id", "UserController.GetInfo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET"}, methods)
+
+	// 参数名不同但结构相同的路由视为重复
+	_, err = registry.Register("get", "/users/:uid", "AccountController.GetInfo")
+	var dupErr *DuplicateRouteError
+	require.True(t, errors.As(err, &dupErr))
+	assert.Equal(t, "GET", dupErr.Method)
+	assert.Equal(t, "UserController.GetInfo", dupErr.Existing)
+	assert.Equal(t, "AccountController.GetInfo", dupErr.Duplicate)
+
+	// ANY与已注册的方法冲突，严格模式下整体拒绝
+	_, err = registry.Register("ANY", "/users/:id", "UserController.Any")
+	require.Error(t, err)
+	_, ok := registry.Lookup("POST", "/users/:id")
+	assert.False(t, ok)
+}
+
+func TestRouteRegistry_LenientSkipsConflictingMethods(t *testing.T) {
+	registry := NewRouteRegistry(false)
+
+	_, err := registry.Register("GET", "/users", "UserController.GetList")
+	require.NoError(t, err)
+
+	methods, err := registry.Register("ANY", "/users", "UserController.List")
+	require.Error(t, err)
+	assert.NotContains(t, methods, "GET")
+	assert.Contains(t, methods, "POST")
+
+	source, _ := registry.Lookup("GET", "/users")
+	assert.Equal(t, "UserController.GetList", source)
+}
+
+func TestApp_StrictRoutingRejectsDuplicate(t *testing.T) {
+	app := NewApp().SetStrictRouting(true)
+	ctrl := &duplicateRouteController{}
+
+	assert.PanicsWithError(t,
+		"duplicate route GET /dup/users: registered by core.duplicateRoute.GetList, conflicts with core.duplicateRoute.GetOther",
+		func() {
+			app.Router(ctrl, "GetList", "GET:/dup/users", "GetOther", "GET:/dup/users")
+		})
+
+	// 与内置路由冲突同样被拒绝
+	assert.Panics(t, func() {
+		app.Router(ctrl, "GetList", "GET:/health")
+	})
+}
+
+func TestApp_LenientRoutingLogsWarning(t *testing.T) {
+	app := NewApp().SetStrictRouting(false)
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	ctrl := &duplicateRouteController{}
+
+	assert.NotPanics(t, func() {
+		app.Router(ctrl, "GetList", "GET:/dup/items", "GetOther", "GET:/dup/items")
+	})
+
+	source, ok := app.GetRouteRegistry().Lookup("GET", "/dup/items")
+	require.True(t, ok)
+	assert.Equal(t, "core.duplicateRoute.GetList", source)
+
+	warned := false
+	for _, entry := range hook.AllEntries() {
+		if strings.Contains(entry.Message, "Duplicate route ignored") {
+			warned = true
+		}
+	}
+	assert.True(t, warned, "lenient mode should log a duplicate route warning")
+}
+
+func TestRouteRegistry_MatchFold(t *testing.T) {
+	registry := NewRouteRegistry(false)
+	_, _ = registry.Register("GET", "/users/:id", "UserController.GetInfo")
+	_, _ = registry.Register("GET", "/users/new", "UserController.GetNew")
+	_, _ = registry.Register("GET", "/static/*filepath", "static")
+
+	canonical, ok := registry.MatchFold("get", "/Users/AbC")
+	require.True(t, ok)
+	assert.Equal(t, "/users/AbC", canonical, "参数段应保留请求中的原值")
+
+	canonical, ok = registry.MatchFold("GET", "/USERS/NEW")
+	require.True(t, ok)
+	assert.Equal(t, "/users/new", canonical, "静态段更多的路由优先")
+
+	canonical, ok = registry.MatchFold("GET", "/Static/css/App.css")
+	require.True(t, ok)
+	assert.Equal(t, "/static/css/App.css", canonical)
+
+	_, ok = registry.MatchFold("POST", "/Users/1")
+	assert.False(t, ok)
+	_, ok = registry.MatchFold("GET", "/Users/1/orders")
+	assert.False(t, ok)
+}
+
+func TestApp_CaseInsensitiveRouting(t *testing.T) {
+	newApp := func() *App {
+		app := NewApp()
+		app.registerRoute("GET", "/users", "test.users", func(c context.Context, ctx *RequestContext) {
+			ctx.String(200, "users")
+		})
+		app.Router(&duplicateRouteController{}, "GetList", "GET:/case/list")
+		return app
+	}
+
+	// 默认区分大小写
+	app := newApp()
+	assert.False(t, app.IsCaseInsensitiveRouting())
+	assert.Equal(t, 404, ut.PerformRequest(app.Engine, "GET", "/Users", nil).Result().StatusCode())
+	assert.Equal(t, 200, ut.PerformRequest(app.Engine, "GET", "/users", nil).Result().StatusCode())
+
+	// 不区分大小写：/Users 匹配 /users
+	app = newApp().SetCaseInsensitiveRouting(true, false)
+	resp := ut.PerformRequest(app.Engine, "GET", "/Users", nil).Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "users", string(resp.Body()))
+	assert.Equal(t, 200, ut.PerformRequest(app.Engine, "GET", "/CASE/List", nil).Result().StatusCode())
+	assert.Equal(t, 404, ut.PerformRequest(app.Engine, "GET", "/missing", nil).Result().StatusCode())
+
+	// 重定向到规范路径
+	app = newApp().SetCaseInsensitiveRouting(true, true)
+	resp = ut.PerformRequest(app.Engine, "GET", "/Users?page=2", nil).Result()
+	assert.Equal(t, 301, resp.StatusCode())
+	assert.Equal(t, "/users?page=2", string(resp.Header.Peek("Location")))
+}
+
+func TestRouteRegistry_AllowedMethods(t *testing.T) {
+	registry := NewRouteRegistry(false)
+	_, _ = registry.Register("GET", "/users", "UserController.GetList")
+	_, _ = registry.Register("POST", "/users", "UserController.PostCreate")
+	_, _ = registry.Register("DELETE", "/users/:id", "UserController.DeleteUser")
+	_, _ = registry.Register("ANY", "/files/*filepath", "files")
+
+	assert.Equal(t, []string{"GET", "POST"}, registry.AllowedMethods("/users", false))
+	assert.Equal(t, []string{"DELETE"}, registry.AllowedMethods("/users/42", false))
+	assert.Len(t, registry.AllowedMethods("/files/a/b.txt", false), len(anyMethods))
+	assert.Empty(t, registry.AllowedMethods("/Users", false))
+	assert.Equal(t, []string{"GET", "POST"}, registry.AllowedMethods("/Users", true))
+	assert.Empty(t, registry.AllowedMethods("/missing", false))
+}
+
+func TestApp_MethodNotAllowed(t *testing.T) {
+	app := NewApp()
+	app.registerRoute("GET", "/users", "test.users", func(c context.Context, ctx *RequestContext) {
+		ctx.String(200, "users")
+	})
+	app.Router(&duplicateRouteController{}, "GetList", "GET:/orders", "GetOther", "PUT:/orders")
+
+	resp := ut.PerformRequest(app.Engine, "POST", "/users", nil).Result()
+	assert.Equal(t, 405, resp.StatusCode())
+	assert.Equal(t, "GET", string(resp.Header.Peek("Allow")))
+
+	resp = ut.PerformRequest(app.Engine, "DELETE", "/orders", nil).Result()
+	assert.Equal(t, 405, resp.StatusCode())
+	assert.Equal(t, "GET, PUT", string(resp.Header.Peek("Allow")))
+
+	// 路径不存在时仍返回404
+	resp = ut.PerformRequest(app.Engine, "POST", "/missing", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+	assert.Empty(t, resp.Header.Peek("Allow"))
+
+	assert.Equal(t, 200, ut.PerformRequest(app.Engine, "GET", "/users", nil).Result().StatusCode())
+}
+
+func TestApp_AutoHead(t *testing.T) {
+	newApp := func() *App {
+		app := NewApp()
+		app.registerRoute("GET", "/users", "test.users", func(c context.Context, ctx *RequestContext) {
+			ctx.Header("X-Total-Count", "2")
+			ctx.JSON(200, []string{"alice", "bob"})
+		})
+		return app
+	}
+
+	app := newApp()
+	get := ut.PerformRequest(app.Engine, "GET", "/users", nil)
+	head := ut.PerformRequest(app.Engine, "HEAD", "/users", nil)
+	getBody := get.Result().Body()
+	require.NotEmpty(t, getBody)
+
+	// 响应头在Result计算Content-Length前读取
+	assert.Equal(t, len(getBody), head.Header().ContentLength())
+	assert.Equal(t, string(get.Header().ContentType()), string(head.Header().ContentType()))
+	assert.Equal(t, "2", string(head.Header().Peek("X-Total-Count")))
+	assert.Equal(t, 200, head.Result().StatusCode())
+	assert.Empty(t, head.Result().Body())
+
+	// 显式注册的HEAD路由优先
+	app.registerRoute("HEAD", "/users", "test.users.head", func(c context.Context, ctx *RequestContext) {
+		ctx.Header("X-Explicit", "true")
+		ctx.SetStatusCode(204)
+	})
+	resp := ut.PerformRequest(app.Engine, "HEAD", "/users", nil).Result()
+	assert.Equal(t, 204, resp.StatusCode())
+	assert.Equal(t, "true", string(resp.Header.Peek("X-Explicit")))
+
+	// 未注册的路径返回404；关闭后按405处理
+	assert.Equal(t, 404, ut.PerformRequest(app.Engine, "HEAD", "/missing", nil).Result().StatusCode())
+	app = newApp().SetAutoHead(false)
+	resp = ut.PerformRequest(app.Engine, "HEAD", "/users", nil).Result()
+	assert.Equal(t, 405, resp.StatusCode())
+	assert.Equal(t, "GET", string(resp.Header.Peek("Allow")))
+}
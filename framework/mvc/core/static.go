@@ -0,0 +1,244 @@
+package core
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	hertzapp "github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	contextenhanced "github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+// defaultSPAExcludePrefixes 默认不回退到单页应用入口的路径前缀
+var defaultSPAExcludePrefixes = []string{"/api"}
+
+// staticMount 静态文件挂载点，URL前缀映射到本地目录（/assets/app.css -> ./public/app.css）
+type staticMount struct {
+	prefix string
+	root   string
+	serve  hertzapp.HandlerFunc
+}
+
+// spaFallback 单页应用回退配置
+type spaFallback struct {
+	index    string
+	excludes []string
+	serve    hertzapp.HandlerFunc
+}
+
+// SetDirectoryListing 设置目录列表（默认关闭）
+// 关闭时访问不含 index.html 的目录返回403，开启时生成目录索引页
+func (app *App) SetDirectoryListing(enabled bool) *App {
+	app.staticMu.Lock()
+	defer app.staticMu.Unlock()
+	app.staticListing = enabled
+	for prefix, mount := range app.staticMounts {
+		app.staticMounts[prefix] = newStaticMount(prefix, mount.root, enabled)
+	}
+	return app
+}
+
+// SetSPAFallback 设置单页应用回退：未匹配路由的GET/HEAD请求返回indexFile（如 ./dist/index.html）
+// excludePrefixes 为不回退的路径前缀，未指定时为 /api；indexFile为空时关闭回退
+func (app *App) SetSPAFallback(indexFile string, excludePrefixes ...string) *App {
+	app.staticMu.Lock()
+	defer app.staticMu.Unlock()
+	if indexFile == "" {
+		app.spa = nil
+		return app
+	}
+	if len(excludePrefixes) == 0 {
+		excludePrefixes = defaultSPAExcludePrefixes
+	}
+	excludes := make([]string, 0, len(excludePrefixes))
+	for _, prefix := range excludePrefixes {
+		excludes = append(excludes, normalizeStaticPrefix(prefix))
+	}
+	app.spa = &spaFallback{
+		index:    indexFile,
+		excludes: excludes,
+		serve:    indexFileHandler(indexFile),
+	}
+	if !app.spaRegistered {
+		app.spaRegistered = true
+		app.Hertz.NoRoute(app.spaFallbackHandler())
+	}
+	return app
+}
+
+// serveStatic 注册静态文件路由，支持Range/If-Range请求（206 Partial Content，超出范围时返回416）
+// 同一URL前缀只注册一次路由，再次调用时更新挂载的本地目录
+func (app *App) serveStatic(urlPath string) {
+	prefix := normalizeStaticPrefix(urlPath)
+
+	app.staticMu.Lock()
+	_, registered := app.staticMounts[prefix]
+	app.staticMounts[prefix] = newStaticMount(prefix, app.StaticPaths[urlPath], app.staticListing)
+	app.staticMu.Unlock()
+	if registered {
+		return
+	}
+
+	handler := app.staticMountHandler(prefix)
+	pattern := path.Join(prefix, "/*filepath")
+	app.GET(pattern, handler)
+	app.HEAD(pattern, handler)
+}
+
+// removeStaticMounts 移除不在pathMap中的挂载点，已注册的路由返回404
+func (app *App) removeStaticMounts(pathMap map[string]string) {
+	keep := make(map[string]bool, len(pathMap))
+	for urlPath := range pathMap {
+		keep[normalizeStaticPrefix(urlPath)] = true
+	}
+
+	app.staticMu.Lock()
+	defer app.staticMu.Unlock()
+	for prefix := range app.staticMounts {
+		if !keep[prefix] {
+			delete(app.staticMounts, prefix)
+		}
+	}
+}
+
+// staticMountHandler 按URL前缀查找挂载点并处理请求，路径中包含 .. 时返回403
+func (app *App) staticMountHandler(prefix string) HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		if hasDotDotSegment(ctx) {
+			ctx.AbortWithMsg("Forbidden", consts.StatusForbidden)
+			return
+		}
+
+		app.staticMu.RLock()
+		mount, ok := app.staticMounts[prefix]
+		app.staticMu.RUnlock()
+		if !ok {
+			ctx.AbortWithMsg("Not Found", consts.StatusNotFound)
+			return
+		}
+		mount.serve(c, ctx)
+	}
+}
+
+// spaFallbackHandler 未匹配路由时返回单页应用入口，作为NoRoute处理器注册
+func (app *App) spaFallbackHandler() HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		app.staticMu.RLock()
+		spa := app.spa
+		app.staticMu.RUnlock()
+		if spa == nil || !spa.matches(ctx) {
+			return
+		}
+		if hasDotDotSegment(ctx) {
+			ctx.AbortWithMsg("Forbidden", consts.StatusForbidden)
+			return
+		}
+		spa.serve(c, ctx)
+	}
+}
+
+// matches 是否回退到入口文件：仅GET/HEAD请求，且路径不在排除前缀下
+func (s *spaFallback) matches(ctx *RequestContext) bool {
+	if method := string(ctx.Method()); method != consts.MethodGet && method != consts.MethodHead {
+		return false
+	}
+	requestPath := string(ctx.Path())
+	for _, prefix := range s.excludes {
+		if hasPathPrefix(requestPath, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// newStaticMount 创建挂载点，请求路径去掉URL前缀后映射到root
+func newStaticMount(prefix, root string, listing bool) *staticMount {
+	return &staticMount{
+		prefix: prefix,
+		root:   root,
+		serve:  newStaticFileHandler(prefix, root, listing),
+	}
+}
+
+// staticFileHandler 创建静态文件处理器，完整请求路径映射到root（/static/app.css -> root/static/app.css）
+func staticFileHandler(root string) hertzapp.HandlerFunc {
+	return newStaticFileHandler("/", root, false)
+}
+
+// newStaticFileHandler 创建静态文件处理器
+// Range由Hertz文件系统处理；响应附带ETag，If-Range与文件当前版本不一致时忽略Range返回完整文件
+func newStaticFileHandler(prefix, root string, listing bool) hertzapp.HandlerFunc {
+	relPath := func(ctx *RequestContext) string {
+		return "/" + strings.TrimLeft(strings.TrimPrefix(string(ctx.Path()), prefix), "/")
+	}
+
+	fs := &hertzapp.FS{
+		Root:               root,
+		AcceptByteRange:    true,
+		IndexNames:         []string{"index.html"},
+		GenerateIndexPages: listing,
+	}
+	if prefix != "/" {
+		fs.PathRewrite = func(ctx *RequestContext) []byte {
+			return []byte(relPath(ctx))
+		}
+	}
+	serve := fs.NewRequestHandler()
+
+	return func(c context.Context, ctx *RequestContext) {
+		info, err := os.Stat(filepath.Join(root, filepath.FromSlash(relPath(ctx))))
+		if err == nil && info.Mode().IsRegular() {
+			etag := contextenhanced.FileETag(info)
+			ctx.Response.Header.Set("ETag", etag)
+			if !contextenhanced.IfRangeMatches(string(ctx.GetHeader("If-Range")), info.ModTime(), etag) {
+				ctx.Request.Header.Del("Range")
+			}
+		}
+		serve(c, ctx)
+	}
+}
+
+// indexFileHandler 创建始终返回指定文件的处理器
+func indexFileHandler(file string) hertzapp.HandlerFunc {
+	name := "/" + filepath.Base(file)
+	fs := &hertzapp.FS{
+		Root: filepath.Dir(file),
+		PathRewrite: func(ctx *RequestContext) []byte {
+			return []byte(name)
+		},
+	}
+	return fs.NewRequestHandler()
+}
+
+// hasDotDotSegment 原始请求路径（解码后）是否包含 .. 路径段
+// Hertz会规范化请求路径，此处检查原始路径以拒绝路径穿越尝试
+func hasDotDotSegment(ctx *RequestContext) bool {
+	raw := string(ctx.Request.URI().PathOriginal())
+	if decoded, err := url.PathUnescape(raw); err == nil {
+		raw = decoded
+	}
+	for _, segment := range strings.FieldsFunc(raw, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeStaticPrefix 规范化URL前缀：以/开头，不以/结尾
+func normalizeStaticPrefix(urlPath string) string {
+	return path.Clean("/" + urlPath)
+}
+
+// hasPathPrefix 按路径段判断前缀，/api 匹配 /api 与 /api/users，不匹配 /apis
+func hasPathPrefix(requestPath, prefix string) bool {
+	if prefix == "/" {
+		return true
+	}
+	return requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/")
+}
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStaticEngine 创建以临时目录为根的静态文件引擎
+func newStaticEngine(t *testing.T, content string) (*route.Engine, time.Time) {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "static"), 0o755))
+	file := filepath.Join(root, "static", "video.bin")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	handler := staticFileHandler(root)
+	engine.GET("/static/*filepath", handler)
+	engine.HEAD("/static/*filepath", handler)
+	return engine, modTime
+}
+
+func TestStaticFileHandler_Range(t *testing.T) {
+	engine, _ := newStaticEngine(t, "0123456789abcdef")
+
+	// 完整文件
+	resp := ut.PerformRequest(engine, "GET", "/static/video.bin", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "bytes", string(resp.Header.Peek("Accept-Ranges")))
+	assert.Equal(t, "0123456789abcdef", string(resp.Body()))
+
+	// 单区间
+	resp = ut.PerformRequest(engine, "GET", "/static/video.bin", nil,
+		ut.Header{Key: "Range", Value: "bytes=4-9"}).Result()
+	require.Equal(t, 206, resp.StatusCode())
+	assert.Equal(t, "bytes 4-9/16", string(resp.Header.Peek("Content-Range")))
+	assert.Equal(t, "456789", string(resp.Body()))
+
+	// 超出文件范围
+	resp = ut.PerformRequest(engine, "GET", "/static/video.bin", nil,
+		ut.Header{Key: "Range", Value: "bytes=32-40"}).Result()
+	assert.Equal(t, 416, resp.StatusCode())
+}
+
+func TestStaticFileHandler_IfRange(t *testing.T) {
+	engine, modTime := newStaticEngine(t, "0123456789abcdef")
+
+	resp := ut.PerformRequest(engine, "GET", "/static/video.bin", nil).Result()
+	etag := string(resp.Header.Peek("ETag"))
+	require.NotEmpty(t, etag)
+
+	tests := []struct {
+		ifRange string
+		status  int
+		body    string
+	}{
+		{etag, 206, "0123"},
+		{modTime.UTC().Format(http.TimeFormat), 206, "0123"},
+		{`"outdated"`, 200, "0123456789abcdef"},
+		{modTime.Add(-time.Minute).UTC().Format(http.TimeFormat), 200, "0123456789abcdef"},
+	}
+
+	for _, tt := range tests {
+		resp := ut.PerformRequest(engine, "GET", "/static/video.bin", nil,
+			ut.Header{Key: "Range", Value: "bytes=0-3"},
+			ut.Header{Key: "If-Range", Value: tt.ifRange}).Result()
+		assert.Equal(t, tt.status, resp.StatusCode(), tt.ifRange)
+		assert.Equal(t, tt.body, string(resp.Body()), tt.ifRange)
+	}
+}
+
+// newMountedApp 创建挂载 /assets 与 /vendor 两个目录的应用，返回两个目录的上级目录
+func newMountedApp(t *testing.T) (*App, string) {
+	t.Helper()
+	base := t.TempDir()
+	files := map[string]string{
+		"public/app.css":         "body{}",
+		"public/docs/guide.txt":  "guide",
+		"public/index.html":      "<html>spa</html>",
+		"third_party/lib/app.js": "console.log(1)",
+		"secret.txt":             "secret",
+	}
+	for name, content := range files {
+		file := filepath.Join(base, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+
+	app := NewApp()
+	app.SetStaticPaths(map[string]string{
+		"/assets": filepath.Join(base, "public"),
+		"/vendor": filepath.Join(base, "third_party"),
+	})
+	return app, base
+}
+
+func TestApp_StaticMounts(t *testing.T) {
+	app, _ := newMountedApp(t)
+
+	resp := ut.PerformRequest(app.Engine, "GET", "/assets/app.css", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "body{}", string(resp.Body()))
+	assert.NotEmpty(t, resp.Header.Peek("ETag"))
+
+	resp = ut.PerformRequest(app.Engine, "GET", "/vendor/lib/app.js", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "console.log(1)", string(resp.Body()))
+
+	resp = ut.PerformRequest(app.Engine, "GET", "/assets/missing.css", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+
+	// 被替换的默认挂载点不再提供文件
+	resp = ut.PerformRequest(app.Engine, "GET", "/static/app.css", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+}
+
+func TestApp_StaticDirectoryListing(t *testing.T) {
+	app, _ := newMountedApp(t)
+
+	resp := ut.PerformRequest(app.Engine, "GET", "/assets/docs/", nil).Result()
+	assert.Equal(t, 403, resp.StatusCode(), "directory listing should be disabled by default")
+
+	app.SetDirectoryListing(true)
+	resp = ut.PerformRequest(app.Engine, "GET", "/assets/docs/", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Contains(t, string(resp.Body()), "guide.txt")
+}
+
+func TestApp_SPAFallback(t *testing.T) {
+	app, base := newMountedApp(t)
+
+	resp := ut.PerformRequest(app.Engine, "GET", "/dashboard/settings", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode(), "fallback should be disabled by default")
+
+	app.SetSPAFallback(filepath.Join(base, "public", "index.html"))
+
+	resp = ut.PerformRequest(app.Engine, "GET", "/dashboard/settings", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "<html>spa</html>", string(resp.Body()))
+
+	// 已注册路由与静态文件不受影响
+	resp = ut.PerformRequest(app.Engine, "GET", "/ping", nil).Result()
+	assert.Contains(t, string(resp.Body()), "pong")
+	resp = ut.PerformRequest(app.Engine, "GET", "/assets/app.css", nil).Result()
+	assert.Equal(t, "body{}", string(resp.Body()))
+
+	// API路径与非GET请求不回退
+	resp = ut.PerformRequest(app.Engine, "GET", "/api/users", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+	resp = ut.PerformRequest(app.Engine, "POST", "/dashboard", nil).Result()
+	assert.Equal(t, 404, resp.StatusCode())
+	resp = ut.PerformRequest(app.Engine, "GET", "/apis", nil).Result()
+	assert.Equal(t, 200, resp.StatusCode(), "exclusion should match whole path segments")
+}
+
+func TestApp_StaticPathTraversal(t *testing.T) {
+	app, base := newMountedApp(t)
+	app.SetSPAFallback(filepath.Join(base, "public", "index.html"))
+
+	paths := []string{
+		"/assets/../secret.txt",
+		"/assets/%2e%2e/secret.txt",
+		"/assets/docs/../../secret.txt",
+		"/vendor/..%2f..%2fsecret.txt",
+		"/../secret.txt",
+	}
+	for _, p := range paths {
+		resp := ut.PerformRequest(app.Engine, "GET", p, nil).Result()
+		assert.Contains(t, []int{403, 404}, resp.StatusCode(), p)
+		assert.NotContains(t, string(resp.Body()), "secret", p)
+	}
+}
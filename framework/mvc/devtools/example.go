@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/zsy619/yyhertz/framework/mvc"
+	templatemanager "github.com/zsy619/yyhertz/framework/template"
 )
 
 // SetupDevTools 设置开发工具
 func SetupDevTools(app *mvc.App) error {
-	// 1. 设置热重载
+	// 1. 设置热重载：模板变化时清除模板缓存，并通过WebSocket通知浏览器刷新
+	liveReload := NewLiveReloadHub(DefaultLiveReloadPath)
 	hotReloadConfig := DefaultHotReloadConfig()
+	hotReloadConfig.LiveReload = liveReload
+	hotReloadConfig.TemplateCaches = []CacheInvalidator{templatemanager.GetTemplateManager().GetEngine()}
 	hotReloadConfig.OnReload = func() error {
 		log.Println("执行热重载...")
 		// 这里可以添加自定义的重载逻辑
@@ -35,13 +39,19 @@ func SetupDevTools(app *mvc.App) error {
 	// 启动性能监控
 	performanceMonitor.Start()
 
+	// 4. 设置性能分析端点（由performance.enable_profiler控制）
+	profilerPanel := NewProfilerPanel(DefaultProfilerConfig())
+
 	// 注册中间件
 	app.Use(debugMiddleware.Handler())
 	app.Use(performanceMonitor.Middleware())
+	app.Use(liveReload.InjectMiddleware())
 
 	// 注册调试和监控路由
 	debugPanel.RegisterRoutes(app.Engine)
 	performancePanel.RegisterRoutes(app.Engine)
+	profilerPanel.RegisterRoutes(app.Engine)
+	app.GET(liveReload.Path(), liveReload.Handler())
 
 	// 在开发环境下启动热重载服务器
 	if isDevelopment() {
@@ -56,6 +66,11 @@ func SetupDevTools(app *mvc.App) error {
 	log.Println("- 调试面板: http://localhost:8080/debug/panel")
 	log.Println("- 性能监控: http://localhost:8080/performance/panel")
 	log.Println("- 热重载: 已启用文件监控")
+	log.Printf("- 自动刷新: ws://localhost:8080%s", liveReload.Path())
+	if profilerPanel.Enabled() {
+		log.Printf("- pprof: http://localhost:8080%s/", profilerPanel.config.PprofPrefix)
+		log.Printf("- 运行时统计: http://localhost:8080%s", profilerPanel.config.StatsPath)
+	}
 
 	return nil
 }
@@ -15,18 +15,37 @@ import (
 	"github.com/zsy619/yyhertz/framework/mvc"
 )
 
+// ChangeKind 文件变化类型
+type ChangeKind int
+
+const (
+	ChangeSource   ChangeKind = iota // Go源码或配置文件，需要重新编译
+	ChangeTemplate                   // 模板文件
+	ChangeStyle                      // 样式表
+	ChangeStatic                     // 其他静态文件
+)
+
+// CacheInvalidator 可清除的缓存（如 view.TemplateEngine）
+type CacheInvalidator interface {
+	ClearCache()
+}
+
 // HotReloader 热重载器
 type HotReloader struct {
-	app         *mvc.App
-	watcher     *fsnotify.Watcher
-	watchDirs   []string
-	excludeDirs []string
-	extensions  []string
-	debounce    time.Duration
-	mu          sync.RWMutex
-	running     bool
-	restartCh   chan struct{}
-	stopCh      chan struct{}
+	app                *mvc.App
+	watcher            *fsnotify.Watcher
+	watchDirs          []string
+	excludeDirs        []string
+	extensions         []string
+	templateExtensions []string
+	templateCaches     []CacheInvalidator
+	staticCaches       []CacheInvalidator
+	liveReload         *LiveReloadHub
+	debounce           time.Duration
+	mu                 sync.RWMutex
+	running            bool
+	restartCh          chan struct{}
+	stopCh             chan struct{}
 
 	// 回调函数
 	onReload     func() error
@@ -36,13 +55,17 @@ type HotReloader struct {
 
 // HotReloadConfig 热重载配置
 type HotReloadConfig struct {
-	WatchDirs    []string             // 监控目录
-	ExcludeDirs  []string             // 排除目录
-	Extensions   []string             // 监控文件扩展名
-	Debounce     time.Duration        // 防抖时间
-	OnReload     func() error         // 重载回调
-	OnError      func(error)          // 错误回调
-	OnFileChange func(string, string) // 文件变化回调
+	WatchDirs          []string             // 监控目录
+	ExcludeDirs        []string             // 排除目录
+	Extensions         []string             // 监控文件扩展名
+	TemplateExtensions []string             // 模板文件扩展名
+	TemplateCaches     []CacheInvalidator   // 模板变化时清除的缓存
+	StaticCaches       []CacheInvalidator   // 静态文件变化时清除的缓存
+	LiveReload         *LiveReloadHub       // 浏览器自动刷新，为nil时不推送
+	Debounce           time.Duration        // 防抖时间
+	OnReload           func() error         // 重载回调
+	OnError            func(error)          // 错误回调
+	OnFileChange       func(string, string) // 文件变化回调
 }
 
 // NewHotReloader 创建热重载器
@@ -60,24 +83,31 @@ func NewHotReloader(app *mvc.App, config HotReloadConfig) (*HotReloader, error)
 		config.ExcludeDirs = []string{"logs", "tmp", ".git", "node_modules", "vendor"}
 	}
 	if len(config.Extensions) == 0 {
-		config.Extensions = []string{".go", ".html", ".css", ".js", ".yaml", ".yml", ".json"}
+		config.Extensions = []string{".go", ".html", ".tmpl", ".tpl", ".css", ".js", ".yaml", ".yml", ".json"}
+	}
+	if len(config.TemplateExtensions) == 0 {
+		config.TemplateExtensions = []string{".html", ".tmpl", ".tpl"}
 	}
 	if config.Debounce == 0 {
 		config.Debounce = 500 * time.Millisecond
 	}
 
 	hr := &HotReloader{
-		app:          app,
-		watcher:      watcher,
-		watchDirs:    config.WatchDirs,
-		excludeDirs:  config.ExcludeDirs,
-		extensions:   config.Extensions,
-		debounce:     config.Debounce,
-		restartCh:    make(chan struct{}, 1),
-		stopCh:       make(chan struct{}),
-		onReload:     config.OnReload,
-		onError:      config.OnError,
-		onFileChange: config.OnFileChange,
+		app:                app,
+		watcher:            watcher,
+		watchDirs:          config.WatchDirs,
+		excludeDirs:        config.ExcludeDirs,
+		extensions:         config.Extensions,
+		templateExtensions: config.TemplateExtensions,
+		templateCaches:     config.TemplateCaches,
+		staticCaches:       config.StaticCaches,
+		liveReload:         config.LiveReload,
+		debounce:           config.Debounce,
+		restartCh:          make(chan struct{}, 1),
+		stopCh:             make(chan struct{}),
+		onReload:           config.OnReload,
+		onError:            config.OnError,
+		onFileChange:       config.OnFileChange,
 	}
 
 	return hr, nil
@@ -153,6 +183,7 @@ func (hr *HotReloader) watchLoop() {
 		timer    *time.Timer
 		timerCh  <-chan time.Time
 		lastFile string
+		changes  = make(map[ChangeKind]bool)
 	)
 
 	for {
@@ -180,10 +211,12 @@ func (hr *HotReloader) watchLoop() {
 			timer = time.NewTimer(hr.debounce)
 			timerCh = timer.C
 			lastFile = event.Name
+			changes[hr.classifyChange(event.Name)] = true
 
 		case <-timerCh:
 			log.Printf("触发重载，最后修改文件: %s", lastFile)
-			hr.triggerReload()
+			hr.handleChanges(changes)
+			changes = make(map[ChangeKind]bool)
 			timer = nil
 			timerCh = nil
 
@@ -239,7 +272,62 @@ func (hr *HotReloader) shouldIgnoreEvent(event fsnotify.Event) bool {
 	return false
 }
 
-// triggerReload 触发重载
+// classifyChange 按扩展名判断文件变化类型
+func (hr *HotReloader) classifyChange(path string) ChangeKind {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, templateExt := range hr.templateExtensions {
+		if ext == templateExt {
+			return ChangeTemplate
+		}
+	}
+	switch ext {
+	case ".go", ".yaml", ".yml", ".json", ".toml":
+		return ChangeSource
+	case ".css":
+		return ChangeStyle
+	default:
+		return ChangeStatic
+	}
+}
+
+// handleChanges 处理一次防抖周期内的文件变化
+// 模板与静态文件变化清除对应缓存并通知浏览器刷新；Go源码变化发出重新编译信号
+func (hr *HotReloader) handleChanges(changes map[ChangeKind]bool) {
+	if changes[ChangeTemplate] {
+		clearCaches(hr.templateCaches)
+	}
+	if changes[ChangeStyle] || changes[ChangeStatic] {
+		clearCaches(hr.staticCaches)
+	}
+
+	switch {
+	case changes[ChangeSource]:
+		hr.broadcast(LiveReloadMessageRebuild)
+		hr.triggerReload()
+		return
+	case changes[ChangeTemplate] || changes[ChangeStatic]:
+		hr.broadcast(LiveReloadMessageReload)
+	case changes[ChangeStyle]:
+		hr.broadcast(LiveReloadMessageCSS)
+	}
+	hr.runReloadCallback()
+}
+
+// broadcast 通知已连接的浏览器
+func (hr *HotReloader) broadcast(message string) {
+	if hr.liveReload != nil {
+		hr.liveReload.Broadcast(message)
+	}
+}
+
+// clearCaches 依次清除缓存
+func clearCaches(caches []CacheInvalidator) {
+	for _, cache := range caches {
+		cache.ClearCache()
+	}
+}
+
+// triggerReload 触发重载（发出重启信号）
 func (hr *HotReloader) triggerReload() {
 	select {
 	case hr.restartCh <- struct{}{}:
@@ -247,6 +335,11 @@ func (hr *HotReloader) triggerReload() {
 		// 如果通道已满，忽略这次重载请求
 	}
 
+	hr.runReloadCallback()
+}
+
+// runReloadCallback 执行重载回调
+func (hr *HotReloader) runReloadCallback() {
 	if hr.onReload != nil {
 		if err := hr.onReload(); err != nil {
 			if hr.onError != nil {
@@ -343,10 +436,11 @@ func (hrs *HotReloadServer) Stop() error {
 // DefaultHotReloadConfig 默认热重载配置
 func DefaultHotReloadConfig() HotReloadConfig {
 	return HotReloadConfig{
-		WatchDirs:   []string{".", "controllers", "views", "static"},
-		ExcludeDirs: []string{"logs", "tmp", ".git", "node_modules", "vendor", "docs"},
-		Extensions:  []string{".go", ".html", ".css", ".js", ".yaml", ".yml", ".json"},
-		Debounce:    500 * time.Millisecond,
+		WatchDirs:          []string{".", "controllers", "views", "static"},
+		ExcludeDirs:        []string{"logs", "tmp", ".git", "node_modules", "vendor", "docs"},
+		Extensions:         []string{".go", ".html", ".tmpl", ".tpl", ".css", ".js", ".yaml", ".yml", ".json"},
+		TemplateExtensions: []string{".html", ".tmpl", ".tpl"},
+		Debounce:           500 * time.Millisecond,
 		OnReload: func() error {
 			log.Println("执行重载操作...")
 			return nil
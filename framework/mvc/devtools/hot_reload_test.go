@@ -0,0 +1,208 @@
+package devtools
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCache 记录清除次数的缓存
+type countingCache struct {
+	cleared atomic.Int32
+}
+
+func (c *countingCache) ClearCache() {
+	c.cleared.Add(1)
+}
+
+// recordingClient 记录收到消息的浏览器连接
+type recordingClient struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (r *recordingClient) Send(message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *recordingClient) Close() error { return nil }
+
+func (r *recordingClient) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.messages...)
+}
+
+// startTestReloader 监控临时目录，返回热重载器与已连接的浏览器
+func startTestReloader(t *testing.T, dir string, templateCache, staticCache CacheInvalidator) (*HotReloader, *recordingClient) {
+	t.Helper()
+	hub := NewLiveReloadHub("")
+	client := &recordingClient{}
+	hub.add(client)
+
+	hr, err := NewHotReloader(nil, HotReloadConfig{
+		WatchDirs:      []string{dir},
+		ExcludeDirs:    []string{"node_modules"},
+		TemplateCaches: []CacheInvalidator{templateCache},
+		StaticCaches:   []CacheInvalidator{staticCache},
+		LiveReload:     hub,
+		Debounce:       20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, hr.Start())
+	t.Cleanup(func() { hr.Stop() })
+	return hr, client
+}
+
+func TestHotReloader_TemplateChangeInvalidatesCache(t *testing.T) {
+	dir := t.TempDir()
+	views := filepath.Join(dir, "views")
+	require.NoError(t, os.MkdirAll(views, 0o755))
+	template := filepath.Join(views, "index.html")
+	require.NoError(t, os.WriteFile(template, []byte("<p>old</p>"), 0o644))
+
+	templateCache, staticCache := &countingCache{}, &countingCache{}
+	hr, client := startTestReloader(t, dir, templateCache, staticCache)
+
+	require.NoError(t, os.WriteFile(template, []byte("<p>new</p>"), 0o644))
+
+	assert.Eventually(t, func() bool { return templateCache.cleared.Load() > 0 }, 2*time.Second, 10*time.Millisecond,
+		"template cache should be cleared after a watched template changes")
+	assert.Eventually(t, func() bool {
+		return len(client.received()) > 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{LiveReloadMessageReload}, client.received())
+	assert.Zero(t, staticCache.cleared.Load(), "static cache should not be cleared by template changes")
+
+	select {
+	case <-hr.RestartChannel():
+		t.Fatal("template change should not emit a rebuild signal")
+	default:
+	}
+}
+
+func TestHotReloader_SourceChangeEmitsRebuild(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(source, []byte("package main\n"), 0o644))
+
+	templateCache, staticCache := &countingCache{}, &countingCache{}
+	hr, client := startTestReloader(t, dir, templateCache, staticCache)
+
+	require.NoError(t, os.WriteFile(source, []byte("package main\n\nfunc main() {}\n"), 0o644))
+
+	select {
+	case <-hr.RestartChannel():
+	case <-time.After(2 * time.Second):
+		t.Fatal("source change should emit a rebuild signal")
+	}
+	assert.Equal(t, []string{LiveReloadMessageRebuild}, client.received())
+	assert.Zero(t, templateCache.cleared.Load())
+}
+
+func TestHotReloader_ClassifyChange(t *testing.T) {
+	hr, err := NewHotReloader(nil, HotReloadConfig{})
+	require.NoError(t, err)
+	defer hr.watcher.Close()
+
+	assert.Equal(t, ChangeSource, hr.classifyChange("controllers/user.go"))
+	assert.Equal(t, ChangeSource, hr.classifyChange("conf/app.yaml"))
+	assert.Equal(t, ChangeTemplate, hr.classifyChange("views/user/index.HTML"))
+	assert.Equal(t, ChangeTemplate, hr.classifyChange("views/layout.tmpl"))
+	assert.Equal(t, ChangeStyle, hr.classifyChange("static/css/site.css"))
+	assert.Equal(t, ChangeStatic, hr.classifyChange("static/js/app.js"))
+}
+
+func TestLiveReloadHub_InjectMiddleware(t *testing.T) {
+	hub := NewLiveReloadHub("/__lr")
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.Use(hub.InjectMiddleware())
+	engine.GET("/page", func(c context.Context, ctx *app.RequestContext) {
+		ctx.Data(200, "text/html; charset=utf-8", []byte("<html><body><p>hi</p></body></html>"))
+	})
+	engine.GET("/api", func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(200, map[string]string{"body": "</body>"})
+	})
+
+	page := string(ut.PerformRequest(engine, "GET", "/page", nil).Result().Body())
+	assert.Contains(t, page, `"/__lr"`)
+	assert.True(t, strings.HasSuffix(page, "</script></body></html>"), "snippet should be injected before </body>")
+
+	api := string(ut.PerformRequest(engine, "GET", "/api", nil).Result().Body())
+	assert.NotContains(t, api, "<script>", "non-HTML responses should not be modified")
+}
+
+func TestLiveReloadHub_Handshake(t *testing.T) {
+	hub := NewLiveReloadHub("")
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.GET(hub.Path(), hub.Handler())
+
+	resp := ut.PerformRequest(engine, "GET", DefaultLiveReloadPath, nil).Result()
+	assert.Equal(t, 400, resp.StatusCode(), "plain HTTP requests should be rejected")
+}
+
+func TestLiveReloadHub_BroadcastOverWebSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	hub := NewLiveReloadHub("")
+	h := server.New(server.WithHostPorts(addr))
+	h.GET(hub.Path(), hub.Handler())
+	go h.Spin()
+	t.Cleanup(func() { _ = h.Close() })
+
+	var conn *gorillaws.Conn
+	require.Eventually(t, func() bool {
+		conn, _, err = gorillaws.DefaultDialer.Dial("ws://"+addr+DefaultLiveReloadPath, nil)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond, "websocket handshake failed")
+	defer conn.Close()
+	require.Eventually(t, func() bool { return hub.ClientCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 1, hub.Broadcast(LiveReloadMessageReload))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	messageType, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, gorillaws.TextMessage, messageType)
+	assert.Equal(t, LiveReloadMessageReload, string(message))
+
+	// 浏览器发送的ping由服务端响应pong
+	pong := make(chan string, 1)
+	conn.SetPongHandler(func(data string) error {
+		pong <- data
+		return nil
+	})
+	require.NoError(t, conn.WriteControl(gorillaws.PingMessage, []byte("hi"), time.Now().Add(time.Second)))
+	go conn.ReadMessage()
+	select {
+	case data := <-pong:
+		assert.Equal(t, "hi", data)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected pong from server")
+	}
+
+	// 浏览器断开后移除连接
+	require.NoError(t, conn.WriteControl(gorillaws.CloseMessage,
+		gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, ""), time.Now().Add(time.Second)))
+	require.Eventually(t, func() bool { return hub.ClientCount() == 0 }, 2*time.Second, 10*time.Millisecond)
+}
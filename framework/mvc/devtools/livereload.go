@@ -0,0 +1,182 @@
+package devtools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/hertz-contrib/websocket"
+)
+
+// DefaultLiveReloadPath 浏览器自动刷新的WebSocket路径
+const DefaultLiveReloadPath = "/__livereload"
+
+// 推送给浏览器的消息
+const (
+	LiveReloadMessageReload  = "reload"  // 模板或静态文件变化，刷新页面
+	LiveReloadMessageCSS     = "css"     // 样式表变化，仅刷新样式
+	LiveReloadMessageRebuild = "rebuild" // Go源码变化，等待重新编译后刷新
+)
+
+// maxWSMessageSize 浏览器发来的单条消息最大长度
+const maxWSMessageSize = 64 << 10
+
+// liveReloadUpgrader WebSocket握手升级器
+var liveReloadUpgrader = websocket.HertzUpgrader{}
+
+// liveReloadClient 已连接的浏览器
+type liveReloadClient interface {
+	Send(message string) error
+	Close() error
+}
+
+// LiveReloadHub 浏览器自动刷新中心，通过WebSocket向已连接的页面推送刷新信号
+type LiveReloadHub struct {
+	path    string
+	mu      sync.Mutex
+	clients map[liveReloadClient]struct{}
+}
+
+// NewLiveReloadHub 创建自动刷新中心，path为空时使用 DefaultLiveReloadPath
+func NewLiveReloadHub(path string) *LiveReloadHub {
+	if path == "" {
+		path = DefaultLiveReloadPath
+	}
+	return &LiveReloadHub{
+		path:    path,
+		clients: make(map[liveReloadClient]struct{}),
+	}
+}
+
+// Path 获取WebSocket路径
+func (h *LiveReloadHub) Path() string {
+	return h.path
+}
+
+// ClientCount 获取已连接的浏览器数量
+func (h *LiveReloadHub) ClientCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Broadcast 向所有已连接的浏览器推送消息，发送失败的连接将被移除，返回成功发送的数量
+func (h *LiveReloadHub) Broadcast(message string) int {
+	h.mu.Lock()
+	clients := make([]liveReloadClient, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	sent := 0
+	for _, client := range clients {
+		if err := client.Send(message); err != nil {
+			h.remove(client)
+			client.Close()
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// add 登记连接
+func (h *LiveReloadHub) add(client liveReloadClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[client] = struct{}{}
+}
+
+// remove 移除连接
+func (h *LiveReloadHub) remove(client liveReloadClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, client)
+}
+
+// Handler WebSocket握手处理函数，握手成功后接管连接直到浏览器断开；非WebSocket请求由升级器返回400
+func (h *LiveReloadHub) Handler() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		_ = liveReloadUpgrader.Upgrade(c, func(conn *websocket.Conn) {
+			client := &wsClient{conn: conn}
+			h.add(client)
+			defer h.remove(client)
+			client.readLoop()
+		})
+	}
+}
+
+// Snippet 注入页面的自动刷新脚本
+func (h *LiveReloadHub) Snippet() string {
+	return fmt.Sprintf(`<script>(function(){
+var url=(location.protocol==="https:"?"wss://":"ws://")+location.host+%q,pending=false;
+function connect(){
+var ws=new WebSocket(url);
+ws.onopen=function(){if(pending){location.reload();}};
+ws.onmessage=function(e){
+if(e.data===%q){document.querySelectorAll('link[rel="stylesheet"]').forEach(function(l){var u=new URL(l.href);u.searchParams.set("_lr",Date.now());l.href=u.toString();});}
+else if(e.data===%q){pending=true;}
+else{location.reload();}
+};
+ws.onclose=function(){setTimeout(connect,1000);};
+}
+connect();
+})();</script>`, h.path, LiveReloadMessageCSS, LiveReloadMessageRebuild)
+}
+
+// InjectMiddleware 在HTML响应的 </body> 之前注入自动刷新脚本
+func (h *LiveReloadHub) InjectMiddleware() app.HandlerFunc {
+	closingTag := []byte("</body>")
+	return func(ctx context.Context, c *app.RequestContext) {
+		c.Next(ctx)
+
+		if !bytes.HasPrefix(c.Response.Header.ContentType(), []byte("text/html")) {
+			return
+		}
+		body := c.Response.Body()
+		idx := bytes.LastIndex(body, closingTag)
+		if idx < 0 {
+			return
+		}
+
+		injected := make([]byte, 0, len(body)+len(h.Snippet()))
+		injected = append(injected, body[:idx]...)
+		injected = append(injected, h.Snippet()...)
+		injected = append(injected, body[idx:]...)
+		c.Response.SetBody(injected)
+	}
+}
+
+// wsClient 已建立的WebSocket连接，仅发送文本消息
+type wsClient struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+// Send 发送文本消息
+func (w *wsClient) Send(message string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+// Close 发送关闭帧并关闭连接
+func (w *wsClient) Close() error {
+	w.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return w.conn.Close()
+}
+
+// readLoop 读取浏览器发来的消息（忽略内容），ping与关闭帧由连接自动响应，连接断开时返回
+func (w *wsClient) readLoop() {
+	w.conn.SetReadLimit(maxWSMessageSize)
+	for {
+		if _, _, err := w.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
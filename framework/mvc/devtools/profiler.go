@@ -0,0 +1,181 @@
+package devtools
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
+	"github.com/cloudwego/hertz/pkg/route"
+
+	"github.com/zsy619/yyhertz/framework/config"
+	"github.com/zsy619/yyhertz/framework/mvc/controller"
+)
+
+const (
+	// DefaultPprofPrefix pprof端点默认前缀
+	DefaultPprofPrefix = "/debug/pprof"
+	// DefaultStatsPath 运行时统计端点默认路径
+	DefaultStatsPath = "/debug/stats"
+)
+
+// pprofProfiles 通过pprof.Handler暴露的命名profile
+var pprofProfiles = []string{"allocs", "block", "goroutine", "heap", "mutex", "threadcreate"}
+
+// ProfilerConfig 性能分析端点配置
+type ProfilerConfig struct {
+	Enabled           bool                                   // 是否启用，关闭时不注册任何路由
+	PprofPrefix       string                                 // pprof端点前缀
+	StatsPath         string                                 // 运行时统计端点路径
+	ControllerManager *controller.OptimizedControllerManager // 提供控制器性能统计，可为空
+}
+
+// DefaultProfilerConfig 默认性能分析配置，读取mvc配置中的performance.enable_profiler
+func DefaultProfilerConfig() *ProfilerConfig {
+	cfg := &ProfilerConfig{
+		PprofPrefix: DefaultPprofPrefix,
+		StatsPath:   DefaultStatsPath,
+	}
+
+	if mvcConfig, err := config.GetMVCConfig(); err == nil && mvcConfig != nil {
+		cfg.Enabled = mvcConfig.Performance.EnableProfiler
+		if mvcConfig.Performance.ProfilerEndpoint != "" {
+			cfg.PprofPrefix = mvcConfig.Performance.ProfilerEndpoint
+		}
+	}
+
+	return cfg
+}
+
+// ProfilerPanel pprof与运行时统计端点
+type ProfilerPanel struct {
+	config *ProfilerConfig
+}
+
+// NewProfilerPanel 创建性能分析面板
+func NewProfilerPanel(cfg *ProfilerConfig) *ProfilerPanel {
+	if cfg == nil {
+		cfg = DefaultProfilerConfig()
+	}
+	if cfg.PprofPrefix == "" {
+		cfg.PprofPrefix = DefaultPprofPrefix
+	}
+	if cfg.StatsPath == "" {
+		cfg.StatsPath = DefaultStatsPath
+	}
+	cfg.PprofPrefix = strings.TrimRight(cfg.PprofPrefix, "/")
+
+	return &ProfilerPanel{config: cfg}
+}
+
+// Enabled 是否启用
+func (pp *ProfilerPanel) Enabled() bool {
+	return pp.config.Enabled
+}
+
+// RegisterRoutes 注册pprof与运行时统计路由，未启用时不注册
+func (pp *ProfilerPanel) RegisterRoutes(engine any) {
+	if !pp.config.Enabled {
+		return
+	}
+
+	h, ok := engine.(*route.Engine)
+	if !ok {
+		log.Println("无法注册性能分析路由，未知引擎类型")
+		return
+	}
+
+	prefix := pp.config.PprofPrefix
+	h.GET(prefix+"/", pprofHandler(http.HandlerFunc(pprof.Index)))
+	h.GET(prefix+"/cmdline", pprofHandler(http.HandlerFunc(pprof.Cmdline)))
+	h.GET(prefix+"/profile", pprofHandler(http.HandlerFunc(pprof.Profile)))
+	h.GET(prefix+"/symbol", pprofHandler(http.HandlerFunc(pprof.Symbol)))
+	h.POST(prefix+"/symbol", pprofHandler(http.HandlerFunc(pprof.Symbol)))
+	h.GET(prefix+"/trace", pprofHandler(http.HandlerFunc(pprof.Trace)))
+
+	// pprof.Index按固定的/debug/pprof/前缀解析profile名称，自定义前缀下需逐个注册
+	for _, name := range pprofProfiles {
+		h.GET(prefix+"/"+name, pprofHandler(pprof.Handler(name)))
+	}
+
+	h.GET(pp.config.StatsPath, pp.getStats)
+}
+
+// pprofHandler 将net/http的pprof处理器适配为Hertz处理函数
+// 输出写入缓冲的响应体而非直接写连接，以便经过中间件并支持ut测试
+func pprofHandler(h http.Handler) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		req, err := adaptor.GetCompatRequest(&c.Request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		h.ServeHTTP(adaptor.GetCompatResponseWriter(&c.Response), req.WithContext(ctx))
+	}
+}
+
+// getStats 获取运行时统计
+func (pp *ProfilerPanel) getStats(ctx context.Context, c *app.RequestContext) {
+	c.JSON(http.StatusOK, map[string]any{
+		"success": true,
+		"data":    pp.RuntimeStats(),
+	})
+}
+
+// RuntimeStats 收集协程、内存、GC及控制器性能统计
+func (pp *ProfilerPanel) RuntimeStats() map[string]any {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	var lastGC time.Time
+	if m.LastGC > 0 {
+		lastGC = time.Unix(0, int64(m.LastGC))
+	}
+
+	stats := map[string]any{
+		"timestamp":  time.Now(),
+		"goroutines": runtime.NumGoroutine(),
+		"num_cpu":    runtime.NumCPU(),
+		"go_version": runtime.Version(),
+		"memory": map[string]any{
+			"alloc":        m.Alloc,
+			"total_alloc":  m.TotalAlloc,
+			"sys":          m.Sys,
+			"heap_alloc":   m.HeapAlloc,
+			"heap_sys":     m.HeapSys,
+			"heap_inuse":   m.HeapInuse,
+			"heap_objects": m.HeapObjects,
+			"stack_inuse":  m.StackInuse,
+			"mallocs":      m.Mallocs,
+			"frees":        m.Frees,
+		},
+		"gc": map[string]any{
+			"num_gc":          m.NumGC,
+			"num_forced_gc":   m.NumForcedGC,
+			"pause_total_ns":  m.PauseTotalNs,
+			"last_pause_ns":   m.PauseNs[(m.NumGC+255)%256],
+			"last_gc":         lastGC,
+			"next_gc":         m.NextGC,
+			"gc_cpu_fraction": m.GCCPUFraction,
+		},
+	}
+
+	if pp.config.ControllerManager != nil {
+		perf := pp.config.ControllerManager.GetStats()
+		stats["performance"] = map[string]any{
+			"total_requests":        perf.TotalRequests,
+			"average_response_time": perf.AverageResponseTime.String(),
+			"cache_hit_rate":        perf.CacheHitRate,
+			"compilation_time":      perf.CompilationTime.String(),
+			"controller_instances":  perf.ControllerInstances,
+			"active_connections":    perf.ActiveConnections,
+		}
+	}
+
+	return stats
+}
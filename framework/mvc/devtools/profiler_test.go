@@ -0,0 +1,93 @@
+package devtools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/mvc/controller"
+)
+
+// routePaths 返回引擎已注册的路由路径
+func routePaths(engine *route.Engine) map[string]bool {
+	paths := make(map[string]bool)
+	for _, r := range engine.Routes() {
+		paths[r.Method+" "+r.Path] = true
+	}
+	return paths
+}
+
+func TestProfilerPanel_DisabledRegistersNothing(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	NewProfilerPanel(&ProfilerConfig{Enabled: false}).RegisterRoutes(engine)
+
+	assert.Empty(t, engine.Routes())
+
+	w := ut.PerformRequest(engine, http.MethodGet, DefaultStatsPath, nil)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode())
+	w = ut.PerformRequest(engine, http.MethodGet, DefaultPprofPrefix+"/heap", nil)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode())
+}
+
+func TestProfilerPanel_EnabledMountsEndpoints(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	NewProfilerPanel(&ProfilerConfig{Enabled: true}).RegisterRoutes(engine)
+
+	paths := routePaths(engine)
+	for _, p := range []string{"/", "/cmdline", "/profile", "/symbol", "/trace", "/heap", "/goroutine", "/allocs"} {
+		assert.True(t, paths["GET "+DefaultPprofPrefix+p], "missing pprof route %s", p)
+	}
+	assert.True(t, paths["POST "+DefaultPprofPrefix+"/symbol"])
+	assert.True(t, paths["GET "+DefaultStatsPath])
+
+	w := ut.PerformRequest(engine, http.MethodGet, DefaultPprofPrefix+"/goroutine?debug=1", nil)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode())
+	assert.Contains(t, string(w.Result().Body()), "goroutine profile")
+
+	w = ut.PerformRequest(engine, http.MethodGet, DefaultStatsPath, nil)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode())
+
+	var resp struct {
+		Success bool           `json:"success"`
+		Data    map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Result().Body(), &resp))
+	assert.True(t, resp.Success)
+	assert.Greater(t, resp.Data["goroutines"], float64(0))
+	assert.Contains(t, resp.Data, "memory")
+	assert.Contains(t, resp.Data, "gc")
+	assert.NotContains(t, resp.Data, "performance")
+}
+
+func TestProfilerPanel_CustomPathsAndControllerStats(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	NewProfilerPanel(&ProfilerConfig{
+		Enabled:           true,
+		PprofPrefix:       "/_dev/pprof/",
+		StatsPath:         "/_dev/stats",
+		ControllerManager: controller.NewOptimizedControllerManager(nil),
+	}).RegisterRoutes(engine)
+
+	w := ut.PerformRequest(engine, http.MethodGet, "/_dev/pprof/heap?debug=1", nil)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode())
+	w = ut.PerformRequest(engine, http.MethodGet, DefaultStatsPath, nil)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode())
+
+	w = ut.PerformRequest(engine, http.MethodGet, "/_dev/stats", nil)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode())
+
+	var resp struct {
+		Data struct {
+			Performance map[string]any `json:"performance"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Result().Body(), &resp))
+	assert.Contains(t, resp.Data.Performance, "total_requests")
+	assert.Contains(t, resp.Data.Performance, "average_response_time")
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -312,7 +313,7 @@ type BusinessErrorHandler struct{}
 
 func (h *BusinessErrorHandler) Handle(ctx *mvccontext.Context, err error) error {
 	if errNo, ok := err.(*errors.ErrNo); ok {
-		ctx.JSON(400, map[string]interface{}{
+		ctx.RenderFormat(400, map[string]interface{}{
 			"code":    errNo.ErrCode,
 			"message": errNo.ErrMsg,
 			"success": false,
@@ -331,11 +332,32 @@ func (h *BusinessErrorHandler) Priority() int {
 	return 100 // 高优先级
 }
 
+// NotFoundErrorHandler 资源不存在错误处理器
+// 处理包装了 errors.DataNotFound 的错误（如 mybatis.ErrNotFound），统一返回404
+type NotFoundErrorHandler struct{}
+
+func (h *NotFoundErrorHandler) Handle(ctx *mvccontext.Context, err error) error {
+	ctx.RenderFormat(404, map[string]interface{}{
+		"code":    errors.DataNotFound.ErrCode,
+		"message": errors.DataNotFound.ErrMsg,
+		"success": false,
+	})
+	return nil
+}
+
+func (h *NotFoundErrorHandler) CanHandle(err error) bool {
+	return stderrors.Is(err, errors.DataNotFound)
+}
+
+func (h *NotFoundErrorHandler) Priority() int {
+	return 110 // 高于业务错误处理器
+}
+
 // SystemErrorHandler 系统错误处理器
 type SystemErrorHandler struct{}
 
 func (h *SystemErrorHandler) Handle(ctx *mvccontext.Context, err error) error {
-	ctx.JSON(500, map[string]interface{}{
+	ctx.RenderFormat(500, map[string]interface{}{
 		"code":    500,
 		"message": "Internal Server Error",
 		"success": false,
@@ -354,8 +376,9 @@ func (h *SystemErrorHandler) Priority() int {
 }
 
 // DefaultFallbackHandler 默认兜底处理器
+// 响应按请求协商得到的格式输出（见 Context.ResponseFormat），未协商时为JSON
 func DefaultFallbackHandler(ctx *mvccontext.Context, err error) error {
-	ctx.JSON(500, map[string]interface{}{
+	ctx.RenderFormat(500, map[string]interface{}{
 		"code":    500,
 		"message": "Unknown Error",
 		"error":   err.Error(),
@@ -369,6 +392,7 @@ var globalDispatcher = NewErrorDispatcher()
 
 func init() {
 	// 注册内置错误处理器
+	globalDispatcher.RegisterHandler(&NotFoundErrorHandler{})
 	globalDispatcher.RegisterHandler(&BusinessErrorHandler{})
 	globalDispatcher.RegisterHandler(&SystemErrorHandler{})
 }
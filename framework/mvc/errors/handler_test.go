@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	frameworkErrors "github.com/zsy619/yyhertz/framework/errors"
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+func TestErrorHandler_UsesNegotiatedFormat(t *testing.T) {
+	hertzCtx := ut.CreateUtRequestContext("GET", "/api/users", nil,
+		ut.Header{Key: "Accept", Value: "application/xml;q=0.9, application/json;q=0.5"})
+
+	// 处理器内完成内容协商
+	handlerCtx := mvccontext.NewContext(hertzCtx)
+	format := handlerCtx.NegotiateFormat(mvccontext.MIMEJSON, mvccontext.MIMEXML)
+	require.Equal(t, mvccontext.MIMEXML, format)
+	assert.Equal(t, mvccontext.MIMEXML, handlerCtx.ResponseFormat())
+
+	// 下游错误处理器使用同一请求的其他Context包装，仍能读取协商结果
+	errorCtx := mvccontext.NewContext(hertzCtx)
+	assert.Equal(t, mvccontext.MIMEXML, errorCtx.ResponseFormat())
+
+	handler := &SystemErrorHandler{}
+	require.NoError(t, handler.Handle(errorCtx, errors.New("boom")))
+
+	assert.Equal(t, 500, hertzCtx.Response.StatusCode())
+	assert.True(t, strings.HasPrefix(string(hertzCtx.Response.Header.ContentType()), "application/xml"))
+	body := string(hertzCtx.Response.Body())
+	assert.Contains(t, body, "<response>")
+	assert.Contains(t, body, "<message>Internal Server Error</message>")
+}
+
+func TestErrorHandler_DefaultsToJSON(t *testing.T) {
+	hertzCtx := ut.CreateUtRequestContext("GET", "/api/users", nil)
+	ctx := mvccontext.NewContext(hertzCtx)
+	assert.Equal(t, "", ctx.ResponseFormat())
+
+	require.NoError(t, DefaultFallbackHandler(ctx, errors.New("boom")))
+
+	assert.True(t, strings.HasPrefix(string(hertzCtx.Response.Header.ContentType()), "application/json"))
+	assert.Contains(t, string(hertzCtx.Response.Body()), `"error":"boom"`)
+}
+
+func TestErrorDispatcher_MapsNotFoundTo404(t *testing.T) {
+	hertzCtx := ut.CreateUtRequestContext("GET", "/api/users/999", nil)
+	ctx := mvccontext.NewContext(hertzCtx)
+
+	// 数据层包装的资源不存在错误统一映射为404
+	err := fmt.Errorf("load user 999: %w", frameworkErrors.DataNotFound)
+	require.NoError(t, DispatchError(ctx, err))
+
+	assert.Equal(t, 404, hertzCtx.Response.StatusCode())
+	assert.Contains(t, string(hertzCtx.Response.Body()), `"message":"Data not found"`)
+
+	// 其他错误不受影响
+	other := ut.CreateUtRequestContext("GET", "/api/users/1", nil)
+	require.NoError(t, DispatchError(mvccontext.NewContext(other), errors.New("boom")))
+	assert.Equal(t, 500, other.Response.StatusCode())
+}
@@ -0,0 +1,243 @@
+package mvc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MapTag 字段映射标签
+// 目标字段使用 `map:"SourceField"` 指定来源字段名，任一侧使用 `map:"-"` 表示忽略该字段
+const MapTag = "map"
+
+// FieldConverter 字段转换函数
+// src为同名（或按标签匹配）的来源字段值；来源结构体中不存在对应字段时为整个来源结构体
+type FieldConverter func(src any) (any, error)
+
+// MapOption 映射选项
+type MapOption func(*mapOptions)
+
+// mapOptions 映射选项集合
+type mapOptions struct {
+	converters map[string]FieldConverter // 目标字段名 -> 转换函数
+	ignored    map[string]bool           // 忽略的目标字段名
+}
+
+// newMapOptions 创建空的映射选项
+func newMapOptions() *mapOptions {
+	return &mapOptions{
+		converters: make(map[string]FieldConverter),
+		ignored:    make(map[string]bool),
+	}
+}
+
+// WithConverter 为目标字段指定转换函数
+func WithConverter(dstField string, converter FieldConverter) MapOption {
+	return func(o *mapOptions) {
+		o.converters[dstField] = converter
+	}
+}
+
+// WithIgnore 忽略指定的目标字段
+func WithIgnore(dstFields ...string) MapOption {
+	return func(o *mapOptions) {
+		for _, field := range dstFields {
+			o.ignored[field] = true
+		}
+	}
+}
+
+// MapStruct 将src的字段按名称（或map标签）复制到dst，dst必须为结构体指针
+// 类型相同或可赋值的字段直接复制；数值之间、字符串类型之间按类型转换；
+// 指针与值之间自动解引用或分配；嵌套结构体与结构体切片递归映射。
+// 目标字段在来源中不存在时保持不变，类型无法转换时返回错误
+func MapStruct(dst, src any, opts ...MapOption) error {
+	options := newMapOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return errors.New("mvc: MapStruct destination must be a non-nil struct pointer")
+	}
+
+	srcValue := reflect.ValueOf(src)
+	for srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return nil
+		}
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Struct {
+		return fmt.Errorf("mvc: MapStruct source must be a struct, got %s", srcValue.Kind())
+	}
+
+	return mapStructValue(dstValue.Elem(), srcValue, options)
+}
+
+// MapTo 将src映射为新的T实例
+func MapTo[T any](src any, opts ...MapOption) (T, error) {
+	var dst T
+	err := MapStruct(&dst, src, opts...)
+	return dst, err
+}
+
+// MapSlice 将来源切片逐个映射为T切片
+func MapSlice[T any, S any](src []S, opts ...MapOption) ([]T, error) {
+	result := make([]T, 0, len(src))
+	for i, item := range src {
+		dst, err := MapTo[T](item, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("mvc: map element %d: %w", i, err)
+		}
+		result = append(result, dst)
+	}
+	return result, nil
+}
+
+// mapStructValue 映射结构体值，匿名嵌入的结构体字段会被展开
+func mapStructValue(dst, src reflect.Value, options *mapOptions) error {
+	dstType := dst.Type()
+	for i := 0; i < dstType.NumField(); i++ {
+		field := dstType.Field(i)
+		fieldValue := dst.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := mapStructValue(fieldValue, src, options); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() || options.ignored[field.Name] {
+			continue
+		}
+
+		tag := field.Tag.Get(MapTag)
+		if tag == "-" {
+			continue
+		}
+		sourceName := field.Name
+		if tag != "" {
+			sourceName = tag
+		}
+
+		sourceField, found := findSourceField(src, sourceName)
+
+		if converter, ok := options.converters[field.Name]; ok {
+			input := src.Interface()
+			if found {
+				input = sourceField.Interface()
+			}
+			converted, err := converter(input)
+			if err != nil {
+				return fmt.Errorf("mvc: convert field %s: %w", field.Name, err)
+			}
+			if converted == nil {
+				continue
+			}
+			if err := assignValue(fieldValue, reflect.ValueOf(converted)); err != nil {
+				return fmt.Errorf("mvc: map field %s: %w", field.Name, err)
+			}
+			continue
+		}
+
+		if !found {
+			continue
+		}
+		if err := assignValue(fieldValue, sourceField); err != nil {
+			return fmt.Errorf("mvc: map field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// findSourceField 按名称查找来源字段（先精确匹配，再忽略大小写），忽略标记为 map:"-" 的字段
+func findSourceField(src reflect.Value, name string) (reflect.Value, bool) {
+	srcType := src.Type()
+	field, ok := srcType.FieldByName(name)
+	if !ok {
+		lower := strings.ToLower(name)
+		field, ok = srcType.FieldByNameFunc(func(candidate string) bool {
+			return strings.ToLower(candidate) == lower
+		})
+	}
+	if !ok || !field.IsExported() || field.Tag.Get(MapTag) == "-" {
+		return reflect.Value{}, false
+	}
+
+	value, err := src.FieldByIndexErr(field.Index)
+	if err != nil {
+		// 经过nil嵌入指针的字段视为不存在
+		return reflect.Value{}, false
+	}
+	return value, true
+}
+
+// assignValue 将来源值赋给目标字段
+func assignValue(dst, src reflect.Value) error {
+	// 来源为指针时解引用，nil指针保持目标不变
+	for src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return nil
+		}
+		if src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		src = src.Elem()
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Struct:
+		if src.Kind() == reflect.Struct {
+			return mapStructValue(dst, src, newMapOptions())
+		}
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := assignValue(slice.Index(i), src.Index(i)); err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+	}
+
+	if convertibleKinds(src.Kind(), dst.Kind()) && src.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", src.Type(), dst.Type())
+}
+
+// convertibleKinds 是否允许按类型转换（避免整数被转换为单个字符的字符串）
+func convertibleKinds(src, dst reflect.Kind) bool {
+	return (isNumberKind(src) && isNumberKind(dst)) || (src == reflect.String && dst == reflect.String) || src == dst
+}
+
+// isNumberKind 是否为数值类型
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package mvc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapTestModel struct {
+	ID        uint
+	CreatedAt time.Time
+}
+
+type mapTestProfile struct {
+	City string
+}
+
+type mapTestUser struct {
+	mapTestModel
+	Username string
+	Email    string
+	Password string `map:"-"`
+	Age      int32
+	Status   int
+	Profile  *mapTestProfile
+	Tags     []string
+}
+
+type mapTestAddress struct {
+	City string `json:"city"`
+}
+
+type mapTestUserResponse struct {
+	ID        int64           `json:"id"`
+	Name      string          `json:"name" map:"Username"`
+	Email     string          `json:"email"`
+	Password  string          `json:"password,omitempty"`
+	Age       int             `json:"age"`
+	Status    string          `json:"status"`
+	Internal  string          `json:"-" map:"-"`
+	Profile   *mapTestAddress `json:"profile"`
+	Tags      []string        `json:"tags"`
+	CreatedAt string          `json:"created_at"`
+}
+
+func newMapTestUser() *mapTestUser {
+	return &mapTestUser{
+		mapTestModel: mapTestModel{ID: 7, CreatedAt: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)},
+		Username:     "alice",
+		Email:        "alice@example.com",
+		Password:     "secret",
+		Age:          30,
+		Status:       1,
+		Profile:      &mapTestProfile{City: "Hangzhou"},
+		Tags:         []string{"admin"},
+	}
+}
+
+func TestMapStruct_UserToResponse(t *testing.T) {
+	statusNames := map[int]string{0: "inactive", 1: "active"}
+
+	resp := mapTestUserResponse{Internal: "keep"}
+	err := MapStruct(&resp, newMapTestUser(),
+		WithConverter("Status", func(src any) (any, error) {
+			return statusNames[src.(int)], nil
+		}),
+		WithConverter("CreatedAt", func(src any) (any, error) {
+			return src.(time.Time).Format("2006-01-02"), nil
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), resp.ID, "嵌入结构体的字段应被展开并转换数值类型")
+	assert.Equal(t, "alice", resp.Name, "map标签指定的来源字段")
+	assert.Equal(t, "alice@example.com", resp.Email)
+	assert.Empty(t, resp.Password, "来源标记为忽略的字段不应被复制")
+	assert.Equal(t, "keep", resp.Internal, "目标标记为忽略的字段保持不变")
+	assert.Equal(t, 30, resp.Age)
+	assert.Equal(t, "active", resp.Status)
+	assert.Equal(t, "2024-05-01", resp.CreatedAt)
+	require.NotNil(t, resp.Profile)
+	assert.Equal(t, "Hangzhou", resp.Profile.City)
+	assert.Equal(t, []string{"admin"}, resp.Tags)
+}
+
+func TestMapStruct_IgnoreAndErrors(t *testing.T) {
+	resp, err := MapTo[mapTestUserResponse](newMapTestUser(),
+		WithIgnore("Email", "Status", "CreatedAt"))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Email)
+	assert.Equal(t, "alice", resp.Name)
+
+	// 类型无法转换时返回错误
+	_, err = MapTo[mapTestUserResponse](newMapTestUser(), WithIgnore("CreatedAt"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Status")
+
+	// 转换函数的错误原样包装返回
+	errConvert := errors.New("bad status")
+	_, err = MapTo[mapTestUserResponse](newMapTestUser(),
+		WithIgnore("CreatedAt"),
+		WithConverter("Status", func(any) (any, error) { return nil, errConvert }))
+	assert.ErrorIs(t, err, errConvert)
+
+	assert.Error(t, MapStruct(mapTestUserResponse{}, newMapTestUser()), "目标必须为结构体指针")
+}
+
+func TestMapSlice(t *testing.T) {
+	users := []*mapTestUser{newMapTestUser(), newMapTestUser()}
+	users[1].Username = "bob"
+
+	responses, err := MapSlice[mapTestUserResponse](users, WithIgnore("Status", "CreatedAt"))
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "alice", responses[0].Name)
+	assert.Equal(t, "bob", responses[1].Name)
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// BodyLimitConfig 请求体大小限制配置
+type BodyLimitConfig struct {
+	MaxBytes int64            // 默认最大请求体字节数，<=0表示不限制
+	Routes   map[string]int64 // 按路由前缀覆盖的限制（最长前缀优先），<=0表示该前缀不限制
+}
+
+// BodyLimitMiddleware 请求体大小限制中间件 - 超过maxBytes的请求在进入控制器前返回413
+func BodyLimitMiddleware(maxBytes int64) Middleware {
+	return BodyLimitMiddlewareWithConfig(BodyLimitConfig{MaxBytes: maxBytes})
+}
+
+// BodyLimitMiddlewareWithConfig 带路由级配置的请求体大小限制中间件
+func BodyLimitMiddlewareWithConfig(cfg BodyLimitConfig) Middleware {
+	// 路由前缀按长度降序排列，便于最长前缀匹配
+	prefixes := make([]string, 0, len(cfg.Routes))
+	for prefix := range cfg.Routes {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		limit := cfg.MaxBytes
+		path := string(ctx.Path())
+		for _, prefix := range prefixes {
+			if pathHasPrefix(path, prefix) {
+				limit = cfg.Routes[prefix]
+				break
+			}
+		}
+
+		if limit > 0 && bodyExceedsLimit(ctx, limit) {
+			method := string(ctx.Method())
+			go func() {
+				config.WithFields(map[string]any{
+					"event":     "body_limit_exceeded",
+					"path":      path,
+					"method":    method,
+					"max_bytes": limit,
+				}).Warn("Request body too large")
+			}()
+
+			ctx.JSON(413, map[string]any{
+				"error":     "Payload Too Large",
+				"message":   "请求体超过大小限制",
+				"max_bytes": limit,
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next(c)
+	}
+}
+
+// bodyExceedsLimit 检查请求体是否超过限制
+// 优先使用Content-Length判断；流式（分块）请求体最多读取limit+1字节，未超限时回填请求体
+func bodyExceedsLimit(ctx *app.RequestContext, limit int64) bool {
+	if int64(ctx.Request.Header.ContentLength()) > limit {
+		return true
+	}
+
+	if ctx.Request.IsBodyStream() {
+		stream := ctx.Request.BodyStream()
+		if stream == nil {
+			return false
+		}
+		body, err := io.ReadAll(io.LimitReader(stream, limit+1))
+		if int64(len(body)) > limit {
+			return true
+		}
+		if err == nil {
+			ctx.Request.SetBodyStream(bytes.NewReader(body), len(body))
+		}
+		return false
+	}
+
+	return int64(len(ctx.Request.Body())) > limit
+}
+
+// pathHasPrefix 判断路径是否位于前缀之下（按路径段匹配）
+func pathHasPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// runBodyLimit 使用指定请求体执行中间件，返回是否进入了后续处理器
+func runBodyLimit(mw Middleware, path string, body *ut.Body) (*app.RequestContext, bool) {
+	reached := false
+	ctx := ut.CreateUtRequestContext("POST", path, body)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), func(c context.Context, ctx *app.RequestContext) {
+		reached = true
+	}})
+	ctx.Next(context.Background())
+	return ctx, reached
+}
+
+func TestBodyLimitMiddleware_UnderAndOverLimit(t *testing.T) {
+	mw := BodyLimitMiddleware(16)
+
+	under := strings.Repeat("a", 16)
+	ctx, reached := runBodyLimit(mw, "/upload", &ut.Body{Body: strings.NewReader(under), Len: len(under)})
+	if !reached {
+		t.Fatalf("Expected body of 16 bytes to pass, got status %d", ctx.Response.StatusCode())
+	}
+
+	over := strings.Repeat("a", 17)
+	ctx, reached = runBodyLimit(mw, "/upload", &ut.Body{Body: strings.NewReader(over), Len: len(over)})
+	if reached {
+		t.Fatal("Expected body of 17 bytes to be rejected before the handler")
+	}
+	if ctx.Response.StatusCode() != 413 {
+		t.Errorf("Expected status 413, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestBodyLimitMiddleware_StreamedBody(t *testing.T) {
+	mw := BodyLimitMiddleware(16)
+
+	// 分块请求体（长度未知）
+	ctx, reached := runBodyLimit(mw, "/upload", &ut.Body{Body: bytes.NewReader(make([]byte, 32)), Len: -1})
+	if reached || ctx.Response.StatusCode() != 413 {
+		t.Errorf("Expected streamed body over the limit to be rejected, got status %d", ctx.Response.StatusCode())
+	}
+
+	ctx, reached = runBodyLimit(mw, "/upload", &ut.Body{Body: strings.NewReader("small"), Len: -1})
+	if !reached {
+		t.Fatal("Expected small streamed body to pass")
+	}
+	if got := string(ctx.Request.Body()); got != "small" {
+		t.Errorf("Expected streamed body to be preserved, got %q", got)
+	}
+}
+
+func TestBodyLimitMiddleware_PerRoute(t *testing.T) {
+	mw := BodyLimitMiddlewareWithConfig(BodyLimitConfig{
+		MaxBytes: 8,
+		Routes: map[string]int64{
+			"/upload":        1024,
+			"/upload/avatar": 4,
+			"/import":        0, // 不限制
+		},
+	})
+	body := func(n int) *ut.Body {
+		return &ut.Body{Body: bytes.NewReader(make([]byte, n)), Len: n}
+	}
+
+	if _, reached := runBodyLimit(mw, "/api/users", body(9)); reached {
+		t.Error("Expected default limit to reject 9 bytes")
+	}
+	if _, reached := runBodyLimit(mw, "/upload/file", body(512)); !reached {
+		t.Error("Expected /upload limit to allow 512 bytes")
+	}
+	if _, reached := runBodyLimit(mw, "/upload/avatar", body(5)); reached {
+		t.Error("Expected longest prefix /upload/avatar to reject 5 bytes")
+	}
+	if _, reached := runBodyLimit(mw, "/import/csv", body(4096)); !reached {
+		t.Error("Expected /import to be unlimited")
+	}
+}
@@ -261,9 +261,8 @@ func (m *MiddlewareManager) registerBasicAuthMiddleware() {
 func (m *MiddlewareManager) registerRequestIDMiddleware() {
 	m.RegisterBuiltin("requestid", func(config interface{}) MiddlewareFunc {
 		return func(ctx *mvccontext.Context) {
-			requestID := generateRequestID()
+			requestID := EnsureRequestID(ctx.Request)
 			ctx.Set("RequestID", requestID)
-			ctx.Request.Response.Header.Set("X-Request-ID", requestID)
 			ctx.Next()
 		}
 	}, MiddlewareMetadata{
@@ -416,21 +415,6 @@ func generateTraceID() string {
 	return fmt.Sprintf("trace-%d-%d", time.Now().UnixNano(), runtime.NumGoroutine())
 }
 
-// generateRequestID 生成请求ID
-func generateRequestID() string {
-	return fmt.Sprintf("req-%d-%s", time.Now().UnixNano(), generateShortID())
-}
-
-// generateShortID 生成短ID
-func generateShortID() string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, 8)
-	for i := range b {
-		b[i] = charset[time.Now().UnixNano()%int64(len(charset))]
-	}
-	return string(b)
-}
-
 // InitExtendedMiddlewares 初始化扩展中间件
 func (m *MiddlewareManager) InitExtendedMiddlewares() {
 	m.registerExtendedBuiltinMiddlewares()
@@ -140,9 +140,8 @@ func BasicAuth(accounts map[string]string) HandlerFunc {
 // RequestID 生成请求ID中间件
 func RequestID() HandlerFunc {
 	return func(c *Context) {
-		requestID := generateRequestID()
+		requestID := EnsureRequestID(c.RequestContext)
 		c.Set("RequestID", requestID)
-		c.Header("X-Request-ID", requestID)
 		c.Next()
 	}
 }
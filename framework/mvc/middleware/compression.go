@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// 支持的响应压缩编码
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+// CompressionConfig 响应压缩中间件配置
+type CompressionConfig struct {
+	Level                int      // 压缩级别 1(gzip.BestSpeed)-9(gzip.BestCompression)，0或超出范围时使用默认级别
+	MinSize              int      // 响应体达到该字节数才压缩，默认1024
+	ExcludedContentTypes []string // 不压缩的内容类型前缀，默认跳过图片、音视频与压缩包等已压缩的类型
+}
+
+// defaultExcludedContentTypes 已压缩的内容类型，再次压缩只会浪费CPU
+var defaultExcludedContentTypes = []string{
+	"image/", "video/", "audio/", "font/woff",
+	"application/zip", "application/gzip", "application/x-gzip",
+	"application/x-7z-compressed", "application/x-rar-compressed",
+}
+
+// DefaultCompressionConfig 默认响应压缩配置
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Level:                gzip.DefaultCompression,
+		MinSize:              1024,
+		ExcludedContentTypes: defaultExcludedContentTypes,
+	}
+}
+
+// CompressionMiddleware 响应压缩中间件 - 根据 Accept-Encoding 使用 gzip 或 deflate 压缩响应体
+// 仅压缩不小于MinSize、未设置Content-Encoding且内容类型未被排除的响应
+func CompressionMiddleware(cfg CompressionConfig) Middleware {
+	if cfg.Level == 0 || cfg.Level < gzip.HuffmanOnly || cfg.Level > gzip.BestCompression {
+		cfg.Level = gzip.DefaultCompression
+	}
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = 1024
+	}
+	if cfg.ExcludedContentTypes == nil {
+		cfg.ExcludedContentTypes = defaultExcludedContentTypes
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		ctx.Next(c)
+
+		resp := &ctx.Response
+		if string(ctx.Method()) == "HEAD" || resp.IsBodyStream() ||
+			resp.StatusCode() == 204 || resp.StatusCode() == 304 ||
+			len(resp.Header.Peek("Content-Encoding")) > 0 {
+			return
+		}
+		body := resp.Body()
+		if len(body) < cfg.MinSize || !compressibleContentType(string(resp.Header.ContentType()), cfg.ExcludedContentTypes) {
+			return
+		}
+
+		// 响应内容随 Accept-Encoding 变化
+		resp.Header.Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(string(ctx.GetHeader("Accept-Encoding")))
+		if encoding == "" {
+			return
+		}
+		compressed, err := compressBody(body, encoding, cfg.Level)
+		if err != nil {
+			return
+		}
+		resp.SetBody(compressed)
+		resp.Header.Set("Content-Encoding", encoding)
+	}
+}
+
+// compressibleContentType 检查内容类型是否需要压缩
+func compressibleContentType(contentType string, excluded []string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if strings.HasPrefix(contentType, "image/svg+xml") {
+		// SVG是文本格式
+		return true
+	}
+	for _, prefix := range excluded {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// negotiateEncoding 根据 Accept-Encoding 选择压缩编码，q值相同时优先gzip，不支持时返回空字符串
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		qualities[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range []string{EncodingGzip, EncodingDeflate} {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressBody 使用指定编码压缩数据，deflate 按 HTTP 规范使用 zlib 格式
+func compressBody(data []byte, encoding string, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	var (
+		w   io.WriteCloser
+		err error
+	)
+	switch encoding {
+	case EncodingGzip:
+		w, err = gzip.NewWriterLevel(&buf, level)
+	default:
+		w, err = zlib.NewWriterLevel(&buf, level)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
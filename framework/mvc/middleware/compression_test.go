@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// runCompression 执行压缩中间件，处理器返回指定的JSON数据
+func runCompression(cfg CompressionConfig, acceptEncoding string, data any) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", "/api/items", nil, ut.Header{Key: "Accept-Encoding", Value: acceptEncoding})
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(CompressionMiddleware(cfg)), func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(200, data)
+	}})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+// largeItems 超过默认最小压缩大小的JSON数据
+func largeItems() []map[string]any {
+	items := make([]map[string]any, 100)
+	for i := range items {
+		items[i] = map[string]any{"id": i, "name": "item", "description": strings.Repeat("compressible ", 4)}
+	}
+	return items
+}
+
+func TestCompressionMiddleware_GzipLargeJSON(t *testing.T) {
+	items := largeItems()
+	original, _ := json.Marshal(items)
+
+	ctx := runCompression(DefaultCompressionConfig(), "deflate;q=0.5, gzip", items)
+	resp := &ctx.Response
+	if got := string(resp.Header.Peek("Content-Encoding")); got != "gzip" {
+		t.Fatalf("Expected gzip Content-Encoding, got %q", got)
+	}
+	if got := string(resp.Header.Peek("Vary")); got != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", got)
+	}
+	if len(resp.Body()) >= len(original) {
+		t.Errorf("Expected compressed body smaller than %d bytes, got %d", len(original), len(resp.Body()))
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(resp.Body()))
+	if err != nil {
+		t.Fatalf("Invalid gzip body: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress body: %v", err)
+	}
+	if !bytes.Equal(decompressed, original) {
+		t.Error("Expected decompressed body to equal the original JSON")
+	}
+}
+
+func TestCompressionMiddleware_Deflate(t *testing.T) {
+	items := largeItems()
+	original, _ := json.Marshal(items)
+
+	ctx := runCompression(CompressionConfig{Level: gzip.BestSpeed}, "gzip;q=0.2, deflate", items)
+	if got := string(ctx.Response.Header.Peek("Content-Encoding")); got != "deflate" {
+		t.Fatalf("Expected deflate Content-Encoding, got %q", got)
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(ctx.Response.Body()))
+	if err != nil {
+		t.Fatalf("Invalid deflate body: %v", err)
+	}
+	decompressed, _ := io.ReadAll(reader)
+	if !bytes.Equal(decompressed, original) {
+		t.Error("Expected decompressed body to equal the original JSON")
+	}
+}
+
+func TestCompressionMiddleware_Skips(t *testing.T) {
+	// 小于最小压缩大小的响应保持原样
+	small := map[string]any{"id": 1}
+	ctx := runCompression(DefaultCompressionConfig(), "gzip", small)
+	if got := string(ctx.Response.Header.Peek("Content-Encoding")); got != "" {
+		t.Errorf("Expected small response not to be compressed, got %q", got)
+	}
+	original, _ := json.Marshal(small)
+	if !bytes.Equal(ctx.Response.Body(), original) {
+		t.Errorf("Expected small body unchanged, got %s", ctx.Response.Body())
+	}
+
+	// 客户端不支持压缩
+	ctx = runCompression(DefaultCompressionConfig(), "identity, gzip;q=0", largeItems())
+	if got := string(ctx.Response.Header.Peek("Content-Encoding")); got != "" {
+		t.Errorf("Expected no compression when gzip is refused, got %q", got)
+	}
+
+	// 已压缩的内容类型
+	png := ut.CreateUtRequestContext("GET", "/logo.png", nil, ut.Header{Key: "Accept-Encoding", Value: "gzip"})
+	png.SetHandlers(app.HandlersChain{app.HandlerFunc(CompressionMiddleware(DefaultCompressionConfig())), func(c context.Context, ctx *app.RequestContext) {
+		ctx.Data(200, "image/png", bytes.Repeat([]byte{0}, 4096))
+	}})
+	png.Next(context.Background())
+	if got := string(png.Response.Header.Peek("Content-Encoding")); got != "" {
+		t.Errorf("Expected image/png not to be compressed, got %q", got)
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                         "",
+		"gzip":                     "gzip",
+		"deflate":                  "deflate",
+		"gzip, deflate, br":        "gzip",
+		"deflate, gzip":            "gzip",
+		"gzip;q=0.5, deflate":      "deflate",
+		"gzip;q=0":                 "",
+		"*":                        "gzip",
+		"*;q=0.1, deflate;q=0.8":   "deflate",
+		"br, identity":             "",
+		"GZIP;q=1.0, deflate;q=.9": "gzip",
+	}
+	for header, want := range cases {
+		if got := negotiateEncoding(header); got != want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
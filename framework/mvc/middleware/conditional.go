@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// Predicate 请求匹配条件，用于决定是否执行中间件
+type Predicate func(c context.Context, ctx *app.RequestContext) bool
+
+// When 条件中间件 - 仅当predicate匹配请求时执行mw，否则直接进入下一个处理器
+func When(predicate Predicate, mw Middleware) Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		if predicate == nil || mw == nil || !predicate(c, ctx) {
+			ctx.Next(c)
+			return
+		}
+		mw(c, ctx)
+	}
+}
+
+// Unless 条件中间件 - 当predicate匹配请求时跳过mw
+func Unless(predicate Predicate, mw Middleware) Middleware {
+	if predicate == nil {
+		return When(func(context.Context, *app.RequestContext) bool { return true }, mw)
+	}
+	return When(Not(predicate), mw)
+}
+
+// MethodIs 匹配指定的请求方法（不区分大小写）
+func MethodIs(methods ...string) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		method := string(ctx.Method())
+		for _, m := range methods {
+			if strings.EqualFold(m, method) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// PathPrefix 匹配指定的路径前缀
+func PathPrefix(prefixes ...string) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		path := string(ctx.Path())
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ContentTypeIs 匹配指定的请求Content-Type（忽略参数部分，如charset）
+func ContentTypeIs(contentTypes ...string) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		contentType := string(ctx.Request.Header.ContentType())
+		if idx := strings.Index(contentType, ";"); idx >= 0 {
+			contentType = contentType[:idx]
+		}
+		contentType = strings.TrimSpace(contentType)
+		for _, ct := range contentTypes {
+			if strings.EqualFold(ct, contentType) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// And 所有条件均匹配时成立
+func And(predicates ...Predicate) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		for _, p := range predicates {
+			if !p(c, ctx) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or 任一条件匹配时成立
+func Or(predicates ...Predicate) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		for _, p := range predicates {
+			if p(c, ctx) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not 条件取反
+func Not(predicate Predicate) Predicate {
+	return func(c context.Context, ctx *app.RequestContext) bool {
+		return !predicate(c, ctx)
+	}
+}
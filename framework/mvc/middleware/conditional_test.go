@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+func TestWhen_RunsOnlyForMatchingRequests(t *testing.T) {
+	calls := 0
+	counter := func(c context.Context, ctx *app.RequestContext) {
+		calls++
+		ctx.Next(c)
+	}
+	mw := When(And(MethodIs("POST", "PUT"), PathPrefix("/api")), counter)
+
+	tests := []struct {
+		method string
+		path   string
+		run    bool
+	}{
+		{"POST", "/api/users", true},
+		{"put", "/api/users/1", true},
+		{"GET", "/api/users", false},
+		{"POST", "/web/form", false},
+	}
+
+	for _, tt := range tests {
+		calls = 0
+		ctx := ut.CreateUtRequestContext(tt.method, tt.path, nil)
+		mw(context.Background(), ctx)
+		if got := calls == 1; got != tt.run {
+			t.Errorf("%s %s: expected run=%v, got calls=%d", tt.method, tt.path, tt.run, calls)
+		}
+	}
+}
+
+func TestWhen_SkippedMiddlewareContinuesChain(t *testing.T) {
+	blocker := func(c context.Context, ctx *app.RequestContext) {
+		ctx.AbortWithStatus(413)
+	}
+	mw := When(ContentTypeIs("application/json"), blocker)
+
+	ctx := ut.CreateUtRequestContext("POST", "/upload", nil,
+		ut.Header{Key: "Content-Type", Value: "multipart/form-data; boundary=x"})
+	mw(context.Background(), ctx)
+	if ctx.IsAborted() {
+		t.Error("Expected non-matching request to skip the wrapped middleware")
+	}
+
+	ctx = ut.CreateUtRequestContext("POST", "/upload", nil,
+		ut.Header{Key: "Content-Type", Value: "application/json; charset=utf-8"})
+	mw(context.Background(), ctx)
+	if !ctx.IsAborted() || ctx.Response.StatusCode() != 413 {
+		t.Errorf("Expected matching request to run the wrapped middleware, got status %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestUnless_SkipsMatchingRequests(t *testing.T) {
+	calls := 0
+	mw := Unless(PathPrefix("/health"), func(c context.Context, ctx *app.RequestContext) {
+		calls++
+	})
+
+	mw(context.Background(), ut.CreateUtRequestContext("GET", "/health", nil))
+	mw(context.Background(), ut.CreateUtRequestContext("GET", "/api", nil))
+	if calls != 1 {
+		t.Errorf("Expected wrapped middleware to run once, got %d", calls)
+	}
+}
@@ -2,12 +2,131 @@ package middleware
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/cloudwego/hertz/pkg/app"
 
 	"github.com/zsy619/yyhertz/framework/config"
 )
 
+// CORSPolicy 跨域策略，可挂载到命名空间/路由组前缀上
+type CORSPolicy struct {
+	AllowOrigins     []string // 允许的来源，"*" 表示全部，"https://*.example.com" 匹配任意子域名
+	AllowMethods     []string // 允许的方法
+	AllowHeaders     []string // 允许的请求头
+	ExposeHeaders    []string // 暴露给客户端的响应头
+	AllowCredentials bool     // 是否允许携带凭证
+	MaxAge           int      // 预检结果缓存时间(秒)
+}
+
+// DefaultCORSPolicy 默认跨域策略：允许所有来源，不携带凭证
+func DefaultCORSPolicy() *CORSPolicy {
+	return &CORSPolicy{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Requested-With"},
+		MaxAge:       3600,
+	}
+}
+
+// CORSMiddlewareWithPolicy 使用指定策略的跨域中间件
+// 按来源白名单输出CORS头，不在白名单中的来源不输出任何CORS头；预检请求直接返回204
+// policy 为 nil 时不输出任何CORS头（禁止跨域）
+func CORSMiddlewareWithPolicy(policy *CORSPolicy) Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		if applyCORSPolicy(ctx, policy) {
+			return
+		}
+		ctx.Next(c)
+	}
+}
+
+// applyCORSPolicy 按策略设置CORS头部，返回true表示预检请求已处理完毕
+func applyCORSPolicy(ctx *app.RequestContext, policy *CORSPolicy) bool {
+	requestOrigin := string(ctx.GetHeader("Origin"))
+	if policy == nil || requestOrigin == "" {
+		// 禁止跨域或非跨域请求，不输出CORS头
+		return false
+	}
+
+	allowedOrigin := ""
+	for _, origin := range policy.AllowOrigins {
+		if origin == "*" {
+			allowedOrigin = "*"
+			if policy.AllowCredentials {
+				// 携带凭证时不能使用通配符
+				allowedOrigin = requestOrigin
+			}
+			break
+		}
+		if matchCORSOrigin(origin, requestOrigin) {
+			allowedOrigin = requestOrigin
+			break
+		}
+	}
+
+	if allowedOrigin == "" {
+		config.WithFields(map[string]any{
+			"event":          "cors_policy_rejected",
+			"request_origin": requestOrigin,
+			"path":           string(ctx.Path()),
+		}).Debug("CORS request from disallowed origin")
+		return false
+	}
+
+	methods := policy.AllowMethods
+	if len(methods) == 0 {
+		methods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	}
+	headers := policy.AllowHeaders
+	if len(headers) == 0 {
+		headers = []string{"Content-Type", "Authorization", "X-Requested-With"}
+	}
+
+	ctx.Header("Access-Control-Allow-Origin", allowedOrigin)
+	if allowedOrigin != "*" {
+		ctx.Response.Header.Add("Vary", "Origin")
+	}
+	ctx.Header("Access-Control-Allow-Methods", joinStrings(methods, ", "))
+	ctx.Header("Access-Control-Allow-Headers", joinStrings(headers, ", "))
+	if len(policy.ExposeHeaders) > 0 {
+		ctx.Header("Access-Control-Expose-Headers", joinStrings(policy.ExposeHeaders, ", "))
+	}
+	if policy.AllowCredentials {
+		ctx.Header("Access-Control-Allow-Credentials", "true")
+	}
+	if policy.MaxAge > 0 {
+		ctx.Header("Access-Control-Max-Age", strconv.Itoa(policy.MaxAge))
+	}
+
+	if string(ctx.Method()) == "OPTIONS" {
+		// 预检结果随请求的方法与头部变化
+		ctx.Response.Header.Add("Vary", "Access-Control-Request-Method")
+		ctx.Response.Header.Add("Vary", "Access-Control-Request-Headers")
+		ctx.Status(204)
+		ctx.Abort()
+		return true
+	}
+	return false
+}
+
+// matchCORSOrigin 匹配来源，支持 "https://*.example.com" 形式的子域名通配
+// 通配符只匹配子域名，不匹配 "https://example.com" 本身
+func matchCORSOrigin(pattern, origin string) bool {
+	if pattern == origin {
+		return true
+	}
+	prefix, suffix, ok := strings.Cut(pattern, "*.")
+	if !ok {
+		return false
+	}
+	suffix = "." + suffix
+	return len(origin) > len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) &&
+		strings.HasSuffix(origin, suffix)
+}
+
 // CORSMiddleware 跨域中间件 - 处理跨域请求
 func CORSMiddleware() Middleware {
 	return func(c context.Context, ctx *app.RequestContext) {
@@ -57,90 +176,20 @@ func CORSMiddleware() Middleware {
 	}
 }
 
-// CORSMiddlewareWithConfig 带配置的跨域中间件
+// CORSMiddlewareWithConfig 带配置的跨域中间件，等同于使用对应 CORSPolicy 的 CORSMiddlewareWithPolicy
+// origins、methods、headers 为空时使用 DefaultCORSPolicy 中的值
 func CORSMiddlewareWithConfig(origins []string, methods []string, headers []string) Middleware {
-	return func(c context.Context, ctx *app.RequestContext) {
-		// 获取请求信息
-		requestOrigin := string(ctx.GetHeader("Origin"))
-		clientIP := ctx.ClientIP()
-		method := string(ctx.Method())
-		path := string(ctx.Path())
-		requestID := ctx.GetString("request_id")
-
-		// 检查请求来源
-		allowedOrigin := "*"
-		originAllowed := true
-
-		// 如果指定了具体的来源，进行匹配
-		if len(origins) > 0 && origins[0] != "*" {
-			originAllowed = false
-			for _, origin := range origins {
-				if origin == requestOrigin {
-					allowedOrigin = origin
-					originAllowed = true
-					break
-				}
-			}
-			if !originAllowed && requestOrigin != "" {
-				// 如果没有匹配到，禁止跨域
-				allowedOrigin = "null"
-			}
-		}
-
-		// 设置默认方法和头部
-		if len(methods) == 0 {
-			methods = []string{"POST", "GET", "OPTIONS", "PUT", "DELETE"}
-		}
-		if len(headers) == 0 {
-			headers = []string{"AccessToken", "Content-Type", "Authorization", "X-Requested-With"}
-		}
-
-		// 使用单例日志系统记录CORS配置处理
-		corsFields := map[string]any{
-			"event":           "cors_config_request",
-			"request_origin":  requestOrigin,
-			"allowed_origin":  allowedOrigin,
-			"origin_allowed":  originAllowed,
-			"client_ip":       clientIP,
-			"method":          method,
-			"path":            path,
-			"request_id":      requestID,
-			"allowed_methods": joinStrings(methods, ", "),
-			"allowed_headers": joinStrings(headers, ", "),
-		}
-
-		// 如果来源不被允许，记录警告
-		if !originAllowed {
-			go func() {
-				config.WithFields(corsFields).Warn("CORS request from disallowed origin")
-			}()
-		} else {
-			go func() {
-				config.WithFields(corsFields).Debug("CORS request from allowed origin")
-			}()
-		}
-
-		// 设置CORS头部
-		ctx.Header("Access-Control-Allow-Origin", allowedOrigin)
-		ctx.Header("Access-Control-Allow-Methods", joinStrings(methods, ", "))
-		ctx.Header("Access-Control-Allow-Headers", joinStrings(headers, ", "))
-		ctx.Header("Access-Control-Allow-Credentials", "true")
-		ctx.Header("Access-Control-Max-Age", "3600")
-
-		// 处理预检请求
-		if method == "OPTIONS" {
-			corsFields["preflight"] = true
-			go func() {
-				config.WithFields(corsFields).Debug("CORS configured preflight request handled")
-			}()
-
-			ctx.Status(204)
-			ctx.Abort()
-			return
-		}
-
-		ctx.Next(c)
+	policy := DefaultCORSPolicy()
+	if len(origins) > 0 {
+		policy.AllowOrigins = origins
+	}
+	if len(methods) > 0 {
+		policy.AllowMethods = methods
+	}
+	if len(headers) > 0 {
+		policy.AllowHeaders = headers
 	}
+	return CORSMiddlewareWithPolicy(policy)
 }
 
 // joinStrings 连接字符串数组
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+func TestCORSMiddlewareWithPolicy_AllowList(t *testing.T) {
+	cors := CORSMiddlewareWithPolicy(&CORSPolicy{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		AllowMethods:     []string{"GET", "PATCH"},
+		AllowHeaders:     []string{"Content-Type", "X-Token"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	})
+
+	// 白名单来源与通配子域名：回显来源并允许凭证
+	for _, origin := range []string{"https://app.example.com", "https://api.example.org", "https://a.b.example.org"} {
+		ctx := ut.CreateUtRequestContext("GET", "/users", nil, ut.Header{Key: "Origin", Value: origin})
+		cors(context.Background(), ctx)
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")); got != origin {
+			t.Errorf("Expected origin %s to be allowed, got %q", origin, got)
+		}
+		if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Credentials")); got != "true" {
+			t.Errorf("Expected credentials for %s, got %q", origin, got)
+		}
+		if ctx.IsAborted() {
+			t.Errorf("Expected simple request from %s to continue", origin)
+		}
+	}
+
+	// 不在白名单中的来源：不输出CORS头，请求继续处理
+	for _, origin := range []string{"https://evil.com", "https://example.org", "https://evilexample.org", "http://api.example.org"} {
+		ctx := ut.CreateUtRequestContext("GET", "/users", nil, ut.Header{Key: "Origin", Value: origin})
+		cors(context.Background(), ctx)
+		for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods", "Access-Control-Allow-Credentials"} {
+			if got := string(ctx.Response.Header.Peek(header)); got != "" {
+				t.Errorf("Expected no %s for origin %s, got %q", header, origin, got)
+			}
+		}
+	}
+}
+
+func TestCORSMiddlewareWithPolicy_Preflight(t *testing.T) {
+	cors := CORSMiddlewareWithPolicy(&CORSPolicy{
+		AllowOrigins: []string{"https://*.example.com"},
+		AllowMethods: []string{"GET", "PATCH"},
+		AllowHeaders: []string{"Content-Type", "X-Token"},
+		MaxAge:       600,
+	})
+
+	ctx := ut.CreateUtRequestContext("OPTIONS", "/users/1", nil,
+		ut.Header{Key: "Origin", Value: "https://app.example.com"},
+		ut.Header{Key: "Access-Control-Request-Method", Value: "PATCH"},
+		ut.Header{Key: "Access-Control-Request-Headers", Value: "X-Token"})
+	cors(context.Background(), ctx)
+
+	if ctx.Response.StatusCode() != 204 || !ctx.IsAborted() {
+		t.Errorf("Expected preflight to short-circuit with 204, got %d (aborted=%v)", ctx.Response.StatusCode(), ctx.IsAborted())
+	}
+	expected := map[string]string{
+		"Access-Control-Allow-Origin":  "https://app.example.com",
+		"Access-Control-Allow-Methods": "GET, PATCH",
+		"Access-Control-Allow-Headers": "Content-Type, X-Token",
+		"Access-Control-Max-Age":       "600",
+	}
+	for header, want := range expected {
+		if got := string(ctx.Response.Header.Peek(header)); got != want {
+			t.Errorf("Expected %s %q, got %q", header, want, got)
+		}
+	}
+	if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Credentials")); got != "" {
+		t.Errorf("Expected no credentials header, got %q", got)
+	}
+
+	// 不允许的来源发起预检：不短路，也不输出CORS头
+	denied := ut.CreateUtRequestContext("OPTIONS", "/users/1", nil,
+		ut.Header{Key: "Origin", Value: "https://evil.com"},
+		ut.Header{Key: "Access-Control-Request-Method", Value: "PATCH"})
+	cors(context.Background(), denied)
+	if denied.IsAborted() || string(denied.Response.Header.Peek("Access-Control-Allow-Origin")) != "" {
+		t.Error("Expected preflight from disallowed origin to receive no CORS headers")
+	}
+}
+
+func TestCORSMiddlewareWithConfig(t *testing.T) {
+	cors := CORSMiddlewareWithConfig([]string{"https://app.example.com"}, []string{"GET"}, nil)
+
+	ctx := ut.CreateUtRequestContext("OPTIONS", "/users", nil, ut.Header{Key: "Origin", Value: "https://app.example.com"})
+	cors(context.Background(), ctx)
+	if ctx.Response.StatusCode() != 204 || !ctx.IsAborted() {
+		t.Errorf("Expected preflight to short-circuit with 204, got %d", ctx.Response.StatusCode())
+	}
+	if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Origin")); got != "https://app.example.com" {
+		t.Errorf("Expected configured origin, got %q", got)
+	}
+	if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Methods")); got != "GET" {
+		t.Errorf("Expected configured methods, got %q", got)
+	}
+	if got := string(ctx.Response.Header.Peek("Access-Control-Allow-Headers")); got != "Content-Type, Authorization, X-Requested-With" {
+		t.Errorf("Expected default headers, got %q", got)
+	}
+
+	denied := ut.CreateUtRequestContext("GET", "/users", nil, ut.Header{Key: "Origin", Value: "https://evil.com"})
+	cors(context.Background(), denied)
+	if got := string(denied.Response.Header.Peek("Access-Control-Allow-Origin")); got != "" {
+		t.Errorf("Expected no CORS headers for disallowed origin, got %q", got)
+	}
+}
+
+func TestMatchCORSOrigin(t *testing.T) {
+	cases := []struct {
+		pattern, origin string
+		want            bool
+	}{
+		{"https://app.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://api.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://badexample.com", false},
+		{"https://*.example.com", "http://api.example.com", false},
+		{"https://*.example.com", "https://api.example.com.evil.io", false},
+	}
+	for _, tc := range cases {
+		if got := matchCORSOrigin(tc.pattern, tc.origin); got != tc.want {
+			t.Errorf("matchCORSOrigin(%q, %q) = %v, want %v", tc.pattern, tc.origin, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+const (
+	// DefaultCSRFCookie 保存CSRF令牌的默认Cookie名称
+	DefaultCSRFCookie = "_csrf"
+	// DefaultCSRFHeader 提交CSRF令牌的默认请求头，响应中同名头返回当前令牌
+	DefaultCSRFHeader = "X-CSRF-Token"
+	// DefaultCSRFFormField 提交CSRF令牌的默认表单字段
+	DefaultCSRFFormField = "_csrf"
+	// CSRFTokenKey 当前请求的CSRF令牌在上下文中的键
+	CSRFTokenKey = "csrf_token"
+)
+
+// CSRFConfig CSRF防护中间件配置
+type CSRFConfig struct {
+	CookieName   string                  // 令牌Cookie名称，默认 _csrf
+	HeaderName   string                  // 令牌请求头，默认 X-CSRF-Token
+	FormField    string                  // 令牌表单字段，默认 _csrf
+	CookiePath   string                  // Cookie路径，默认 /
+	CookieDomain string                  // Cookie域名
+	MaxAge       int                     // Cookie有效期（秒），默认24小时
+	Secure       bool                    // 是否仅HTTPS传输
+	SameSite     protocol.CookieSameSite // SameSite策略，默认Lax
+	ExemptPaths  []string                // 免检的路径前缀（如第三方回调 /webhooks）
+}
+
+// CSRFMiddleware 使用默认配置的CSRF防护中间件
+func CSRFMiddleware() Middleware {
+	return CSRFMiddlewareWithConfig(CSRFConfig{})
+}
+
+// CSRFMiddlewareWithConfig CSRF防护中间件 - 双重提交Cookie模式：
+// 首次请求下发令牌Cookie（前端脚本可读取），并通过响应头与上下文（模板中使用 CSRFToken 获取）暴露令牌；
+// POST、PUT、PATCH、DELETE 等非安全方法须通过请求头或表单字段回传与Cookie一致的令牌，否则返回403
+func CSRFMiddlewareWithConfig(cfg CSRFConfig) Middleware {
+	if cfg.CookieName == "" {
+		cfg.CookieName = DefaultCSRFCookie
+	}
+	if cfg.HeaderName == "" {
+		cfg.HeaderName = DefaultCSRFHeader
+	}
+	if cfg.FormField == "" {
+		cfg.FormField = DefaultCSRFFormField
+	}
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 86400
+	}
+	if cfg.SameSite == protocol.CookieSameSiteDisabled {
+		cfg.SameSite = protocol.CookieSameSiteLaxMode
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		path := string(ctx.Path())
+		for _, prefix := range cfg.ExemptPaths {
+			if pathHasPrefix(path, prefix) {
+				ctx.Next(c)
+				return
+			}
+		}
+
+		token := string(ctx.Cookie(cfg.CookieName))
+		if !methodIn(string(ctx.Method()), []string{"GET", "HEAD", "OPTIONS", "TRACE"}) {
+			submitted := string(ctx.GetHeader(cfg.HeaderName))
+			if submitted == "" {
+				submitted = string(ctx.PostForm(cfg.FormField))
+			}
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(submitted)) != 1 {
+				method := string(ctx.Method())
+				go func() {
+					config.WithFields(map[string]any{
+						"event":  "csrf_rejected",
+						"path":   path,
+						"method": method,
+					}).Warn("CSRF token missing or invalid")
+				}()
+
+				ctx.JSON(403, map[string]any{
+					"error":   "Forbidden",
+					"code":    "CSRF_TOKEN_INVALID",
+					"message": "CSRF令牌缺失或无效",
+				})
+				ctx.Abort()
+				return
+			}
+		}
+
+		if token == "" {
+			token = newCSRFToken()
+			ctx.SetCookie(cfg.CookieName, token, cfg.MaxAge, cfg.CookiePath, cfg.CookieDomain, cfg.SameSite, cfg.Secure, false)
+		}
+		ctx.Set(CSRFTokenKey, token)
+		ctx.Response.Header.Set(cfg.HeaderName, token)
+
+		ctx.Next(c)
+	}
+}
+
+// CSRFToken 返回当前请求的CSRF令牌，用于渲染表单隐藏字段或页面 meta 标签
+func CSRFToken(ctx *app.RequestContext) string {
+	return ctx.GetString(CSRFTokenKey)
+}
+
+// newCSRFToken 生成随机令牌
+func newCSRFToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol"
+)
+
+// runCSRF 执行请求并返回上下文与处理器是否执行
+func runCSRF(mw Middleware, method, path, body string, headers ...ut.Header) (*app.RequestContext, bool) {
+	var reqBody *ut.Body
+	if body != "" {
+		reqBody = &ut.Body{Body: strings.NewReader(body), Len: len(body)}
+		headers = append(headers, ut.Header{Key: "Content-Type", Value: "application/x-www-form-urlencoded"})
+	}
+	ctx := ut.CreateUtRequestContext(method, path, reqBody, headers...)
+	handled := false
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), func(c context.Context, ctx *app.RequestContext) {
+		handled = true
+		ctx.String(200, "ok")
+	}})
+	ctx.Next(context.Background())
+	return ctx, handled
+}
+
+// issuedCSRFToken 执行GET请求获取下发的令牌
+func issuedCSRFToken(t *testing.T, mw Middleware) string {
+	t.Helper()
+	ctx, handled := runCSRF(mw, "GET", "/form", "")
+	if !handled {
+		t.Fatal("Expected GET request to pass")
+	}
+
+	cookie := protocol.AcquireCookie()
+	defer protocol.ReleaseCookie(cookie)
+	cookie.SetKey(DefaultCSRFCookie)
+	if !ctx.Response.Header.Cookie(cookie) || len(cookie.Value()) == 0 {
+		t.Fatal("Expected CSRF cookie to be issued")
+	}
+	if cookie.HTTPOnly() || cookie.SameSite() != protocol.CookieSameSiteLaxMode {
+		t.Errorf("Expected readable Lax cookie, got %s", cookie.String())
+	}
+
+	token := string(cookie.Value())
+	if got := string(ctx.Response.Header.Peek(DefaultCSRFHeader)); got != token {
+		t.Errorf("Expected response header token %q, got %q", token, got)
+	}
+	if got := CSRFToken(ctx); got != token {
+		t.Errorf("Expected context token %q, got %q", token, got)
+	}
+	return token
+}
+
+func TestCSRFMiddleware_ValidToken(t *testing.T) {
+	mw := CSRFMiddleware()
+	token := issuedCSRFToken(t, mw)
+	cookie := ut.Header{Key: "Cookie", Value: DefaultCSRFCookie + "=" + token}
+
+	// 请求头提交
+	ctx, handled := runCSRF(mw, "POST", "/orders", "", cookie, ut.Header{Key: DefaultCSRFHeader, Value: token})
+	if !handled || ctx.Response.StatusCode() != 200 {
+		t.Errorf("Expected header token to pass, got %d", ctx.Response.StatusCode())
+	}
+
+	// 表单字段提交
+	ctx, handled = runCSRF(mw, "POST", "/orders", "item=book&_csrf="+token, cookie)
+	if !handled || ctx.Response.StatusCode() != 200 {
+		t.Errorf("Expected form token to pass, got %d", ctx.Response.StatusCode())
+	}
+
+	// 已有令牌时不重新下发
+	if strings.Contains(string(ctx.Response.Header.Header()), "Set-Cookie") {
+		t.Error("Expected existing token not to be reissued")
+	}
+}
+
+func TestCSRFMiddleware_MissingOrInvalidToken(t *testing.T) {
+	mw := CSRFMiddleware()
+	token := issuedCSRFToken(t, mw)
+	cookie := ut.Header{Key: "Cookie", Value: DefaultCSRFCookie + "=" + token}
+
+	tests := []struct {
+		name    string
+		method  string
+		headers []ut.Header
+	}{
+		{"no cookie and no token", "POST", nil},
+		{"cookie without token", "POST", []ut.Header{cookie}},
+		{"token without cookie", "PUT", []ut.Header{{Key: DefaultCSRFHeader, Value: token}}},
+		{"mismatched token", "DELETE", []ut.Header{cookie, {Key: DefaultCSRFHeader, Value: token + "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, handled := runCSRF(mw, tt.method, "/orders", "", tt.headers...)
+			if handled {
+				t.Error("Expected handler not to run")
+			}
+			if ctx.Response.StatusCode() != 403 || !strings.Contains(string(ctx.Response.Body()), "CSRF_TOKEN_INVALID") {
+				t.Errorf("Expected 403 CSRF_TOKEN_INVALID, got %d %s", ctx.Response.StatusCode(), ctx.Response.Body())
+			}
+		})
+	}
+}
+
+func TestCSRFMiddleware_ExemptPath(t *testing.T) {
+	mw := CSRFMiddlewareWithConfig(CSRFConfig{ExemptPaths: []string{"/webhooks"}})
+
+	ctx, handled := runCSRF(mw, "POST", "/webhooks/github", "")
+	if !handled || ctx.Response.StatusCode() != 200 {
+		t.Errorf("Expected exempt path to bypass CSRF check, got %d", ctx.Response.StatusCode())
+	}
+
+	// 仅匹配完整路径段
+	if _, handled := runCSRF(mw, "POST", "/webhooks-admin", ""); handled {
+		t.Error("Expected /webhooks-admin not to be exempt")
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// DefaultRequestTimeoutHeader 上游网关传递请求超时时间的默认请求头
+const DefaultRequestTimeoutHeader = "X-Request-Timeout"
+
+// RequestTimeoutConfig 请求超时中间件配置
+type RequestTimeoutConfig struct {
+	Header         string        // 携带超时时间的请求头，默认 X-Request-Timeout
+	DefaultTimeout time.Duration // 未携带请求头时的超时时间，0表示不设置截止时间
+	MaxTimeout     time.Duration // 服务端允许的最大超时时间，0表示不限制
+}
+
+// RequestTimeoutMiddleware 请求超时中间件 - 根据上游请求头派生请求上下文的截止时间
+// 超时时间不超过maxTimeout；下游处理器应使用传入的context.Context（如数据库查询），以便超时后被取消
+func RequestTimeoutMiddleware(maxTimeout time.Duration) Middleware {
+	return RequestTimeoutMiddlewareWithConfig(RequestTimeoutConfig{MaxTimeout: maxTimeout})
+}
+
+// RequestTimeoutMiddlewareWithConfig 带配置的请求超时中间件
+func RequestTimeoutMiddlewareWithConfig(cfg RequestTimeoutConfig) Middleware {
+	if cfg.Header == "" {
+		cfg.Header = DefaultRequestTimeoutHeader
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		timeout := cfg.DefaultTimeout
+		if value := string(ctx.GetHeader(cfg.Header)); value != "" {
+			parsed, err := ParseRequestTimeout(value)
+			if err != nil {
+				ctx.JSON(400, map[string]any{
+					"error":   "Invalid request timeout",
+					"message": "无效的请求超时时间: " + value,
+				})
+				ctx.Abort()
+				return
+			}
+			timeout = parsed
+		}
+		if cfg.MaxTimeout > 0 && (timeout <= 0 || timeout > cfg.MaxTimeout) {
+			timeout = cfg.MaxTimeout
+		}
+		if timeout <= 0 {
+			ctx.Next(c)
+			return
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(c, timeout)
+		defer cancel()
+
+		ctx.Next(timeoutCtx)
+
+		// 处理器因超时放弃且未输出响应时，返回网关超时
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) && len(ctx.Response.Body()) == 0 {
+			path := string(ctx.Path())
+			method := string(ctx.Method())
+			go func() {
+				config.WithFields(map[string]any{
+					"event":   "request_timeout",
+					"path":    path,
+					"method":  method,
+					"timeout": timeout.String(),
+				}).Warn("Request deadline exceeded")
+			}()
+
+			ctx.JSON(504, map[string]any{
+				"error":   "Request timeout",
+				"message": "请求处理超时",
+				"timeout": timeout.String(),
+			})
+			ctx.Abort()
+		}
+	}
+}
+
+// ParseRequestTimeout 解析超时请求头，支持Go时间格式（如 "1.5s"、"200ms"）或以秒为单位的数字
+func ParseRequestTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 || math.IsNaN(seconds) {
+			return 0, errors.New("invalid request timeout")
+		}
+		if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+			return time.Duration(math.MaxInt64), nil
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout < 0 {
+		return 0, errors.New("negative request timeout")
+	}
+	return timeout, nil
+}
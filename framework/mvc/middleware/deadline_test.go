@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+func TestRequestTimeoutMiddleware_CancelsHandler(t *testing.T) {
+	var handlerErr error
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		// 模拟耗时的数据库查询
+		select {
+		case <-c.Done():
+			handlerErr = c.Err()
+		case <-time.After(time.Second):
+			ctx.String(200, "done")
+		}
+	}
+
+	ctx := ut.CreateUtRequestContext("GET", "/slow", nil,
+		ut.Header{Key: "X-Request-Timeout", Value: "20ms"})
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(RequestTimeoutMiddleware(time.Second)), handler})
+
+	start := time.Now()
+	ctx.Next(context.Background())
+
+	if handlerErr != context.DeadlineExceeded {
+		t.Fatalf("Expected handler to be cancelled with DeadlineExceeded, got %v", handlerErr)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected handler to stop near the inbound timeout, took %v", elapsed)
+	}
+	if ctx.Response.StatusCode() != 504 {
+		t.Errorf("Expected status 504, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestRequestTimeoutMiddleware_ClampsToMax(t *testing.T) {
+	var remaining time.Duration
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		deadline, ok := c.Deadline()
+		if !ok {
+			t.Fatal("Expected request context to have a deadline")
+		}
+		remaining = time.Until(deadline)
+		ctx.String(200, "ok")
+	}
+
+	ctx := ut.CreateUtRequestContext("GET", "/api", nil,
+		ut.Header{Key: "X-Request-Timeout", Value: "3600"})
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(RequestTimeoutMiddleware(100 * time.Millisecond)), handler})
+	ctx.Next(context.Background())
+
+	if remaining <= 0 || remaining > 100*time.Millisecond {
+		t.Errorf("Expected deadline clamped to 100ms, got %v", remaining)
+	}
+	if ctx.Response.StatusCode() != 200 {
+		t.Errorf("Expected status 200, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestParseRequestTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"5", 5 * time.Second, false},
+		{"1.5", 1500 * time.Millisecond, false},
+		{"250ms", 250 * time.Millisecond, false},
+		{"-1", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRequestTimeout(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRequestTimeout(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseRequestTimeout(%q) = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// ETagConfig ETag中间件配置
+type ETagConfig struct {
+	Weak bool // 生成弱ETag（W/"..."），适用于内容语义相同但字节可能不同的响应
+}
+
+// ETagMiddleware ETag中间件 - 根据响应体生成强ETag，If-None-Match匹配时返回304
+func ETagMiddleware() Middleware {
+	return ETagMiddlewareWithConfig(ETagConfig{})
+}
+
+// ETagMiddlewareWithConfig 带配置的ETag中间件
+// 仅处理GET/HEAD的200响应；处理器已设置ETag（如静态文件）时沿用该ETag
+// 请求未携带If-None-Match时，带Last-Modified的响应（如静态文件）按If-Modified-Since判断
+func ETagMiddlewareWithConfig(cfg ETagConfig) Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		ctx.Next(c)
+
+		method := string(ctx.Method())
+		resp := &ctx.Response
+		if (method != "GET" && method != "HEAD") || resp.StatusCode() != 200 || resp.IsBodyStream() {
+			return
+		}
+
+		etag := string(resp.Header.Peek("ETag"))
+		if etag == "" {
+			etag = BodyETag(resp.Body(), cfg.Weak)
+			resp.Header.Set("ETag", etag)
+		}
+
+		notModified := false
+		if ifNoneMatch := string(ctx.GetHeader("If-None-Match")); ifNoneMatch != "" {
+			notModified = ETagMatches(ifNoneMatch, etag)
+		} else if ifModifiedSince := string(ctx.GetHeader("If-Modified-Since")); ifModifiedSince != "" {
+			notModified = notModifiedSince(string(resp.Header.Peek("Last-Modified")), ifModifiedSince)
+		}
+		if notModified {
+			resp.ResetBody()
+			resp.Header.Del("Content-Length")
+			resp.SetStatusCode(304)
+		}
+	}
+}
+
+// BodyETag 根据响应体长度与哈希生成ETag
+func BodyETag(body []byte, weak bool) string {
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	etag := fmt.Sprintf(`"%x-%x"`, len(body), h.Sum64())
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// ETagMatches 判断If-None-Match是否与ETag匹配（弱比较，支持逗号分隔的多个ETag与"*"）
+func ETagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince 判断资源自If-Modified-Since以来是否未修改（精确到秒）
+func notModifiedSince(lastModified, ifModifiedSince string) bool {
+	if lastModified == "" {
+		return false
+	}
+	modTime, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !modTime.Truncate(time.Second).After(since)
+}
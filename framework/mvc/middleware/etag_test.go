@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// runETag 执行ETag中间件，处理器返回固定内容
+func runETag(mw Middleware, handler app.HandlerFunc, headers ...ut.Header) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", "/api/items", nil, headers...)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), handler})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+func itemsHandler(c context.Context, ctx *app.RequestContext) {
+	ctx.JSON(200, map[string]any{"items": []string{"a", "b", "c"}})
+}
+
+func TestETagMiddleware_NotModified(t *testing.T) {
+	mw := ETagMiddleware()
+
+	first := runETag(mw, itemsHandler)
+	etag := string(first.Response.Header.Peek("ETag"))
+	if first.Response.StatusCode() != 200 || len(first.Response.Body()) == 0 {
+		t.Fatalf("Expected 200 with body, got %d", first.Response.StatusCode())
+	}
+	if !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("Expected strong ETag, got %q", etag)
+	}
+
+	second := runETag(mw, itemsHandler, ut.Header{Key: "If-None-Match", Value: etag})
+	if second.Response.StatusCode() != 304 {
+		t.Errorf("Expected 304, got %d", second.Response.StatusCode())
+	}
+	if len(second.Response.Body()) != 0 {
+		t.Errorf("Expected empty body, got %q", second.Response.Body())
+	}
+	if got := string(second.Response.Header.Peek("ETag")); got != etag {
+		t.Errorf("Expected 304 to carry ETag %s, got %s", etag, got)
+	}
+
+	// ETag不匹配时返回完整响应
+	changed := runETag(mw, itemsHandler, ut.Header{Key: "If-None-Match", Value: `"other", W/"stale"`})
+	if changed.Response.StatusCode() != 200 || len(changed.Response.Body()) == 0 {
+		t.Errorf("Expected 200 with body for stale ETag, got %d", changed.Response.StatusCode())
+	}
+}
+
+func TestETagMiddleware_Weak(t *testing.T) {
+	mw := ETagMiddlewareWithConfig(ETagConfig{Weak: true})
+
+	first := runETag(mw, itemsHandler)
+	etag := string(first.Response.Header.Peek("ETag"))
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("Expected weak ETag, got %q", etag)
+	}
+
+	// 弱比较：去掉W/前缀同样匹配
+	second := runETag(mw, itemsHandler, ut.Header{Key: "If-None-Match", Value: strings.TrimPrefix(etag, "W/")})
+	if second.Response.StatusCode() != 304 {
+		t.Errorf("Expected 304, got %d", second.Response.StatusCode())
+	}
+}
+
+func TestETagMiddleware_IfModifiedSince(t *testing.T) {
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	staticHandler := func(c context.Context, ctx *app.RequestContext) {
+		ctx.Response.Header.Set("Last-Modified", modTime.Format(http.TimeFormat))
+		ctx.Data(200, "text/css", []byte("body { color: red; }"))
+	}
+	mw := ETagMiddleware()
+
+	fresh := runETag(mw, staticHandler, ut.Header{Key: "If-Modified-Since", Value: modTime.Format(http.TimeFormat)})
+	if fresh.Response.StatusCode() != 304 || len(fresh.Response.Body()) != 0 {
+		t.Errorf("Expected 304 without body, got %d", fresh.Response.StatusCode())
+	}
+
+	stale := runETag(mw, staticHandler, ut.Header{Key: "If-Modified-Since", Value: modTime.Add(-time.Hour).Format(http.TimeFormat)})
+	if stale.Response.StatusCode() != 200 {
+		t.Errorf("Expected 200 for modified resource, got %d", stale.Response.StatusCode())
+	}
+
+	// 无Last-Modified的动态响应忽略If-Modified-Since
+	dynamic := runETag(mw, itemsHandler, ut.Header{Key: "If-Modified-Since", Value: modTime.Format(http.TimeFormat)})
+	if dynamic.Response.StatusCode() != 200 {
+		t.Errorf("Expected 200 for dynamic response, got %d", dynamic.Response.StatusCode())
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// HSTSMiddleware HSTS中间件 - TLS启用（basic.enable）且配置了hsts.enable时，
+// 在HTTPS请求（含代理转发的HTTPS）的响应上按 TLSServerConfig.HSTS 写入 Strict-Transport-Security 头，明文HTTP请求不发送
+func HSTSMiddleware(tlsConfig *config.TLSServerConfig) Middleware {
+	var header, value string
+	if tlsConfig != nil && tlsConfig.Basic.Enable {
+		header, value = hstsFromTLSConfig(tlsConfig)
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		if value != "" && isHTTPSRequest(ctx) {
+			ctx.Response.Header.Set(header, value)
+		}
+		ctx.Next(c)
+	}
+}
+
+// hstsFromTLSConfig 根据 TLSServerConfig.HSTS 生成HSTS响应头名称与取值，未启用时取值为空
+func hstsFromTLSConfig(tlsConfig *config.TLSServerConfig) (string, string) {
+	if tlsConfig == nil || !tlsConfig.HSTS.Enable || tlsConfig.HSTS.MaxAge < 0 {
+		return "", ""
+	}
+
+	header := tlsConfig.HSTS.Header
+	if header == "" {
+		header = "Strict-Transport-Security"
+	}
+	value := "max-age=" + strconv.Itoa(tlsConfig.HSTS.MaxAge)
+	if tlsConfig.HSTS.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if tlsConfig.HSTS.Preload {
+		value += "; preload"
+	}
+	return header, value
+}
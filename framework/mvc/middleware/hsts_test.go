@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+func TestHSTSMiddleware(t *testing.T) {
+	cfg := hstsTLSConfig()
+	cfg.Basic.Enable = true
+	cfg.HSTS.Preload = true
+	mw := HSTSMiddleware(cfg)
+
+	https := ut.Header{Key: "X-Forwarded-Proto", Value: "https"}
+	ctx := runSecurityHeaders(mw, "/", https)
+	if got := string(ctx.Response.Header.Peek("Strict-Transport-Security")); got != "max-age=31536000; includeSubDomains; preload" {
+		t.Errorf("Expected HSTS header under TLS, got %q", got)
+	}
+	if ctx.Response.StatusCode() != 200 {
+		t.Errorf("Expected handler to run, got %d", ctx.Response.StatusCode())
+	}
+
+	ctx = runSecurityHeaders(mw, "/")
+	if got := ctx.Response.Header.Peek("Strict-Transport-Security"); len(got) != 0 {
+		t.Errorf("Expected no HSTS over plain HTTP, got %q", got)
+	}
+
+	// 自定义头名称与最小配置
+	cfg.HSTS.Header = "X-HSTS"
+	cfg.HSTS.IncludeSubDomains = false
+	cfg.HSTS.Preload = false
+	cfg.HSTS.MaxAge = 600
+	ctx = runSecurityHeaders(HSTSMiddleware(cfg), "https://example.com/")
+	if got := string(ctx.Response.Header.Peek("X-HSTS")); got != "max-age=600" {
+		t.Errorf("Expected custom HSTS header, got %q", got)
+	}
+}
+
+func TestHSTSMiddleware_Disabled(t *testing.T) {
+	https := ut.Header{Key: "X-Forwarded-Proto", Value: "https"}
+
+	tlsDisabled := hstsTLSConfig()
+	hstsDisabled := hstsTLSConfig()
+	hstsDisabled.Basic.Enable = true
+	hstsDisabled.HSTS.Enable = false
+
+	for name, mw := range map[string]Middleware{
+		"nil config":    HSTSMiddleware(nil),
+		"tls disabled":  HSTSMiddleware(tlsDisabled),
+		"hsts disabled": HSTSMiddleware(hstsDisabled),
+	} {
+		ctx := runSecurityHeaders(mw, "/", https)
+		if got := ctx.Response.Header.Peek("Strict-Transport-Security"); len(got) != 0 {
+			t.Errorf("%s: expected no HSTS header, got %q", name, got)
+		}
+		if ctx.Response.StatusCode() != 200 {
+			t.Errorf("%s: expected handler to run, got %d", name, ctx.Response.StatusCode())
+		}
+	}
+}
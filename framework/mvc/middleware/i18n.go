@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/i18n"
+)
+
+// I18nMiddleware 国际化中间件 - 根据 Accept-Language 解析请求语言，bundle 为 nil 时使用全局实例
+// 语言与消息包写入请求上下文，控制器可通过 Context.T 按请求语言翻译
+func I18nMiddleware(bundle *i18n.I18n) Middleware {
+	if bundle == nil {
+		bundle = i18n.Default()
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		locale := bundle.MatchLocale(string(ctx.GetHeader("Accept-Language")))
+		ctx.Set(i18n.LocaleKey, locale)
+		ctx.Set(i18n.BundleKey, bundle)
+		ctx.Response.Header.Set("Content-Language", locale)
+		ctx.Response.Header.Add("Vary", "Accept-Language")
+
+		ctx.Next(c)
+	}
+}
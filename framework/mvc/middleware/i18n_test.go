@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	"github.com/zsy619/yyhertz/framework/i18n"
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+func TestI18nMiddleware_ResolvesLocale(t *testing.T) {
+	bundle := i18n.NewI18n("en")
+	bundle.AddMessages("en", map[string]string{"greeting": "Hello, {name}!", "farewell": "Goodbye"})
+	bundle.AddMessages("zh-CN", map[string]string{"greeting": "你好，{name}！"})
+
+	translate := func(acceptLanguage string) (string, string, *app.RequestContext) {
+		var greeting, farewell string
+		ctx := ut.CreateUtRequestContext("GET", "/", nil, ut.Header{Key: "Accept-Language", Value: acceptLanguage})
+		ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(I18nMiddleware(bundle)), func(c context.Context, rc *app.RequestContext) {
+			mc := mvccontext.NewContext(rc)
+			greeting = mc.T("greeting", i18n.Params{"name": "Alice"})
+			farewell = mc.T("farewell")
+		}})
+		ctx.Next(context.Background())
+		return greeting, farewell, ctx
+	}
+
+	greeting, farewell, ctx := translate("zh-CN,zh;q=0.9,en;q=0.8")
+	if greeting != "你好，Alice！" {
+		t.Errorf("Expected Chinese greeting, got %q", greeting)
+	}
+	if farewell != "Goodbye" {
+		t.Errorf("Expected fallback to default locale, got %q", farewell)
+	}
+	if got := string(ctx.Response.Header.Peek("Content-Language")); got != "zh-CN" {
+		t.Errorf("Expected Content-Language zh-CN, got %q", got)
+	}
+
+	greeting, _, _ = translate("fr-FR")
+	if greeting != "Hello, Alice!" {
+		t.Errorf("Expected default locale for unsupported language, got %q", greeting)
+	}
+}
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/cache"
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// DefaultIdempotencyHeader 携带幂等键的默认请求头
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyConfig 幂等键中间件配置
+type IdempotencyConfig struct {
+	Store   cache.DistributedCache // 响应缓存，默认使用进程内存；多实例部署时应使用共享缓存
+	Locker  *cache.DistributedLock // 并发重复请求的互斥锁，默认进程内互斥；多实例部署时应使用分布式锁
+	TTL     time.Duration          // 响应缓存时间，默认24小时
+	LockTTL time.Duration          // 处理中锁的过期时间，默认30秒
+	Header  string                 // 幂等键请求头，默认 Idempotency-Key
+	Methods []string               // 需要幂等处理的方法，默认 POST、PUT、PATCH
+	KeyFunc IdempotencyKeyFunc     // 幂等键的归属主体，默认 IdempotencyKeyByPrincipal
+}
+
+// IdempotencyKeyFunc 返回请求所属的主体，幂等键按主体隔离，不同主体使用相同幂等键互不影响
+type IdempotencyKeyFunc func(ctx *app.RequestContext) string
+
+// IdempotencyKeyByPrincipal 默认主体：优先使用认证中间件写入的 user_id，未认证时使用客户端IP
+func IdempotencyKeyByPrincipal(ctx *app.RequestContext) string {
+	if userID := ctx.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+// idempotentResponse 缓存的响应
+type idempotentResponse struct {
+	Fingerprint string `json:"fingerprint"` // 请求体摘要，同一幂等键只能用于相同的请求
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyMiddleware 幂等键中间件 - 携带幂等键的请求首次执行后缓存响应（状态码与响应体），
+// 重复请求直接重放缓存的响应而不再执行处理器；相同幂等键的并发请求返回409，
+// 同一幂等键用于不同请求体时返回422；5xx响应不缓存，客户端可使用同一幂等键重试
+// 幂等键按请求主体（KeyFunc，默认为认证用户，未认证时为客户端IP）隔离，不同用户的相同幂等键不会共享响应
+func IdempotencyMiddleware(cfg IdempotencyConfig) Middleware {
+	if cfg.Store == nil {
+		store := cache.NewMemoryDistributedCache("idempotency:")
+		// 登记进程内响应缓存，供缓存管理接口查看与清空
+		cache.Register(cache.NewInspector(cache.ResponseCacheName, "幂等响应缓存", store.Len, func() { store.Clear() }))
+		cfg.Store = store
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = 30 * time.Second
+	}
+	if cfg.Header == "" {
+		cfg.Header = DefaultIdempotencyHeader
+	}
+	if len(cfg.Methods) == 0 {
+		cfg.Methods = []string{"POST", "PUT", "PATCH"}
+	}
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = IdempotencyKeyByPrincipal
+	}
+	acquire := localIdempotencyLock()
+	if cfg.Locker != nil {
+		acquire = distributedIdempotencyLock(cfg.Locker, cfg.LockTTL)
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		key := string(ctx.GetHeader(cfg.Header))
+		if key == "" || !methodIn(string(ctx.Method()), cfg.Methods) {
+			ctx.Next(c)
+			return
+		}
+
+		cacheKey := cfg.KeyFunc(ctx) + ":" + string(ctx.Method()) + ":" + string(ctx.Path()) + ":" + key
+		sum := sha256.Sum256(ctx.Request.Body())
+		fingerprint := hex.EncodeToString(sum[:])
+
+		if replayIdempotentResponse(ctx, cfg.Store, cacheKey, fingerprint) {
+			return
+		}
+
+		release, ok := acquire(cacheKey)
+		if !ok {
+			ctx.JSON(409, map[string]any{
+				"error": "A request with this idempotency key is already in progress",
+				"code":  "IDEMPOTENCY_IN_PROGRESS",
+			})
+			ctx.Abort()
+			return
+		}
+		defer release()
+
+		// 获取锁期间其他请求可能已完成
+		if replayIdempotentResponse(ctx, cfg.Store, cacheKey, fingerprint) {
+			return
+		}
+
+		ctx.Next(c)
+
+		status := ctx.Response.StatusCode()
+		if status >= 500 || ctx.Response.IsBodyStream() {
+			return
+		}
+		record, err := json.Marshal(idempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: string(ctx.Response.Header.ContentType()),
+			Body:        append([]byte(nil), ctx.Response.Body()...),
+		})
+		if err == nil {
+			err = cfg.Store.Set(cacheKey, string(record), cfg.TTL)
+		}
+		if err != nil {
+			config.Errorf("Failed to store idempotent response for %s: %v", cacheKey, err)
+		}
+	}
+}
+
+// replayIdempotentResponse 存在缓存的响应时重放并返回true
+func replayIdempotentResponse(ctx *app.RequestContext, store cache.DistributedCache, cacheKey, fingerprint string) bool {
+	value, found, err := store.Get(cacheKey)
+	if err != nil || !found {
+		return false
+	}
+	raw, ok := value.(string)
+	if !ok {
+		return false
+	}
+	var record idempotentResponse
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return false
+	}
+
+	if record.Fingerprint != fingerprint {
+		ctx.JSON(422, map[string]any{
+			"error": "Idempotency key was already used with a different request body",
+			"code":  "IDEMPOTENCY_KEY_REUSED",
+		})
+		ctx.Abort()
+		return true
+	}
+
+	ctx.Response.Header.Set("Idempotent-Replayed", "true")
+	ctx.Data(record.Status, record.ContentType, record.Body)
+	ctx.Abort()
+	return true
+}
+
+// idempotencyLock 获取幂等键的处理锁，返回释放函数与是否获取成功
+type idempotencyLock func(key string) (release func(), ok bool)
+
+// localIdempotencyLock 进程内互斥
+func localIdempotencyLock() idempotencyLock {
+	var inFlight sync.Map
+	return func(key string) (func(), bool) {
+		if _, loaded := inFlight.LoadOrStore(key, struct{}{}); loaded {
+			return nil, false
+		}
+		return func() { inFlight.Delete(key) }, true
+	}
+}
+
+// distributedIdempotencyLock 基于分布式锁的互斥，锁服务异常时按未获取处理
+func distributedIdempotencyLock(locker *cache.DistributedLock, ttl time.Duration) idempotencyLock {
+	return func(key string) (func(), bool) {
+		lock, err := locker.TryLock("idempotency:"+key, ttl)
+		if err != nil {
+			return nil, false
+		}
+		return func() { _ = lock.Unlock() }, true
+	}
+}
+
+// methodIn 检查方法是否在列表中（不区分大小写）
+func methodIn(method string, methods []string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	"github.com/zsy619/yyhertz/framework/cache"
+)
+
+// runIdempotent 使用幂等键执行POST请求
+func runIdempotent(mw Middleware, handler app.HandlerFunc, key, body string) *app.RequestContext {
+	headers := []ut.Header{{Key: "Content-Type", Value: "application/json"}}
+	if key != "" {
+		headers = append(headers, ut.Header{Key: DefaultIdempotencyHeader, Value: key})
+	}
+	ctx := ut.CreateUtRequestContext("POST", "/orders", &ut.Body{Body: strings.NewReader(body), Len: len(body)}, headers...)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), handler})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+func TestIdempotencyMiddleware_ReplaysDuplicatePost(t *testing.T) {
+	var calls int32
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		n := atomic.AddInt32(&calls, 1)
+		ctx.JSON(201, map[string]any{"order": n})
+	}
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+
+	first := runIdempotent(mw, handler, "order-1", `{"item":"book"}`)
+	second := runIdempotent(mw, handler, "order-1", `{"item":"book"}`)
+
+	if calls != 1 {
+		t.Fatalf("Expected handler to run once, ran %d times", calls)
+	}
+	if second.Response.StatusCode() != 201 || string(second.Response.Body()) != string(first.Response.Body()) {
+		t.Errorf("Expected replayed 201 %s, got %d %s", first.Response.Body(), second.Response.StatusCode(), second.Response.Body())
+	}
+	if got := string(second.Response.Header.Peek("Idempotent-Replayed")); got != "true" {
+		t.Errorf("Expected Idempotent-Replayed header, got %q", got)
+	}
+	if !strings.HasPrefix(string(second.Response.Header.ContentType()), "application/json") {
+		t.Errorf("Expected replayed content type, got %q", second.Response.Header.ContentType())
+	}
+
+	// 不同的幂等键或未携带幂等键时正常执行
+	runIdempotent(mw, handler, "order-2", `{"item":"book"}`)
+	runIdempotent(mw, handler, "", `{"item":"book"}`)
+	if calls != 3 {
+		t.Errorf("Expected new keys to execute the handler, ran %d times", calls)
+	}
+}
+
+func TestIdempotencyMiddleware_RejectsReusedKeyWithDifferentBody(t *testing.T) {
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(201, map[string]any{"ok": true})
+	}
+
+	runIdempotent(mw, handler, "order-1", `{"item":"book"}`)
+	ctx := runIdempotent(mw, handler, "order-1", `{"item":"pen"}`)
+	if ctx.Response.StatusCode() != 422 {
+		t.Errorf("Expected 422 for reused key, got %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestIdempotencyMiddleware_ConcurrentDuplicate(t *testing.T) {
+	var calls int32
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+
+	var duplicate *app.RequestContext
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		atomic.AddInt32(&calls, 1)
+		// 首个请求处理期间到达的重复请求
+		duplicate = runIdempotent(mw, func(c context.Context, ctx *app.RequestContext) {
+			atomic.AddInt32(&calls, 1)
+		}, "order-1", `{}`)
+		ctx.JSON(201, map[string]any{"ok": true})
+	}
+	runIdempotent(mw, handler, "order-1", `{}`)
+
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls)
+	}
+	if duplicate.Response.StatusCode() != 409 {
+		t.Errorf("Expected concurrent duplicate to get 409, got %d", duplicate.Response.StatusCode())
+	}
+}
+
+func TestIdempotencyMiddleware_DoesNotCacheServerErrors(t *testing.T) {
+	var calls int32
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			ctx.JSON(503, map[string]any{"error": "unavailable"})
+			return
+		}
+		ctx.JSON(201, map[string]any{"ok": true})
+	}
+
+	runIdempotent(mw, handler, "order-1", `{}`)
+	retry := runIdempotent(mw, handler, "order-1", `{}`)
+	if calls != 2 || retry.Response.StatusCode() != 201 {
+		t.Errorf("Expected retry after 5xx to execute again, calls=%d status=%d", calls, retry.Response.StatusCode())
+	}
+}
+
+func TestIdempotencyMiddleware_ScopesKeyByUser(t *testing.T) {
+	var calls int32
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		n := atomic.AddInt32(&calls, 1)
+		ctx.JSON(201, map[string]any{"order": n, "user": ctx.GetString("user_id")})
+	}
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+
+	// runAs 以指定用户身份发送携带相同幂等键的请求
+	runAs := func(userID string) *app.RequestContext {
+		body := `{"item":"book"}`
+		ctx := ut.CreateUtRequestContext("POST", "/orders", &ut.Body{Body: strings.NewReader(body), Len: len(body)},
+			ut.Header{Key: "Content-Type", Value: "application/json"},
+			ut.Header{Key: DefaultIdempotencyHeader, Value: "order-1"})
+		ctx.Set("user_id", userID)
+		ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), handler})
+		ctx.Next(context.Background())
+		return ctx
+	}
+
+	alice := runAs("alice")
+	bob := runAs("bob")
+	if calls != 2 {
+		t.Fatalf("Expected each user's request to execute the handler, ran %d times", calls)
+	}
+	if got := string(bob.Response.Header.Peek("Idempotent-Replayed")); got != "" {
+		t.Error("bob must not receive alice's cached response")
+	}
+	if strings.Contains(string(bob.Response.Body()), "alice") {
+		t.Errorf("Response leaked across users: %s", bob.Response.Body())
+	}
+
+	// 同一用户重复请求仍然重放自己的响应
+	replay := runAs("alice")
+	if calls != 2 || string(replay.Response.Body()) != string(alice.Response.Body()) {
+		t.Errorf("Expected alice's duplicate request to replay her response, got %s", replay.Response.Body())
+	}
+}
+
+func TestIdempotencyMiddleware_RegistersResponseCache(t *testing.T) {
+	mw := IdempotencyMiddleware(IdempotencyConfig{})
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(201, map[string]any{"ok": true})
+	}
+	runIdempotent(mw, handler, "order-1", `{"item":"book"}`)
+	runIdempotent(mw, handler, "order-2", `{"item":"book"}`)
+
+	inspector, ok := cache.Lookup(cache.ResponseCacheName)
+	if !ok {
+		t.Fatal("Expected response cache to be registered")
+	}
+	if size := inspector.GetStats().Size; size != 2 {
+		t.Errorf("Expected 2 cached responses, got %d", size)
+	}
+
+	// 清空后相同幂等键重新执行处理器
+	inspector.Clear()
+	if size := inspector.GetStats().Size; size != 0 {
+		t.Errorf("Expected flushed response cache to be empty, got %d", size)
+	}
+	replay := runIdempotent(mw, handler, "order-1", `{"item":"book"}`)
+	if got := string(replay.Response.Header.Peek("Idempotent-Replayed")); got != "" {
+		t.Errorf("Expected handler to run after flush, got replay header %q", got)
+	}
+}
@@ -2,12 +2,14 @@ package middleware
 
 import (
 	"context"
+	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
 
 	"github.com/zsy619/yyhertz/framework/config"
-	"github.com/zsy619/yyhertz/framework/util"
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
 )
 
 // MiddlewareLoggerConfig 日志中间件配置
@@ -16,6 +18,54 @@ type MiddlewareLoggerConfig struct {
 	EnableResponseBody bool     // 是否记录响应体
 	SkipPaths          []string // 跳过记录的路径
 	MaxBodySize        int      // 最大记录的Body大小
+
+	SlowThreshold time.Duration // 慢请求阈值，超过时以Warn级别记录并附带耗时分解（0表示不检测）
+	SlowOnly      bool          // 仅记录慢请求和服务端错误请求（需设置SlowThreshold）
+
+	// SampleRates 按路径前缀配置访问日志采样率（0-1），最长前缀优先，未匹配的路径全部记录
+	// 未被采样的请求不输出访问日志，但服务端错误和慢请求始终记录
+	SampleRates map[string]float64
+	Sampler     func(rate float64) bool // 采样判定，默认按采样率随机采样
+}
+
+// RequestTimingsKey 请求上下文中耗时分解的键
+const RequestTimingsKey = "request_timings"
+
+// AddRequestTiming 记录请求处理中某个阶段的耗时，慢请求日志中将包含这些阶段
+func AddRequestTiming(ctx *app.RequestContext, phase string, duration time.Duration) {
+	value, _ := ctx.Get(RequestTimingsKey)
+	timings, _ := value.(map[string]time.Duration)
+	if timings == nil {
+		timings = make(map[string]time.Duration)
+		ctx.Set(RequestTimingsKey, timings)
+	}
+	timings[phase] += duration
+}
+
+// sampleRate 获取路径的采样率，按最长前缀匹配，未配置时为1
+func (cfg *MiddlewareLoggerConfig) sampleRate(path string) float64 {
+	rate, matched := 1.0, -1
+	for prefix, r := range cfg.SampleRates {
+		if len(prefix) > matched && strings.HasPrefix(path, prefix) {
+			rate, matched = r, len(prefix)
+		}
+	}
+	return rate
+}
+
+// sampled 判定本次请求是否记录访问日志
+func (cfg *MiddlewareLoggerConfig) sampled(path string) bool {
+	rate := cfg.sampleRate(path)
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	if cfg.Sampler != nil {
+		return cfg.Sampler(rate)
+	}
+	return rand.Float64() < rate
 }
 
 // DefaultLoggerConfig 返回默认日志中间件配置
@@ -47,9 +97,8 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 			}
 		}
 
-		// 生成请求ID
-		requestID := util.ShortID()
-		ctx.Set("request_id", requestID)
+		// 沿用上游请求ID或生成新的请求ID
+		requestID := EnsureRequestID(ctx)
 
 		// 记录请求开始
 		fields := map[string]any{
@@ -60,6 +109,11 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 			"client_ip":  ctx.ClientIP(),
 			"timestamp":  start.Format(time.RFC3339),
 		}
+		// 追踪中间件先于日志中间件执行，记录追踪ID便于关联调用链
+		traceID := ctx.GetString(TraceIDKey)
+		if traceID != "" {
+			fields["trace_id"] = traceID
+		}
 
 		// 记录请求体（如果启用）
 		if logConfig.EnableRequestBody && ctx.Request.Body() != nil {
@@ -72,13 +126,20 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 			}
 		}
 
-		config.WithFields(fields).Info("Request started")
+		// 仅记录慢请求或未被采样时不输出请求开始日志
+		quiet := (logConfig.SlowOnly && logConfig.SlowThreshold > 0) || !logConfig.sampled(path)
+		if !quiet {
+			config.WithFields(fields).Info("Request started")
+		}
 
 		// 继续处理请求
+		handlerStart := time.Now()
 		ctx.Next(c)
+		handlerDuration := time.Since(handlerStart)
 
 		// 计算处理时间
 		duration := time.Since(start)
+		slow := logConfig.SlowThreshold > 0 && duration >= logConfig.SlowThreshold
 		statusCode := ctx.Response.StatusCode()
 
 		// 准备响应日志字段
@@ -91,6 +152,15 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 			"duration_ms": duration.Milliseconds(),
 			"duration":    duration.String(),
 		}
+		if traceID != "" {
+			responseFields["trace_id"] = traceID
+		}
+		// 处理器通过 Context.WithLogFields 添加的请求级字段，不覆盖内置字段
+		for k, v := range mvccontext.RequestLogFields(ctx) {
+			if _, exists := responseFields[k]; !exists {
+				responseFields[k] = v
+			}
+		}
 
 		// 记录响应体（如果启用）
 		if logConfig.EnableResponseBody {
@@ -103,9 +173,18 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 			}
 		}
 
-		// 根据状态码选择日志级别使用单例日志系统
+		if slow {
+			responseFields["slow_threshold"] = logConfig.SlowThreshold.String()
+			responseFields["duration_breakdown"] = requestDurationBreakdown(ctx, duration, handlerDuration)
+		}
+
+		// 根据状态码选择日志级别使用单例日志系统，慢请求升级为Warn
 		if statusCode >= 500 {
 			config.WithFields(responseFields).Error("Request completed with server error")
+		} else if slow {
+			config.WithFields(responseFields).Warn("Slow request detected")
+		} else if quiet {
+			return
 		} else if statusCode >= 400 {
 			config.WithFields(responseFields).Warn("Request completed with client error")
 		} else {
@@ -114,6 +193,26 @@ func LoggerMiddlewareWithConfig(logConfig *MiddlewareLoggerConfig) Middleware {
 	}
 }
 
+// requestDurationBreakdown 请求耗时分解（毫秒）：中间件链处理、日志中间件自身开销及记录的各阶段
+func requestDurationBreakdown(ctx *app.RequestContext, total, handler time.Duration) map[string]float64 {
+	breakdown := map[string]float64{
+		"handler_ms": durationMillis(handler),
+		"logger_ms":  durationMillis(total - handler),
+	}
+	value, _ := ctx.Get(RequestTimingsKey)
+	if timings, ok := value.(map[string]time.Duration); ok {
+		for phase, d := range timings {
+			breakdown[phase+"_ms"] = durationMillis(d)
+		}
+	}
+	return breakdown
+}
+
+// durationMillis 转换为毫秒（保留小数）
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 // AccessLogMiddleware 简化的访问日志中间件
 func AccessLogMiddleware() Middleware {
 	return func(c context.Context, ctx *app.RequestContext) {
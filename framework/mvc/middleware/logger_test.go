@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/zsy619/yyhertz/framework/config"
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+// runLoggedRequest 使用日志中间件执行一次请求，返回记录的请求完成日志
+func runLoggedRequest(t *testing.T, logConfig *MiddlewareLoggerConfig, handler app.HandlerFunc) []*logrus.Entry {
+	t.Helper()
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	defer hook.Reset()
+
+	ctx := ut.CreateUtRequestContext("GET", "/api/orders", nil)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(LoggerMiddlewareWithConfig(logConfig)), handler})
+	ctx.Next(context.Background())
+
+	var entries []*logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["path"] == "/api/orders" {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 && !logConfig.SlowOnly {
+		t.Fatal("Expected request to be logged")
+	}
+	return entries
+}
+
+func TestLoggerMiddleware_SlowRequestEscalates(t *testing.T) {
+	logConfig := DefaultLoggerConfig()
+	logConfig.SlowThreshold = 50 * time.Millisecond
+
+	// 快速请求以Info级别记录
+	entries := runLoggedRequest(t, logConfig, func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(200, "ok")
+	})
+	last := entries[len(entries)-1]
+	if last.Level != logrus.InfoLevel {
+		t.Errorf("Expected fast request to log at info, got %s", last.Level)
+	}
+	if _, ok := last.Data["duration_breakdown"]; ok {
+		t.Error("Fast request should not include duration breakdown")
+	}
+
+	// 慢请求升级为Warn并附带耗时分解
+	entries = runLoggedRequest(t, logConfig, func(c context.Context, ctx *app.RequestContext) {
+		time.Sleep(30 * time.Millisecond)
+		AddRequestTiming(ctx, "db", 30*time.Millisecond)
+		time.Sleep(30 * time.Millisecond)
+		ctx.String(200, "ok")
+	})
+	last = entries[len(entries)-1]
+	if last.Level != logrus.WarnLevel || last.Message != "Slow request detected" {
+		t.Fatalf("Expected slow request to escalate to warn, got %s %q", last.Level, last.Message)
+	}
+	breakdown, ok := last.Data["duration_breakdown"].(map[string]float64)
+	if !ok {
+		t.Fatalf("Expected duration breakdown, got %v", last.Data["duration_breakdown"])
+	}
+	if breakdown["handler_ms"] < 50 {
+		t.Errorf("Expected handler duration above threshold, got %v", breakdown["handler_ms"])
+	}
+	if breakdown["db_ms"] != 30 {
+		t.Errorf("Expected recorded db phase of 30ms, got %v", breakdown["db_ms"])
+	}
+	if last.Data["slow_threshold"] != "50ms" {
+		t.Errorf("Expected slow threshold field, got %v", last.Data["slow_threshold"])
+	}
+}
+
+func TestLoggerMiddleware_SlowOnlySkipsFastRequests(t *testing.T) {
+	logConfig := DefaultLoggerConfig()
+	logConfig.SlowThreshold = 50 * time.Millisecond
+	logConfig.SlowOnly = true
+
+	entries := runLoggedRequest(t, logConfig, func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(404, "missing")
+	})
+	if len(entries) != 0 {
+		t.Errorf("Expected fast request to be skipped, got %d entries", len(entries))
+	}
+
+	// 服务端错误仍然记录
+	entries = runLoggedRequest(t, logConfig, func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(500, "boom")
+	})
+	if len(entries) != 1 || entries[0].Level != logrus.ErrorLevel {
+		t.Errorf("Expected server error to be logged once at error level, got %d entries", len(entries))
+	}
+
+	entries = runLoggedRequest(t, logConfig, func(c context.Context, ctx *app.RequestContext) {
+		time.Sleep(60 * time.Millisecond)
+		ctx.String(200, "ok")
+	})
+	if len(entries) != 1 || entries[0].Level != logrus.WarnLevel {
+		t.Errorf("Expected only the slow request to be logged at warn, got %d entries", len(entries))
+	}
+}
+
+func TestLoggerMiddleware_IncludesRequestScopedFields(t *testing.T) {
+	entries := runLoggedRequest(t, DefaultLoggerConfig(), func(c context.Context, ctx *app.RequestContext) {
+		mvccontext.NewContext(ctx).WithLogFields(map[string]any{"tenant": "acme", "status_code": "ignored"})
+		ctx.String(200, "ok")
+	})
+
+	last := entries[len(entries)-1]
+	if last.Data["tenant"] != "acme" {
+		t.Errorf("Expected request-scoped field in completion log, got %v", last.Data["tenant"])
+	}
+	if last.Data["status_code"] != 200 {
+		t.Errorf("Request-scoped fields must not override built-in fields, got %v", last.Data["status_code"])
+	}
+	if last.Data["request_id"] == nil {
+		t.Error("Expected request_id in completion log")
+	}
+}
+
+// countSampledRequests 执行n次请求，返回记录的请求完成日志条数
+func countSampledRequests(logConfig *MiddlewareLoggerConfig, path string, status, n int) int {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	defer hook.Reset()
+
+	mw := LoggerMiddlewareWithConfig(logConfig)
+	for i := 0; i < n; i++ {
+		ctx := ut.CreateUtRequestContext("GET", path, nil)
+		ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), func(c context.Context, ctx *app.RequestContext) {
+			ctx.String(status, "ok")
+		}})
+		ctx.Next(context.Background())
+	}
+
+	count := 0
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["path"] == path && entry.Data["status_code"] != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func TestLoggerMiddleware_SamplesHighVolumePaths(t *testing.T) {
+	logConfig := DefaultLoggerConfig()
+	logConfig.SampleRates = map[string]float64{
+		"/api/":       0.1,
+		"/api/audit/": 1,
+	}
+
+	// 约10%的访问日志被记录
+	if got := countSampledRequests(logConfig, "/api/metrics", 200, 2000); got < 120 || got > 280 {
+		t.Errorf("Expected about 200 of 2000 requests to be logged, got %d", got)
+	}
+	// 最长前缀优先
+	if got := countSampledRequests(logConfig, "/api/audit/events", 200, 50); got != 50 {
+		t.Errorf("Expected every audit request to be logged, got %d", got)
+	}
+	// 未配置采样的路径全部记录
+	if got := countSampledRequests(logConfig, "/orders", 200, 50); got != 50 {
+		t.Errorf("Expected unsampled path to be fully logged, got %d", got)
+	}
+}
+
+func TestLoggerMiddleware_SamplingAlwaysLogsServerErrors(t *testing.T) {
+	logConfig := DefaultLoggerConfig()
+	logConfig.SampleRates = map[string]float64{"/api/": 0.1}
+	logConfig.Sampler = func(rate float64) bool { return false }
+
+	if got := countSampledRequests(logConfig, "/api/metrics", 200, 100); got != 0 {
+		t.Errorf("Expected unsampled successful requests to be skipped, got %d", got)
+	}
+	if got := countSampledRequests(logConfig, "/api/metrics", 503, 100); got != 100 {
+		t.Errorf("Expected every 5xx request to be logged, got %d", got)
+	}
+}
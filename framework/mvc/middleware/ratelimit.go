@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"context"
+	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
@@ -172,3 +175,158 @@ func IPBasedRateLimitMiddleware(maxRequests int, duration time.Duration) Middlew
 		ctx.Next(c)
 	}
 }
+
+// RateLimitKeyFunc 限流分区键函数，返回空字符串表示不限流
+type RateLimitKeyFunc func(ctx *app.RequestContext) string
+
+// RateLimitKeyByIP 按客户端IP分区
+func RateLimitKeyByIP(ctx *app.RequestContext) string {
+	return ctx.ClientIP()
+}
+
+// RateLimitKeyByRouteAndIP 按路由（方法+路径）与客户端IP组合分区
+func RateLimitKeyByRouteAndIP(ctx *app.RequestContext) string {
+	route := ctx.FullPath()
+	if route == "" {
+		route = string(ctx.Path())
+	}
+	return string(ctx.Method()) + ":" + route + ":" + ctx.ClientIP()
+}
+
+// tokenBucket 单个分区的令牌桶
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// KeyedRateLimiter 按键分区的令牌桶限流器，定期清理空闲的令牌桶
+type KeyedRateLimiter struct {
+	capacity    float64       // 桶容量（突发上限）
+	refillRate  float64       // 每秒补充的令牌数
+	idleTimeout time.Duration // 空闲超过该时间的桶会被清理
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+	mu          sync.Mutex
+	now         func() time.Time
+}
+
+// NewKeyedRateLimiter 创建按键分区的限流器，每个键在duration内最多允许maxRequests个请求
+func NewKeyedRateLimiter(maxRequests int, duration time.Duration) *KeyedRateLimiter {
+	if maxRequests <= 0 {
+		maxRequests = 1
+	}
+	if duration <= 0 {
+		duration = time.Second
+	}
+	return &KeyedRateLimiter{
+		capacity:    float64(maxRequests),
+		refillRate:  float64(maxRequests) / duration.Seconds(),
+		idleTimeout: duration,
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// Allow 为指定键消耗一个令牌
+// 返回是否允许、剩余令牌数，以及被拒绝时建议的重试等待时间
+func (l *KeyedRateLimiter) Allow(key string) (allowed bool, remaining int, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanupLocked(now)
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.capacity, lastSeen: now}
+		l.buckets[key] = bucket
+	} else {
+		elapsed := now.Sub(bucket.lastSeen).Seconds()
+		bucket.tokens = math.Min(l.capacity, bucket.tokens+elapsed*l.refillRate)
+		bucket.lastSeen = now
+	}
+
+	if bucket.tokens < 1 {
+		wait := (1 - bucket.tokens) / l.refillRate
+		return false, 0, time.Duration(wait * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return true, int(bucket.tokens), 0
+}
+
+// Len 返回当前持有的令牌桶数量
+func (l *KeyedRateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// cleanupLocked 清理空闲令牌桶（空闲超过idleTimeout的桶已补满，删除后与新建等价）
+func (l *KeyedRateLimiter) cleanupLocked(now time.Time) {
+	if now.Sub(l.lastCleanup) < l.idleTimeout {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) >= l.idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// RateLimitMiddlewareByKey 按键分区的限流中间件
+// 每个键（如客户端IP、路由+IP）拥有独立的令牌桶，在duration内最多允许maxRequests个请求
+func RateLimitMiddlewareByKey(maxRequests int, duration time.Duration, keyFunc RateLimitKeyFunc) Middleware {
+	return RateLimitMiddlewareWithLimiter(NewKeyedRateLimiter(maxRequests, duration), keyFunc)
+}
+
+// RateLimitMiddlewareWithLimiter 使用指定限流器的限流中间件
+func RateLimitMiddlewareWithLimiter(limiter *KeyedRateLimiter, keyFunc RateLimitKeyFunc) Middleware {
+	if keyFunc == nil {
+		keyFunc = RateLimitKeyByIP
+	}
+	limit := strconv.Itoa(int(limiter.capacity))
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		key := keyFunc(ctx)
+		if key == "" {
+			ctx.Next(c)
+			return
+		}
+
+		allowed, remaining, retryAfter := limiter.Allow(key)
+		ctx.Header("X-RateLimit-Limit", limit)
+		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			retrySeconds := int(math.Ceil(retryAfter.Seconds()))
+			if retrySeconds < 1 {
+				retrySeconds = 1
+			}
+			ctx.Header("Retry-After", strconv.Itoa(retrySeconds))
+
+			fields := map[string]any{
+				"event":       "keyed_rate_limit_exceeded",
+				"limit_key":   key,
+				"path":        string(ctx.Path()),
+				"method":      string(ctx.Method()),
+				"retry_after": retrySeconds,
+			}
+			go func() {
+				config.WithFields(fields).Warn("Keyed rate limit exceeded")
+			}()
+
+			ctx.JSON(429, map[string]any{
+				"error":       "Rate limit exceeded",
+				"message":     "请求过于频繁，请稍后再试",
+				"retry_after": retrySeconds,
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next(c)
+	}
+}
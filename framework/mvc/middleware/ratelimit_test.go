@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// newRateLimitTestContext 创建指定客户端IP的测试请求上下文
+func newRateLimitTestContext(path, clientIP string) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", path, nil)
+	ctx.SetClientIPFunc(func(*app.RequestContext) string { return clientIP })
+	return ctx
+}
+
+func TestRateLimitMiddlewareByKey_IndependentIPQuotas(t *testing.T) {
+	mw := RateLimitMiddlewareByKey(2, time.Minute, RateLimitKeyByIP)
+
+	for i := 0; i < 2; i++ {
+		ctx := newRateLimitTestContext("/api/users", "10.0.0.1")
+		mw(context.Background(), ctx)
+		if ctx.IsAborted() {
+			t.Fatalf("Request %d from 10.0.0.1 should be allowed", i+1)
+		}
+	}
+
+	blocked := newRateLimitTestContext("/api/users", "10.0.0.1")
+	mw(context.Background(), blocked)
+	if !blocked.IsAborted() || blocked.Response.StatusCode() != 429 {
+		t.Fatalf("Third request from 10.0.0.1 should be rejected, got status %d", blocked.Response.StatusCode())
+	}
+	if got := string(blocked.Response.Header.Peek("X-RateLimit-Remaining")); got != "0" {
+		t.Errorf("Expected X-RateLimit-Remaining 0, got %q", got)
+	}
+	if got := string(blocked.Response.Header.Peek("Retry-After")); got == "" {
+		t.Error("Expected Retry-After header on rejected request")
+	}
+
+	// 另一个IP拥有独立的配额
+	other := newRateLimitTestContext("/api/users", "10.0.0.2")
+	mw(context.Background(), other)
+	if other.IsAborted() {
+		t.Fatal("Request from 10.0.0.2 should not be affected by 10.0.0.1's quota")
+	}
+	if got := string(other.Response.Header.Peek("X-RateLimit-Remaining")); got != "1" {
+		t.Errorf("Expected X-RateLimit-Remaining 1 for 10.0.0.2, got %q", got)
+	}
+}
+
+func TestRateLimitMiddlewareByKey_RouteAndIP(t *testing.T) {
+	mw := RateLimitMiddlewareByKey(1, time.Minute, RateLimitKeyByRouteAndIP)
+
+	first := newRateLimitTestContext("/api/users", "10.0.0.1")
+	mw(context.Background(), first)
+	second := newRateLimitTestContext("/api/orders", "10.0.0.1")
+	mw(context.Background(), second)
+
+	if first.IsAborted() || second.IsAborted() {
+		t.Fatal("Different routes from the same IP should have independent quotas")
+	}
+}
+
+func TestKeyedRateLimiter_RefillAndCleanup(t *testing.T) {
+	now := time.Now()
+	limiter := NewKeyedRateLimiter(1, time.Second)
+	limiter.now = func() time.Time { return now }
+
+	if allowed, _, _ := limiter.Allow("a"); !allowed {
+		t.Fatal("First request should be allowed")
+	}
+	allowed, _, retryAfter := limiter.Allow("a")
+	if allowed {
+		t.Fatal("Second request should be rejected")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("Unexpected retryAfter %v", retryAfter)
+	}
+
+	// 令牌补充
+	now = now.Add(time.Second)
+	if allowed, _, _ := limiter.Allow("a"); !allowed {
+		t.Fatal("Request after refill should be allowed")
+	}
+
+	// 空闲的桶被清理
+	limiter.Allow("b")
+	now = now.Add(2 * time.Second)
+	limiter.Allow("c")
+	if limiter.Len() != 1 {
+		t.Errorf("Expected idle buckets to be cleaned up, got %d buckets", limiter.Len())
+	}
+}
@@ -12,42 +12,82 @@ import (
 	"github.com/zsy619/yyhertz/framework/response"
 )
 
+// RecoveryConfig 恢复中间件配置
+type RecoveryConfig struct {
+	// ErrorBody 生成500响应体，默认返回标准错误响应
+	ErrorBody func(ctx *app.RequestContext, recovered any) any
+	// OnPanic 捕获panic后调用（如发送告警），stack 为完整的goroutine堆栈
+	OnPanic func(ctx *app.RequestContext, recovered any, stack []byte)
+}
+
+// DefaultRecoveryConfig 默认恢复中间件配置
+func DefaultRecoveryConfig() RecoveryConfig {
+	return RecoveryConfig{
+		ErrorBody: func(ctx *app.RequestContext, recovered any) any {
+			return response.BuildErrorResp(errors.ServiceError.WithMessage("Internal Server Error"))
+		},
+	}
+}
+
 // RecoveryMiddleware 恢复中间件 - 捕获panic并恢复(参考FreeCar项目)
 func RecoveryMiddleware() Middleware {
+	return RecoveryMiddlewareWithConfig(DefaultRecoveryConfig())
+}
+
+// RecoveryMiddlewareWithConfig 带配置的恢复中间件
+// 捕获panic后记录完整堆栈（携带request_id），调用 OnPanic 钩子并返回500 JSON响应
+func RecoveryMiddlewareWithConfig(cfg RecoveryConfig) Middleware {
+	if cfg.ErrorBody == nil {
+		cfg.ErrorBody = DefaultRecoveryConfig().ErrorBody
+	}
+
 	return func(c context.Context, ctx *app.RequestContext) {
 		defer func() {
-			if err := recover(); err != nil {
-				// 使用单例日志系统记录详细的错误信息和堆栈
-				stack := debug.Stack()
-
-				// 获取请求信息用于结构化日志
-				method := string(ctx.Method())
-				path := string(ctx.Path())
-				clientIP := ctx.ClientIP()
-				userAgent := string(ctx.UserAgent())
-				go func() {
-					// 使用结构化日志记录panic信息
-					config.WithFields(map[string]any{
-						"error":      fmt.Sprintf("%v", err),
-						"method":     method,
-						"path":       path,
-						"client_ip":  clientIP,
-						"user_agent": userAgent,
-						"stack":      string(stack),
-					}).Error("PANIC recovered in middleware")
-				}()
-
-				// 返回标准错误响应
-				response := response.BuildErrorResp(errors.ServiceError.WithMessage("Internal Server Error"))
-				ctx.JSON(500, response)
-				ctx.Abort()
+			recovered := recover()
+			if recovered == nil {
+				return
 			}
+			stack := debug.Stack()
+
+			// 使用单例日志系统记录详细的错误信息和堆栈
+			fields := map[string]any{
+				"error":      fmt.Sprintf("%v", recovered),
+				"method":     string(ctx.Method()),
+				"path":       string(ctx.Path()),
+				"client_ip":  ctx.ClientIP(),
+				"user_agent": string(ctx.UserAgent()),
+				"stack":      string(stack),
+			}
+			if requestID := ctx.GetString("request_id"); requestID != "" {
+				fields["request_id"] = requestID
+			}
+			if traceID := ctx.GetString(TraceIDKey); traceID != "" {
+				fields["trace_id"] = traceID
+			}
+			config.WithFields(fields).Error("PANIC recovered in middleware")
+
+			if cfg.OnPanic != nil {
+				invokePanicHook(cfg.OnPanic, ctx, recovered, stack)
+			}
+
+			ctx.JSON(500, cfg.ErrorBody(ctx, recovered))
+			ctx.Abort()
 		}()
 
 		ctx.Next(c)
 	}
 }
 
+// invokePanicHook 调用panic钩子，钩子自身的panic不影响错误响应
+func invokePanicHook(hook func(*app.RequestContext, any, []byte), ctx *app.RequestContext, recovered any, stack []byte) {
+	defer func() {
+		if err := recover(); err != nil {
+			config.Errorf("Recovery OnPanic hook panicked: %v", err)
+		}
+	}()
+	hook(ctx, recovered, stack)
+}
+
 // RecoveryMiddlewareWithHandler 带自定义处理器的恢复中间件
 func RecoveryMiddlewareWithHandler(handler func(c context.Context, ctx *app.RequestContext, err any)) Middleware {
 	return func(c context.Context, ctx *app.RequestContext) {
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// panickingHandler 触发panic的处理器
+func panickingHandler(c context.Context, ctx *app.RequestContext) {
+	panic("boom")
+}
+
+// runRecovered 使用恢复中间件执行会panic的请求
+func runRecovered(mw Middleware) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", "/api/panic", nil)
+	ctx.Set("request_id", "req-42")
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), panickingHandler})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+func TestRecoveryMiddleware_LogsStackWithRequestID(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	defer hook.Reset()
+
+	ctx := runRecovered(RecoveryMiddleware())
+
+	if ctx.Response.StatusCode() != 500 {
+		t.Errorf("Expected status 500, got %d", ctx.Response.StatusCode())
+	}
+	var body map[string]any
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("Expected JSON error body, got %q: %v", ctx.Response.Body(), err)
+	}
+	if body["message"] != "Internal Server Error" {
+		t.Errorf("Expected default error message, got %v", body["message"])
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("Expected panic to be logged")
+	}
+	if entry.Data["request_id"] != "req-42" || entry.Data["error"] != "boom" {
+		t.Errorf("Expected request_id and error fields, got %v", entry.Data)
+	}
+	if stack, _ := entry.Data["stack"].(string); !strings.Contains(stack, "panickingHandler") {
+		t.Errorf("Expected logged stack to include the panicking handler, got %q", stack)
+	}
+}
+
+func TestRecoveryMiddlewareWithConfig_HookAndCustomBody(t *testing.T) {
+	var hookRecovered any
+	var hookStack []byte
+	mw := RecoveryMiddlewareWithConfig(RecoveryConfig{
+		ErrorBody: func(ctx *app.RequestContext, recovered any) any {
+			return map[string]any{"error": "unexpected", "code": "INTERNAL"}
+		},
+		OnPanic: func(ctx *app.RequestContext, recovered any, stack []byte) {
+			hookRecovered, hookStack = recovered, stack
+		},
+	})
+
+	ctx := runRecovered(mw)
+
+	if ctx.Response.StatusCode() != 500 {
+		t.Errorf("Expected status 500, got %d", ctx.Response.StatusCode())
+	}
+	if got := string(ctx.Response.Body()); got != `{"code":"INTERNAL","error":"unexpected"}` {
+		t.Errorf("Expected custom error body, got %s", got)
+	}
+	if hookRecovered != "boom" {
+		t.Errorf("Expected hook to receive recovered value, got %v", hookRecovered)
+	}
+	if !strings.Contains(string(hookStack), "panickingHandler") {
+		t.Errorf("Expected hook to receive full stack, got %q", hookStack)
+	}
+}
+
+func TestRecoveryMiddlewareWithConfig_HookPanicStillResponds(t *testing.T) {
+	mw := RecoveryMiddlewareWithConfig(RecoveryConfig{
+		OnPanic: func(ctx *app.RequestContext, recovered any, stack []byte) {
+			panic("alerting failed")
+		},
+	})
+
+	ctx := runRecovered(mw)
+	if ctx.Response.StatusCode() != 500 {
+		t.Errorf("Expected status 500 even when hook panics, got %d", ctx.Response.StatusCode())
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/mybatis"
+)
+
+// RequestCacheMiddleware 请求级查询缓存中间件 - 为每个请求开启MyBatis一级缓存
+// 同一请求内中间件与处理器重复执行的相同查询只访问一次数据库，请求结束时清空缓存；
+// 处理器需将传入的context.Context传给SimpleSession才能命中缓存
+func RequestCacheMiddleware() Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		cacheCtx, cache := mybatis.WithRequestCache(c)
+		defer cache.Clear()
+
+		ctx.Next(cacheCtx)
+	}
+}
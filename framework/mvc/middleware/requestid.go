@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+	"github.com/zsy619/yyhertz/framework/util"
+)
+
+// maxRequestIDLength 接受的上游请求ID最大长度
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware 请求ID中间件 - 沿用 X-Request-ID 请求头（或生成UUID），写入上下文并在响应头中回显
+// 追踪与日志中间件同样通过 EnsureRequestID 获取请求ID，同一请求内始终使用同一个ID
+func RequestIDMiddleware() Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		EnsureRequestID(ctx)
+		ctx.Next(c)
+	}
+}
+
+// EnsureRequestID 获取本次请求的请求ID，尚未设置时读取 X-Request-ID 请求头，缺失或不合法时生成UUID
+// 请求ID保存在上下文中（可通过 Context.RequestID 读取），并写入响应头 X-Request-ID
+func EnsureRequestID(ctx *app.RequestContext) string {
+	requestID := ctx.GetString(mvccontext.RequestIDKey)
+	if requestID == "" {
+		requestID = string(ctx.GetHeader(mvccontext.RequestIDHeader))
+		if !validRequestID(requestID) {
+			requestID = util.UUID()
+		}
+		ctx.Set(mvccontext.RequestIDKey, requestID)
+	}
+	ctx.Response.Header.Set(mvccontext.RequestIDHeader, requestID)
+	return requestID
+}
+
+// validRequestID 上游请求ID需非空、不超过最大长度且仅包含可见ASCII字符，避免日志与响应头注入
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
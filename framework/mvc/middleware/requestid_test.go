@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// runRequestID 使用请求ID中间件执行一次请求，返回处理器通过 Context.RequestID 读取到的请求ID
+func runRequestID(headers ...ut.Header) (*app.RequestContext, string) {
+	var seen string
+	ctx := ut.CreateUtRequestContext("GET", "/orders", nil, headers...)
+	ctx.SetHandlers(app.HandlersChain{
+		app.HandlerFunc(RequestIDMiddleware()),
+		func(c context.Context, ctx *app.RequestContext) {
+			seen = mvccontext.NewContext(ctx).RequestID()
+			ctx.String(200, "ok")
+		},
+	})
+	ctx.Next(context.Background())
+	return ctx, seen
+}
+
+func TestRequestIDMiddleware_GeneratesWhenAbsent(t *testing.T) {
+	ctx, seen := runRequestID()
+
+	if !uuidPattern.MatchString(seen) {
+		t.Errorf("Expected generated UUID, got %q", seen)
+	}
+	if echoed := string(ctx.Response.Header.Peek("X-Request-ID")); echoed != seen {
+		t.Errorf("Expected response header %q, got %q", seen, echoed)
+	}
+
+	_, other := runRequestID()
+	if other == seen {
+		t.Error("Expected a new request ID for each request")
+	}
+}
+
+func TestRequestIDMiddleware_PassesThroughProvided(t *testing.T) {
+	ctx, seen := runRequestID(ut.Header{Key: "X-Request-ID", Value: "upstream-42"})
+
+	if seen != "upstream-42" {
+		t.Errorf("Expected incoming request ID, got %q", seen)
+	}
+	if echoed := string(ctx.Response.Header.Peek("X-Request-ID")); echoed != "upstream-42" {
+		t.Errorf("Expected incoming request ID to be echoed, got %q", echoed)
+	}
+}
+
+func TestRequestIDMiddleware_ReplacesInvalid(t *testing.T) {
+	for _, value := range []string{"has space", strings.Repeat("a", maxRequestIDLength+1)} {
+		_, seen := runRequestID(ut.Header{Key: "X-Request-ID", Value: value})
+		if !uuidPattern.MatchString(seen) {
+			t.Errorf("Expected invalid request ID %q to be replaced, got %q", value, seen)
+		}
+	}
+}
+
+func TestRequestID_SharedByTracingAndLogger(t *testing.T) {
+	var seen string
+	ctx := ut.CreateUtRequestContext("GET", "/orders", nil, ut.Header{Key: "X-Request-ID", Value: "upstream-7"})
+	ctx.SetHandlers(app.HandlersChain{
+		app.HandlerFunc(TracingMiddleware()),
+		app.HandlerFunc(LoggerMiddleware()),
+		func(c context.Context, ctx *app.RequestContext) {
+			seen = ctx.GetString(mvccontext.RequestIDKey)
+		},
+	})
+	ctx.Next(context.Background())
+
+	if seen != "upstream-7" {
+		t.Errorf("Expected tracing and logger to keep incoming request ID, got %q", seen)
+	}
+	if echoed := string(ctx.Response.Header.Peek("X-Request-ID")); echoed != "upstream-7" {
+		t.Errorf("Expected request ID to be echoed, got %q", echoed)
+	}
+}
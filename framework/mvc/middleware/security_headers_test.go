@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// runSecurityHeaders 执行请求并返回上下文
+func runSecurityHeaders(mw Middleware, path string, headers ...ut.Header) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", path, nil, headers...)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(200, "ok")
+	}})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+// hstsTLSConfig 启用HSTS的TLS配置
+func hstsTLSConfig() *config.TLSServerConfig {
+	cfg := config.DefaultTLSServerConfig()
+	cfg.HSTS.Enable = true
+	cfg.HSTS.MaxAge = 31536000
+	cfg.HSTS.IncludeSubDomains = true
+	return cfg
+}
+
+func TestSecurityHeadersMiddleware_Defaults(t *testing.T) {
+	ctx := runSecurityHeaders(SecurityHeadersMiddlewareWithConfig(SecurityHeadersConfig{}), "/")
+
+	want := map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"X-Frame-Options":         "DENY",
+		"Referrer-Policy":         "strict-origin-when-cross-origin",
+		"Content-Security-Policy": DefaultContentSecurityPolicy,
+	}
+	for name, value := range want {
+		if got := string(ctx.Response.Header.Peek(name)); got != value {
+			t.Errorf("Expected %s %q, got %q", name, value, got)
+		}
+	}
+	if got := ctx.Response.Header.Peek("Strict-Transport-Security"); len(got) != 0 {
+		t.Errorf("Expected no HSTS without TLS config, got %q", got)
+	}
+}
+
+func TestSecurityHeadersMiddleware_Custom(t *testing.T) {
+	mw := SecurityHeadersMiddlewareWithConfig(SecurityHeadersConfig{
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "-",
+		ContentSecurityPolicy: "default-src 'self'",
+		CSPRoutes: map[string]string{
+			"/admin":         "default-src 'self'; script-src 'self' 'unsafe-inline'",
+			"/admin/reports": "default-src 'self' https://cdn.example.com",
+			"/embed":         "-",
+		},
+	})
+
+	ctx := runSecurityHeaders(mw, "/users")
+	if got := string(ctx.Response.Header.Peek("X-Frame-Options")); got != "SAMEORIGIN" {
+		t.Errorf("Expected X-Frame-Options SAMEORIGIN, got %q", got)
+	}
+	if got := ctx.Response.Header.Peek("Referrer-Policy"); len(got) != 0 {
+		t.Errorf("Expected Referrer-Policy to be disabled, got %q", got)
+	}
+
+	tests := map[string]string{
+		"/users":           "default-src 'self'",
+		"/admin/users":     "default-src 'self'; script-src 'self' 'unsafe-inline'",
+		"/admin/reports/1": "default-src 'self' https://cdn.example.com",
+		"/administrators":  "default-src 'self'",
+		"/embed/video":     "",
+	}
+	for path, want := range tests {
+		ctx := runSecurityHeaders(mw, path)
+		if got := string(ctx.Response.Header.Peek("Content-Security-Policy")); got != want {
+			t.Errorf("%s: expected CSP %q, got %q", path, want, got)
+		}
+	}
+}
+
+func TestSecurityHeadersMiddleware_HSTSOnlyOverTLS(t *testing.T) {
+	mw := SecurityHeadersMiddlewareWithConfig(SecurityHeadersConfig{TLS: hstsTLSConfig()})
+
+	ctx := runSecurityHeaders(mw, "/")
+	if got := ctx.Response.Header.Peek("Strict-Transport-Security"); len(got) != 0 {
+		t.Errorf("Expected no HSTS over plain HTTP, got %q", got)
+	}
+
+	for _, ctx := range []*app.RequestContext{
+		runSecurityHeaders(mw, "https://example.com/"),
+		runSecurityHeaders(mw, "/", ut.Header{Key: "X-Forwarded-Proto", Value: "https"}),
+	} {
+		if got := string(ctx.Response.Header.Peek("Strict-Transport-Security")); got != "max-age=31536000; includeSubDomains" {
+			t.Errorf("Expected HSTS over TLS, got %q", got)
+		}
+	}
+
+	// 未启用HSTS时不设置
+	disabled := hstsTLSConfig()
+	disabled.HSTS.Enable = false
+	ctx = runSecurityHeaders(SecurityHeadersMiddlewareWithConfig(SecurityHeadersConfig{TLS: disabled}), "/",
+		ut.Header{Key: "X-Forwarded-Proto", Value: "https"})
+	if got := ctx.Response.Header.Peek("Strict-Transport-Security"); len(got) != 0 {
+		t.Errorf("Expected no HSTS when disabled, got %q", got)
+	}
+}
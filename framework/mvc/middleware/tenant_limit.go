@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// TenantIDKey 已解析的租户ID在请求上下文中的键（由认证或租户解析中间件写入）
+const TenantIDKey = "tenant_id"
+
+// TenantKeyFunc 租户分区键函数
+// 仅使用上下文中由服务端解析的租户ID（认证或租户解析中间件写入），为空时使用默认租户
+func TenantKeyFunc(defaultTenant string) RateLimitKeyFunc {
+	return func(ctx *app.RequestContext) string {
+		if tenant := ctx.GetString(TenantIDKey); tenant != "" {
+			return tenant
+		}
+		return defaultTenant
+	}
+}
+
+// TenantHeaderKeyFunc 信任租户请求头的分区键函数（需显式启用）
+// 优先使用上下文中已解析的租户ID，其次读取租户请求头；请求头由客户端控制，
+// 仅应在网关已校验或覆盖该请求头时使用，否则客户端可轮换请求头绕过租户限流
+func TenantHeaderKeyFunc(header, defaultTenant string) RateLimitKeyFunc {
+	if header == "" {
+		header = "X-Tenant-ID"
+	}
+	return func(ctx *app.RequestContext) string {
+		if tenant := ctx.GetString(TenantIDKey); tenant != "" {
+			return tenant
+		}
+		if tenant := string(ctx.GetHeader(header)); tenant != "" {
+			return tenant
+		}
+		return defaultTenant
+	}
+}
+
+// TenantConcurrencyLimiter 按租户公平分配并发槽位
+// 每个租户的份额为 maxConcurrent / 活跃租户数（至少为1），活跃租户包括正在占用槽位的租户与本次请求的租户；
+// 新租户总能获得至少一个槽位，已超出份额的租户在其请求完成、占用降到份额以下之前不会获得新槽位
+type TenantConcurrencyLimiter struct {
+	maxConcurrent int
+	inflight      map[string]int
+	mu            sync.Mutex
+}
+
+// NewTenantConcurrencyLimiter 创建租户并发限制器
+func NewTenantConcurrencyLimiter(maxConcurrent int) *TenantConcurrencyLimiter {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+	return &TenantConcurrencyLimiter{
+		maxConcurrent: maxConcurrent,
+		inflight:      make(map[string]int),
+	}
+}
+
+// Acquire 为租户申请一个并发槽位，返回是否成功及当前份额
+func (l *TenantConcurrencyLimiter) Acquire(tenant string) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	active := len(l.inflight)
+	if _, exists := l.inflight[tenant]; !exists {
+		active++
+	}
+	share := l.maxConcurrent / active
+	if share < 1 {
+		share = 1
+	}
+
+	if l.inflight[tenant] >= share {
+		return false, share
+	}
+	l.inflight[tenant]++
+	return true, share
+}
+
+// Release 释放租户的一个并发槽位
+func (l *TenantConcurrencyLimiter) Release(tenant string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight[tenant] <= 1 {
+		delete(l.inflight, tenant)
+		return
+	}
+	l.inflight[tenant]--
+}
+
+// InFlight 获取租户当前占用的并发槽位数
+func (l *TenantConcurrencyLimiter) InFlight(tenant string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight[tenant]
+}
+
+// TenantLimitConfig 租户限流配置
+type TenantLimitConfig struct {
+	MaxConcurrent int              // 全部租户共享的并发槽位数，按活跃租户公平分配（0表示不限制并发）
+	MaxRequests   int              // 每个租户在Window内的请求上限（0表示不限制频率）
+	Window        time.Duration    // 频率限制的时间窗口
+	KeyFunc       RateLimitKeyFunc // 租户解析函数，默认 TenantKeyFunc("default")
+	TrustHeader   string           // 信任的租户请求头（显式启用），为空时不读取客户端请求头
+}
+
+// TenantRateLimitMiddleware 按租户的并发与频率限制中间件
+// 频率限制复用按键分区的令牌桶限流器；超出频率或并发份额的请求返回429
+func TenantRateLimitMiddleware(cfg TenantLimitConfig) Middleware {
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = TenantKeyFunc("default")
+		if cfg.TrustHeader != "" {
+			keyFunc = TenantHeaderKeyFunc(cfg.TrustHeader, "default")
+		}
+	}
+
+	var rateLimiter *KeyedRateLimiter
+	if cfg.MaxRequests > 0 {
+		rateLimiter = NewKeyedRateLimiter(cfg.MaxRequests, cfg.Window)
+	}
+	var concurrency *TenantConcurrencyLimiter
+	if cfg.MaxConcurrent > 0 {
+		concurrency = NewTenantConcurrencyLimiter(cfg.MaxConcurrent)
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		tenant := keyFunc(ctx)
+		if tenant == "" {
+			ctx.Next(c)
+			return
+		}
+
+		if rateLimiter != nil {
+			allowed, remaining, retryAfter := rateLimiter.Allow(tenant)
+			ctx.Header("X-RateLimit-Limit", strconv.Itoa(cfg.MaxRequests))
+			ctx.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			if !allowed {
+				retrySeconds := int(math.Ceil(retryAfter.Seconds()))
+				if retrySeconds < 1 {
+					retrySeconds = 1
+				}
+				ctx.Header("Retry-After", strconv.Itoa(retrySeconds))
+				rejectTenant(ctx, tenant, "tenant_rate_limit_exceeded", map[string]any{
+					"error":       "Tenant rate limit exceeded",
+					"message":     "租户请求过于频繁，请稍后再试",
+					"retry_after": retrySeconds,
+				})
+				return
+			}
+		}
+
+		if concurrency != nil {
+			acquired, share := concurrency.Acquire(tenant)
+			if !acquired {
+				ctx.Header("Retry-After", "1")
+				rejectTenant(ctx, tenant, "tenant_concurrency_exceeded", map[string]any{
+					"error":   "Tenant concurrency limit exceeded",
+					"message": "租户并发请求过多，请稍后再试",
+					"limit":   share,
+				})
+				return
+			}
+			defer concurrency.Release(tenant)
+		}
+
+		ctx.Next(c)
+	}
+}
+
+// rejectTenant 记录租户限流事件并返回429
+func rejectTenant(ctx *app.RequestContext, tenant, event string, body map[string]any) {
+	fields := map[string]any{
+		"event":  event,
+		"tenant": tenant,
+		"path":   string(ctx.Path()),
+		"method": string(ctx.Method()),
+	}
+	go func() {
+		config.WithFields(fields).Warn("Tenant limit exceeded")
+	}()
+
+	ctx.JSON(429, body)
+	ctx.Abort()
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+// newTenantTestContext 创建已由服务端解析租户的测试请求上下文
+func newTenantTestContext(tenant string) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", "/api/orders", nil)
+	ctx.Set(TenantIDKey, tenant)
+	return ctx
+}
+
+// newTenantHeaderContext 创建仅携带租户请求头（客户端可控）的测试请求上下文
+func newTenantHeaderContext(tenant string) *app.RequestContext {
+	return ut.CreateUtRequestContext("GET", "/api/orders", nil, ut.Header{Key: "X-Tenant-ID", Value: tenant})
+}
+
+func TestTenantRateLimitMiddleware_ThrottlesNoisyTenant(t *testing.T) {
+	mw := TenantRateLimitMiddleware(TenantLimitConfig{MaxRequests: 2, Window: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		ctx := newTenantTestContext("tenant-a")
+		mw(context.Background(), ctx)
+		if ctx.IsAborted() {
+			t.Fatalf("Request %d from tenant-a should be allowed", i+1)
+		}
+	}
+
+	burst := newTenantTestContext("tenant-a")
+	mw(context.Background(), burst)
+	if !burst.IsAborted() || burst.Response.StatusCode() != 429 {
+		t.Fatalf("Burst request from tenant-a should be rejected, got status %d", burst.Response.StatusCode())
+	}
+	if got := string(burst.Response.Header.Peek("Retry-After")); got == "" {
+		t.Error("Expected Retry-After header on rejected request")
+	}
+
+	// 另一个租户不受影响
+	other := newTenantTestContext("tenant-b")
+	mw(context.Background(), other)
+	if other.IsAborted() {
+		t.Fatal("Request from tenant-b should not be affected by tenant-a's burst")
+	}
+}
+
+func TestTenantRateLimitMiddleware_IgnoresTenantHeaderByDefault(t *testing.T) {
+	mw := TenantRateLimitMiddleware(TenantLimitConfig{MaxRequests: 2, Window: time.Minute})
+
+	// 客户端轮换租户请求头不能绕过限流，未解析租户的请求共享默认租户的配额
+	for i, tenant := range []string{"rotate-1", "rotate-2"} {
+		ctx := newTenantHeaderContext(tenant)
+		mw(context.Background(), ctx)
+		if ctx.IsAborted() {
+			t.Fatalf("Request %d should be allowed", i+1)
+		}
+	}
+	rotated := newTenantHeaderContext("rotate-3")
+	mw(context.Background(), rotated)
+	if !rotated.IsAborted() || rotated.Response.StatusCode() != 429 {
+		t.Fatalf("Rotating X-Tenant-ID should not bypass the limit, got status %d", rotated.Response.StatusCode())
+	}
+}
+
+func TestTenantRateLimitMiddleware_TrustHeaderOptIn(t *testing.T) {
+	mw := TenantRateLimitMiddleware(TenantLimitConfig{MaxRequests: 1, Window: time.Minute, TrustHeader: "X-Tenant-ID"})
+
+	first := newTenantHeaderContext("tenant-a")
+	mw(context.Background(), first)
+	other := newTenantHeaderContext("tenant-b")
+	mw(context.Background(), other)
+	if first.IsAborted() || other.IsAborted() {
+		t.Fatal("Trusted tenant headers should partition the quota")
+	}
+
+	// 上下文中已解析的租户优先于请求头
+	resolved := newTenantHeaderContext("tenant-a")
+	resolved.Set(TenantIDKey, "tenant-c")
+	mw(context.Background(), resolved)
+	if resolved.IsAborted() {
+		t.Fatal("Request resolved to tenant-c should use tenant-c's quota")
+	}
+}
+
+func TestTenantRateLimitMiddleware_FairConcurrencyShares(t *testing.T) {
+	mw := TenantRateLimitMiddleware(TenantLimitConfig{MaxConcurrent: 2})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking := func(c context.Context, ctx *app.RequestContext) {
+		started <- struct{}{}
+		<-release
+	}
+
+	// 启动一个阻塞中的请求，返回完成通知
+	inflight := func(tenant string) (*app.RequestContext, chan struct{}) {
+		ctx := newTenantTestContext(tenant)
+		ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), blocking})
+		done := make(chan struct{})
+		go func() {
+			ctx.Next(context.Background())
+			close(done)
+		}()
+		return ctx, done
+	}
+
+	// tenant-a 单独活跃时可以使用全部槽位
+	_, doneA1 := inflight("tenant-a")
+	<-started
+	_, doneA2 := inflight("tenant-a")
+	<-started
+
+	// tenant-b 仍能获得公平份额
+	ctxB, doneB := inflight("tenant-b")
+	select {
+	case <-started:
+	case <-doneB:
+		t.Fatalf("tenant-b should get a fair share, got status %d", ctxB.Response.StatusCode())
+	}
+
+	// tenant-a 已超出份额，新的请求被拒绝
+	overflow := newTenantTestContext("tenant-a")
+	mw(context.Background(), overflow)
+	if !overflow.IsAborted() || overflow.Response.StatusCode() != 429 {
+		t.Fatalf("tenant-a overflow should be rejected, got status %d", overflow.Response.StatusCode())
+	}
+
+	close(release)
+	<-doneA1
+	<-doneA2
+	<-doneB
+
+	// 请求完成后槽位被释放
+	after := newTenantTestContext("tenant-a")
+	mw(context.Background(), after)
+	if after.IsAborted() {
+		t.Fatal("tenant-a should be allowed after its requests completed")
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/zsy619/yyhertz/framework/config"
+	"github.com/zsy619/yyhertz/framework/errors"
+	"github.com/zsy619/yyhertz/framework/response"
+)
+
+// TimeoutConfig 处理超时中间件配置
+type TimeoutConfig struct {
+	Timeout time.Duration // 处理超时时间，0表示不限制
+	// StatusCode 超时响应状态码，默认503（与 net/http.TimeoutHandler 一致），网关场景可使用504
+	StatusCode int
+	// TimeoutBody 生成超时响应体，默认返回标准错误响应 errors.TimeoutError
+	TimeoutBody func(ctx *app.RequestContext) any
+}
+
+// DefaultTimeoutConfig 默认处理超时中间件配置
+func DefaultTimeoutConfig(timeout time.Duration) TimeoutConfig {
+	return TimeoutConfig{
+		Timeout:    timeout,
+		StatusCode: 503,
+		TimeoutBody: func(ctx *app.RequestContext) any {
+			return response.BuildErrorResp(errors.TimeoutError)
+		},
+	}
+}
+
+// TimeoutMiddleware 处理超时中间件 - 下游处理超过d时立即返回503并取消请求上下文
+func TimeoutMiddleware(d time.Duration) Middleware {
+	return TimeoutMiddlewareWithConfig(DefaultTimeoutConfig(d))
+}
+
+// TimeoutMiddlewareWithConfig 带配置的处理超时中间件
+// 下游处理器在请求上下文的副本上执行，并接收带截止时间的 context.Context（数据库查询等应使用它，以便超时后中止）。
+// 按时完成时合并副本的响应与上下文键；超时后立即返回超时响应，处理器之后的写入被丢弃。
+// 以处理器完成时间与截止时间比较判定结果，处理器恰好在截止时间前完成时仍返回处理器的响应。
+func TimeoutMiddlewareWithConfig(cfg TimeoutConfig) Middleware {
+	defaults := DefaultTimeoutConfig(cfg.Timeout)
+	if cfg.StatusCode == 0 {
+		cfg.StatusCode = defaults.StatusCode
+	}
+	if cfg.TimeoutBody == nil {
+		cfg.TimeoutBody = defaults.TimeoutBody
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		if cfg.Timeout <= 0 {
+			ctx.Next(c)
+			return
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(c, cfg.Timeout)
+		defer cancel()
+		deadline, _ := timeoutCtx.Deadline()
+
+		worker := ctx.Copy()
+		worker.SetHandlers(ctx.Handlers())
+		worker.SetIndex(ctx.GetIndex())
+
+		var recovered any
+		var finishedAt time.Time
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer func() {
+				finishedAt = time.Now()
+				if r := recover(); r != nil {
+					recovered = r
+					if finishedAt.After(deadline) {
+						config.WithFields(map[string]any{
+							"error":  r,
+							"path":   string(worker.Path()),
+							"method": string(worker.Method()),
+						}).Error("PANIC in handler after request timeout")
+					}
+				}
+			}()
+			worker.Next(timeoutCtx)
+		}()
+
+		select {
+		case <-done:
+		case <-timeoutCtx.Done():
+		}
+
+		// 处理器在截止时间前完成时以处理器结果为准，包括与超时同时触发的情况；
+		// 因上下文取消才返回的处理器视为超时
+		completed := false
+		select {
+		case <-done:
+			completed = !finishedAt.After(deadline)
+		default:
+		}
+		if !completed {
+			config.WithFields(map[string]any{
+				"event":   "handler_timeout",
+				"path":    string(ctx.Path()),
+				"method":  string(ctx.Method()),
+				"timeout": cfg.Timeout.String(),
+			}).Warn("Handler timed out")
+
+			ctx.JSON(cfg.StatusCode, cfg.TimeoutBody(ctx))
+			ctx.Abort()
+			return
+		}
+
+		if recovered != nil {
+			// 交由外层恢复中间件处理
+			panic(recovered)
+		}
+		worker.Response.CopyTo(&ctx.Response)
+		worker.ForEachKey(func(key string, value any) {
+			ctx.Set(key, value)
+		})
+		// 下游处理器已在副本上执行，跳过剩余处理器
+		ctx.SetIndex(worker.GetIndex())
+	}
+}
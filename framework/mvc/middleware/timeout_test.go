@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+)
+
+func TestTimeoutMiddleware_FastHandler(t *testing.T) {
+	var calls int
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		calls++
+		if _, ok := c.Deadline(); !ok {
+			t.Error("Expected handler context to have a deadline")
+		}
+		ctx.Set("user", "alice")
+		ctx.String(201, "created")
+	}
+
+	ctx := ut.CreateUtRequestContext("POST", "/fast", nil)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(TimeoutMiddleware(time.Second)), handler})
+	ctx.Next(context.Background())
+
+	if ctx.Response.StatusCode() != 201 || string(ctx.Response.Body()) != "created" {
+		t.Errorf("Expected handler response, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	if ctx.GetString("user") != "alice" {
+		t.Errorf("Expected keys set by handler to be visible, got %q", ctx.GetString("user"))
+	}
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestTimeoutMiddleware_SlowHandler(t *testing.T) {
+	handlerErr := make(chan error, 1)
+	finished := make(chan struct{})
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		defer close(finished)
+		// 模拟遵循上下文的数据库查询
+		select {
+		case <-c.Done():
+			handlerErr <- c.Err()
+		case <-time.After(time.Second):
+			handlerErr <- nil
+		}
+		ctx.String(200, "late")
+	}
+
+	ctx := ut.CreateUtRequestContext("GET", "/slow", nil)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(TimeoutMiddleware(20 * time.Millisecond)), handler})
+
+	start := time.Now()
+	ctx.Next(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected timeout response near 20ms, took %v", elapsed)
+	}
+	if ctx.Response.StatusCode() != 503 {
+		t.Errorf("Expected status 503, got %d", ctx.Response.StatusCode())
+	}
+	if !strings.Contains(string(ctx.Response.Body()), "Request timeout") {
+		t.Errorf("Expected timeout body, got %s", ctx.Response.Body())
+	}
+	if !ctx.IsAborted() {
+		t.Error("Expected context to be aborted after timeout")
+	}
+
+	if err := <-handlerErr; err != context.DeadlineExceeded {
+		t.Errorf("Expected handler context to be cancelled, got %v", err)
+	}
+	<-finished
+	// 超时后处理器的写入不影响已返回的响应
+	if ctx.Response.StatusCode() != 503 || string(ctx.Response.Body()) == "late" {
+		t.Errorf("Expected late write to be discarded, got %d %q", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+}
+
+func TestTimeoutMiddlewareWithConfig_CustomResponse(t *testing.T) {
+	mw := TimeoutMiddlewareWithConfig(TimeoutConfig{
+		Timeout:    10 * time.Millisecond,
+		StatusCode: 504,
+		TimeoutBody: func(ctx *app.RequestContext) any {
+			return map[string]string{"error": "upstream too slow"}
+		},
+	})
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		<-c.Done()
+	}
+
+	ctx := ut.CreateUtRequestContext("GET", "/slow", nil)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(mw), handler})
+	ctx.Next(context.Background())
+
+	if ctx.Response.StatusCode() != 504 {
+		t.Errorf("Expected status 504, got %d", ctx.Response.StatusCode())
+	}
+	if !strings.Contains(string(ctx.Response.Body()), "upstream too slow") {
+		t.Errorf("Expected custom body, got %s", ctx.Response.Body())
+	}
+}
+
+func TestTimeoutMiddleware_PanicReachesRecovery(t *testing.T) {
+	handler := func(c context.Context, ctx *app.RequestContext) {
+		panic("boom")
+	}
+
+	ctx := ut.CreateUtRequestContext("GET", "/panic", nil)
+	ctx.SetHandlers(app.HandlersChain{
+		app.HandlerFunc(RecoveryMiddleware()),
+		app.HandlerFunc(TimeoutMiddleware(time.Second)),
+		handler,
+	})
+	ctx.Next(context.Background())
+
+	if ctx.Response.StatusCode() != 500 {
+		t.Errorf("Expected panic to be recovered with 500, got %d", ctx.Response.StatusCode())
+	}
+}
@@ -2,6 +2,11 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
@@ -11,48 +16,194 @@ import (
 	"github.com/zsy619/yyhertz/framework/util"
 )
 
-func generateOpenTelemetryTraceID() string {
-	ctx := context.Background()
-	tracer := trace.NewNoopTracerProvider().Tracer("")
-	ctx, _ = tracer.Start(ctx, "dummy-span")
-	span := trace.SpanFromContext(ctx)
-	return span.SpanContext().TraceID().String()
+// W3C Trace Context 相关常量
+const (
+	TraceparentHeader = "traceparent" // W3C traceparent 请求头
+	TraceIDKey        = "trace_id"    // RequestContext 中的追踪ID键
+	SpanIDKey         = "span_id"     // RequestContext 中的当前SpanID键
+)
+
+// TraceContext 当前请求的追踪上下文
+type TraceContext struct {
+	TraceID      trace.TraceID // 追踪ID，整条调用链共用
+	SpanID       trace.SpanID  // 当前服务处理请求的SpanID
+	ParentSpanID trace.SpanID  // 上游调用方的SpanID，新建追踪时为空
+	Flags        trace.TraceFlags
+}
+
+// Traceparent 格式化为 traceparent 请求头
+func (tc TraceContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%s", tc.TraceID, tc.SpanID, tc.Flags)
+}
+
+// Child 创建子Span，用于向下游发起请求
+func (tc TraceContext) Child() TraceContext {
+	return TraceContext{TraceID: tc.TraceID, SpanID: newSpanID(), ParentSpanID: tc.SpanID, Flags: tc.Flags}
+}
+
+// traceContextKey context.Context 中存放 TraceContext 的键
+type traceContextKey struct{}
+
+// ParseTraceparent 解析 traceparent 请求头（version-traceid-parentid-flags），格式无效时返回false
+func ParseTraceparent(header string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return TraceContext{}, false
+	}
+	// 版本00必须恰好4段，更高版本允许追加字段
+	if parts[0] == "00" && len(parts) != 4 {
+		return TraceContext{}, false
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 || !isLowerHex(header) {
+		return TraceContext{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return TraceContext{}, false
+	}
+	parentID, err := trace.SpanIDFromHex(parts[2])
+	if err != nil {
+		return TraceContext{}, false
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return TraceContext{}, false
+	}
+	return TraceContext{TraceID: traceID, ParentSpanID: parentID, Flags: trace.TraceFlags(flags)}, true
+}
+
+// ContextWithTrace 将追踪上下文写入 context.Context
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceFromContext 获取 context.Context 中的追踪上下文
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// InjectTraceparent 将当前Span写入出站请求的 traceparent 请求头，下游以其作为父Span
+func InjectTraceparent(ctx context.Context, header http.Header) {
+	if tc, ok := TraceFromContext(ctx); ok {
+		header.Set(TraceparentHeader, tc.Traceparent())
+	}
+}
+
+// tracingTransport 自动注入 traceparent 的 http.RoundTripper
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip 实现 http.RoundTripper，不修改调用方传入的请求
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := TraceFromContext(req.Context()); ok {
+		req = req.Clone(req.Context())
+		InjectTraceparent(req.Context(), req.Header)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// NewTracingHTTPClient 创建向下游传播追踪上下文的HTTP客户端，base为nil时使用 http.DefaultClient 的配置
+// 请求需携带处理函数收到的 context（http.NewRequestWithContext）
+func NewTracingHTTPClient(base *http.Client) *http.Client {
+	client := &http.Client{}
+	if base != nil {
+		*client = *base
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.Transport = &tracingTransport{base: transport}
+	return client
+}
+
+// startTrace 根据请求头继续上游追踪或开始新的追踪
+// 优先使用 traceparent，其次兼容 X-Trace-ID（合法的32位十六进制ID）
+func startTrace(c *app.RequestContext) TraceContext {
+	if tc, ok := ParseTraceparent(string(c.GetHeader(TraceparentHeader))); ok {
+		tc.SpanID = newSpanID()
+		return tc
+	}
+
+	tc := TraceContext{SpanID: newSpanID(), Flags: trace.FlagsSampled}
+	if traceID, err := trace.TraceIDFromHex(string(c.GetHeader("X-Trace-ID"))); err == nil {
+		tc.TraceID = traceID
+	} else {
+		tc.TraceID = newTraceID()
+	}
+	return tc
+}
+
+// newTraceID 生成随机追踪ID
+func newTraceID() trace.TraceID {
+	var id trace.TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// newSpanID 生成随机SpanID
+func newSpanID() trace.SpanID {
+	var id trace.SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// isLowerHex 检查 traceparent 是否只包含小写十六进制字符与分隔符
+func isLowerHex(s string) bool {
+	for _, r := range strings.TrimSpace(s) {
+		if !(r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f')) {
+			return false
+		}
+	}
+	return true
 }
 
 // TracingMiddleware 链路追踪中间件 - 使用单例日志系统
+// 读取 W3C traceparent 继续上游追踪（或开始新的追踪），追踪ID与SpanID写入 RequestContext 与 context.Context，
+// 处理函数可通过 TraceFromContext 获取，并使用 NewTracingHTTPClient 向下游传播
 func TracingMiddleware() Middleware {
 	return func(ctx context.Context, c *app.RequestContext) {
 		start := time.Now()
 
-		// 从 Header 中提取 TraceID，或生成新的
-		traceID := string(c.GetHeader("X-Trace-ID"))
-		if traceID == "" {
-			traceID = generateOpenTelemetryTraceID()
-		}
+		tc := startTrace(c)
+		traceID, spanID := tc.TraceID.String(), tc.SpanID.String()
 
-		// 如果没有request_id，也生成一个
-		requestID := c.GetString("request_id")
-		if requestID == "" {
-			requestID = util.ShortID()
-			c.Set("request_id", requestID)
-		}
+		// 沿用上游请求ID或生成新的请求ID
+		requestID := EnsureRequestID(c)
 
-		// 将 TraceID 放入上下文，便于后续使用
+		// 将追踪上下文放入上下文，便于后续使用
+		ctx = ContextWithTrace(ctx, tc)
 		ctx = context.WithValue(ctx, "traceID", traceID)
 		c.Set("traceID", traceID)
+		c.Set(TraceIDKey, traceID)
+		c.Set(SpanIDKey, spanID)
+		c.Response.Header.Set("X-Trace-ID", traceID)
 
 		// 使用单例日志系统记录追踪开始
-		go func() {
-			config.WithFields(map[string]any{
-				"trace_id":   traceID,
-				"request_id": requestID,
-				"method":     string(c.Method()),
-				"path":       string(c.Path()),
-				"client_ip":  c.ClientIP(),
-				"user_agent": string(c.UserAgent()),
-				"start_time": start.Format(time.RFC3339),
-			}).Info("Tracing: Request started")
-		}()
+		startFields := map[string]any{
+			"trace_id":   traceID,
+			"span_id":    spanID,
+			"request_id": requestID,
+			"method":     string(c.Method()),
+			"path":       string(c.Path()),
+			"client_ip":  c.ClientIP(),
+			"user_agent": string(c.UserAgent()),
+			"start_time": start.Format(time.RFC3339),
+		}
+		if tc.ParentSpanID.IsValid() {
+			startFields["parent_span_id"] = tc.ParentSpanID.String()
+		}
+		config.WithFields(startFields).Info("Tracing: Request started")
 
 		// 处理请求
 		c.Next(ctx)
@@ -64,6 +215,7 @@ func TracingMiddleware() Middleware {
 		// 使用单例日志系统记录追踪结束
 		endFields := map[string]any{
 			"trace_id":    traceID,
+			"span_id":     spanID,
 			"request_id":  requestID,
 			"method":      string(c.Method()),
 			"path":        string(c.Path()),
@@ -75,17 +227,11 @@ func TracingMiddleware() Middleware {
 
 		// 根据状态码选择日志级别
 		if statusCode >= 500 {
-			go func() {
-				config.WithFields(endFields).Error("Tracing: Request completed with server error")
-			}()
+			config.WithFields(endFields).Error("Tracing: Request completed with server error")
 		} else if statusCode >= 400 {
-			go func() {
-				config.WithFields(endFields).Warn("Tracing: Request completed with client error")
-			}()
+			config.WithFields(endFields).Warn("Tracing: Request completed with client error")
 		} else {
-			go func() {
-				config.WithFields(endFields).Info("Tracing: Request completed successfully")
-			}()
+			config.WithFields(endFields).Info("Tracing: Request completed successfully")
 		}
 	}
 }
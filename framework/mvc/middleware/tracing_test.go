@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID  = "00f067aa0ba902b7"
+	incomingHeader  = "00-" + incomingTraceID + "-" + incomingSpanID + "-01"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tc, ok := ParseTraceparent(incomingHeader)
+	if !ok {
+		t.Fatal("Expected valid traceparent")
+	}
+	if tc.TraceID.String() != incomingTraceID || tc.ParentSpanID.String() != incomingSpanID || !tc.Flags.IsSampled() {
+		t.Errorf("Unexpected trace context %+v", tc)
+	}
+
+	invalid := []string{
+		"",
+		"00-" + incomingTraceID + "-" + incomingSpanID,                  // 缺少flags
+		"00-00000000000000000000000000000000-" + incomingSpanID + "-01", // 全零追踪ID
+		"00-" + incomingTraceID + "-0000000000000000-01",                // 全零SpanID
+		"ff-" + incomingTraceID + "-" + incomingSpanID + "-01",          // 无效版本
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-" + incomingSpanID + "-01", // 大写
+		incomingHeader + "-extra",                                       // 版本00不允许追加字段
+	}
+	for _, header := range invalid {
+		if _, ok := ParseTraceparent(header); ok {
+			t.Errorf("Expected %q to be rejected", header)
+		}
+	}
+}
+
+// runTracedRequest 依次执行追踪、日志中间件与处理函数
+func runTracedRequest(handler app.HandlerFunc, headers ...ut.Header) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("GET", "/api/traced", nil, headers...)
+	ctx.SetHandlers(app.HandlersChain{
+		app.HandlerFunc(TracingMiddleware()),
+		app.HandlerFunc(LoggerMiddlewareWithConfig(DefaultLoggerConfig())),
+		handler,
+	})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+func TestTracingMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	defer hook.Reset()
+
+	// 模拟下游服务，记录收到的traceparent
+	var outbound string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outbound = r.Header.Get(TraceparentHeader)
+	}))
+	defer downstream.Close()
+
+	var current TraceContext
+	ctx := runTracedRequest(func(c context.Context, ctx *app.RequestContext) {
+		tc, ok := TraceFromContext(c)
+		if !ok {
+			t.Error("Expected trace context in handler context")
+			return
+		}
+		current = tc
+
+		req, _ := http.NewRequestWithContext(c, http.MethodGet, downstream.URL, nil)
+		resp, err := NewTracingHTTPClient(nil).Do(req)
+		if err != nil {
+			t.Errorf("Outbound request failed: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if req.Header.Get(TraceparentHeader) != "" {
+			t.Error("Expected caller request to be left unmodified")
+		}
+		ctx.String(200, "ok")
+	}, ut.Header{Key: TraceparentHeader, Value: incomingHeader})
+
+	// 继续上游追踪：追踪ID不变，上游SpanID成为父Span
+	if current.TraceID.String() != incomingTraceID {
+		t.Errorf("Expected trace %s to continue, got %s", incomingTraceID, current.TraceID)
+	}
+	if current.ParentSpanID.String() != incomingSpanID {
+		t.Errorf("Expected parent span %s, got %s", incomingSpanID, current.ParentSpanID)
+	}
+	if !current.SpanID.IsValid() || current.SpanID.String() == incomingSpanID {
+		t.Errorf("Expected a new span ID, got %s", current.SpanID)
+	}
+	if ctx.GetString(TraceIDKey) != incomingTraceID || ctx.GetString(SpanIDKey) != current.SpanID.String() {
+		t.Errorf("Expected trace IDs in request context, got %s/%s", ctx.GetString(TraceIDKey), ctx.GetString(SpanIDKey))
+	}
+
+	// 出站请求携带同一追踪ID，父Span为当前Span
+	next, ok := ParseTraceparent(outbound)
+	if !ok {
+		t.Fatalf("Expected outbound traceparent, got %q", outbound)
+	}
+	if next.TraceID != current.TraceID || next.ParentSpanID != current.SpanID || next.Flags != current.Flags {
+		t.Errorf("Expected outbound span to be a child of %s, got %q", current.SpanID, outbound)
+	}
+
+	// 日志字段包含追踪ID
+	logged := 0
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["path"] != "/api/traced" {
+			continue
+		}
+		logged++
+		if entry.Data["trace_id"] != incomingTraceID {
+			t.Errorf("Expected trace_id in %q log, got %v", entry.Message, entry.Data["trace_id"])
+		}
+	}
+	if logged == 0 {
+		t.Error("Expected request logs")
+	}
+}
+
+func TestTracingMiddleware_StartsNewTrace(t *testing.T) {
+	var first, second TraceContext
+	runTracedRequest(func(c context.Context, ctx *app.RequestContext) {
+		first, _ = TraceFromContext(c)
+	}, ut.Header{Key: TraceparentHeader, Value: "invalid"})
+	runTracedRequest(func(c context.Context, ctx *app.RequestContext) {
+		second, _ = TraceFromContext(c)
+	})
+
+	if !first.TraceID.IsValid() || !second.TraceID.IsValid() || first.TraceID == second.TraceID {
+		t.Errorf("Expected distinct new traces, got %s and %s", first.TraceID, second.TraceID)
+	}
+	if first.ParentSpanID.IsValid() {
+		t.Errorf("Expected new trace without parent span, got %s", first.ParentSpanID)
+	}
+}
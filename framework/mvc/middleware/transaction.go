@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+	"github.com/zsy619/yyhertz/framework/orm"
+)
+
+// TransactionMiddleware 请求级事务中间件 - 为每个请求开启事务，处理器通过 Context.Tx()
+// 或 orm.GetDBFromContext(c) 获取；响应为2xx且未记录错误时提交，否则（含panic）回滚
+// db 为nil时使用默认ORM连接
+func TransactionMiddleware(db *gorm.DB) Middleware {
+	return func(c context.Context, ctx *app.RequestContext) {
+		conn := db
+		if conn == nil {
+			conn = orm.GetDefaultORM().DB()
+		}
+
+		tx := conn.WithContext(c).Begin()
+		if tx.Error != nil {
+			config.Errorf("Failed to begin request transaction: %v", tx.Error)
+			ctx.JSON(500, map[string]any{
+				"error": "Failed to begin transaction",
+				"code":  "TRANSACTION_BEGIN_FAILED",
+			})
+			ctx.Abort()
+			return
+		}
+		ctx.Set(orm.RequestTransactionKey, tx)
+
+		finished := false
+		defer func() {
+			if finished {
+				return
+			}
+			// 处理器panic时回滚后继续向上抛出，由恢复中间件处理
+			if err := tx.Rollback().Error; err != nil {
+				config.Errorf("Failed to rollback request transaction after panic: %v", err)
+			}
+		}()
+
+		ctx.Next(orm.WithTransactionInContext(c, tx))
+		finished = true
+
+		status := ctx.Response.StatusCode()
+		if status < 200 || status >= 300 || len(ctx.Errors) > 0 {
+			if err := tx.Rollback().Error; err != nil {
+				config.Errorf("Failed to rollback request transaction: %v", err)
+			}
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			config.Errorf("Failed to commit request transaction: %v", err)
+			ctx.JSON(500, map[string]any{
+				"error": fmt.Sprintf("Failed to commit transaction: %v", err),
+				"code":  "TRANSACTION_COMMIT_FAILED",
+			})
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	mvccontext "github.com/zsy619/yyhertz/framework/mvc/context"
+	"github.com/zsy619/yyhertz/framework/orm"
+)
+
+type txTestOrder struct {
+	ID   uint
+	Name string
+}
+
+func openTxTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tx.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&txTestOrder{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return db
+}
+
+// runInTransaction 在事务中间件中执行处理器
+func runInTransaction(db *gorm.DB, handler app.HandlerFunc) *app.RequestContext {
+	ctx := ut.CreateUtRequestContext("POST", "/orders", nil)
+	ctx.SetHandlers(app.HandlersChain{app.HandlerFunc(TransactionMiddleware(db)), handler})
+	ctx.Next(context.Background())
+	return ctx
+}
+
+func countTxTestOrders(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&txTestOrder{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count orders: %v", err)
+	}
+	return count
+}
+
+func TestTransactionMiddleware_CommitsOnSuccess(t *testing.T) {
+	db := openTxTestDB(t)
+
+	runInTransaction(db, func(c context.Context, ctx *app.RequestContext) {
+		tx := mvccontext.NewContext(ctx).Tx()
+		if tx == nil {
+			t.Fatal("Expected request transaction")
+		}
+		tx.Create(&txTestOrder{Name: "book"})
+		// 通过 context.Context 获取的也是同一事务
+		orm.GetDBFromContext(c).Create(&txTestOrder{Name: "pen"})
+		ctx.JSON(201, map[string]any{"ok": true})
+	})
+
+	if got := countTxTestOrders(t, db); got != 2 {
+		t.Errorf("Expected committed orders, got %d", got)
+	}
+}
+
+func TestTransactionMiddleware_RollsBackOnServerError(t *testing.T) {
+	db := openTxTestDB(t)
+
+	runInTransaction(db, func(c context.Context, ctx *app.RequestContext) {
+		mvccontext.NewContext(ctx).Tx().Create(&txTestOrder{Name: "book"})
+		ctx.JSON(500, map[string]any{"error": "failed"})
+	})
+
+	if got := countTxTestOrders(t, db); got != 0 {
+		t.Errorf("Expected rollback on 500, got %d orders", got)
+	}
+}
+
+func TestTransactionMiddleware_RollsBackOnPanic(t *testing.T) {
+	db := openTxTestDB(t)
+
+	ctx := ut.CreateUtRequestContext("POST", "/orders", nil)
+	ctx.SetHandlers(app.HandlersChain{
+		app.HandlerFunc(RecoveryMiddleware()),
+		app.HandlerFunc(TransactionMiddleware(db)),
+		func(c context.Context, ctx *app.RequestContext) {
+			mvccontext.NewContext(ctx).Tx().Create(&txTestOrder{Name: "book"})
+			panic("boom")
+		},
+	})
+	ctx.Next(context.Background())
+
+	if ctx.Response.StatusCode() != 500 {
+		t.Errorf("Expected recovered 500, got %d", ctx.Response.StatusCode())
+	}
+	if got := countTxTestOrders(t, db); got != 0 {
+		t.Errorf("Expected rollback on panic, got %d orders", got)
+	}
+}
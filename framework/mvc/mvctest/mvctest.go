@@ -0,0 +1,130 @@
+// Package mvctest 提供控制器单元测试辅助工具
+// 由httptest风格的*http.Request构建完整初始化的*context.Context，并记录响应的状态码、响应头与响应体
+package mvctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/cloudwego/hertz/pkg/route/param"
+
+	"github.com/zsy619/yyhertz/framework/mvc/context"
+)
+
+// NewRequest 创建测试请求，参数同httptest.NewRequest
+func NewRequest(method, target string, body io.Reader) *http.Request {
+	return httptest.NewRequest(method, target, body)
+}
+
+// NewJSONRequest 创建JSON请求体的测试请求
+func NewJSONRequest(method, target string, v any) *http.Request {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("mvctest: 序列化请求体失败: " + err.Error())
+	}
+	req := NewRequest(method, target, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// NewContext 由net/http请求构建Context，返回Context及其响应记录器
+// 请求方法、URI、Host、请求头与请求体均会复制到底层的app.RequestContext
+func NewContext(req *http.Request) (*context.Context, *ResponseRecorder) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	rc := engine.NewContext()
+	copyRequest(req, rc)
+
+	ctx := context.NewContextWithContext(rc, req.Context())
+	return ctx, &ResponseRecorder{rc: rc}
+}
+
+// SetParams 设置路由参数，同时写入Context与底层RequestContext
+func SetParams(ctx *context.Context, params map[string]string) {
+	for key, value := range params {
+		ctx.Params = append(ctx.Params, context.Param{Key: key, Value: value})
+		if ctx.Request != nil {
+			ctx.Request.Params = append(ctx.Request.Params, param.Param{Key: key, Value: value})
+		}
+	}
+}
+
+// copyRequest 将net/http请求复制到Hertz请求
+func copyRequest(req *http.Request, rc *app.RequestContext) {
+	rc.Request.Header.SetMethod(req.Method)
+	rc.Request.SetRequestURI(req.URL.RequestURI())
+	rc.Request.Header.SetHost(req.Host)
+	for key, values := range req.Header {
+		for _, value := range values {
+			rc.Request.Header.Add(key, value)
+		}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			panic("mvctest: 读取请求体失败: " + err.Error())
+		}
+		rc.Request.SetBody(body)
+	}
+}
+
+// ResponseRecorder 记录控制器写出的响应
+type ResponseRecorder struct {
+	rc *app.RequestContext
+}
+
+// Code 响应状态码
+func (r *ResponseRecorder) Code() int {
+	return r.rc.Response.StatusCode()
+}
+
+// Header 响应头
+func (r *ResponseRecorder) Header() http.Header {
+	header := make(http.Header)
+	r.rc.Response.Header.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+	return header
+}
+
+// ContentType 响应的Content-Type
+func (r *ResponseRecorder) ContentType() string {
+	return string(r.rc.Response.Header.ContentType())
+}
+
+// Body 响应体
+func (r *ResponseRecorder) Body() []byte {
+	return r.rc.Response.Body()
+}
+
+// BodyString 响应体字符串
+func (r *ResponseRecorder) BodyString() string {
+	return string(r.Body())
+}
+
+// DecodeJSON 将响应体解析为JSON
+func (r *ResponseRecorder) DecodeJSON(v any) error {
+	return json.Unmarshal(r.Body(), v)
+}
+
+// Result 将记录的响应转换为*http.Response
+func (r *ResponseRecorder) Result() *http.Response {
+	body := r.Body()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Code(), http.StatusText(r.Code())),
+		StatusCode:    r.Code(),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
@@ -0,0 +1,181 @@
+package mvctest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/zsy619/yyhertz/framework/mvc/controller"
+	"github.com/zsy619/yyhertz/framework/mvc/core"
+)
+
+// userController 通过BaseController输出JSON的测试控制器
+type userController struct {
+	core.BaseController
+}
+
+func (uc *userController) GetShow() {
+	id := uc.GetParam("id")
+	if id == "0" {
+		uc.JSONWithStatus(http.StatusNotFound, map[string]any{"error": "user not found"})
+		return
+	}
+
+	uc.SetHeader("X-User-Id", id)
+	uc.JSON(map[string]any{
+		"id":      id,
+		"fields":  uc.GetString("fields"),
+		"traceId": uc.GetHeader("X-Trace-Id"),
+	})
+}
+
+// createUserRequest 创建用户请求
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+// accountController 由OptimizedControllerManager分发的测试控制器
+type accountController struct {
+	core.BaseController
+}
+
+func (ac *accountController) PostCreate(req createUserRequest) (map[string]any, error) {
+	return map[string]any{"name": req.Name, "email": req.Email, "created": true}, nil
+}
+
+func TestNewContext_CopiesRequest(t *testing.T) {
+	req := NewRequest("POST", "/users?page=2", strings.NewReader(`name=alice`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Trace-Id", "trace-1")
+
+	ctx, _ := NewContext(req)
+	defer ctx.Release()
+
+	if got := string(ctx.Request.Method()); got != "POST" {
+		t.Errorf("Expected method POST, got %s", got)
+	}
+	if got := string(ctx.Request.Path()); got != "/users" {
+		t.Errorf("Expected path /users, got %s", got)
+	}
+	if got := ctx.Query("page"); got != "2" {
+		t.Errorf("Expected query page=2, got %q", got)
+	}
+	if got := ctx.GetHeader("X-Trace-Id"); got != "trace-1" {
+		t.Errorf("Expected header X-Trace-Id=trace-1, got %q", got)
+	}
+	if got := string(ctx.Request.PostForm("name")); got != "alice" {
+		t.Errorf("Expected form name=alice, got %q", got)
+	}
+	if ctx.Context != req.Context() {
+		t.Error("Expected Context to carry the request context")
+	}
+}
+
+func TestController_JSONResponse(t *testing.T) {
+	req := NewRequest("GET", "/users/42?fields=name", nil)
+	req.Header.Set("X-Trace-Id", "trace-42")
+
+	ctx, rec := NewContext(req)
+	defer ctx.Release()
+	SetParams(ctx, map[string]string{"id": "42"})
+
+	ctrl := &userController{}
+	ctrl.Init(ctx, "UserController", "GetShow", nil)
+	ctrl.GetShow()
+
+	if rec.Code() != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code())
+	}
+	if !strings.HasPrefix(rec.ContentType(), "application/json") {
+		t.Errorf("Expected JSON content type, got %s", rec.ContentType())
+	}
+	if got := rec.Header().Get("X-User-Id"); got != "42" {
+		t.Errorf("Expected X-User-Id header 42, got %q", got)
+	}
+
+	var body map[string]string
+	if err := rec.DecodeJSON(&body); err != nil {
+		t.Fatalf("Invalid JSON body %s: %v", rec.BodyString(), err)
+	}
+	if body["id"] != "42" || body["fields"] != "name" || body["traceId"] != "trace-42" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+
+	resp := rec.Result()
+	if resp.StatusCode != http.StatusOK || resp.Status != "200 OK" {
+		t.Errorf("Unexpected result status: %d %s", resp.StatusCode, resp.Status)
+	}
+	if resp.ContentLength != int64(len(rec.Body())) {
+		t.Errorf("Expected content length %d, got %d", len(rec.Body()), resp.ContentLength)
+	}
+}
+
+func TestController_JSONErrorStatus(t *testing.T) {
+	ctx, rec := NewContext(NewRequest("GET", "/users/0", nil))
+	defer ctx.Release()
+	SetParams(ctx, map[string]string{"id": "0"})
+
+	ctrl := &userController{}
+	ctrl.Init(ctx, "UserController", "GetShow", nil)
+	ctrl.GetShow()
+
+	if rec.Code() != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %d", rec.Code())
+	}
+	if rec.BodyString() != `{"error":"user not found"}` {
+		t.Errorf("Unexpected body: %s", rec.BodyString())
+	}
+}
+
+func TestOptimizedControllerManager_JSONResult(t *testing.T) {
+	manager := controller.NewOptimizedControllerManager(nil)
+	if err := manager.RegisterController(&accountController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+
+	ctx, rec := NewContext(NewJSONRequest("POST", "/accounts", map[string]string{
+		"name":  "alice",
+		"email": "alice@example.com",
+	}))
+	defer ctx.Release()
+
+	if err := manager.HandleRequest(ctx, "accountController", "PostCreate"); err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+
+	if rec.Code() != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code())
+	}
+	var body struct {
+		Name    string `json:"name"`
+		Email   string `json:"email"`
+		Created bool   `json:"created"`
+	}
+	if err := rec.DecodeJSON(&body); err != nil {
+		t.Fatalf("Invalid JSON body %s: %v", rec.BodyString(), err)
+	}
+	if body.Name != "alice" || body.Email != "alice@example.com" || !body.Created {
+		t.Errorf("Unexpected body: %+v", body)
+	}
+}
+
+func TestOptimizedControllerManager_ValidationResponse(t *testing.T) {
+	manager := controller.NewOptimizedControllerManager(nil)
+	if err := manager.RegisterController(&accountController{}); err != nil {
+		t.Fatalf("Failed to register controller: %v", err)
+	}
+
+	ctx, rec := NewContext(NewJSONRequest("POST", "/accounts", map[string]string{"name": "alice"}))
+	defer ctx.Release()
+
+	if err := manager.HandleRequest(ctx, "accountController", "PostCreate"); err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if rec.Code() != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d", rec.Code())
+	}
+	if !strings.Contains(rec.BodyString(), "VALIDATION_FAILED") {
+		t.Errorf("Expected validation error body, got %s", rec.BodyString())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/zsy619/yyhertz/framework/mvc/core"
+	"github.com/zsy619/yyhertz/framework/mvc/middleware"
 )
 
 // NamespaceFunc 定义命名空间配置函数类型
@@ -16,6 +17,8 @@ type Namespace struct {
 	routers     []routerInfo
 	namespaces  []*Namespace
 	middlewares []core.HandlerFunc
+	corsPolicy  *middleware.CORSPolicy // 命名空间级跨域策略
+	corsSet     bool                   // 是否设置了命名空间级跨域策略
 }
 
 type controllerInfo struct {
@@ -76,19 +79,38 @@ func NSNamespace(prefix string, funcs ...NamespaceFunc) NamespaceFunc {
 	}
 }
 
-// NSMiddleware 添加命名空间中间件
+// NSMiddleware 添加命名空间中间件，作用于该命名空间及其子命名空间下的所有路由
+// 执行顺序：全局中间件 -> 父级命名空间中间件 -> 本级命名空间中间件 -> 控制器命名中间件
 func NSMiddleware(middlewares ...core.HandlerFunc) NamespaceFunc {
 	return func(ns *Namespace) {
 		ns.middlewares = append(ns.middlewares, middlewares...)
 	}
 }
 
+// NSCORS 为命名空间设置跨域策略，覆盖全局默认的CORS配置
+func NSCORS(policy *middleware.CORSPolicy) NamespaceFunc {
+	return func(ns *Namespace) {
+		ns.corsPolicy = policy
+		ns.corsSet = true
+	}
+}
+
+// NSDisableCORS 禁止命名空间下的路由跨域访问
+func NSDisableCORS() NamespaceFunc {
+	return NSCORS(nil)
+}
+
 // Register 将命名空间注册到应用（内部方法）
 func (ns *Namespace) Register(app *core.App) {
+	// 注册命名空间级跨域策略
+	if ns.corsSet {
+		app.GetCORSRegistry().Set(ns.prefix, ns.corsPolicy)
+	}
+
 	// 注册自动路由控制器
 	for _, ctrl := range ns.controllers {
 		if ctrl.autoRoute {
-			app.AutoRouterPrefix(ns.prefix, ctrl.controller)
+			app.AutoRoutersPrefixWithMiddleware(ns.prefix, ns.middlewares, ctrl.controller)
 		}
 	}
 
@@ -112,7 +134,9 @@ func (ns *Namespace) Register(app *core.App) {
 			controllers: subNs.controllers,
 			routers:     subNs.routers,
 			namespaces:  subNs.namespaces,
-			middlewares: append(ns.middlewares, subNs.middlewares...), // 继承父级中间件
+			middlewares: ns.inheritMiddlewares(subNs), // 继承父级中间件
+			corsPolicy:  subNs.corsPolicy,
+			corsSet:     subNs.corsSet, // 未设置时按前缀继承父级跨域策略
 		}
 
 		subNsCopy.Register(app)
@@ -140,7 +164,14 @@ func (ns *Namespace) registerRouter(app *core.App, router routerInfo) {
 
 	// 使用手动路由注册，传递prefix作为basePath，router.path作为相对路径
 	routeSpec := httpMethod + ":" + router.path
-	app.RouterPrefix(ns.prefix, router.controller, methodName, routeSpec)
+	app.RouterPrefixWithMiddleware(ns.prefix, ns.middlewares, router.controller, methodName, routeSpec)
+}
+
+// inheritMiddlewares 组合父级与子命名空间的中间件（复制切片，避免兄弟命名空间共享底层数组）
+func (ns *Namespace) inheritMiddlewares(subNs *Namespace) []core.HandlerFunc {
+	middlewares := make([]core.HandlerFunc, 0, len(ns.middlewares)+len(subNs.middlewares))
+	middlewares = append(middlewares, ns.middlewares...)
+	return append(middlewares, subNs.middlewares...)
 }
 
 // GetPrefix 获取命名空间前缀
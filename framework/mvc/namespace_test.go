@@ -0,0 +1,105 @@
+package mvc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zsy619/yyhertz/framework/mvc/core"
+)
+
+// nsTestController 命名空间测试控制器
+type nsTestController struct {
+	core.BaseController
+}
+
+func (c *nsTestController) GetList() {
+	c.String("list")
+}
+
+// nsRecord 记录执行顺序的中间件
+func nsRecord(order *[]string, name string) HandlerFunc {
+	return func(c context.Context, ctx *RequestContext) {
+		*order = append(*order, name)
+		ctx.Next(c)
+	}
+}
+
+func TestNamespace_MiddlewareInheritance(t *testing.T) {
+	app := NewApp()
+	var order []string
+	ctrl := &nsTestController{}
+
+	ns := NewNamespace("/api",
+		NSMiddleware(nsRecord(&order, "api")),
+		NSNamespace("/v1",
+			NSMiddleware(nsRecord(&order, "v1")),
+			NSNamespace("/admin",
+				NSMiddleware(nsRecord(&order, "admin.auth"), nsRecord(&order, "admin.audit")),
+				NSRouter("/users", ctrl, "GET:GetList"),
+			),
+			NSNamespace("/public",
+				NSRouter("/users", ctrl, "GET:GetList"),
+			),
+		),
+		NSRouter("/status", ctrl, "GET:GetList"),
+	)
+	ns.Register(app)
+
+	// 三级嵌套的路由依次继承所有祖先命名空间的中间件
+	resp := ut.PerformRequest(app.Engine, "GET", "/api/v1/admin/users", nil).Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Equal(t, "list", string(resp.Body()))
+	assert.Equal(t, []string{"api", "v1", "admin.auth", "admin.audit"}, order)
+
+	// 兄弟命名空间不受影响
+	order = nil
+	ut.PerformRequest(app.Engine, "GET", "/api/v1/public/users", nil)
+	assert.Equal(t, []string{"api", "v1"}, order)
+
+	// 父级命名空间的路由只执行本级中间件
+	order = nil
+	ut.PerformRequest(app.Engine, "GET", "/api/status", nil)
+	assert.Equal(t, []string{"api"}, order)
+}
+
+func TestNamespace_RoutesListed(t *testing.T) {
+	app := NewApp().EnableRouteListing()
+	var order []string
+	ctrl := &nsTestController{}
+
+	NewNamespace("/api",
+		NSMiddleware(nsRecord(&order, "api")),
+		NSNamespace("/v1",
+			NSRouter("/users", ctrl, "GET:GetList"),
+		),
+	).Register(app)
+	app.AutoRoutersPrefix("/auto", &nsTestController{})
+	app.Router(&nsTestController{}, "GetList", "GET:/manual/list")
+
+	resp := ut.PerformRequest(app.Engine, "GET", core.DefaultRouteListingPath, nil).Result()
+	assert.Equal(t, 200, resp.StatusCode())
+
+	var body struct {
+		Routes []core.RouteDescriptor `json:"routes"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body(), &body))
+
+	listed := make(map[string]core.RouteDescriptor)
+	for _, route := range body.Routes {
+		listed[route.Method+" "+route.Path] = route
+	}
+
+	// 命名空间、自动路由与手动路由均出现在列表中
+	nsRoute, ok := listed["GET /api/v1/users"]
+	if assert.True(t, ok, "namespace route should be listed") {
+		assert.Contains(t, nsRoute.Handler, "GetList")
+		assert.Contains(t, nsRoute.Middleware[len(nsRoute.Middleware)-1], "nsRecord", "namespace middleware should be listed")
+	}
+	assert.Contains(t, listed, "GET /auto/nstest/list")
+	assert.Contains(t, listed, "GET /manual/list")
+	assert.Equal(t, 0, len(order), "listing routes should not execute route middleware")
+}
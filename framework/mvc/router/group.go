@@ -48,6 +48,11 @@ func (g *Group) Use(middleware ...middleware.HandlerFunc) {
 	g.middleware = append(g.middleware, middleware...)
 }
 
+// CORS 为路由组设置跨域策略，policy 为 nil 时禁止该组路由跨域
+func (g *Group) CORS(policy *middleware.CORSPolicy) {
+	g.router.app.GetCORSRegistry().Set(g.prefix, policy)
+}
+
 // RegisterController 在路由组中注册控制器
 func (g *Group) RegisterController(path string, ctrl core.IController) {
 	fullPath := g.prefix + path
@@ -106,8 +106,20 @@ func (rh *RequestHandler) RegisterRoute(route *RouteInfo) error {
 	// 创建处理函数
 	handler := rh.CreateHandler(route)
 
-	// 根据HTTP方法注册路由
-	return rh.registerToEngine(route.HTTPMethod, route.Path, handler)
+	if rh.app == nil {
+		// 根据HTTP方法注册路由
+		return rh.registerToEngine(route.HTTPMethod, route.Path, handler)
+	}
+
+	// 登记到应用路由注册表，与其他注册方式统一检测重复路由
+	methods, err := rh.app.ReserveRoute(route.HTTPMethod, route.Path, route.TypeName+"."+route.MethodName)
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		rh.engine.Handle(method, route.Path, handler)
+	}
+	return nil
 }
 
 // validateRoute 验证路由信息
@@ -0,0 +1,155 @@
+package mybatis
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// AggregateValueColumn 聚合查询中数值列的列名
+	AggregateValueColumn = "value"
+	// AggregateFieldColumn 分组聚合查询中分组列的列名
+	AggregateFieldColumn = "field"
+
+	// aggregateAvgScale 平均值至少保留的小数位数
+	aggregateAvgScale = 4
+)
+
+// DecimalAggregate 数值列的精确聚合结果
+// 在Go中以Decimal逐行累加，不依赖数据库SUM/AVG的浮点计算
+type DecimalAggregate struct {
+	Count int64   `json:"count"` // 非NULL值的数量
+	Sum   Decimal `json:"sum"`
+	Avg   Decimal `json:"avg"` // 保留至少4位小数，四舍五入
+	Min   Decimal `json:"min"`
+	Max   Decimal `json:"max"`
+}
+
+// GroupedDecimalAggregate 分组的精确聚合结果
+type GroupedDecimalAggregate struct {
+	Field string `json:"field"`
+	DecimalAggregate
+}
+
+// add 累加一个值
+func (a *DecimalAggregate) add(value Decimal) {
+	if a.Count == 0 || value.Cmp(a.Min) < 0 {
+		a.Min = value
+	}
+	if a.Count == 0 || value.Cmp(a.Max) > 0 {
+		a.Max = value
+	}
+	a.Sum = a.Sum.Add(value)
+	a.Count++
+}
+
+// finish 计算平均值
+func (a *DecimalAggregate) finish() {
+	if a.Count > 0 {
+		a.Avg = a.Sum.DivInt(a.Count, maxScale(a.Sum.Scale(), aggregateAvgScale))
+	}
+}
+
+// AggregateDecimal 查询数值列并精确计算count/sum/avg/min/max，NULL值不参与计算
+// 查询应以value为数值列的别名，仅返回一列时可省略别名，如：SELECT price AS value FROM orders
+func AggregateDecimal(ctx context.Context, session SimpleSession, sql string, args ...interface{}) (*DecimalAggregate, error) {
+	rows, err := session.SelectList(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := &DecimalAggregate{}
+	for _, row := range rows {
+		value, ok, err := aggregateValue(row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			aggregate.add(value)
+		}
+	}
+	aggregate.finish()
+	return aggregate, nil
+}
+
+// AggregateDecimalBy 按field列分组，精确聚合value列，分组按首次出现的顺序返回
+// 如：SELECT status AS field, price AS value FROM orders ORDER BY status
+func AggregateDecimalBy(ctx context.Context, session SimpleSession, sql string, args ...interface{}) ([]*GroupedDecimalAggregate, error) {
+	rows, err := session.SelectList(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*GroupedDecimalAggregate, 0)
+	index := make(map[string]*GroupedDecimalAggregate)
+	for _, row := range rows {
+		columns, ok := row.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("mybatis: unexpected aggregate row type %T", row)
+		}
+		field, ok := columns[AggregateFieldColumn]
+		if !ok {
+			return nil, fmt.Errorf("mybatis: aggregate query must select a %q column", AggregateFieldColumn)
+		}
+
+		key := aggregateFieldString(field)
+		group, exists := index[key]
+		if !exists {
+			group = &GroupedDecimalAggregate{Field: key}
+			index[key] = group
+			groups = append(groups, group)
+		}
+
+		value, ok, err := aggregateValue(columns)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			group.add(value)
+		}
+	}
+
+	for _, group := range groups {
+		group.finish()
+	}
+	return groups, nil
+}
+
+// aggregateValue 读取一行中的数值列，NULL时返回false
+func aggregateValue(row interface{}) (Decimal, bool, error) {
+	columns, ok := row.(map[string]interface{})
+	if !ok {
+		return Decimal{}, false, fmt.Errorf("mybatis: unexpected aggregate row type %T", row)
+	}
+
+	raw, ok := columns[AggregateValueColumn]
+	if !ok {
+		if len(columns) != 1 {
+			return Decimal{}, false, fmt.Errorf("mybatis: aggregate query must select a %q column", AggregateValueColumn)
+		}
+		for _, only := range columns {
+			raw = only
+		}
+	}
+	if raw == nil {
+		return Decimal{}, false, nil
+	}
+
+	var value Decimal
+	if err := value.Scan(raw); err != nil {
+		return Decimal{}, false, err
+	}
+	return value, true, nil
+}
+
+// aggregateFieldString 将分组列的值转换为字符串
+func aggregateFieldString(field interface{}) string {
+	switch v := field.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,242 @@
+package mybatis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupOrdersDB 创建带金额列的订单表
+func setupOrdersDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	db.Exec(`CREATE TABLE orders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		status TEXT,
+		price DECIMAL(10,2),
+		amount TEXT
+	)`)
+	for _, order := range []struct {
+		status string
+		price  interface{}
+		amount string
+	}{
+		{"paid", 0.1, "9007199254740993.01"},
+		{"paid", 0.2, "0.02"},
+		{"pending", 19.99, "0.10"},
+		{"pending", 5.01, "0.20"},
+		{"pending", 0.7, "0.30"},
+		{"cancelled", nil, "0"},
+	} {
+		db.Exec("INSERT INTO orders (status, price, amount) VALUES (?, ?, ?)", order.status, order.price, order.amount)
+	}
+	return db
+}
+
+func TestAggregateDecimal_SumsPriceExactly(t *testing.T) {
+	session := NewSimpleSession(setupOrdersDB(t))
+	ctx := context.Background()
+
+	// 数据库按浮点求和会产生舍入误差
+	var floatSum float64
+	if err := session.Scan(ctx, &floatSum, "SELECT SUM(price) FROM orders WHERE status = ?", "paid"); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if floatSum == 0.3 {
+		t.Fatalf("Expected float SUM to be inexact, got %v", floatSum)
+	}
+
+	paid, err := AggregateDecimal(ctx, session, "SELECT price AS value FROM orders WHERE status = ?", "paid")
+	if err != nil {
+		t.Fatalf("AggregateDecimal failed: %v", err)
+	}
+	if paid.Sum.String() != "0.3" || !paid.Sum.Equal(MustParseDecimal("0.30")) {
+		t.Errorf("Expected exact sum 0.3, got %s", paid.Sum)
+	}
+
+	total, err := AggregateDecimal(ctx, session, "SELECT price FROM orders")
+	if err != nil {
+		t.Fatalf("AggregateDecimal failed: %v", err)
+	}
+	if total.Count != 5 {
+		t.Errorf("Expected NULL price to be skipped, got count %d", total.Count)
+	}
+	expected := map[string]string{
+		"sum": "26.00",
+		"avg": "5.2000",
+		"min": "0.1",
+		"max": "19.99",
+	}
+	actual := map[string]string{
+		"sum": total.Sum.String(),
+		"avg": total.Avg.String(),
+		"min": total.Min.String(),
+		"max": total.Max.String(),
+	}
+	for name, want := range expected {
+		if actual[name] != want {
+			t.Errorf("Expected %s %s, got %s", name, want, actual[name])
+		}
+	}
+}
+
+func TestAggregateDecimal_TextColumnBeyondFloatPrecision(t *testing.T) {
+	session := NewSimpleSession(setupOrdersDB(t))
+
+	paid, err := AggregateDecimal(context.Background(), session, "SELECT amount AS value FROM orders WHERE status = ?", "paid")
+	if err != nil {
+		t.Fatalf("AggregateDecimal failed: %v", err)
+	}
+	if paid.Sum.String() != "9007199254740993.03" {
+		t.Errorf("Expected 9007199254740993.03, got %s", paid.Sum)
+	}
+}
+
+func TestAggregateDecimalBy_GroupsInOrder(t *testing.T) {
+	session := NewSimpleSession(setupOrdersDB(t))
+
+	groups, err := AggregateDecimalBy(context.Background(), session,
+		"SELECT status AS field, price AS value FROM orders ORDER BY id")
+	if err != nil {
+		t.Fatalf("AggregateDecimalBy failed: %v", err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+
+	pending := groups[1]
+	if pending.Field != "pending" || pending.Count != 3 {
+		t.Fatalf("Unexpected pending group: %+v", pending)
+	}
+	if pending.Sum.String() != "25.70" || pending.Avg.String() != "8.5667" {
+		t.Errorf("Expected sum 25.70 avg 8.5667, got sum %s avg %s", pending.Sum, pending.Avg)
+	}
+	if pending.Min.String() != "0.7" || pending.Max.String() != "19.99" {
+		t.Errorf("Expected min 0.7 max 19.99, got min %s max %s", pending.Min, pending.Max)
+	}
+
+	cancelled := groups[2]
+	if cancelled.Field != "cancelled" || cancelled.Count != 0 || !cancelled.Sum.IsZero() {
+		t.Errorf("Expected empty cancelled group, got %+v", cancelled)
+	}
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"field":"pending","count":3,"sum":"25.70","avg":"8.5667","min":"0.7","max":"19.99"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
+
+func TestAggregateDecimalBy_RequiresFieldColumn(t *testing.T) {
+	session := NewSimpleSession(setupOrdersDB(t))
+
+	if _, err := AggregateDecimalBy(context.Background(), session, "SELECT price AS value FROM orders"); err == nil {
+		t.Error("Expected error for missing field column")
+	}
+	if _, err := AggregateDecimal(context.Background(), session, "SELECT status, price FROM orders"); err == nil {
+		t.Error("Expected error for missing value column")
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"new", NewDecimal(1999, 2), "19.99"},
+		{"new negative scale", NewDecimal(12, -2), "1200"},
+		{"small fraction", MustParseDecimal("-.05"), "-0.05"},
+		{"add", MustParseDecimal("0.1").Add(MustParseDecimal("0.20")), "0.30"},
+		{"sub", MustParseDecimal("1").Sub(MustParseDecimal("1.01")), "-0.01"},
+		{"round half up", MustParseDecimal("2.345").Round(2), "2.35"},
+		{"round negative", MustParseDecimal("-2.345").Round(2), "-2.35"},
+		{"round down", MustParseDecimal("2.344").Round(2), "2.34"},
+		{"round extends", MustParseDecimal("2.3").Round(2), "2.30"},
+		{"div", MustParseDecimal("10").DivInt(3, 2), "3.33"},
+		{"div rounds", MustParseDecimal("20").DivInt(3, 2), "6.67"},
+		{"float", DecimalFromFloat(0.1), "0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, tt.got)
+			}
+		})
+	}
+
+	if MustParseDecimal("1.50").Cmp(MustParseDecimal("1.5")) != 0 {
+		t.Error("Expected 1.50 to equal 1.5")
+	}
+	if MustParseDecimal("-1").Cmp(MustParseDecimal("0.01")) != -1 {
+		t.Error("Expected -1 < 0.01")
+	}
+	var zero Decimal
+	if zero.String() != "0" || !zero.Add(NewDecimal(5, 1)).Equal(MustParseDecimal("0.5")) {
+		t.Errorf("Unexpected zero value behaviour: %s", zero)
+	}
+}
+
+func TestDecimal_ParseErrors(t *testing.T) {
+	for _, input := range []string{"", ".", "-", "1.2.3", "1e5", "abc", "1.-5", "0x10"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("Expected error parsing %q", input)
+		}
+	}
+}
+
+func TestDecimal_ScanAndJSON(t *testing.T) {
+	inputs := []struct {
+		src  interface{}
+		want string
+	}{
+		{int64(42), "42"},
+		{19.99, "19.99"},
+		{[]byte("0.10"), "0.10"},
+		{"-3.5", "-3.5"},
+		{nil, "0"},
+	}
+	for _, in := range inputs {
+		var d Decimal
+		if err := d.Scan(in.src); err != nil {
+			t.Fatalf("Scan(%v) failed: %v", in.src, err)
+		}
+		if d.String() != in.want {
+			t.Errorf("Scan(%v): expected %s, got %s", in.src, in.want, d)
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan(true); err == nil {
+		t.Error("Expected error scanning bool")
+	}
+
+	value, err := MustParseDecimal("19.90").Value()
+	if err != nil || value != "19.90" {
+		t.Errorf("Expected driver value 19.90, got %v (%v)", value, err)
+	}
+
+	var decoded struct {
+		Price Decimal `json:"price"`
+		Total Decimal `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":"19.90","total":0.3}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Price.String() != "19.90" || decoded.Total.String() != "0.3" {
+		t.Errorf("Unexpected decoded values: %s %s", decoded.Price, decoded.Total)
+	}
+	data, _ := json.Marshal(decoded)
+	if string(data) != `{"price":"19.90","total":"0.3"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
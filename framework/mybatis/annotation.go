@@ -0,0 +1,168 @@
+package mybatis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// sqlAnnotations 注解名到语句类型的映射
+var sqlAnnotations = map[string]StatementType{
+	"Select": StatementTypeSelect,
+	"Insert": StatementTypeInsert,
+	"Update": StatementTypeUpdate,
+	"Delete": StatementTypeDelete,
+}
+
+// ParseMapperAnnotations 解析映射器接口方法注释中的注解，构建以方法名为ID的语句
+//
+// Go 运行时无法读取注释，因此需要提供接口所在的源文件（src 为nil时读取 filename，用法同 parser.ParseFile）。
+// 支持的注解：
+//
+//	@Select("...") / @Insert("...") / @Update("...") / @Delete("...")
+//	@Options(useGeneratedKeys=true, keyProperty="id", useCache=false, timeout=10)
+//	@ResultMap("userMap")
+//
+// 没有SQL注解的方法被忽略。
+func ParseMapperAnnotations(filename string, src interface{}, interfaceName string) (map[string]*Statement, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mapper source: %w", err)
+	}
+
+	var iface *ast.InterfaceType
+	ast.Inspect(file, func(node ast.Node) bool {
+		if spec, ok := node.(*ast.TypeSpec); ok && spec.Name.Name == interfaceName {
+			iface, _ = spec.Type.(*ast.InterfaceType)
+			return false
+		}
+		return iface == nil
+	})
+	if iface == nil {
+		return nil, fmt.Errorf("mapper interface %s not found in %s", interfaceName, filename)
+	}
+
+	statements := make(map[string]*Statement)
+	for _, method := range iface.Methods.List {
+		if len(method.Names) == 0 || method.Doc == nil {
+			continue
+		}
+		name := method.Names[0].Name
+		stmt, err := parseStatementAnnotations(method.Doc, name, interfaceName)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", interfaceName, name, err)
+		}
+		if stmt != nil {
+			statements[name] = stmt
+		}
+	}
+	return statements, nil
+}
+
+// RegisterAnnotatedMapper 解析映射器接口的注解并以接口名为命名空间注册语句
+func (mb *MyBatisGorm) RegisterAnnotatedMapper(filename string, src interface{}, interfaceName string) error {
+	statements, err := ParseMapperAnnotations(filename, src, interfaceName)
+	if err != nil {
+		return err
+	}
+	mb.RegisterMapper(interfaceName, statements)
+	return nil
+}
+
+// parseStatementAnnotations 解析单个方法的注解，没有SQL注解时返回nil
+func parseStatementAnnotations(doc *ast.CommentGroup, id, namespace string) (*Statement, error) {
+	var stmt *Statement
+	var options, resultMap string
+
+	for _, comment := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") || !strings.HasSuffix(line, ")") {
+			continue
+		}
+		open := strings.Index(line, "(")
+		if open < 0 {
+			continue
+		}
+		name, body := line[1:open], strings.TrimSpace(line[open+1:len(line)-1])
+
+		switch {
+		case name == "Options":
+			options = body
+		case name == "ResultMap":
+			value, err := strconv.Unquote(body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid @ResultMap value %s", body)
+			}
+			resultMap = value
+		default:
+			statementType, ok := sqlAnnotations[name]
+			if !ok {
+				continue
+			}
+			if stmt != nil {
+				return nil, fmt.Errorf("multiple SQL annotations")
+			}
+			sql, err := strconv.Unquote(body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid @%s SQL %s", name, body)
+			}
+			stmt = &Statement{
+				ID:            id,
+				Namespace:     namespace,
+				SQL:           sql,
+				StatementType: statementType,
+				UseCache:      statementType == StatementTypeSelect,
+				Timeout:       30,
+			}
+		}
+	}
+
+	if stmt == nil {
+		if options != "" || resultMap != "" {
+			return nil, fmt.Errorf("@Options or @ResultMap without SQL annotation")
+		}
+		return nil, nil
+	}
+	stmt.ResultMap = resultMap
+	if options != "" {
+		if err := applyStatementOptions(stmt, options); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// applyStatementOptions 应用 @Options 中的配置项
+func applyStatementOptions(stmt *Statement, options string) error {
+	for _, option := range strings.Split(options, ",") {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			return fmt.Errorf("invalid @Options entry %q", strings.TrimSpace(option))
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		var err error
+		switch key {
+		case "useGeneratedKeys":
+			stmt.UseGeneratedKeys, err = strconv.ParseBool(value)
+		case "keyProperty":
+			stmt.KeyProperty = value
+		case "useCache":
+			stmt.UseCache, err = strconv.ParseBool(value)
+		case "timeout":
+			stmt.Timeout, err = strconv.Atoi(value)
+		default:
+			return fmt.Errorf("unsupported @Options key %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid @Options %s: %w", key, err)
+		}
+	}
+	return nil
+}
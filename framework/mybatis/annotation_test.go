@@ -0,0 +1,145 @@
+package mybatis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const annotatedMapperSource = `package example
+
+type AccountMapper interface {
+	// SelectById 根据ID查询
+	// @Select("SELECT id, name, email FROM members WHERE id = #{id}")
+	SelectById(id int64) (*Member, error)
+
+	// SelectByName 按名称查询，不使用缓存
+	// @Select("SELECT id, name, email FROM members WHERE name = #{name} ORDER BY id")
+	// @Options(useCache=false, timeout=5)
+	SelectByName(query *MemberQuery) ([]*Member, error)
+
+	// Insert 插入并回填ID
+	// @Insert("INSERT INTO members (name, email) VALUES (#{name}, #{email, jdbcType=VARCHAR})")
+	// @Options(useGeneratedKeys=true, keyProperty="id")
+	Insert(member *Member) (int64, error)
+
+	// @Update("UPDATE members SET email = #{email} WHERE id = #{id}")
+	Update(member *Member) (int64, error)
+
+	// @Delete("DELETE FROM members WHERE id = #{id}")
+	Delete(id int64) (int64, error)
+
+	// Custom 未注解的方法被忽略
+	Custom() error
+}
+`
+
+type member struct {
+	ID    int64
+	Name  string
+	Email string
+}
+
+func newAnnotatedMyBatis(t *testing.T) *MyBatisGorm {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "members.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Exec("CREATE TABLE members (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, email TEXT)")
+	db.Exec("INSERT INTO members (name, email) VALUES ('Alice', 'alice@example.com')")
+
+	mb := NewMyBatisGorm(db, nil)
+	if err := mb.RegisterAnnotatedMapper("account_mapper.go", annotatedMapperSource, "AccountMapper"); err != nil {
+		t.Fatalf("RegisterAnnotatedMapper failed: %v", err)
+	}
+	return mb
+}
+
+func TestParseMapperAnnotations(t *testing.T) {
+	statements, err := ParseMapperAnnotations("account_mapper.go", annotatedMapperSource, "AccountMapper")
+	if err != nil {
+		t.Fatalf("ParseMapperAnnotations failed: %v", err)
+	}
+	if len(statements) != 5 {
+		t.Fatalf("Expected 5 annotated statements, got %d", len(statements))
+	}
+	if _, exists := statements["Custom"]; exists {
+		t.Error("Expected method without annotation to be ignored")
+	}
+
+	selectByID := statements["SelectById"]
+	if selectByID.SQL != "SELECT id, name, email FROM members WHERE id = #{id}" ||
+		selectByID.StatementType != StatementTypeSelect || selectByID.Namespace != "AccountMapper" || !selectByID.UseCache {
+		t.Errorf("Unexpected SelectById statement: %+v", *selectByID)
+	}
+	if selectByName := statements["SelectByName"]; selectByName.UseCache || selectByName.Timeout != 5 {
+		t.Errorf("Expected @Options to disable cache and set timeout, got %+v", *selectByName)
+	}
+	insert := statements["Insert"]
+	if insert.StatementType != StatementTypeInsert || !insert.UseGeneratedKeys || insert.KeyProperty != "id" {
+		t.Errorf("Unexpected Insert statement: %+v", *insert)
+	}
+	if statements["Update"].StatementType != StatementTypeUpdate || statements["Delete"].StatementType != StatementTypeDelete {
+		t.Error("Expected update and delete statement types")
+	}
+}
+
+func TestParseMapperAnnotations_Errors(t *testing.T) {
+	if _, err := ParseMapperAnnotations("account_mapper.go", annotatedMapperSource, "MissingMapper"); err == nil {
+		t.Error("Expected error for missing interface")
+	}
+
+	unknownOption := `package example
+type M interface {
+	// @Select("SELECT 1")
+	// @Options(fetchSize=10)
+	Get() error
+}`
+	if _, err := ParseMapperAnnotations("m.go", unknownOption, "M"); err == nil {
+		t.Error("Expected error for unsupported @Options key")
+	}
+}
+
+func TestAnnotatedMapper_ExecutesAnnotatedSQL(t *testing.T) {
+	session := newAnnotatedMyBatis(t).OpenSession()
+	defer session.Close()
+
+	alice, err := SelectOneTyped[member](session, "AccountMapper.SelectById", 1)
+	if err != nil {
+		t.Fatalf("SelectById failed: %v", err)
+	}
+	if alice == nil || alice.Name != "Alice" || alice.Email != "alice@example.com" {
+		t.Fatalf("Unexpected member: %+v", alice)
+	}
+
+	bob := &member{Name: "Bob", Email: "bob@example.com"}
+	if affected, err := session.Insert("AccountMapper.Insert", bob); err != nil || affected != 1 {
+		t.Fatalf("Insert failed: affected=%d err=%v", affected, err)
+	}
+	if bob.ID != 2 {
+		t.Errorf("Expected generated id 2 to be written back, got %d", bob.ID)
+	}
+
+	bob.Email = "bob@example.org"
+	if affected, err := session.Update("AccountMapper.Update", bob); err != nil || affected != 1 {
+		t.Fatalf("Update failed: affected=%d err=%v", affected, err)
+	}
+	members, err := SelectListTyped[member](session, "AccountMapper.SelectByName", map[string]interface{}{"name": "Bob"})
+	if err != nil {
+		t.Fatalf("SelectByName failed: %v", err)
+	}
+	if len(members) != 1 || members[0].Email != "bob@example.org" {
+		t.Errorf("Expected updated Bob, got %+v", members)
+	}
+
+	if affected, err := session.Delete("AccountMapper.Delete", bob.ID); err != nil || affected != 1 {
+		t.Fatalf("Delete failed: affected=%d err=%v", affected, err)
+	}
+	if missing, _ := SelectOneTyped[member](session, "AccountMapper.SelectById", bob.ID); missing != nil {
+		t.Errorf("Expected deleted member, got %+v", missing)
+	}
+}
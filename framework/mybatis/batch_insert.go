@@ -0,0 +1,146 @@
+package mybatis
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultCreateBatchSize 多行INSERT每条语句默认包含的行数，对应GORM的CreateBatchSize
+	DefaultCreateBatchSize = 100
+
+	// maxInsertParams 单条语句的参数上限，取SQLite旧版本的999以兼容各数据库
+	maxInsertParams = 999
+)
+
+// insertIdentifierPattern 表名与列名只允许字母、数字、下划线，可带一级schema前缀
+var insertIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// BatchSize 设置多行INSERT每条语句包含的最大行数，小于等于0时使用DefaultCreateBatchSize
+func (s *defaultSession) BatchSize(size int) SimpleSession {
+	s.config.CreateBatchSize = size
+	return s
+}
+
+// BatchInsertRows 以多行 INSERT ... VALUES (...),(...) 批量插入，按批大小拆分为多条语句并在同一事务中执行
+func (s *defaultSession) BatchInsertRows(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error) {
+	statements, err := buildBatchInsertStatements(table, columns, rows, s.insertBatchSize(len(columns)))
+	if err != nil {
+		return 0, err
+	}
+
+	startTime := time.Now()
+
+	// 执行前钩子
+	for _, stmt := range statements {
+		for _, hook := range s.beforeHooks {
+			if err := hook(ctx, stmt.SQL, stmt.Args); err != nil {
+				return 0, fmt.Errorf("before hook error: %w", err)
+			}
+		}
+	}
+
+	var affectedRows int64
+
+	if s.config.DryRun {
+		// DryRun模式：只打印SQL，不实际执行
+		for _, stmt := range statements {
+			s.logSQL("[DryRun BATCH INSERT]", stmt.SQL, stmt.Args)
+		}
+	} else if len(statements) > 0 {
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			var total int64
+			for i, stmt := range statements {
+				if s.config.Debug {
+					s.logSQL(fmt.Sprintf("[Debug BATCH INSERT %d]", i+1), stmt.SQL, stmt.Args)
+				}
+
+				result := tx.Exec(stmt.SQL, stmt.Args...)
+				if result.Error != nil {
+					return fmt.Errorf("batch insert statement %d failed: %w", i+1, result.Error)
+				}
+				total += result.RowsAffected
+			}
+			affectedRows = total
+			return nil
+		})
+		invalidateRequestCache(ctx)
+		if err != nil {
+			s.logError("BATCH INSERT failed, transaction rolled back", err)
+			affectedRows = 0
+		}
+	}
+
+	duration := time.Since(startTime)
+
+	// 执行后钩子
+	for _, hook := range s.afterHooks {
+		hook(ctx, affectedRows, duration, err)
+	}
+
+	return affectedRows, err
+}
+
+// insertBatchSize 计算每条语句的行数，同时受批大小与参数上限约束
+func (s *defaultSession) insertBatchSize(columnCount int) int {
+	size := s.config.CreateBatchSize
+	if size <= 0 {
+		size = DefaultCreateBatchSize
+	}
+	if columnCount > 0 && size*columnCount > maxInsertParams {
+		size = maxInsertParams / columnCount
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// buildBatchInsertStatements 构建多行INSERT语句，每条语句最多包含batchSize行
+func buildBatchInsertStatements(table string, columns []string, rows [][]interface{}, batchSize int) ([]BatchStatement, error) {
+	if !insertIdentifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("mybatis: invalid table name %q", table)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("mybatis: batch insert into %s requires at least one column", table)
+	}
+	for _, column := range columns {
+		if !insertIdentifierPattern.MatchString(column) {
+			return nil, fmt.Errorf("mybatis: invalid column name %q", column)
+		}
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("mybatis: row %d has %d values, expected %d", i+1, len(row), len(columns))
+		}
+	}
+
+	prefix := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES "
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	statements := make([]BatchStatement, 0, (len(rows)+batchSize-1)/batchSize)
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var sql strings.Builder
+		sql.WriteString(prefix)
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		for i, row := range rows[start:end] {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString(placeholders)
+			args = append(args, row...)
+		}
+		statements = append(statements, BatchStatement{SQL: sql.String(), Args: args})
+	}
+	return statements, nil
+}
@@ -619,6 +619,8 @@ func (builder *CacheBuilder) Build() (Cache, error) {
 			cache = NewSerializedCache(cache, nil)
 		case "SYNCHRONIZED":
 			cache = NewSynchronizedCache(cache)
+		case "SINGLEFLIGHT":
+			cache = NewSingleflightCache(cache)
 		case "TRANSACTIONAL":
 			cache = NewTransactionalCache(cache)
 		}
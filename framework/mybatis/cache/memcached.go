@@ -0,0 +1,324 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	frameworkcache "github.com/zsy619/yyhertz/framework/cache"
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+const (
+	// memcachedMaxKeyLength Memcached键的最大长度
+	memcachedMaxKeyLength = 250
+	// memcachedMaxRelativeExpiration 超过30天的过期时间会被Memcached视为Unix时间戳
+	memcachedMaxRelativeExpiration = 30 * 24 * time.Hour
+)
+
+func init() {
+	// 查询结果中常见的动态类型，自定义结构体需调用gob.Register注册
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+	gob.Register([]map[string]any{})
+	gob.Register(time.Time{})
+}
+
+// GobSerializer 基于gob的序列化器，反序列化后保留原始类型
+type GobSerializer struct{}
+
+// gobEnvelope 以接口字段包装值，使gob记录具体类型
+type gobEnvelope struct {
+	Value any
+}
+
+// Serialize 序列化对象
+func (GobSerializer) Serialize(obj any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&gobEnvelope{Value: obj}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Deserialize 反序列化对象
+func (GobSerializer) Deserialize(data []byte) (any, error) {
+	var envelope gobEnvelope
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	return envelope.Value, nil
+}
+
+// JSONSerializer 基于JSON的序列化器，反序列化后为map[string]any、[]any等通用类型
+type JSONSerializer struct{}
+
+// Serialize 序列化对象
+func (JSONSerializer) Serialize(obj any) ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+// Deserialize 反序列化对象
+func (JSONSerializer) Deserialize(data []byte) (any, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// MemcachedCache Memcached缓存实现
+//
+// 值经Serializer序列化后以TTL存储。Memcached无法按前缀删除，
+// 键中包含命名空间版本号，Clear时递增版本号使旧键失效并由Memcached自然淘汰
+type MemcachedCache struct {
+	id         string
+	client     *memcache.Client
+	prefix     string
+	ttl        time.Duration
+	serializer Serializer
+}
+
+// NewMemcachedClient 创建Memcached客户端（bradfitz/gomemcache），addrs为 host:port 列表
+// 按键的哈希在多个服务器间分片，空地址会被忽略
+func NewMemcachedClient(addrs ...string) (*memcache.Client, error) {
+	servers := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			servers = append(servers, addr)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("memcached: no server address configured")
+	}
+
+	var selector memcache.ServerList
+	if err := selector.SetServers(servers...); err != nil {
+		return nil, fmt.Errorf("memcached: %w", err)
+	}
+	client := memcache.NewFromSelector(&selector)
+	client.Timeout = 3 * time.Second
+	return client, nil
+}
+
+// NewMemcachedCache 创建Memcached缓存，serializer为nil时使用GobSerializer，ttl<=0表示不过期
+func NewMemcachedCache(id string, client *memcache.Client, prefix string, ttl time.Duration, serializer Serializer) *MemcachedCache {
+	if serializer == nil {
+		serializer = GobSerializer{}
+	}
+	return &MemcachedCache{
+		id:         id,
+		client:     client,
+		prefix:     prefix,
+		ttl:        ttl,
+		serializer: serializer,
+	}
+}
+
+// GetId 获取缓存ID
+func (cache *MemcachedCache) GetId() string {
+	return cache.id
+}
+
+// Put 存储对象
+func (cache *MemcachedCache) Put(key string, value any) {
+	data, err := cache.serializer.Serialize(value)
+	if err != nil {
+		config.Warnf("Memcached cache %s: serialize %s failed: %v", cache.id, key, err)
+		return
+	}
+	itemKey, err := cache.itemKey(key)
+	if err != nil {
+		config.Warnf("Memcached cache %s: %v", cache.id, err)
+		return
+	}
+	item := &memcache.Item{Key: itemKey, Value: data, Expiration: memcachedExpiration(cache.ttl)}
+	if err := cache.client.Set(item); err != nil {
+		config.Warnf("Memcached cache %s: put %s failed: %v", cache.id, key, err)
+	}
+}
+
+// Get 获取对象
+func (cache *MemcachedCache) Get(key string) (any, bool) {
+	itemKey, err := cache.itemKey(key)
+	if err != nil {
+		config.Warnf("Memcached cache %s: %v", cache.id, err)
+		return nil, false
+	}
+	item, err := cache.client.Get(itemKey)
+	if err != nil {
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			config.Warnf("Memcached cache %s: get %s failed: %v", cache.id, key, err)
+		}
+		return nil, false
+	}
+	value, err := cache.serializer.Deserialize(item.Value)
+	if err != nil {
+		config.Warnf("Memcached cache %s: deserialize %s failed: %v", cache.id, key, err)
+		return nil, false
+	}
+	return value, true
+}
+
+// Remove 移除对象，返回被移除的值
+func (cache *MemcachedCache) Remove(key string) any {
+	value, _ := cache.Get(key)
+	itemKey, err := cache.itemKey(key)
+	if err != nil {
+		config.Warnf("Memcached cache %s: %v", cache.id, err)
+		return value
+	}
+	if err := cache.client.Delete(itemKey); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		config.Warnf("Memcached cache %s: remove %s failed: %v", cache.id, key, err)
+	}
+	return value
+}
+
+// Clear 清空缓存：递增命名空间版本号
+func (cache *MemcachedCache) Clear() {
+	_, err := cache.client.Increment(cache.namespaceKey(), 1)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		// 版本号不存在（未使用或已被淘汰），下次访问时会以新版本号初始化
+		return
+	}
+	if err != nil {
+		config.Warnf("Memcached cache %s: clear failed: %v", cache.id, err)
+	}
+}
+
+// GetSize Memcached不支持按前缀统计键数量，始终返回0
+func (cache *MemcachedCache) GetSize() int {
+	return 0
+}
+
+// PutObject 存储对象（兼容旧接口）
+func (cache *MemcachedCache) PutObject(key any, value any) {
+	cache.Put(fmt.Sprint(key), value)
+}
+
+// GetObject 获取对象（兼容旧接口）
+func (cache *MemcachedCache) GetObject(key any) any {
+	value, _ := cache.Get(fmt.Sprint(key))
+	return value
+}
+
+// RemoveObject 移除对象（兼容旧接口）
+func (cache *MemcachedCache) RemoveObject(key any) any {
+	return cache.Remove(fmt.Sprint(key))
+}
+
+// namespaceKey 命名空间版本号的键
+func (cache *MemcachedCache) namespaceKey() string {
+	return cache.prefix + cache.id + ":ns"
+}
+
+// namespace 获取命名空间版本号，不存在时以当前纳秒时间初始化，避免与被淘汰前的版本号重复
+func (cache *MemcachedCache) namespace() (string, error) {
+	key := cache.namespaceKey()
+	item, err := cache.client.Get(key)
+	if err == nil {
+		return string(item.Value), nil
+	}
+	if !errors.Is(err, memcache.ErrCacheMiss) {
+		return "", err
+	}
+
+	version := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err := cache.client.Add(&memcache.Item{Key: key, Value: version}); err != nil {
+		if errors.Is(err, memcache.ErrNotStored) {
+			// 并发初始化，使用已存储的版本号
+			if item, err = cache.client.Get(key); err != nil {
+				return "", err
+			}
+			return string(item.Value), nil
+		}
+		return "", err
+	}
+	return string(version), nil
+}
+
+// itemKey 生成Memcached键，过长或含空白、控制字符的键使用SHA1摘要
+func (cache *MemcachedCache) itemKey(key string) (string, error) {
+	ns, err := cache.namespace()
+	if err != nil {
+		return "", fmt.Errorf("get namespace: %w", err)
+	}
+
+	base := cache.prefix + cache.id + ":" + ns + ":"
+	if len(base)+len(key) > memcachedMaxKeyLength || strings.ContainsFunc(key, func(r rune) bool {
+		return r <= ' ' || r == 0x7f
+	}) {
+		key = digestKey(key)
+	}
+	return base + key, nil
+}
+
+// memcachedExpiration 将TTL转换为Memcached过期时间：不足1秒按1秒，超过30天使用Unix时间戳
+func memcachedExpiration(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > memcachedMaxRelativeExpiration {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+	seconds := int32(ttl / time.Second)
+	if ttl%time.Second != 0 {
+		seconds++
+	}
+	return seconds
+}
+
+// digestKey 计算键的SHA1摘要
+func digestKey(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewCacheFromConfig 根据数据库配置的cache段创建缓存
+// type为memcached时使用memcached_addrs（逗号分隔），为redis时使用redis_addr、redis_password与redis_db，
+// 两者均使用ttl与key_prefix；memory或未配置时使用LRU内存缓存
+func NewCacheFromConfig(id string, cfg *config.DatabaseConfig) (Cache, error) {
+	if cfg == nil {
+		return NewLruCache(NewPerpetualCache(id), 1024), nil
+	}
+
+	var ttl time.Duration
+	if cfg.Cache.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(cfg.Cache.TTL); err != nil {
+			return nil, fmt.Errorf("invalid cache ttl %q: %w", cfg.Cache.TTL, err)
+		}
+	}
+
+	switch strings.ToLower(cfg.Cache.Type) {
+	case "", "memory":
+		size := cfg.Cache.MaxSize
+		if size <= 0 {
+			size = 1024
+		}
+		return NewLruCache(NewPerpetualCache(id), size), nil
+	case "memcached":
+		client, err := NewMemcachedClient(strings.Split(cfg.Cache.MemcachedAddrs, ",")...)
+		if err != nil {
+			return nil, err
+		}
+		return NewMemcachedCache(id, client, cfg.Cache.KeyPrefix, ttl, nil), nil
+	case "redis":
+		if cfg.Cache.RedisAddr == "" {
+			return nil, errors.New("redis cache requires cache.redis_addr")
+		}
+		client := frameworkcache.SharedRedisClient(cfg.Cache.RedisAddr, cfg.Cache.RedisPassword, cfg.Cache.RedisDB)
+		return NewRedisCache(id, client, cfg.Cache.KeyPrefix, ttl, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache type %q", cfg.Cache.Type)
+	}
+}
@@ -0,0 +1,333 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// fakeMemcached 实现get/set/add/delete/incr文本协议的内存Memcached，时钟可手动推进
+type fakeMemcached struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	items    map[string]fakeMemcachedItem
+	now      time.Time
+	lastTTL  int64
+	commands int
+}
+
+type fakeMemcachedItem struct {
+	value    []byte
+	expireAt time.Time
+}
+
+func newFakeMemcached(t *testing.T) *fakeMemcached {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	server := &fakeMemcached{
+		listener: listener,
+		items:    make(map[string]fakeMemcachedItem),
+		now:      time.Now(),
+	}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeMemcached) addr() string {
+	return s.listener.Addr().String()
+}
+
+// advance 推进时钟
+func (s *fakeMemcached) advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = s.now.Add(d)
+}
+
+// ttl 最近一次存储命令的exptime
+func (s *fakeMemcached) ttl() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastTTL
+}
+
+// commandCount 已处理的命令数
+func (s *fakeMemcached) commandCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands
+}
+
+func (s *fakeMemcached) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeMemcached) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var data []byte
+		if fields[0] == "set" || fields[0] == "add" {
+			size, _ := strconv.Atoi(fields[4])
+			data = make([]byte, size+2)
+			if _, err := io.ReadFull(reader, data); err != nil {
+				return
+			}
+			data = data[:size]
+		}
+		if _, err := conn.Write([]byte(s.execute(fields, data))); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeMemcached) execute(fields []string, data []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands++
+
+	key := fields[1]
+	item, exists := s.items[key]
+	if exists && !item.expireAt.IsZero() && !s.now.Before(item.expireAt) {
+		delete(s.items, key)
+		exists = false
+	}
+
+	switch fields[0] {
+	case "get", "gets":
+		if !exists {
+			return "END\r\n"
+		}
+		return fmt.Sprintf("VALUE %s 0 %d\r\n%s\r\nEND\r\n", key, len(item.value), item.value)
+	case "set", "add":
+		if fields[0] == "add" && exists {
+			return "NOT_STORED\r\n"
+		}
+		ttl, _ := strconv.ParseInt(fields[3], 10, 64)
+		s.lastTTL = ttl
+		stored := fakeMemcachedItem{value: append([]byte(nil), data...)}
+		if ttl > 0 {
+			stored.expireAt = s.now.Add(time.Duration(ttl) * time.Second)
+		}
+		s.items[key] = stored
+		return "STORED\r\n"
+	case "delete":
+		if !exists {
+			return "NOT_FOUND\r\n"
+		}
+		delete(s.items, key)
+		return "DELETED\r\n"
+	case "incr":
+		if !exists {
+			return "NOT_FOUND\r\n"
+		}
+		current, _ := strconv.ParseUint(string(item.value), 10, 64)
+		delta, _ := strconv.ParseUint(fields[2], 10, 64)
+		item.value = []byte(strconv.FormatUint(current+delta, 10))
+		s.items[key] = item
+		return string(item.value) + "\r\n"
+	}
+	return "ERROR\r\n"
+}
+
+type cachedUser struct {
+	ID   int
+	Name string
+}
+
+func newTestMemcachedCache(t *testing.T, server *fakeMemcached, ttl time.Duration) *MemcachedCache {
+	client, err := NewMemcachedClient(server.addr())
+	if err != nil {
+		t.Fatalf("NewMemcachedClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewMemcachedCache("UserMapper", client, "test:", ttl, nil)
+}
+
+func TestMemcachedCache_PutGet(t *testing.T) {
+	server := newFakeMemcached(t)
+	cache := newTestMemcachedCache(t, server, time.Hour)
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Fatal("Expected miss for unknown key")
+	}
+
+	rows := []map[string]any{{"id": int64(1), "name": "alice"}}
+	cache.Put("select users", rows)
+	value, ok := cache.Get("select users")
+	if !ok {
+		t.Fatal("Expected hit after Put")
+	}
+	got, ok := value.([]map[string]any)
+	if !ok || len(got) != 1 || got[0]["name"] != "alice" || got[0]["id"] != int64(1) {
+		t.Errorf("Expected rows to round-trip with types, got %#v", value)
+	}
+	if server.ttl() != 3600 {
+		t.Errorf("Expected exptime 3600, got %d", server.ttl())
+	}
+
+	// 结构体需注册后才能以接口类型序列化
+	gob.Register(cachedUser{})
+	cache.PutObject(42, cachedUser{ID: 42, Name: "bob"})
+	if user, ok := cache.GetObject(42).(cachedUser); !ok || user.Name != "bob" {
+		t.Errorf("Expected cachedUser, got %#v", cache.GetObject(42))
+	}
+
+	// 过长或含空白的键使用摘要
+	longKey := strings.Repeat("SELECT * FROM users WHERE id = ? ", 20)
+	cache.Put(longKey, "long")
+	if value, ok := cache.Get(longKey); !ok || value != "long" {
+		t.Errorf("Expected long key to round-trip, got %v %v", value, ok)
+	}
+
+	if removed := cache.Remove("select users"); removed == nil {
+		t.Error("Expected Remove to return the removed value")
+	}
+	if _, ok := cache.Get("select users"); ok {
+		t.Error("Expected miss after Remove")
+	}
+}
+
+func TestMemcachedCache_Expiry(t *testing.T) {
+	server := newFakeMemcached(t)
+	cache := newTestMemcachedCache(t, server, 1500*time.Millisecond)
+
+	cache.Put("k", "v")
+	if server.ttl() != 2 {
+		t.Errorf("Expected sub-second TTL to round up to 2s, got %d", server.ttl())
+	}
+
+	server.advance(time.Second)
+	if value, ok := cache.Get("k"); !ok || value != "v" {
+		t.Fatalf("Expected value before expiry, got %v %v", value, ok)
+	}
+
+	server.advance(time.Second)
+	if _, ok := cache.Get("k"); ok {
+		t.Error("Expected miss after TTL elapsed")
+	}
+}
+
+func TestMemcachedCache_ClearInvalidatesNamespace(t *testing.T) {
+	server := newFakeMemcached(t)
+	cache := newTestMemcachedCache(t, server, 0)
+	if server.ttl() != 0 {
+		t.Fatalf("Unexpected TTL %d", server.ttl())
+	}
+
+	client, _ := NewMemcachedClient(server.addr())
+	defer client.Close()
+	other := NewMemcachedCache("OrderMapper", client, "test:", 0, JSONSerializer{})
+
+	cache.Put("a", 1)
+	other.Put("a", 2)
+	cache.Clear()
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected miss after Clear")
+	}
+	if value, ok := other.Get("a"); !ok || value != float64(2) {
+		t.Errorf("Expected other namespace untouched, got %v %v", value, ok)
+	}
+
+	cache.Put("a", 3)
+	if value, ok := cache.Get("a"); !ok || value != 3 {
+		t.Errorf("Expected new value after Clear, got %v %v", value, ok)
+	}
+}
+
+func TestMemcachedClient_Sharding(t *testing.T) {
+	first, second := newFakeMemcached(t), newFakeMemcached(t)
+	client, err := NewMemcachedClient(first.addr(), " ", second.addr())
+	if err != nil {
+		t.Fatalf("NewMemcachedClient failed: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		if err := client.Set(&memcache.Item{Key: key, Value: []byte(key)}); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		if item, err := client.Get(key); err != nil || string(item.Value) != key {
+			t.Fatalf("Get(%s) = %v, %v", key, item, err)
+		}
+	}
+	if first.commandCount() == 0 || second.commandCount() == 0 {
+		t.Errorf("Expected keys on both servers, got %d and %d commands", first.commandCount(), second.commandCount())
+	}
+
+	if _, err := NewMemcachedClient(); err == nil {
+		t.Error("Expected error without addresses")
+	}
+}
+
+func TestNewCacheFromConfig(t *testing.T) {
+	server := newFakeMemcached(t)
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Cache.Type = "memcached"
+	cfg.Cache.MemcachedAddrs = server.addr()
+	cfg.Cache.TTL = "10m"
+	cfg.Cache.KeyPrefix = "yyhertz:db:"
+
+	c, err := NewCacheFromConfig("UserMapper", cfg)
+	if err != nil {
+		t.Fatalf("NewCacheFromConfig failed: %v", err)
+	}
+	if _, ok := c.(*MemcachedCache); !ok {
+		t.Fatalf("Expected *MemcachedCache, got %T", c)
+	}
+	c.Put("k", "v")
+	if server.ttl() != 600 {
+		t.Errorf("Expected exptime 600, got %d", server.ttl())
+	}
+
+	cfg.Cache.Type = "memory"
+	if c, err = NewCacheFromConfig("UserMapper", cfg); err != nil {
+		t.Fatalf("NewCacheFromConfig failed: %v", err)
+	}
+	if _, ok := c.(*LruCache); !ok {
+		t.Errorf("Expected *LruCache, got %T", c)
+	}
+
+	cfg.Cache.Type = "memcached"
+	cfg.Cache.TTL = "soon"
+	if _, err := NewCacheFromConfig("UserMapper", cfg); err == nil {
+		t.Error("Expected error for invalid ttl")
+	}
+	cfg.Cache.Type = "unknown"
+	if _, err := NewCacheFromConfig("UserMapper", cfg); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+const (
+	// redisMaxKeyLength 超过该长度的缓存键使用SHA1摘要，避免以完整SQL作为Redis键
+	redisMaxKeyLength = 250
+	// redisScanCount 每次SCAN返回的建议数量
+	redisScanCount = 500
+)
+
+// RedisCache Redis缓存实现
+//
+// 键格式为 <prefix><id>:<key>，值经Serializer序列化后以TTL存储；
+// Clear通过SCAN匹配本缓存的键后批量删除，不影响其他缓存
+type RedisCache struct {
+	id         string
+	client     redis.Cmdable
+	prefix     string
+	ttl        time.Duration
+	serializer Serializer
+}
+
+// NewRedisCache 创建Redis缓存，client为go-redis客户端，serializer为nil时使用GobSerializer，ttl<=0表示不过期
+func NewRedisCache(id string, client redis.Cmdable, prefix string, ttl time.Duration, serializer Serializer) *RedisCache {
+	if serializer == nil {
+		serializer = GobSerializer{}
+	}
+	return &RedisCache{
+		id:         id,
+		client:     client,
+		prefix:     prefix,
+		ttl:        ttl,
+		serializer: serializer,
+	}
+}
+
+// GetId 获取缓存ID
+func (cache *RedisCache) GetId() string {
+	return cache.id
+}
+
+// Put 存储对象
+func (cache *RedisCache) Put(key string, value any) {
+	data, err := cache.serializer.Serialize(value)
+	if err != nil {
+		config.Warnf("Redis cache %s: serialize %s failed: %v", cache.id, key, err)
+		return
+	}
+
+	ttl := time.Duration(0)
+	if cache.ttl > 0 {
+		ttl = max(cache.ttl, time.Millisecond)
+	}
+	if err := cache.client.Set(context.Background(), cache.itemKey(key), data, ttl).Err(); err != nil {
+		config.Warnf("Redis cache %s: put %s failed: %v", cache.id, key, err)
+	}
+}
+
+// Get 获取对象
+func (cache *RedisCache) Get(key string) (any, bool) {
+	data, err := cache.client.Get(context.Background(), cache.itemKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		config.Warnf("Redis cache %s: get %s failed: %v", cache.id, key, err)
+		return nil, false
+	}
+
+	value, err := cache.serializer.Deserialize(data)
+	if err != nil {
+		config.Warnf("Redis cache %s: deserialize %s failed: %v", cache.id, key, err)
+		return nil, false
+	}
+	return value, true
+}
+
+// Remove 移除对象，返回被移除的值
+func (cache *RedisCache) Remove(key string) any {
+	value, _ := cache.Get(key)
+	if err := cache.client.Del(context.Background(), cache.itemKey(key)).Err(); err != nil {
+		config.Warnf("Redis cache %s: remove %s failed: %v", cache.id, key, err)
+	}
+	return value
+}
+
+// Clear 删除本缓存的所有键
+func (cache *RedisCache) Clear() {
+	err := cache.scan(func(keys []string) error {
+		return cache.client.Del(context.Background(), keys...).Err()
+	})
+	if err != nil {
+		config.Warnf("Redis cache %s: clear failed: %v", cache.id, err)
+	}
+}
+
+// GetSize 统计本缓存的键数量
+func (cache *RedisCache) GetSize() int {
+	size := 0
+	err := cache.scan(func(keys []string) error {
+		size += len(keys)
+		return nil
+	})
+	if err != nil {
+		config.Warnf("Redis cache %s: size failed: %v", cache.id, err)
+	}
+	return size
+}
+
+// PutObject 存储对象（兼容旧接口）
+func (cache *RedisCache) PutObject(key any, value any) {
+	cache.Put(fmt.Sprint(key), value)
+}
+
+// GetObject 获取对象（兼容旧接口）
+func (cache *RedisCache) GetObject(key any) any {
+	value, _ := cache.Get(fmt.Sprint(key))
+	return value
+}
+
+// RemoveObject 移除对象（兼容旧接口）
+func (cache *RedisCache) RemoveObject(key any) any {
+	return cache.Remove(fmt.Sprint(key))
+}
+
+// itemKey 生成Redis键，过长的键使用SHA1摘要
+func (cache *RedisCache) itemKey(key string) string {
+	base := cache.prefix + cache.id + ":"
+	if len(base)+len(key) > redisMaxKeyLength {
+		key = digestKey(key)
+	}
+	return base + key
+}
+
+// scan 遍历本缓存的所有键，每批调用一次fn
+func (cache *RedisCache) scan(fn func(keys []string) error) error {
+	pattern := escapeRedisPattern(cache.prefix+cache.id+":") + "*"
+	var cursor uint64
+	for {
+		keys, next, err := cache.client.Scan(context.Background(), cursor, pattern, redisScanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// escapeRedisPattern 转义SCAN MATCH中的通配符
+func escapeRedisPattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// newTestRedisCache 通过配置创建连接miniredis的缓存
+func newTestRedisCache(t *testing.T, server *miniredis.Miniredis, id string, ttl string) *RedisCache {
+	cfg := &config.DatabaseConfig{}
+	cfg.Cache.Type = "redis"
+	cfg.Cache.RedisAddr = server.Addr()
+	cfg.Cache.RedisDB = 2
+	cfg.Cache.KeyPrefix = "yyhertz:db:"
+	cfg.Cache.TTL = ttl
+
+	c, err := NewCacheFromConfig(id, cfg)
+	if err != nil {
+		t.Fatalf("NewCacheFromConfig failed: %v", err)
+	}
+	redisCache, ok := c.(*RedisCache)
+	if !ok {
+		t.Fatalf("Expected *RedisCache, got %T", c)
+	}
+	return redisCache
+}
+
+// cachedSelect 按二级缓存的方式执行查询：命中缓存时不访问数据库
+func cachedSelect(c Cache, key string, query func() []any) []any {
+	if cached, ok := c.Get(key); ok {
+		if results, ok := cached.([]any); ok {
+			return results
+		}
+	}
+	results := query()
+	c.Put(key, results)
+	return results
+}
+
+func TestRedisCache_CachedSelectHitsRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+	cache := newTestRedisCache(t, server, "UserMapper", "10m")
+
+	queries := 0
+	query := func() []any {
+		queries++
+		return []any{map[string]any{"id": int64(1), "name": "alice"}}
+	}
+
+	key := "[UserMapper.selectById 0 0 SELECT * FROM users WHERE id = ? 1]"
+	first := cachedSelect(cache, key, query)
+	second := cachedSelect(cache, key, query)
+	if queries != 1 {
+		t.Errorf("Expected second select to be served from Redis, got %d queries", queries)
+	}
+	row, ok := second[0].(map[string]any)
+	if !ok || row["name"] != "alice" || row["id"] != int64(1) || len(first) != 1 {
+		t.Errorf("Unexpected cached result %#v", second)
+	}
+
+	// 键包含前缀与缓存ID，写入配置的redis_db
+	wantKey := "yyhertz:db:UserMapper:" + key
+	if keys := server.DB(2).Keys(); len(keys) != 1 || keys[0] != wantKey {
+		t.Errorf("Expected key %q in db 2, got %v", wantKey, keys)
+	}
+	if keys := server.DB(0).Keys(); len(keys) != 0 {
+		t.Errorf("Expected db 0 untouched, got %v", keys)
+	}
+	if ttl := server.DB(2).TTL(wantKey); ttl != 10*time.Minute {
+		t.Errorf("Expected TTL 10m, got %v", ttl)
+	}
+}
+
+func TestRedisCache_ExpiresPerTTL(t *testing.T) {
+	server := miniredis.RunT(t)
+	cache := newTestRedisCache(t, server, "UserMapper", "30s")
+
+	cache.Put("k", "v")
+	server.FastForward(29 * time.Second)
+	if value, ok := cache.Get("k"); !ok || value != "v" {
+		t.Fatalf("Expected value before TTL, got %v %v", value, ok)
+	}
+
+	server.FastForward(time.Second)
+	if _, ok := cache.Get("k"); ok {
+		t.Error("Expected miss after TTL elapsed")
+	}
+
+	// 未配置TTL时不过期
+	persistent := newTestRedisCache(t, server, "OrderMapper", "")
+	persistent.Put("k", "v")
+	server.FastForward(24 * time.Hour)
+	if _, ok := persistent.Get("k"); !ok {
+		t.Error("Expected value without TTL to persist")
+	}
+}
+
+func TestRedisCache_ClearRemoveAndSize(t *testing.T) {
+	server := miniredis.RunT(t)
+	users := newTestRedisCache(t, server, "UserMapper", "1h")
+	orders := newTestRedisCache(t, server, "OrderMapper", "1h")
+
+	users.Put("a", 1)
+	users.PutObject(2, "b")
+	orders.Put("a", 3)
+	if users.GetSize() != 2 || orders.GetSize() != 1 {
+		t.Fatalf("Expected sizes 2 and 1, got %d and %d", users.GetSize(), orders.GetSize())
+	}
+
+	if removed := users.RemoveObject(2); removed != "b" {
+		t.Errorf("Expected removed value b, got %v", removed)
+	}
+	if users.GetObject(2) != nil {
+		t.Error("Expected miss after RemoveObject")
+	}
+
+	users.Clear()
+	if users.GetSize() != 0 {
+		t.Errorf("Expected empty cache after Clear, got %d", users.GetSize())
+	}
+	if value, ok := orders.Get("a"); !ok || value != 3 {
+		t.Errorf("Expected other cache untouched, got %v %v", value, ok)
+	}
+
+	// 过长的键使用摘要
+	longKey := strings.Repeat("SELECT * FROM orders WHERE id = ? ", 20)
+	orders.Put(longKey, "long")
+	if value, ok := orders.Get(longKey); !ok || value != "long" {
+		t.Errorf("Expected long key to round-trip, got %v %v", value, ok)
+	}
+	for _, key := range server.DB(2).Keys() {
+		if len(key) > redisMaxKeyLength {
+			t.Errorf("Expected digested key, got %d bytes", len(key))
+		}
+	}
+}
+
+func TestNewCacheFromConfig_RedisRequiresAddr(t *testing.T) {
+	cfg := &config.DatabaseConfig{}
+	cfg.Cache.Type = "redis"
+	if _, err := NewCacheFromConfig("UserMapper", cfg); err == nil {
+		t.Error("Expected error without redis_addr")
+	}
+}
+
+func TestEscapeRedisPattern(t *testing.T) {
+	if got := escapeRedisPattern("app:[v1]*?"); got != `app:\[v1\]\*\?` {
+		t.Errorf("Unexpected escaped pattern %s", got)
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"golang.org/x/sync/singleflight"
+)
+
+// LoadingCache 支持在未命中时加载并回填的缓存
+type LoadingCache interface {
+	Cache
+
+	// GetOrLoad 命中时返回缓存值，未命中时调用load并将结果写入缓存
+	GetOrLoad(key string, load func() (any, error)) (any, error)
+}
+
+// SingleflightCache 防止缓存击穿的装饰器
+//
+// 同一键的并发未命中只执行一次load，其余调用等待并共享结果，
+// 避免缓存过期瞬间大量请求同时访问数据库。load返回错误时不写入缓存，等待者收到同一错误
+type SingleflightCache struct {
+	delegate Cache
+	group    singleflight.Group
+}
+
+// NewSingleflightCache 创建防击穿缓存
+func NewSingleflightCache(delegate Cache) *SingleflightCache {
+	return &SingleflightCache{delegate: delegate}
+}
+
+// GetOrLoad 命中时返回缓存值，未命中时合并并发加载
+func (cache *SingleflightCache) GetOrLoad(key string, load func() (any, error)) (any, error) {
+	if value, ok := cache.delegate.Get(key); ok {
+		return value, nil
+	}
+
+	value, err, _ := cache.group.Do(key, func() (any, error) {
+		// 上一轮加载可能在本次未命中后刚写入
+		if value, ok := cache.delegate.Get(key); ok {
+			return value, nil
+		}
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cache.delegate.Put(key, value)
+		return value, nil
+	})
+	return value, err
+}
+
+// SingleflightCache 方法实现
+func (cache *SingleflightCache) GetId() string                { return cache.delegate.GetId() }
+func (cache *SingleflightCache) Put(key string, value any)    { cache.delegate.Put(key, value) }
+func (cache *SingleflightCache) Get(key string) (any, bool)   { return cache.delegate.Get(key) }
+func (cache *SingleflightCache) Remove(key string) any        { return cache.delegate.Remove(key) }
+func (cache *SingleflightCache) Clear()                       { cache.delegate.Clear() }
+func (cache *SingleflightCache) GetSize() int                 { return cache.delegate.GetSize() }
+func (cache *SingleflightCache) PutObject(key any, value any) { cache.delegate.PutObject(key, value) }
+func (cache *SingleflightCache) GetObject(key any) any        { return cache.delegate.GetObject(key) }
+func (cache *SingleflightCache) RemoveObject(key any) any     { return cache.delegate.RemoveObject(key) }
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// loadConcurrently 同时发起n次GetOrLoad，返回各次结果与错误
+func loadConcurrently(c LoadingCache, n int, key string, load func() (any, error)) ([]any, []error) {
+	values := make([]any, n)
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			values[i], errs[i] = c.GetOrLoad(key, load)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return values, errs
+}
+
+func TestSingleflightCache_ConcurrentMissesQueryOnce(t *testing.T) {
+	c := NewSingleflightCache(NewLruCache(NewPerpetualCache("UserMapper"), 16))
+
+	const goroutines = 50
+	var dbCalls int32
+	query := func() (any, error) {
+		atomic.AddInt32(&dbCalls, 1)
+		// 模拟慢查询，保证其余请求在加载期间到达
+		time.Sleep(50 * time.Millisecond)
+		return []any{map[string]any{"id": int64(1), "name": "alice"}}, nil
+	}
+
+	key := "[UserMapper.selectById 0 0 SELECT * FROM users WHERE id = ? 1]"
+	values, errs := loadConcurrently(c, goroutines, key, query)
+
+	if calls := atomic.LoadInt32(&dbCalls); calls != 1 {
+		t.Fatalf("Expected exactly 1 DB call for %d concurrent misses, got %d", goroutines, calls)
+	}
+	for i := range values {
+		if errs[i] != nil {
+			t.Fatalf("Goroutine %d failed: %v", i, errs[i])
+		}
+		results, ok := values[i].([]any)
+		if !ok || len(results) != 1 {
+			t.Fatalf("Goroutine %d got unexpected result %#v", i, values[i])
+		}
+	}
+
+	// 结果已回填，后续查询直接命中缓存
+	if _, err := c.GetOrLoad(key, query); err != nil {
+		t.Fatalf("GetOrLoad failed: %v", err)
+	}
+	if calls := atomic.LoadInt32(&dbCalls); calls != 1 {
+		t.Errorf("Expected cache hit after load, got %d DB calls", calls)
+	}
+}
+
+func TestSingleflightCache_ReloadsOnceAfterExpiry(t *testing.T) {
+	c := NewSingleflightCache(NewLruCache(NewPerpetualCache("UserMapper"), 16))
+
+	var dbCalls int32
+	query := func() (any, error) {
+		atomic.AddInt32(&dbCalls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return []any{"row"}, nil
+	}
+
+	loadConcurrently(c, 20, "k", query)
+	// 模拟缓存过期
+	c.Remove("k")
+	loadConcurrently(c, 20, "k", query)
+
+	if calls := atomic.LoadInt32(&dbCalls); calls != 2 {
+		t.Errorf("Expected one DB call per expiry, got %d", calls)
+	}
+}
+
+func TestSingleflightCache_ErrorSharedAndNotCached(t *testing.T) {
+	c := NewSingleflightCache(NewPerpetualCache("UserMapper"))
+
+	queryErr := errors.New("connection refused")
+	var dbCalls int32
+	failing := func() (any, error) {
+		atomic.AddInt32(&dbCalls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return nil, queryErr
+	}
+
+	_, errs := loadConcurrently(c, 10, "k", failing)
+	for i, err := range errs {
+		if !errors.Is(err, queryErr) {
+			t.Fatalf("Goroutine %d expected shared error, got %v", i, err)
+		}
+	}
+	if calls := atomic.LoadInt32(&dbCalls); calls != 1 {
+		t.Errorf("Expected 1 DB call, got %d", calls)
+	}
+	if c.GetSize() != 0 {
+		t.Errorf("Expected failed load not cached, got size %d", c.GetSize())
+	}
+
+	value, err := c.GetOrLoad("k", func() (any, error) { return "ok", nil })
+	if err != nil || value != "ok" {
+		t.Errorf("Expected retry to load, got %v %v", value, err)
+	}
+}
+
+func TestCacheBuilder_SingleflightDecorator(t *testing.T) {
+	c, err := NewCacheBuilder("UserMapper").Decorator("SINGLEFLIGHT").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, ok := c.(LoadingCache); !ok {
+		t.Errorf("Expected LoadingCache, got %T", c)
+	}
+}
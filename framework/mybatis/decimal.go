@@ -0,0 +1,257 @@
+package mybatis
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal 定点十进制数，用于金额等需要精确计算的列，避免浮点舍入误差
+// 以未缩放整数与小数位数表示：unscaled × 10^-scale
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal 由未缩放整数与小数位数创建Decimal，如 NewDecimal(1999, 2) 表示 19.99
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// ParseDecimal 解析十进制字符串，如 "19.99"、"-0.5"、"+3"
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Decimal{}, fmt.Errorf("mybatis: invalid decimal %q", s)
+	}
+
+	digits := str
+	var scale int32
+	if dot := strings.IndexByte(str, '.'); dot >= 0 {
+		fraction := str[dot+1:]
+		digits = str[:dot] + fraction
+		scale = int32(len(fraction))
+	}
+	if digits == "" || digits == "+" || digits == "-" || strings.ContainsAny(digits[1:], "+-") {
+		return Decimal{}, fmt.Errorf("mybatis: invalid decimal %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("mybatis: invalid decimal %q", s)
+	}
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// MustParseDecimal 解析十进制字符串，失败时panic，用于常量初始化与测试
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat 由浮点数创建Decimal，取能精确还原该浮点数的最短十进制表示
+func DecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// int 返回未缩放整数，零值Decimal视为0
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale 返回按指定小数位数（不小于当前位数）缩放后的整数
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// Scale 小数位数
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign 符号：负数-1，零0，正数1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero 是否为零
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Add 加法，结果小数位数取两者较大值
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Sub 减法，结果小数位数取两者较大值
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Cmp 比较大小：d<other返回-1，相等返回0，d>other返回1
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d.scale, other.scale)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+// Equal 数值是否相等（忽略小数位数差异，如 1.50 与 1.5 相等）
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// DivInt 除以整数，结果保留scale位小数并四舍五入（远离零）
+func (d Decimal) DivInt(n int64, scale int32) Decimal {
+	if n == 0 {
+		panic("mybatis: decimal division by zero")
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	if scale < d.scale {
+		return Decimal{unscaled: roundQuo(d.int(), new(big.Int).Mul(big.NewInt(n), pow10(d.scale-scale))), scale: scale}
+	}
+	return Decimal{unscaled: roundQuo(d.rescale(scale), big.NewInt(n)), scale: scale}
+}
+
+// Round 四舍五入（远离零）到指定小数位数
+func (d Decimal) Round(scale int32) Decimal {
+	return d.DivInt(1, scale)
+}
+
+// String 十进制字符串表示，保留全部小数位，如 "19.90"
+func (d Decimal) String() string {
+	str := d.int().String()
+	if d.scale <= 0 {
+		return str
+	}
+
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+	if pad := int(d.scale) + 1 - len(str); pad > 0 {
+		str = strings.Repeat("0", pad) + str
+	}
+	point := len(str) - int(d.scale)
+	str = str[:point] + "." + str[point:]
+	if negative {
+		str = "-" + str
+	}
+	return str
+}
+
+// Float64 转换为浮点数，仅用于展示等允许精度损失的场景
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Scan 实现sql.Scanner，支持整数、浮点、字符串与字节切片，NULL扫描为零
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case int64:
+		*d = NewDecimal(v, 0)
+	case int:
+		*d = NewDecimal(int64(v), 0)
+	case int32:
+		*d = NewDecimal(int64(v), 0)
+	case float64:
+		*d = DecimalFromFloat(v)
+	case float32:
+		*d, _ = ParseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case []byte:
+		parsed, err := ParseDecimal(string(v))
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	case string:
+		parsed, err := ParseDecimal(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	default:
+		return fmt.Errorf("mybatis: cannot scan %T into Decimal", src)
+	}
+	return nil
+}
+
+// Value 实现driver.Valuer，以字符串写入数据库以保留精度
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON 序列化为JSON字符串，避免前端按浮点解析丢失精度
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON 支持JSON字符串与数字两种形式
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+	parsed, err := ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// roundQuo 整数除法，按四舍五入（远离零）取整
+func roundQuo(a, b *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(a, b, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	// |2r| >= |b| 时向远离零方向进一
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(b)) >= 0 {
+		if a.Sign()*b.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
+
+// pow10 返回10的n次方
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// maxScale 返回较大的小数位数
+func maxScale(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -18,6 +18,9 @@ const (
 	UserIDKey    contextKey = "user_id"
 	RequestIDKey contextKey = "request_id"
 	TxKey        contextKey = "transaction"
+
+	// RequestCacheKey 请求级查询缓存
+	RequestCacheKey contextKey = "request_cache"
 )
 
 // PerformanceHook 性能监控钩子 - 记录慢查询
@@ -0,0 +1,80 @@
+package mybatis
+
+import (
+	"log"
+	"time"
+)
+
+// Invocation 一次语句执行的调用信息，拦截器可在执行前修改 SQL 与 Args
+type Invocation struct {
+	StatementID string        // 语句ID（namespace.id）
+	Statement   *Statement    // 语句定义
+	Parameter   interface{}   // 调用方传入的参数
+	SQL         string        // 待执行的SQL
+	Args        []interface{} // SQL参数
+}
+
+// Invoker 继续执行调用链，查询返回 []map[string]interface{}，更新返回影响行数 int64
+type Invoker func() (interface{}, error)
+
+// Interceptor 语句执行拦截器
+// 调用 next 之前可修改 SQL 与参数，之后可观察或替换结果；不调用 next 即短路执行，
+// 此时返回值须与 Invoker 的结果类型一致
+type Interceptor interface {
+	Intercept(invocation *Invocation, next Invoker) (interface{}, error)
+}
+
+// InterceptorFunc 函数形式的拦截器
+type InterceptorFunc func(invocation *Invocation, next Invoker) (interface{}, error)
+
+// Intercept 实现 Interceptor 接口
+func (f InterceptorFunc) Intercept(invocation *Invocation, next Invoker) (interface{}, error) {
+	return f(invocation, next)
+}
+
+// AddInterceptor 注册拦截器，先注册的拦截器位于调用链外层
+// 命中会话缓存的查询不经过拦截器
+func (mb *MyBatisGorm) AddInterceptor(interceptors ...Interceptor) *MyBatisGorm {
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+
+	mb.interceptors = append(mb.interceptors, interceptors...)
+	return mb
+}
+
+// intercept 依次经过已注册的拦截器后执行语句
+func (mb *MyBatisGorm) intercept(invocation *Invocation, execute func(invocation *Invocation) (interface{}, error)) (interface{}, error) {
+	mb.mutex.RLock()
+	interceptors := mb.interceptors
+	mb.mutex.RUnlock()
+
+	var proceed func(index int) (interface{}, error)
+	proceed = func(index int) (interface{}, error) {
+		if index == len(interceptors) {
+			return execute(invocation)
+		}
+		return interceptors[index].Intercept(invocation, func() (interface{}, error) {
+			return proceed(index + 1)
+		})
+	}
+	return proceed(0)
+}
+
+// LoggingInterceptor 记录语句SQL、参数与耗时，耗时超过 slowThreshold（大于0时）标记为慢查询
+func LoggingInterceptor(slowThreshold time.Duration) Interceptor {
+	return InterceptorFunc(func(invocation *Invocation, next Invoker) (interface{}, error) {
+		start := time.Now()
+		result, err := next()
+		duration := time.Since(start)
+
+		switch {
+		case err != nil:
+			log.Printf("[SQL ERROR] %s %s %v Duration:%v Error:%v", invocation.StatementID, invocation.SQL, invocation.Args, duration, err)
+		case slowThreshold > 0 && duration > slowThreshold:
+			log.Printf("[SLOW SQL] %s %s %v Duration:%v", invocation.StatementID, invocation.SQL, invocation.Args, duration)
+		default:
+			log.Printf("[SQL] %s %s %v Duration:%v", invocation.StatementID, invocation.SQL, invocation.Args, duration)
+		}
+		return result, err
+	})
+}
@@ -0,0 +1,125 @@
+package mybatis
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newInterceptedMyBatis(t *testing.T) *MyBatisGorm {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "products.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Exec("CREATE TABLE products (name TEXT, tenant TEXT)")
+	db.Exec("INSERT INTO products VALUES ('pen', 'a'), ('book', 'b'), ('cup', 'a')")
+
+	mb := NewMyBatisGorm(db, nil)
+	mb.RegisterMapper("ProductMapper", map[string]*Statement{
+		"selectAll": {ID: "selectAll", SQL: "SELECT name FROM products ORDER BY rowid", StatementType: StatementTypeSelect},
+		"insert":    {ID: "insert", SQL: "INSERT INTO products (name, tenant) VALUES (?, 'a')", StatementType: StatementTypeInsert},
+	})
+	return mb
+}
+
+func productNames(t *testing.T, session SqlSession) []string {
+	t.Helper()
+	results, err := session.SelectList("ProductMapper.selectAll", nil)
+	if err != nil {
+		t.Fatalf("SelectList failed: %v", err)
+	}
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.(map[string]interface{})["name"].(string)
+	}
+	return names
+}
+
+func TestInterceptor_ObservesAndRewritesSQL(t *testing.T) {
+	mb := newInterceptedMyBatis(t)
+	var observed []string
+	mb.AddInterceptor(InterceptorFunc(func(invocation *Invocation, next Invoker) (interface{}, error) {
+		observed = append(observed, invocation.StatementID+": "+invocation.SQL)
+		// 为查询追加租户过滤
+		if invocation.Statement.StatementType == StatementTypeSelect {
+			invocation.SQL = strings.Replace(invocation.SQL, "ORDER BY", "WHERE tenant = ? ORDER BY", 1)
+			invocation.Args = append(invocation.Args, "a")
+		}
+		return next()
+	}))
+	session := mb.OpenSession()
+	defer session.Close()
+
+	if names := productNames(t, session); strings.Join(names, ",") != "pen,cup" {
+		t.Errorf("Expected rewritten SQL to filter by tenant, got %v", names)
+	}
+	if _, err := session.Insert("ProductMapper.insert", "lamp"); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	expected := []string{
+		"ProductMapper.selectAll: SELECT name FROM products ORDER BY rowid",
+		"ProductMapper.insert: INSERT INTO products (name, tenant) VALUES (?, 'a')",
+	}
+	if strings.Join(observed, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected observed SQL %v, got %v", expected, observed)
+	}
+}
+
+func TestInterceptor_ShortCircuitAndOrder(t *testing.T) {
+	mb := newInterceptedMyBatis(t)
+	var order []string
+	mb.AddInterceptor(
+		InterceptorFunc(func(invocation *Invocation, next Invoker) (interface{}, error) {
+			order = append(order, "outer-before")
+			result, err := next()
+			order = append(order, "outer-after")
+			return result, err
+		}),
+		InterceptorFunc(func(invocation *Invocation, next Invoker) (interface{}, error) {
+			order = append(order, "inner")
+			if invocation.Statement.StatementType == StatementTypeInsert {
+				// 短路：不执行插入
+				return int64(0), nil
+			}
+			return []map[string]interface{}{{"name": "cached"}}, nil
+		}),
+		LoggingInterceptor(0),
+	)
+	session := mb.OpenSession()
+	defer session.Close()
+
+	if names := productNames(t, session); len(names) != 1 || names[0] != "cached" {
+		t.Errorf("Expected short-circuited result, got %v", names)
+	}
+	if strings.Join(order, ",") != "outer-before,inner,outer-after" {
+		t.Errorf("Unexpected interceptor order: %v", order)
+	}
+
+	if affected, err := session.Insert("ProductMapper.insert", "lamp"); err != nil || affected != 0 {
+		t.Fatalf("Expected short-circuited insert, got affected=%d err=%v", affected, err)
+	}
+	var count int64
+	mb.db.Raw("SELECT COUNT(*) FROM products").Scan(&count)
+	if count != 3 {
+		t.Errorf("Expected insert to be skipped, got %d rows", count)
+	}
+}
+
+func TestInterceptor_InvalidShortCircuitResult(t *testing.T) {
+	mb := newInterceptedMyBatis(t)
+	mb.AddInterceptor(InterceptorFunc(func(invocation *Invocation, next Invoker) (interface{}, error) {
+		return "unexpected", nil
+	}))
+	session := mb.OpenSession()
+	defer session.Close()
+
+	if _, err := session.SelectList("ProductMapper.selectAll", nil); err == nil {
+		t.Error("Expected error for mismatched interceptor result")
+	}
+}
@@ -33,9 +33,11 @@ package mybatis
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gorm.io/gorm"
@@ -439,6 +441,12 @@ type MyBatisGorm struct {
 	mappers map[string]*MapperInfo
 	cache   *LegacyCache
 	mutex   sync.RWMutex
+
+	replicas    []*gorm.DB // 从库连接，查询语句自动路由到从库
+	replicaNext uint64     // 轮询计数
+
+	typeHandlers *config.TypeHandlerRegistry // 结果映射使用的类型处理器
+	interceptors []Interceptor               // 语句执行拦截器
 }
 
 // GormConfig MyBatis GORM集成配置
@@ -457,6 +465,9 @@ type GormConfig struct {
 	// 其他配置
 	MapUnderscoreToCamelCase bool
 	LogLevel                 string
+
+	// 类型处理器注册表，可与完整版MyBatis共享（Configuration.GetTypeHandlerRegistry）
+	TypeHandlers *config.TypeHandlerRegistry
 }
 
 // MapperInfo 映射器信息
@@ -477,6 +488,10 @@ type Statement struct {
 	ResultMap     string
 	UseCache      bool
 	Timeout       int
+
+	// 插入语句回填自增主键：UseGeneratedKeys 为true时将自增ID写入参数的 KeyProperty 字段
+	UseGeneratedKeys bool
+	KeyProperty      string
 }
 
 // StatementType 语句类型
@@ -525,9 +540,10 @@ type SqlSession interface {
 
 // DefaultSqlSession 默认SQL会话实现
 type DefaultSqlSession struct {
-	mybatis *MyBatisGorm
-	db      *gorm.DB
-	tx      *gorm.DB // 事务数据库连接
+	mybatis      *MyBatisGorm
+	db           *gorm.DB
+	tx           *gorm.DB // 事务数据库连接
+	forcePrimary bool     // 查询强制使用主库（写后读一致性）
 }
 
 // SqlSessionAdapter 会话适配器（完整版MyBatis到GORM版的桥接）
@@ -543,10 +559,11 @@ func NewMyBatisGorm(db *gorm.DB, config *GormConfig) *MyBatisGorm {
 	}
 	
 	mb := &MyBatisGorm{
-		db:      db,
-		config:  config,
-		mappers: make(map[string]*MapperInfo),
-		cache:   NewLegacyCache(config.CacheSize),
+		db:           db,
+		config:       config,
+		mappers:      make(map[string]*MapperInfo),
+		cache:        NewLegacyCache(config.CacheSize),
+		typeHandlers: config.TypeHandlers,
 	}
 	
 	return mb
@@ -590,15 +607,53 @@ func (mb *MyBatisGorm) OpenSessionWithTx() SqlSession {
 	}
 }
 
+// SetReplicas 设置从库连接，启用读写分离：查询语句按轮询路由到从库，写语句和事务使用主库
+// 数据库配置中 Replica.LoadBalancingStrategy 为 random 时随机选择从库
+func (mb *MyBatisGorm) SetReplicas(replicas ...*gorm.DB) *MyBatisGorm {
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	mb.replicas = append([]*gorm.DB(nil), replicas...)
+	return mb
+}
+
+// OpenPrimarySession 打开查询强制使用主库的会话，用于写后读一致性
+func (mb *MyBatisGorm) OpenPrimarySession() SqlSession {
+	return &DefaultSqlSession{
+		mybatis:      mb,
+		db:           mb.db,
+		forcePrimary: true,
+	}
+}
+
+// replica 选择一个从库，未配置从库时返回nil
+func (mb *MyBatisGorm) replica() *gorm.DB {
+	mb.mutex.RLock()
+	defer mb.mutex.RUnlock()
+
+	if len(mb.replicas) == 0 {
+		return nil
+	}
+	if cfg := mb.config.DatabaseConfig; cfg != nil && cfg.Replica.LoadBalancingStrategy == "random" {
+		return mb.replicas[rand.Intn(len(mb.replicas))]
+	}
+	next := atomic.AddUint64(&mb.replicaNext, 1) - 1
+	return mb.replicas[next%uint64(len(mb.replicas))]
+}
+
 // RegisterMapper 注册映射器
 func (mb *MyBatisGorm) RegisterMapper(namespace string, statements map[string]*Statement) {
 	mb.mutex.Lock()
 	defer mb.mutex.Unlock()
 	
+	resultMaps := make(map[string]*ResultMap)
+	if existing, ok := mb.mappers[namespace]; ok {
+		// 保留已注册的结果映射
+		resultMaps = existing.ResultMaps
+	}
 	mb.mappers[namespace] = &MapperInfo{
 		Namespace:  namespace,
 		Statements: statements,
-		ResultMaps: make(map[string]*ResultMap),
+		ResultMaps: resultMaps,
 	}
 }
 
@@ -654,17 +709,42 @@ func (session *DefaultSqlSession) SelectList(statement string, parameter interfa
 		return nil, err
 	}
 	
-	// 执行查询
-	db := session.getDB()
-	var results []map[string]interface{}
-	err = db.Raw(sql, args...).Scan(&results).Error
+	// 经过拦截器执行查询（启用读写分离时路由到从库）
+	db := session.getReadDB()
+	invocation := &Invocation{StatementID: statement, Statement: stmt, Parameter: parameter, SQL: sql, Args: args}
+	raw, err := session.mybatis.intercept(invocation, func(invocation *Invocation) (interface{}, error) {
+		var rows []map[string]interface{}
+		if err := db.Raw(invocation.SQL, invocation.Args...).Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to execute query: %w", err)
+		}
+		return rows, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, err
+	}
+	results, ok := raw.([]map[string]interface{})
+	if !ok && raw != nil {
+		return nil, fmt.Errorf("interceptor returned %T for select %s", raw, statement)
 	}
 	
-	// 转换结果
+	// 转换结果：配置了ResultMap或ResultType时映射为结构体，并应用类型处理器
+	resultMap, err := session.getResultMap(statement, stmt)
+	if err != nil {
+		return nil, err
+	}
+	if resultMap == nil && isStructType(stmt.ResultType) {
+		resultMap = &ResultMap{ID: stmt.ID, Type: stmt.ResultType}
+	}
 	convertedResults := make([]interface{}, len(results))
 	for i, result := range results {
+		if resultMap != nil {
+			mapped, err := session.mybatis.mapResult(result, resultMap)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map result for %s: %w", statement, err)
+			}
+			convertedResults[i] = mapped
+			continue
+		}
 		converted := session.convertResult(result, stmt)
 		convertedResults[i] = converted
 	}
@@ -710,14 +790,28 @@ func (session *DefaultSqlSession) executeUpdate(statement string, parameter inte
 		return 0, err
 	}
 	
-	// 执行更新
+	// 经过拦截器执行更新
 	db := session.getDB()
-	result := db.Exec(sql, args...)
-	if result.Error != nil {
-		return 0, fmt.Errorf("failed to execute update: %w", result.Error)
+	invocation := &Invocation{StatementID: statement, Statement: stmt, Parameter: parameter, SQL: sql, Args: args}
+	raw, err := session.mybatis.intercept(invocation, func(invocation *Invocation) (interface{}, error) {
+		if stmt.StatementType == StatementTypeInsert && stmt.UseGeneratedKeys {
+			return execWithGeneratedKey(db, stmt, invocation.SQL, invocation.Args, parameter)
+		}
+		result := db.Exec(invocation.SQL, invocation.Args...)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to execute update: %w", result.Error)
+		}
+		return result.RowsAffected, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	affected, ok := raw.(int64)
+	if !ok && raw != nil {
+		return 0, fmt.Errorf("interceptor returned %T for %s", raw, statement)
 	}
 	
-	return result.RowsAffected, nil
+	return affected, nil
 }
 
 // GetMapper 获取映射器代理
@@ -790,11 +884,33 @@ func (session *DefaultSqlSession) getDB() *gorm.DB {
 	return session.db
 }
 
+// getReadDB 获取查询使用的数据库连接：事务内或强制主库时使用主库，否则优先使用从库
+func (session *DefaultSqlSession) getReadDB() *gorm.DB {
+	if session.tx != nil || session.forcePrimary {
+		return session.getDB()
+	}
+	if replica := session.mybatis.replica(); replica != nil {
+		return replica
+	}
+	return session.db
+}
+
+// ForcePrimary 之后的查询强制使用主库，用于写入后立即读取的场景
+func (session *DefaultSqlSession) ForcePrimary() *DefaultSqlSession {
+	session.forcePrimary = true
+	return session
+}
+
 // buildSQL 构建SQL和参数
 func (session *DefaultSqlSession) buildSQL(stmt *Statement, parameter interface{}) (string, []interface{}, error) {
 	sql := stmt.SQL
 	var args []interface{}
 	
+	// #{name} 形式的命名参数按名称绑定
+	if strings.Contains(sql, "#{") {
+		return bindNamedParameters(sql, parameter)
+	}
+	
 	// 简化的参数处理
 	if parameter != nil {
 		args = session.extractParameters(parameter, sql)
@@ -0,0 +1,34 @@
+package mybatis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidOrderBy 排序字段不在白名单中
+var ErrInvalidOrderBy = errors.New("mybatis: order by column not allowed")
+
+// OrderByWhitelist 排序字段白名单，将客户端传入的排序键映射为可信的列名
+// 相当于MyBatis中用 <choose> 枚举 ORDER BY 列，避免把用户输入直接拼接进SQL
+type OrderByWhitelist map[string]string
+
+// Resolve 解析排序键，返回 "ORDER BY <列名> [DESC]" 子句
+// 排序键不区分大小写（白名单键应使用小写）；为空时返回空字符串，不在白名单中时返回ErrInvalidOrderBy
+func (w OrderByWhitelist) Resolve(key string, desc bool) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", nil
+	}
+
+	column, ok := w[strings.ToLower(key)]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidOrderBy, key)
+	}
+
+	clause := "ORDER BY " + column
+	if desc {
+		clause += " DESC"
+	}
+	return clause, nil
+}
@@ -0,0 +1,101 @@
+package mybatis
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// namedParamPattern 匹配 #{name} 与 #{name,jdbcType=...} 形式的参数
+var namedParamPattern = regexp.MustCompile(`#\{\s*(\w+)\s*(?:,[^}]*)?\}`)
+
+// bindNamedParameters 将 #{name} 替换为占位符并按名称从参数中取值
+// 参数为结构体时按字段名或 gorm column 标签匹配（忽略大小写和下划线），为map时按键匹配，其他类型直接作为参数值
+func bindNamedParameters(sql string, parameter interface{}) (string, []interface{}, error) {
+	var args []interface{}
+	var bindErr error
+	bound := namedParamPattern.ReplaceAllStringFunc(sql, func(match string) string {
+		name := namedParamPattern.FindStringSubmatch(match)[1]
+		value, err := namedParameterValue(parameter, name)
+		if err != nil && bindErr == nil {
+			bindErr = err
+		}
+		args = append(args, value)
+		return "?"
+	})
+	if bindErr != nil {
+		return "", nil, bindErr
+	}
+	return bound, args, nil
+}
+
+// namedParameterValue 获取命名参数的值
+func namedParameterValue(parameter interface{}, name string) (interface{}, error) {
+	value := reflect.ValueOf(parameter)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != reflect.TypeOf(time.Time{}) {
+			if field := fieldByColumn(value, name); field.IsValid() {
+				return field.Interface(), nil
+			}
+			return nil, fmt.Errorf("parameter %s not found in %s", name, value.Type())
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() == reflect.String {
+			for _, key := range value.MapKeys() {
+				if key.String() == name {
+					return value.MapIndex(key).Interface(), nil
+				}
+			}
+			return nil, fmt.Errorf("parameter %s not found", name)
+		}
+	}
+	// 单个参数直接使用
+	return parameter, nil
+}
+
+// execWithGeneratedKey 执行插入语句并将自增主键回填到参数的 KeyProperty 字段
+// 依赖驱动的 LastInsertId 支持（如MySQL、SQLite）
+func execWithGeneratedKey(db *gorm.DB, stmt *Statement, sql string, args []interface{}, parameter interface{}) (int64, error) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := db.Statement.ConnPool.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute update: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if stmt.KeyProperty == "" {
+		return affected, nil
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return affected, fmt.Errorf("failed to get generated key: %w", err)
+	}
+	target := reflect.ValueOf(parameter)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return affected, fmt.Errorf("key property %s requires a struct pointer parameter, got %T", stmt.KeyProperty, parameter)
+	}
+	field := fieldByColumn(target.Elem(), strings.TrimSpace(stmt.KeyProperty))
+	if !field.IsValid() || !field.CanSet() {
+		return affected, fmt.Errorf("key property %s not found in %s", stmt.KeyProperty, target.Elem().Type())
+	}
+	if err := assignColumnValue(field, id); err != nil {
+		return affected, fmt.Errorf("key property %s: %w", stmt.KeyProperty, err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,114 @@
+package mybatis
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openReadWriteTestDB 创建包含一行数据的数据库，用于区分主库与从库
+func openReadWriteTestDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name+".db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open %s database: %v", name, err)
+	}
+	if err := db.Exec("CREATE TABLE items (name TEXT)").Error; err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if err := db.Exec("INSERT INTO items (name) VALUES (?)", name).Error; err != nil {
+		t.Fatalf("failed to seed %s: %v", name, err)
+	}
+	return db
+}
+
+// newReadWriteMyBatis 创建配置了一主两从的MyBatisGorm
+func newReadWriteMyBatis(t *testing.T) (*MyBatisGorm, *gorm.DB) {
+	primary := openReadWriteTestDB(t, "primary")
+	mb := NewMyBatisGorm(primary, nil).SetReplicas(
+		openReadWriteTestDB(t, "replica-1"),
+		openReadWriteTestDB(t, "replica-2"),
+	)
+	mb.RegisterMapper("ItemMapper", map[string]*Statement{
+		"selectNames": {ID: "selectNames", SQL: "SELECT name FROM items ORDER BY rowid", StatementType: StatementTypeSelect},
+		"insert":      {ID: "insert", SQL: "INSERT INTO items (name) VALUES (?)", StatementType: StatementTypeInsert},
+	})
+	return mb, primary
+}
+
+// selectNames 查询全部名称
+func selectNames(t *testing.T, session SqlSession) []string {
+	t.Helper()
+	results, err := session.SelectList("ItemMapper.selectNames", nil)
+	if err != nil {
+		t.Fatalf("SelectList failed: %v", err)
+	}
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func TestReadWriteSplitting_SelectsUseReplicas(t *testing.T) {
+	mb, primary := newReadWriteMyBatis(t)
+	session := mb.OpenSession()
+	defer session.Close()
+
+	// 查询按轮询路由到各个从库
+	hits := map[string]int{}
+	for i := 0; i < 4; i++ {
+		names := selectNames(t, session)
+		if len(names) != 1 {
+			t.Fatalf("Expected one row from replica, got %v", names)
+		}
+		hits[names[0]]++
+	}
+	if hits["replica-1"] != 2 || hits["replica-2"] != 2 {
+		t.Fatalf("Expected selects to be balanced across replicas, got %v", hits)
+	}
+
+	// 写语句使用主库
+	if _, err := session.Insert("ItemMapper.insert", "written"); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	var count int64
+	primary.Raw("SELECT COUNT(*) FROM items WHERE name = ?", "written").Scan(&count)
+	if count != 1 {
+		t.Fatalf("Expected insert to reach primary, got %d rows", count)
+	}
+	if names := selectNames(t, session); len(names) != 1 {
+		t.Fatalf("Expected replica to be unaffected by the write, got %v", names)
+	}
+}
+
+func TestReadWriteSplitting_ForcePrimary(t *testing.T) {
+	mb, _ := newReadWriteMyBatis(t)
+
+	session := mb.OpenSession().(*DefaultSqlSession).ForcePrimary()
+	defer session.Close()
+	if _, err := session.Insert("ItemMapper.insert", "written"); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	// 写后立即读取主库
+	names := selectNames(t, session)
+	if len(names) != 2 || names[0] != "primary" || names[1] != "written" {
+		t.Fatalf("Expected read-after-write from primary, got %v", names)
+	}
+
+	primarySession := mb.OpenPrimarySession()
+	defer primarySession.Close()
+	if names := selectNames(t, primarySession); len(names) != 2 {
+		t.Fatalf("Expected primary session to read from primary, got %v", names)
+	}
+
+	// 事务内的查询同样使用主库
+	txSession := mb.OpenSessionWithTx()
+	defer txSession.Close()
+	if names := selectNames(t, txSession); len(names) != 2 || names[0] != "primary" {
+		t.Fatalf("Expected transaction to read from primary, got %v", names)
+	}
+}
@@ -0,0 +1,96 @@
+package mybatis
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RequestCache 请求级一级缓存
+// 同一请求内相同语句与参数的查询只访问一次数据库，后续直接返回内存结果；
+// 任何写操作（Insert/Update/Delete/BatchExec）都会清空缓存，避免读到旧数据。
+// 缓存返回的行数据与首次查询共享，调用方不应修改
+type RequestCache struct {
+	mu      sync.Mutex
+	entries map[string]interface{}
+}
+
+// NewRequestCache 创建请求级缓存
+func NewRequestCache() *RequestCache {
+	return &RequestCache{entries: make(map[string]interface{})}
+}
+
+// WithRequestCache 在context中开启请求级缓存，请求结束时应调用Clear释放
+func WithRequestCache(ctx context.Context) (context.Context, *RequestCache) {
+	cache := NewRequestCache()
+	return context.WithValue(ctx, RequestCacheKey, cache), cache
+}
+
+// GetRequestCache 获取context中的请求级缓存，未开启时返回nil
+func GetRequestCache(ctx context.Context) *RequestCache {
+	if ctx == nil {
+		return nil
+	}
+	if cache, ok := ctx.Value(RequestCacheKey).(*RequestCache); ok {
+		return cache
+	}
+	return nil
+}
+
+// Get 获取缓存结果
+func (c *RequestCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries[key]
+	return value, ok
+}
+
+// Set 写入缓存结果
+func (c *RequestCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+}
+
+// Clear 清空缓存
+func (c *RequestCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]interface{})
+}
+
+// Len 缓存条目数
+func (c *RequestCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// requestCacheKey 生成请求级缓存键，Scan结果按目标类型区分
+func requestCacheKey(kind string, sql string, args []interface{}) string {
+	return fmt.Sprintf("%s|%s", kind, generateCacheKey(sql, args))
+}
+
+// invalidateRequestCache 写操作后清空请求级缓存
+func invalidateRequestCache(ctx context.Context) {
+	if cache := GetRequestCache(ctx); cache != nil {
+		cache.Clear()
+	}
+}
+
+// copyScanResult 将缓存的Scan结果复制到dest
+func copyScanResult(dest interface{}, cached reflect.Value) bool {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Type() != cached.Type() {
+		return false
+	}
+	if cached.Kind() == reflect.Slice && !cached.IsNil() {
+		// 复制切片，避免调用方append影响缓存
+		copied := reflect.MakeSlice(cached.Type(), cached.Len(), cached.Len())
+		reflect.Copy(copied, cached)
+		cached = copied
+	}
+	target.Elem().Set(cached)
+	return true
+}
@@ -0,0 +1,296 @@
+package mybatis
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zsy619/yyhertz/framework/mybatis/config"
+)
+
+// RegisterResultMap 注册结果映射，语句通过 Statement.ResultMap 引用（同命名空间可省略命名空间前缀）
+func (mb *MyBatisGorm) RegisterResultMap(namespace string, resultMap *ResultMap) {
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+
+	mapperInfo, exists := mb.mappers[namespace]
+	if !exists {
+		mapperInfo = &MapperInfo{
+			Namespace:  namespace,
+			Statements: make(map[string]*Statement),
+			ResultMaps: make(map[string]*ResultMap),
+		}
+		mb.mappers[namespace] = mapperInfo
+	}
+	mapperInfo.ResultMaps[resultMap.ID] = resultMap
+}
+
+// RegisterTypeHandler 注册结果映射使用的类型处理器
+// 处理器的 GetResult 接收当前行（map[string]interface{}）与列名，返回字段值
+func (mb *MyBatisGorm) RegisterTypeHandler(javaType reflect.Type, handler config.TypeHandler) {
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+
+	if mb.typeHandlers == nil {
+		mb.typeHandlers = config.NewTypeHandlerRegistry()
+	}
+	mb.typeHandlers.RegisterTypeHandler(javaType, handler)
+}
+
+// typeHandler 获取类型处理器，未注册时返回nil
+func (mb *MyBatisGorm) typeHandler(javaType reflect.Type) config.TypeHandler {
+	mb.mutex.RLock()
+	defer mb.mutex.RUnlock()
+
+	if mb.typeHandlers == nil || javaType == nil {
+		return nil
+	}
+	return mb.typeHandlers.GetTypeHandler(javaType, "")
+}
+
+// getResultMap 获取语句引用的结果映射，未配置时返回nil
+func (session *DefaultSqlSession) getResultMap(statementID string, stmt *Statement) (*ResultMap, error) {
+	if stmt.ResultMap == "" {
+		return nil, nil
+	}
+
+	namespace, id := stmt.Namespace, stmt.ResultMap
+	if idx := strings.LastIndex(id, "."); idx > 0 {
+		namespace, id = id[:idx], id[idx+1:]
+	} else if namespace == "" {
+		namespace = strings.SplitN(statementID, ".", 2)[0]
+	}
+
+	session.mybatis.mutex.RLock()
+	defer session.mybatis.mutex.RUnlock()
+
+	if mapperInfo, exists := session.mybatis.mappers[namespace]; exists {
+		if resultMap, exists := mapperInfo.ResultMaps[id]; exists {
+			return resultMap, nil
+		}
+	}
+	return nil, fmt.Errorf("result map not found: %s.%s", namespace, id)
+}
+
+// mapResult 按结果映射将一行数据转换为结构体
+// 显式映射的列按 ColumnMapping 赋值，其余列按字段名自动映射（忽略大小写和下划线）
+// ResultMap.Type 为指针类型时返回指针，否则返回结构体值
+func (mb *MyBatisGorm) mapResult(row map[string]interface{}, resultMap *ResultMap) (interface{}, error) {
+	structType := resultMap.Type
+	if structType == nil {
+		return nil, fmt.Errorf("result map %s has no type", resultMap.ID)
+	}
+	isPtr := structType.Kind() == reflect.Ptr
+	if isPtr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("result map %s type must be a struct, got %s", resultMap.ID, structType)
+	}
+
+	target := reflect.New(structType)
+	value := target.Elem()
+	mapped := make(map[string]bool, len(resultMap.Columns))
+
+	for _, column := range resultMap.Columns {
+		mapped[strings.ToLower(column.Column)] = true
+		field := value.FieldByName(column.Property)
+		if !field.IsValid() || !field.CanSet() {
+			return nil, fmt.Errorf("result map %s: no settable field %s", resultMap.ID, column.Property)
+		}
+		raw, exists := lookupColumn(row, column.Column)
+		if !exists {
+			continue
+		}
+
+		javaType := column.JavaType
+		if javaType == nil {
+			javaType = field.Type()
+		}
+		if handler := mb.typeHandler(javaType); handler != nil {
+			converted, err := handler.GetResult(row, column.Column)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", column.Column, err)
+			}
+			raw = converted
+		}
+		if err := assignColumnValue(field, raw); err != nil {
+			return nil, fmt.Errorf("column %s to field %s: %w", column.Column, column.Property, err)
+		}
+	}
+
+	// 自动映射未显式配置的列
+	for columnName, raw := range row {
+		if mapped[strings.ToLower(columnName)] {
+			continue
+		}
+		field := fieldByColumn(value, columnName)
+		if !field.IsValid() {
+			continue
+		}
+		if handler := mb.typeHandler(field.Type()); handler != nil {
+			converted, err := handler.GetResult(row, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", columnName, err)
+			}
+			raw = converted
+		}
+		if err := assignColumnValue(field, raw); err != nil {
+			return nil, fmt.Errorf("column %s: %w", columnName, err)
+		}
+	}
+
+	if isPtr {
+		return target.Interface(), nil
+	}
+	return value.Interface(), nil
+}
+
+// isStructType 是否为结构体或结构体指针类型
+func isStructType(t reflect.Type) bool {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// lookupColumn 按列名获取值（不区分大小写）
+func lookupColumn(row map[string]interface{}, column string) (interface{}, bool) {
+	if raw, exists := row[column]; exists {
+		return raw, true
+	}
+	for name, raw := range row {
+		if strings.EqualFold(name, column) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// fieldByColumn 按列名查找可设置的字段：优先匹配 gorm 标签中的 column，其次按字段名匹配（忽略大小写和下划线）
+func fieldByColumn(value reflect.Value, column string) reflect.Value {
+	normalized := strings.ReplaceAll(strings.ToLower(column), "_", "")
+	structType := value.Type()
+	match := -1
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, option := range strings.Split(field.Tag.Get("gorm"), ";") {
+			if name, ok := strings.CutPrefix(strings.TrimSpace(option), "column:"); ok && strings.EqualFold(name, column) {
+				return value.Field(i)
+			}
+		}
+		if match < 0 && strings.ToLower(field.Name) == normalized {
+			match = i
+		}
+	}
+	if match < 0 {
+		return reflect.Value{}
+	}
+	return value.Field(match)
+}
+
+// assignColumnValue 将数据库值赋给字段，支持数值、字符串、布尔与时间之间的常见转换
+func assignColumnValue(field reflect.Value, raw interface{}) error {
+	// 扫描结果可能是 *interface{} 等指针，先解引用到实际值
+	for raw != nil {
+		rv := reflect.ValueOf(raw)
+		if rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Interface {
+			break
+		}
+		if rv.IsNil() {
+			raw = nil
+			break
+		}
+		if rv.Type().AssignableTo(field.Type()) {
+			break
+		}
+		raw = rv.Elem().Interface()
+	}
+	if raw == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	value := reflect.ValueOf(raw)
+	if value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignColumnValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if b, ok := raw.([]byte); ok {
+		raw = string(b)
+		value = reflect.ValueOf(raw)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprint(raw))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(fmt.Sprint(raw), 10, 64)
+		if err != nil {
+			if f, ferr := strconv.ParseFloat(fmt.Sprint(raw), 64); ferr == nil {
+				n, err = int64(f), nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(fmt.Sprint(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(fmt.Sprint(raw), 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		switch v := raw.(type) {
+		case int64:
+			field.SetBool(v != 0)
+			return nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+			return nil
+		}
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		if s, ok := raw.(string); ok {
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05", "2006-01-02"} {
+				if t, err := time.Parse(layout, s); err == nil {
+					field.Set(reflect.ValueOf(t))
+					return nil
+				}
+			}
+		}
+	}
+	if value.Type().ConvertibleTo(field.Type()) {
+		field.Set(value.Convert(field.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot convert %T to %s", raw, field.Type())
+}
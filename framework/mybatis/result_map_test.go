@@ -0,0 +1,129 @@
+package mybatis
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// accountStatus 数据库中以字符存储的状态
+type accountStatus int
+
+const (
+	accountInactive accountStatus = iota
+	accountActive
+)
+
+// statusTypeHandler 将 A/I 转换为 accountStatus
+type statusTypeHandler struct{}
+
+func (statusTypeHandler) SetParameter(stmt any, i int, parameter any, jdbcType string) error {
+	return nil
+}
+
+func (statusTypeHandler) GetResult(rs any, columnName string) (any, error) {
+	switch code := fmt.Sprint(rs.(map[string]interface{})[columnName]); code {
+	case "A":
+		return accountActive, nil
+	case "I":
+		return accountInactive, nil
+	default:
+		return nil, fmt.Errorf("unknown status %q", code)
+	}
+}
+
+func (statusTypeHandler) GetResultByIndex(rs any, columnIndex int) (any, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+type accountView struct {
+	AccountID   int64
+	DisplayName string
+	Balance     float64
+	Status      accountStatus
+	Email       string // 按列名自动映射
+}
+
+func newResultMapMyBatis(t *testing.T) *MyBatisGorm {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "accounts.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, name TEXT, balance_cents INTEGER, status TEXT, email TEXT)")
+	db.Exec("INSERT INTO accounts VALUES (1, 'Alice', 1250, 'A', 'alice@example.com'), (2, 'Bob', 0, 'I', 'bob@example.com')")
+
+	mb := NewMyBatisGorm(db, nil)
+	mb.RegisterTypeHandler(reflect.TypeOf(accountStatus(0)), statusTypeHandler{})
+	mb.RegisterResultMap("AccountMapper", &ResultMap{
+		ID:   "accountViewMap",
+		Type: reflect.TypeOf(&accountView{}),
+		Columns: []ColumnMapping{
+			{Property: "AccountID", Column: "acct_no"},
+			{Property: "DisplayName", Column: "acct_label"},
+			{Property: "Balance", Column: "balance"},
+			{Property: "Status", Column: "state"},
+		},
+	})
+	mb.RegisterMapper("AccountMapper", map[string]*Statement{
+		"selectViews": {
+			ID:            "selectViews",
+			SQL:           "SELECT id AS acct_no, name AS acct_label, balance_cents / 100.0 AS balance, status AS state, email FROM accounts ORDER BY id",
+			StatementType: StatementTypeSelect,
+			ResultMap:     "accountViewMap",
+		},
+		"selectMissingMap": {
+			ID:            "selectMissingMap",
+			SQL:           "SELECT id FROM accounts",
+			StatementType: StatementTypeSelect,
+			ResultMap:     "OtherMapper.unknown",
+		},
+	})
+	return mb
+}
+
+func TestResultMap_MapsAliasedColumnsToFields(t *testing.T) {
+	mb := newResultMapMyBatis(t)
+	session := mb.OpenSession()
+	defer session.Close()
+
+	results, err := session.SelectList("AccountMapper.selectViews", nil)
+	if err != nil {
+		t.Fatalf("SelectList failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(results))
+	}
+
+	alice, ok := results[0].(*accountView)
+	if !ok {
+		t.Fatalf("Expected *accountView, got %T", results[0])
+	}
+	expected := accountView{AccountID: 1, DisplayName: "Alice", Balance: 12.5, Status: accountActive, Email: "alice@example.com"}
+	if *alice != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *alice)
+	}
+	if bob := results[1].(*accountView); bob.Status != accountInactive || bob.DisplayName != "Bob" {
+		t.Errorf("Expected inactive Bob, got %+v", *bob)
+	}
+
+	one, err := session.SelectOne("AccountMapper.selectViews", nil)
+	if err == nil {
+		t.Fatalf("Expected SelectOne to reject multiple rows, got %+v", one)
+	}
+}
+
+func TestResultMap_UnknownResultMap(t *testing.T) {
+	mb := newResultMapMyBatis(t)
+	session := mb.OpenSession()
+	defer session.Close()
+
+	if _, err := session.SelectList("AccountMapper.selectMissingMap", nil); err == nil {
+		t.Fatal("Expected error for unknown result map")
+	}
+}
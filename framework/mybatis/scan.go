@@ -0,0 +1,57 @@
+package mybatis
+
+import (
+	"context"
+	"fmt"
+
+	frameworkErrors "github.com/zsy619/yyhertz/framework/errors"
+)
+
+// ErrNotFound 必需的记录不存在，可通过 errors.Is 判断
+// 同时匹配 errors.DataNotFound，错误处理层据此统一返回404
+var ErrNotFound = fmt.Errorf("mybatis: record not found: %w", frameworkErrors.DataNotFound)
+
+// ScanOne 查询单条记录并扫描为T类型，无记录时返回nil，多条记录时返回错误
+func ScanOne[T any](ctx context.Context, session SimpleSession, sql string, args ...interface{}) (*T, error) {
+	results, err := ScanList[T](ctx, session, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	if len(results) > 1 {
+		return nil, fmt.Errorf("expected one result but found %d", len(results))
+	}
+
+	return results[0], nil
+}
+
+// ScanOneRequired 查询单条必需记录并扫描为T类型，无记录时返回ErrNotFound
+func ScanOneRequired[T any](ctx context.Context, session SimpleSession, sql string, args ...interface{}) (*T, error) {
+	result, err := ScanOne[T](ctx, session, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ErrNotFound
+	}
+
+	return result, nil
+}
+
+// ScanList 查询多条记录并扫描为T类型列表
+func ScanList[T any](ctx context.Context, session SimpleSession, sql string, args ...interface{}) ([]*T, error) {
+	var rows []*T
+	if err := session.Scan(ctx, &rows, sql, args...); err != nil {
+		return nil, err
+	}
+
+	if rows == nil {
+		rows = make([]*T, 0)
+	}
+	return rows, nil
+}
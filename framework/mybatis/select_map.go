@@ -0,0 +1,63 @@
+package mybatis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateMapKey SelectMap遇到重复键且策略为DuplicateKeyError
+var ErrDuplicateMapKey = errors.New("mybatis: duplicate map key")
+
+// DuplicateKeyPolicy SelectMap遇到重复键时的处理策略
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeyLastWins 后出现的记录覆盖先前的记录（默认，与MyBatis @MapKey一致）
+	DuplicateKeyLastWins DuplicateKeyPolicy = iota
+	// DuplicateKeyError 出现重复键时返回ErrDuplicateMapKey
+	DuplicateKeyError
+)
+
+// DuplicateKeys 设置SelectMap的重复键处理策略
+func (s *defaultSession) DuplicateKeys(policy DuplicateKeyPolicy) SimpleSession {
+	s.config.DuplicateKeyPolicy = policy
+	return s
+}
+
+// SelectMap 查询多条记录并以keyColumn列的值为键返回，相当于MyBatis的 @MapKey
+// 字节切片类型的键转换为字符串，结果中缺少keyColumn列时返回错误
+func (s *defaultSession) SelectMap(ctx context.Context, keyColumn string, sql string, args ...interface{}) (map[any]map[string]any, error) {
+	results, err := s.SelectList(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	keyed := make(map[any]map[string]any, len(results))
+	for _, result := range results {
+		row, ok := result.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("mybatis: unexpected row type %T", result)
+		}
+
+		value, ok := row[keyColumn]
+		if !ok {
+			return nil, fmt.Errorf("mybatis: map key column %q not found in result", keyColumn)
+		}
+		key := mapKey(value)
+
+		if _, exists := keyed[key]; exists && s.config.DuplicateKeyPolicy == DuplicateKeyError {
+			return nil, fmt.Errorf("%w: %s=%v", ErrDuplicateMapKey, keyColumn, key)
+		}
+		keyed[key] = row
+	}
+	return keyed, nil
+}
+
+// mapKey 将列值转换为可作为map键的值
+func mapKey(value interface{}) any {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
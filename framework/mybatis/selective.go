@@ -0,0 +1,85 @@
+package mybatis
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// SelectiveTag 选择性更新标签，selective:"always" 的字段即使为零值也会被包含
+const SelectiveTag = "selective"
+
+// SelectiveColumns 按MyBatis <set> 语义提取实体中的非零字段，用于构建选择性更新的SET子句
+// 列名取db标签，未设置时按gorm命名规则转换；db:"-" 的字段与exclude中的列被忽略；
+// nil指针与零值字段被跳过，除非字段带有 selective:"always" 标签
+func SelectiveColumns(entity interface{}, exclude ...string) ([]string, []interface{}, error) {
+	v := reflect.ValueOf(entity)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil, fmt.Errorf("selective: nil entity")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("selective: unsupported entity type %T, expected struct", entity)
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, column := range exclude {
+		excluded[column] = true
+	}
+
+	columns := make([]string, 0, v.NumField())
+	values := make([]interface{}, 0, v.NumField())
+	collectSelective(v, excluded, &columns, &values)
+	return columns, values, nil
+}
+
+// collectSelective 递归收集结构体（含匿名嵌入结构体）中需要更新的字段
+func collectSelective(v reflect.Value, excluded map[string]bool, columns *[]string, values *[]interface{}) {
+	naming := schema.NamingStrategy{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := strings.Split(sf.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if sf.Anonymous && tag == "" && field.Kind() == reflect.Struct {
+			collectSelective(field, excluded, columns, values)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = naming.ColumnName("", sf.Name)
+		}
+		if excluded[column] {
+			continue
+		}
+
+		always := sf.Tag.Get(SelectiveTag) == "always"
+		if !always && field.IsZero() {
+			continue
+		}
+
+		var value interface{}
+		if field.Kind() == reflect.Ptr {
+			if !field.IsNil() {
+				value = field.Elem().Interface()
+			}
+		} else {
+			value = field.Interface()
+		}
+
+		*columns = append(*columns, column)
+		*values = append(*values, value)
+	}
+}
@@ -476,6 +476,20 @@ func (executor *CachingExecutor) Query(ms *MappedStatement, parameter any, rowBo
 		}
 		
 		keyStr := fmt.Sprintf("%v", cacheKey.UpdateList)
+		if loadingCache, ok := executor.cache.(cache.LoadingCache); ok {
+			// 同一键的并发未命中只查询一次数据库
+			loaded, err := loadingCache.GetOrLoad(keyStr, func() (any, error) {
+				return executor.delegate.Query(ms, parameter, rowBounds, resultHandler, cacheKey, boundSql)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if results, ok := loaded.([]any); ok {
+				return results, nil
+			}
+			return executor.delegate.Query(ms, parameter, rowBounds, resultHandler, cacheKey, boundSql)
+		}
+
 		if cached, exists := executor.cache.Get(keyStr); exists {
 			if results, ok := cached.([]any); ok {
 				return results, nil
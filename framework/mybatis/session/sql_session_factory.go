@@ -6,6 +6,7 @@ package session
 import (
 	"sync"
 
+	frameworkcache "github.com/zsy619/yyhertz/framework/cache"
 	"github.com/zsy619/yyhertz/framework/mybatis/cache"
 	"github.com/zsy619/yyhertz/framework/mybatis/config"
 	"github.com/zsy619/yyhertz/framework/orm"
@@ -98,13 +99,41 @@ func NewDefaultSqlSessionFactory(configuration *config.Configuration) (SqlSessio
 		if err != nil {
 			return nil, err
 		}
+
+		// 注册慢查询插件，慢查询记录到全局慢查询监控器
+		if err := ormInstance.DB().Use(orm.NewSlowQueryPluginFromConfig(configuration.GetDatabaseConfig())); err != nil {
+			return nil, err
+		}
+
+		// 开发模式下注册查询计划日志插件
+		if explainLogger := orm.NewExplainPlanLoggerFromConfig(configuration.GetDatabaseConfig()); explainLogger.Enabled() {
+			if err := ormInstance.DB().Use(explainLogger); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// 创建缓存
 	var c cache.Cache
 	if configuration.CacheEnabled && configuration.DefaultCacheConfig != nil {
-		perpetualCache := cache.NewPerpetualCache("factory")
-		c = cache.NewLruCache(perpetualCache, configuration.DefaultCacheConfig.Size)
+		dbConfig := configuration.GetDatabaseConfig()
+		externalCache := dbConfig != nil && dbConfig.Cache.Enable &&
+			(dbConfig.Cache.Type == "memcached" || dbConfig.Cache.Type == "redis")
+		if externalCache {
+			// 使用数据库配置中的外部缓存作为二级缓存
+			c, err = cache.NewCacheFromConfig("factory", dbConfig)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			perpetualCache := cache.NewPerpetualCache("factory")
+			c = cache.NewLruCache(perpetualCache, configuration.DefaultCacheConfig.Size)
+		}
+		// 缓存过期时合并同一查询的并发未命中，防止缓存击穿
+		c = cache.NewSingleflightCache(c)
+
+		// 登记查询缓存，供缓存管理接口查看与清空
+		frameworkcache.Register(frameworkcache.NewInspector(frameworkcache.QueryCacheName, "MyBatis查询缓存", c.GetSize, c.Clear))
 	}
 
 	factory := &DefaultSqlSessionFactory{
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 	"time"
 
@@ -17,11 +18,20 @@ import (
 type SimpleSession interface {
 	SelectOne(ctx context.Context, sql string, args ...interface{}) (interface{}, error)
 	SelectList(ctx context.Context, sql string, args ...interface{}) ([]interface{}, error)
+	SelectMap(ctx context.Context, keyColumn string, sql string, args ...interface{}) (map[any]map[string]any, error)
+	SelectStream(ctx context.Context, sql string, args ...interface{}) (RowIterator, error)
 	SelectPage(ctx context.Context, sql string, page PageRequest, args ...interface{}) (*PageResult, error)
+	Scan(ctx context.Context, dest interface{}, sql string, args ...interface{}) error
 	Insert(ctx context.Context, sql string, args ...interface{}) (int64, error)
 	Update(ctx context.Context, sql string, args ...interface{}) (int64, error)
 	Delete(ctx context.Context, sql string, args ...interface{}) (int64, error)
 	
+	// BatchExec 在同一事务中执行多条参数化更新语句，返回总影响行数，任一失败则整体回滚
+	BatchExec(ctx context.Context, statements []BatchStatement) (int64, error)
+	
+	// BatchInsertRows 以多行INSERT批量插入，按批大小拆分语句并在同一事务中执行，返回总插入行数
+	BatchInsertRows(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error)
+	
 	// 钩子方法
 	AddBeforeHook(hook BeforeHook) SimpleSession
 	AddAfterHook(hook AfterHook) SimpleSession
@@ -29,13 +39,17 @@ type SimpleSession interface {
 	// 配置方法
 	DryRun(enabled bool) SimpleSession
 	Debug(enabled bool) SimpleSession
+	BatchSize(size int) SimpleSession
+	DuplicateKeys(policy DuplicateKeyPolicy) SimpleSession
 }
 
 // SessionConfig 会话配置
 type SessionConfig struct {
-	DryRun bool
-	Debug  bool
-	Logger *log.Logger
+	DryRun             bool
+	Debug              bool
+	Logger             *log.Logger
+	CreateBatchSize    int                // 多行INSERT每条语句的最大行数，0表示使用DefaultCreateBatchSize
+	DuplicateKeyPolicy DuplicateKeyPolicy // SelectMap遇到重复键时的处理策略
 }
 
 // defaultSession 默认会话实现
@@ -52,6 +66,12 @@ type BeforeHook func(ctx context.Context, sql string, args []interface{}) error
 // AfterHook 执行后钩子
 type AfterHook func(ctx context.Context, result interface{}, duration time.Duration, err error)
 
+// BatchStatement 批量执行的单条语句
+type BatchStatement struct {
+	SQL  string        `json:"sql"`  // SQL语句
+	Args []interface{} `json:"args"` // 参数
+}
+
 // PageRequest 分页请求
 type PageRequest struct {
 	Page int `json:"page"` // 页码，从1开始
@@ -123,6 +143,16 @@ func (s *defaultSession) SelectOne(ctx context.Context, sql string, args ...inte
 
 // SelectList 查询多条记录
 func (s *defaultSession) SelectList(ctx context.Context, sql string, args ...interface{}) ([]interface{}, error) {
+	// 请求级缓存命中时直接返回，不再访问数据库
+	cache := GetRequestCache(ctx)
+	cacheKey := requestCacheKey("list", sql, args)
+	if cache != nil && !s.config.DryRun {
+		if cached, ok := cache.Get(cacheKey); ok {
+			rows := cached.([]interface{})
+			return append(make([]interface{}, 0, len(rows)), rows...), nil
+		}
+	}
+	
 	startTime := time.Now()
 	
 	// 执行前钩子
@@ -155,6 +185,9 @@ func (s *defaultSession) SelectList(ctx context.Context, sql string, args ...int
 			for i, row := range rows {
 				result[i] = row
 			}
+			if cache != nil {
+				cache.Set(cacheKey, append(make([]interface{}, 0, len(result)), result...))
+			}
 		}
 	}
 	
@@ -168,6 +201,58 @@ func (s *defaultSession) SelectList(ctx context.Context, sql string, args ...int
 	return result, err
 }
 
+// Scan 查询并直接扫描到dest（结构体切片指针），列名按gorm命名规则映射到字段
+func (s *defaultSession) Scan(ctx context.Context, dest interface{}, sql string, args ...interface{}) error {
+	// 请求级缓存命中时直接复制结果，不再访问数据库
+	cache := GetRequestCache(ctx)
+	cacheKey := requestCacheKey(fmt.Sprintf("scan:%T", dest), sql, args)
+	if cache != nil && !s.config.DryRun {
+		if cached, ok := cache.Get(cacheKey); ok && copyScanResult(dest, cached.(reflect.Value)) {
+			return nil
+		}
+	}
+	
+	startTime := time.Now()
+	
+	// 执行前钩子
+	for _, hook := range s.beforeHooks {
+		if err := hook(ctx, sql, args); err != nil {
+			return fmt.Errorf("before hook error: %w", err)
+		}
+	}
+	
+	var err error
+	
+	if s.config.DryRun {
+		// DryRun模式：只打印SQL，不实际执行
+		s.logSQL("[DryRun]", sql, args)
+	} else {
+		if s.config.Debug {
+			s.logSQL("[Debug]", sql, args)
+		}
+		
+		err = s.db.Raw(sql, args...).Scan(dest).Error
+		if err != nil {
+			s.logError("Scan failed", err)
+		} else if cache != nil {
+			if target := reflect.ValueOf(dest); target.Kind() == reflect.Ptr && !target.IsNil() {
+				cached := reflect.New(target.Elem().Type()).Elem()
+				copyScanResult(cached.Addr().Interface(), target.Elem())
+				cache.Set(cacheKey, cached)
+			}
+		}
+	}
+	
+	duration := time.Since(startTime)
+	
+	// 执行后钩子
+	for _, hook := range s.afterHooks {
+		hook(ctx, dest, duration, err)
+	}
+	
+	return err
+}
+
 // SelectPage 分页查询
 func (s *defaultSession) SelectPage(ctx context.Context, sql string, page PageRequest, args ...interface{}) (*PageResult, error) {
 	// 参数验证
@@ -296,6 +381,7 @@ func (s *defaultSession) executeUpdate(ctx context.Context, operation, sql strin
 		
 		result := s.db.Exec(sql, args...)
 		err = result.Error
+		invalidateRequestCache(ctx)
 		if err != nil {
 			s.logError(fmt.Sprintf("%s failed", operation), err)
 		} else {
@@ -313,6 +399,61 @@ func (s *defaultSession) executeUpdate(ctx context.Context, operation, sql strin
 	return affectedRows, err
 }
 
+// BatchExec 在同一事务中批量执行更新语句
+func (s *defaultSession) BatchExec(ctx context.Context, statements []BatchStatement) (int64, error) {
+	startTime := time.Now()
+	
+	// 执行前钩子
+	for _, stmt := range statements {
+		for _, hook := range s.beforeHooks {
+			if err := hook(ctx, stmt.SQL, stmt.Args); err != nil {
+				return 0, fmt.Errorf("before hook error: %w", err)
+			}
+		}
+	}
+	
+	var affectedRows int64
+	var err error
+	
+	if s.config.DryRun {
+		// DryRun模式：只打印SQL，不实际执行
+		for _, stmt := range statements {
+			s.logSQL("[DryRun BATCH]", stmt.SQL, stmt.Args)
+		}
+	} else if len(statements) > 0 {
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			var total int64
+			for i, stmt := range statements {
+				if s.config.Debug {
+					s.logSQL(fmt.Sprintf("[Debug BATCH %d]", i+1), stmt.SQL, stmt.Args)
+				}
+				
+				result := tx.Exec(stmt.SQL, stmt.Args...)
+				if result.Error != nil {
+					return fmt.Errorf("batch statement %d failed: %w", i+1, result.Error)
+				}
+				total += result.RowsAffected
+			}
+			affectedRows = total
+			return nil
+		})
+		invalidateRequestCache(ctx)
+		if err != nil {
+			s.logError("BATCH failed, transaction rolled back", err)
+			affectedRows = 0
+		}
+	}
+	
+	duration := time.Since(startTime)
+	
+	// 执行后钩子
+	for _, hook := range s.afterHooks {
+		hook(ctx, affectedRows, duration, err)
+	}
+	
+	return affectedRows, err
+}
+
 // buildCountSQL 构建count查询SQL
 func (s *defaultSession) buildCountSQL(sql string) string {
 	// 移除ORDER BY子句
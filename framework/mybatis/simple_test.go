@@ -3,14 +3,19 @@ package mybatis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	frameworkErrors "github.com/zsy619/yyhertz/framework/errors"
 )
 
 // 设置测试数据库
@@ -283,6 +288,638 @@ func TestPerformanceHook(t *testing.T) {
 	log.Println("TestPerformanceHook passed")
 }
 
+// TestBatchExec 测试批量执行及回滚
+func TestBatchExec(t *testing.T) {
+	db := setupTestDB()
+	session := NewSimpleSession(db)
+	ctx := context.Background()
+	
+	// 批量更新成功
+	affected, err := session.BatchExec(ctx, []BatchStatement{
+		{SQL: "UPDATE users SET name = ? WHERE id = ?", Args: []interface{}{"Batch 1", 1}},
+		{SQL: "UPDATE users SET name = ? WHERE id = ?", Args: []interface{}{"Batch 2", 2}},
+	})
+	if err != nil {
+		t.Fatalf("BatchExec failed: %v", err)
+	}
+	if affected != 2 {
+		t.Fatalf("Expected 2 affected rows, got %d", affected)
+	}
+	
+	// 任一语句失败时整体回滚
+	_, err = session.BatchExec(ctx, []BatchStatement{
+		{SQL: "UPDATE users SET name = ? WHERE id = ?", Args: []interface{}{"Rollback", 1}},
+		{SQL: "UPDATE missing_table SET name = ?", Args: []interface{}{"Rollback"}},
+	})
+	if err == nil {
+		t.Fatal("Expected BatchExec to fail")
+	}
+	
+	result, err := session.SelectOne(ctx, "SELECT name FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("SelectOne failed: %v", err)
+	}
+	if name := result.(map[string]interface{})["name"]; name != "Batch 1" {
+		t.Fatalf("Expected rollback to keep 'Batch 1', got %v", name)
+	}
+	
+	log.Println("TestBatchExec passed")
+}
+
+// TestScanTyped 测试直接扫描为结构体
+func TestScanTyped(t *testing.T) {
+	db := setupTestDB()
+	session := NewSimpleSession(db)
+	ctx := context.Background()
+	
+	user, err := ScanOne[User](ctx, session, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("ScanOne failed: %v", err)
+	}
+	if user == nil || user.ID != 1 || user.Name == "" || user.Email == "" {
+		t.Fatalf("Expected user 1 to be scanned, got %+v", user)
+	}
+	
+	// 无记录时返回nil
+	missing, err := ScanOne[User](ctx, session, "SELECT * FROM users WHERE id = ?", 999)
+	if err != nil {
+		t.Fatalf("ScanOne failed: %v", err)
+	}
+	if missing != nil {
+		t.Fatalf("Expected nil for missing row, got %+v", missing)
+	}
+	
+	users, err := ScanList[User](ctx, session, "SELECT * FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("ScanList failed: %v", err)
+	}
+	if len(users) != 3 || users[2].ID != 3 {
+		t.Fatalf("Expected 3 users ordered by id, got %d", len(users))
+	}
+	
+	// 多条记录时ScanOne返回错误
+	if _, err := ScanOne[User](ctx, session, "SELECT * FROM users"); err == nil {
+		t.Fatal("Expected error when ScanOne matches multiple rows")
+	}
+	
+	log.Println("TestScanTyped passed")
+}
+
+// TestRequestCache 测试请求级缓存
+func TestRequestCache(t *testing.T) {
+	db := setupTestDB()
+	
+	queries := 0
+	session := NewSimpleSession(db).AddBeforeHook(func(ctx context.Context, sql string, args []interface{}) error {
+		queries++
+		return nil
+	})
+	
+	ctx, cache := WithRequestCache(context.Background())
+	defer cache.Clear()
+	
+	// 同一请求内两次相同查询只访问一次数据库
+	first, err := session.SelectOne(ctx, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("SelectOne failed: %v", err)
+	}
+	second, err := session.SelectOne(ctx, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("SelectOne failed: %v", err)
+	}
+	if queries != 1 {
+		t.Fatalf("Expected 1 query for duplicate reads, got %d", queries)
+	}
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Fatalf("Expected cached result %v, got %v", first, second)
+	}
+	
+	// 参数不同则重新查询
+	if _, err := session.SelectOne(ctx, "SELECT * FROM users WHERE id = ?", 2); err != nil {
+		t.Fatalf("SelectOne failed: %v", err)
+	}
+	if queries != 2 {
+		t.Fatalf("Expected 2 queries for different params, got %d", queries)
+	}
+	
+	// 类型化扫描同样命中缓存
+	user, err := ScanOne[User](ctx, session, "SELECT * FROM users WHERE id = ?", 3)
+	if err != nil {
+		t.Fatalf("ScanOne failed: %v", err)
+	}
+	cachedUser, err := ScanOne[User](ctx, session, "SELECT * FROM users WHERE id = ?", 3)
+	if err != nil {
+		t.Fatalf("ScanOne failed: %v", err)
+	}
+	if queries != 3 || cachedUser == nil || cachedUser.Name != user.Name {
+		t.Fatalf("Expected cached scan result, got %d queries and %+v", queries, cachedUser)
+	}
+	
+	// 写操作清空缓存
+	if _, err := session.Update(ctx, "UPDATE users SET name = ? WHERE id = ?", "Johnny", 1); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	updated, err := session.SelectOne(ctx, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("SelectOne failed: %v", err)
+	}
+	if queries != 5 {
+		t.Fatalf("Expected re-query after update, got %d queries", queries)
+	}
+	if name := updated.(map[string]interface{})["name"]; name != "Johnny" {
+		t.Fatalf("Expected updated name, got %v", name)
+	}
+	
+	// 未开启缓存的context每次都查询
+	session.SelectOne(context.Background(), "SELECT * FROM users WHERE id = ?", 1)
+	session.SelectOne(context.Background(), "SELECT * FROM users WHERE id = ?", 1)
+	if queries != 7 {
+		t.Fatalf("Expected uncached queries to hit the database, got %d queries", queries)
+	}
+	
+	log.Println("TestRequestCache passed")
+}
+
+// TestSelectiveColumns 测试选择性更新字段提取
+func TestSelectiveColumns(t *testing.T) {
+	type auditFields struct {
+		Operator string `db:"operator"`
+	}
+	type profile struct {
+		auditFields
+		ID       int64      `db:"id"`
+		Nickname string     `db:"nickname"`
+		Score    int        `db:"score" selective:"always"`
+		Level    int        `db:"level"`
+		Birthday *time.Time `db:"birthday"`
+		Remark   string
+		Secret   string `db:"-"`
+	}
+	
+	birthday := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	columns, values, err := SelectiveColumns(&profile{
+		auditFields: auditFields{Operator: "admin"},
+		ID:          7,
+		Nickname:    "neo",
+		Birthday:    &birthday,
+		Remark:      "hi",
+		Secret:      "ignored",
+	}, "id")
+	if err != nil {
+		t.Fatalf("SelectiveColumns failed: %v", err)
+	}
+	
+	// 零值的Level被跳过，带always标签的Score即使为0也包含
+	expected := []string{"operator", "nickname", "score", "birthday", "remark"}
+	if fmt.Sprint(columns) != fmt.Sprint(expected) {
+		t.Fatalf("Expected columns %v, got %v", expected, columns)
+	}
+	if values[2] != 0 || values[3] != birthday {
+		t.Fatalf("Unexpected values %v", values)
+	}
+	
+	if _, _, err := SelectiveColumns(nil); err == nil {
+		t.Fatal("Expected error for nil entity")
+	}
+	
+	log.Println("TestSelectiveColumns passed")
+}
+
+// TestScanOneRequired 测试必需记录查询
+func TestScanOneRequired(t *testing.T) {
+	db := setupTestDB()
+	session := NewSimpleSession(db)
+	ctx := context.Background()
+	
+	user, err := ScanOneRequired[User](ctx, session, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil || user == nil || user.ID != 1 {
+		t.Fatalf("Expected user 1, got %+v, %v", user, err)
+	}
+	
+	// 必需变体返回ErrNotFound
+	missing, err := ScanOneRequired[User](ctx, session, "SELECT * FROM users WHERE id = ?", 999)
+	if !errors.Is(err, ErrNotFound) || missing != nil {
+		t.Fatalf("Expected ErrNotFound, got %+v, %v", missing, err)
+	}
+	if !errors.Is(err, frameworkErrors.DataNotFound) {
+		t.Fatalf("Expected ErrNotFound to match DataNotFound, got %v", err)
+	}
+	
+	// 标准变体返回nil
+	missing, err = ScanOne[User](ctx, session, "SELECT * FROM users WHERE id = ?", 999)
+	if err != nil || missing != nil {
+		t.Fatalf("Expected nil result without error, got %+v, %v", missing, err)
+	}
+	
+	log.Println("TestScanOneRequired passed")
+}
+
+// TestOrderByWhitelist 测试排序字段白名单
+func TestOrderByWhitelist(t *testing.T) {
+	whitelist := OrderByWhitelist{"id": "id", "created_at": "create_at"}
+	
+	clause, err := whitelist.Resolve("created_at", true)
+	if err != nil || clause != "ORDER BY create_at DESC" {
+		t.Fatalf("Expected whitelisted column, got %q, %v", clause, err)
+	}
+	
+	if clause, err := whitelist.Resolve("", false); err != nil || clause != "" {
+		t.Fatalf("Expected empty clause, got %q, %v", clause, err)
+	}
+	
+	for _, key := range []string{"id; DROP TABLE users", "name", "id DESC"} {
+		if _, err := whitelist.Resolve(key, false); !errors.Is(err, ErrInvalidOrderBy) {
+			t.Fatalf("Expected ErrInvalidOrderBy for %q, got %v", key, err)
+		}
+	}
+	
+	// 解析结果可直接用于查询
+	db := setupTestDB()
+	session := NewSimpleSession(db)
+	clause, _ = whitelist.Resolve("id", true)
+	users, err := ScanList[User](context.Background(), session, "SELECT * FROM users "+clause)
+	if err != nil || len(users) != 3 || users[0].ID != 3 {
+		t.Fatalf("Expected users ordered by id desc, got %d users, %v", len(users), err)
+	}
+	
+	log.Println("TestOrderByWhitelist passed")
+}
+
+// TestSelectMap 测试以列值为键返回查询结果
+func TestSelectMap(t *testing.T) {
+	db := setupTestDB()
+	session := NewSimpleSession(db)
+	ctx := context.Background()
+	
+	users, err := session.SelectMap(ctx, "email", "SELECT id, name, email FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("SelectMap failed: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("Expected 3 users, got %d", len(users))
+	}
+	
+	jane, ok := users["jane@example.com"]
+	if !ok {
+		t.Fatalf("Expected jane@example.com key, got %v", users)
+	}
+	if jane["name"] != "Jane Smith" {
+		t.Fatalf("Expected Jane Smith, got %v", jane["name"])
+	}
+	
+	if _, err := session.SelectMap(ctx, "missing", "SELECT id, email FROM users"); err == nil {
+		t.Fatal("Expected error for missing key column")
+	}
+	
+	log.Println("TestSelectMap passed")
+}
+
+// TestSelectMapDuplicateKeys 测试重复键的处理策略
+func TestSelectMapDuplicateKeys(t *testing.T) {
+	db := setupTestDB()
+	db.Exec("INSERT INTO users (name, email, create_at) VALUES (?, ?, ?)", "Johnny Doe", "john@example.com", time.Now())
+	session := NewSimpleSession(db)
+	ctx := context.Background()
+	
+	// 默认后出现的记录覆盖先前的记录
+	users, err := session.SelectMap(ctx, "email", "SELECT id, name, email FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("SelectMap failed: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("Expected 3 distinct emails, got %d", len(users))
+	}
+	if name := users["john@example.com"]["name"]; name != "Johnny Doe" {
+		t.Fatalf("Expected last row to win, got %v", name)
+	}
+	
+	_, err = session.DuplicateKeys(DuplicateKeyError).SelectMap(ctx, "email", "SELECT id, name, email FROM users ORDER BY id")
+	if !errors.Is(err, ErrDuplicateMapKey) {
+		t.Fatalf("Expected ErrDuplicateMapKey, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "john@example.com") {
+		t.Fatalf("Expected error to name the duplicate key, got %v", err)
+	}
+	
+	log.Println("TestSelectMapDuplicateKeys passed")
+}
+
+// setupStreamDB 创建包含大量记录的事件表，连接池限制为单连接以便检测游标是否释放连接
+func setupStreamDB(t *testing.T, n int) *gorm.DB {
+	db := setupTestDB()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("DB failed: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	
+	db.Exec(`CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, payload TEXT)`)
+	payload := strings.Repeat("x", 512)
+	rows := make([][]interface{}, n)
+	for i := range rows {
+		rows[i] = []interface{}{fmt.Sprintf("event%d", i), payload}
+	}
+	if _, err := NewSimpleSession(db).BatchInsertRows(context.Background(), "events", []string{"name", "payload"}, rows); err != nil {
+		t.Fatalf("BatchInsertRows failed: %v", err)
+	}
+	return db
+}
+
+// heapAlloc 执行GC后返回堆上已分配的字节数
+func heapAlloc() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// TestSelectStream 测试逐行读取大结果集时内存保持有界
+func TestSelectStream(t *testing.T) {
+	const total = 20000
+	db := setupStreamDB(t, total)
+	
+	var hookRows interface{}
+	session := NewSimpleSession(db).AddAfterHook(func(ctx context.Context, result interface{}, duration time.Duration, err error) {
+		hookRows = result
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	
+	baseline := heapAlloc()
+	var peak uint64
+	
+	rows, err := session.SelectStream(ctx, "SELECT id, name, payload FROM events ORDER BY id")
+	if err != nil {
+		t.Fatalf("SelectStream failed: %v", err)
+	}
+	count := 0
+	for rows.Next() {
+		row, err := rows.Row()
+		if err != nil {
+			t.Fatalf("Row failed: %v", err)
+		}
+		if row["name"] != fmt.Sprintf("event%d", count) {
+			t.Fatalf("Unexpected row %d: %v", count, row["name"])
+		}
+		count++
+		if count%2000 == 0 {
+			if used := heapAlloc(); used > baseline && used-baseline > peak {
+				peak = used - baseline
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+	if count != total {
+		t.Fatalf("Expected %d rows, got %d", total, count)
+	}
+	
+	// 结果集约10MB，流式读取时堆增长应远小于结果集大小
+	if limit := uint64(total * 512 / 4); peak > limit {
+		t.Fatalf("Expected heap growth below %d bytes, got %d", limit, peak)
+	}
+	
+	// 读取完毕后游标自动关闭，连接已释放
+	if rows.Next() {
+		t.Fatal("Expected Next to return false after exhaustion")
+	}
+	if _, err := rows.Row(); err == nil {
+		t.Fatal("Expected Row to fail on closed iterator")
+	}
+	if hookRows != int64(total) {
+		t.Fatalf("Expected after hook to receive %d rows, got %v", total, hookRows)
+	}
+	if _, err := session.SelectOne(ctx, "SELECT COUNT(*) AS count FROM events"); err != nil {
+		t.Fatalf("Expected connection to be released: %v", err)
+	}
+	
+	log.Println("TestSelectStream passed")
+}
+
+// TestSelectStreamEarlyClose 测试提前结束遍历时关闭游标并释放连接
+func TestSelectStreamEarlyClose(t *testing.T) {
+	db := setupStreamDB(t, 500)
+	session := NewSimpleSession(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	
+	rows, err := session.SelectStream(ctx, "SELECT id, name FROM events ORDER BY id")
+	if err != nil {
+		t.Fatalf("SelectStream failed: %v", err)
+	}
+	var event struct {
+		ID   int64
+		Name string
+	}
+	for i := 0; i < 10 && rows.Next(); i++ {
+		if err := rows.Scan(&event); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+	}
+	if event.ID != 10 || event.Name != "event9" {
+		t.Fatalf("Unexpected scanned event: %+v", event)
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("Expected repeated Close to succeed: %v", err)
+	}
+	if rows.Next() {
+		t.Fatal("Expected Next to return false after Close")
+	}
+	
+	// 单连接池下，未关闭的游标会使后续查询阻塞至超时
+	if _, err := session.SelectOne(ctx, "SELECT COUNT(*) AS count FROM events"); err != nil {
+		t.Fatalf("Expected connection to be released after Close: %v", err)
+	}
+	
+	// Each在回调返回错误时停止并关闭游标
+	stop := errors.New("stop")
+	rows, err = session.SelectStream(ctx, "SELECT id FROM events ORDER BY id")
+	if err != nil {
+		t.Fatalf("SelectStream failed: %v", err)
+	}
+	visited := 0
+	err = rows.Each(func(row map[string]interface{}) error {
+		visited++
+		if visited == 5 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || visited != 5 {
+		t.Fatalf("Expected Each to stop after 5 rows, got %d rows, err %v", visited, err)
+	}
+	if _, err := session.SelectOne(ctx, "SELECT COUNT(*) AS count FROM events"); err != nil {
+		t.Fatalf("Expected connection to be released after Each: %v", err)
+	}
+	
+	// 查询失败时不返回游标
+	if _, err := session.SelectStream(ctx, "SELECT * FROM missing_table"); err == nil {
+		t.Fatal("Expected SelectStream to fail for missing table")
+	}
+	
+	log.Println("TestSelectStreamEarlyClose passed")
+}
+
+// setupBatchInsertDB 创建批量插入测试表
+func setupBatchInsertDB() *gorm.DB {
+	db := setupTestDB()
+	db.Exec(`CREATE TABLE accounts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT,
+		email TEXT UNIQUE,
+		age INTEGER
+	)`)
+	return db
+}
+
+// accountRows 生成n行账户数据
+func accountRows(n int) [][]interface{} {
+	rows := make([][]interface{}, n)
+	for i := range rows {
+		rows[i] = []interface{}{fmt.Sprintf("user%d", i), fmt.Sprintf("user%d@example.com", i), 20 + i%50}
+	}
+	return rows
+}
+
+// TestBatchInsertRows 测试多行INSERT批量插入
+func TestBatchInsertRows(t *testing.T) {
+	db := setupBatchInsertDB()
+	statements := 0
+	session := NewSimpleSession(db).AddBeforeHook(func(ctx context.Context, sql string, args []interface{}) error {
+		statements++
+		return nil
+	})
+	ctx := context.Background()
+	
+	affected, err := session.BatchInsertRows(ctx, "accounts", []string{"name", "email", "age"}, accountRows(1000))
+	if err != nil {
+		t.Fatalf("BatchInsertRows failed: %v", err)
+	}
+	if affected != 1000 {
+		t.Fatalf("Expected 1000 affected rows, got %d", affected)
+	}
+	if statements != 1000/DefaultCreateBatchSize {
+		t.Fatalf("Expected %d statements, got %d", 1000/DefaultCreateBatchSize, statements)
+	}
+	
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM accounts").Scan(&count)
+	if count != 1000 {
+		t.Fatalf("Expected 1000 persisted rows, got %d", count)
+	}
+	
+	var last struct {
+		Name  string
+		Email string
+		Age   int
+	}
+	db.Raw("SELECT name, email, age FROM accounts WHERE email = ?", "user999@example.com").Scan(&last)
+	if last.Name != "user999" || last.Age != 20+999%50 {
+		t.Fatalf("Unexpected last row: %+v", last)
+	}
+	
+	log.Println("TestBatchInsertRows passed")
+}
+
+// TestBatchInsertRowsBatchSize 测试批大小受参数上限约束
+func TestBatchInsertRowsBatchSize(t *testing.T) {
+	rows := accountRows(1000)
+	
+	statements, err := buildBatchInsertStatements("accounts", []string{"name", "email", "age"}, rows, 300)
+	if err != nil {
+		t.Fatalf("buildBatchInsertStatements failed: %v", err)
+	}
+	if len(statements) != 4 || len(statements[3].Args) != 100*3 {
+		t.Fatalf("Expected 4 statements with 100 rows in the last, got %d", len(statements))
+	}
+	if !strings.HasPrefix(statements[0].SQL, "INSERT INTO accounts (name, email, age) VALUES (?, ?, ?), (?, ?, ?)") {
+		t.Fatalf("Unexpected SQL: %.80s", statements[0].SQL)
+	}
+	
+	session := NewSimpleSession(setupBatchInsertDB()).BatchSize(1000).(*defaultSession)
+	if size := session.insertBatchSize(3); size != maxInsertParams/3 {
+		t.Fatalf("Expected batch size capped at %d, got %d", maxInsertParams/3, size)
+	}
+	if size := session.BatchSize(0).(*defaultSession).insertBatchSize(3); size != DefaultCreateBatchSize {
+		t.Fatalf("Expected default batch size %d, got %d", DefaultCreateBatchSize, size)
+	}
+	
+	log.Println("TestBatchInsertRowsBatchSize passed")
+}
+
+// TestBatchInsertRowsRollback 测试任一批次失败时整体回滚
+func TestBatchInsertRowsRollback(t *testing.T) {
+	db := setupBatchInsertDB()
+	session := NewSimpleSession(db).BatchSize(100)
+	ctx := context.Background()
+	
+	// 第二批中的邮箱与第一批重复，违反唯一约束
+	rows := accountRows(200)
+	rows[150][1] = rows[0][1]
+	
+	affected, err := session.BatchInsertRows(ctx, "accounts", []string{"name", "email", "age"}, rows)
+	if err == nil {
+		t.Fatal("Expected BatchInsertRows to fail")
+	}
+	if affected != 0 {
+		t.Fatalf("Expected 0 affected rows after rollback, got %d", affected)
+	}
+	
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM accounts").Scan(&count)
+	if count != 0 {
+		t.Fatalf("Expected rollback to leave no rows, got %d", count)
+	}
+	
+	// 非法标识符与列数不匹配在执行前被拒绝
+	if _, err := session.BatchInsertRows(ctx, "accounts; DROP TABLE users", []string{"name"}, [][]interface{}{{"x"}}); err == nil {
+		t.Fatal("Expected invalid table name to be rejected")
+	}
+	if _, err := session.BatchInsertRows(ctx, "accounts", []string{"name", "email"}, [][]interface{}{{"x"}}); err == nil {
+		t.Fatal("Expected row length mismatch to be rejected")
+	}
+	
+	log.Println("TestBatchInsertRowsRollback passed")
+}
+
+// BenchmarkBatchInsertRows 多行INSERT插入1000行
+func BenchmarkBatchInsertRows(b *testing.B) {
+	rows := accountRows(1000)
+	ctx := context.Background()
+	
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		session := NewSimpleSession(setupBatchInsertDB())
+		b.StartTimer()
+		
+		if _, err := session.BatchInsertRows(ctx, "accounts", []string{"name", "email", "age"}, rows); err != nil {
+			b.Fatalf("BatchInsertRows failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkInsertLoop 逐行INSERT插入1000行，作为BatchInsertRows的对照
+func BenchmarkInsertLoop(b *testing.B) {
+	rows := accountRows(1000)
+	ctx := context.Background()
+	
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		session := NewSimpleSession(setupBatchInsertDB())
+		b.StartTimer()
+		
+		for _, row := range rows {
+			if _, err := session.Insert(ctx, "INSERT INTO accounts (name, email, age) VALUES (?, ?, ?)", row...); err != nil {
+				b.Fatalf("Insert failed: %v", err)
+			}
+		}
+	}
+}
+
 // TestMain 测试入口
 func TestMain(m *testing.M) {
 	log.Println("Starting MyBatis simplified version tests...")
@@ -0,0 +1,183 @@
+package mybatis
+
+import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RowIterator 逐行读取查询结果的游标，用于不宜一次性加载到内存的大结果集
+// 读取完毕、出错或调用Close后底层连接即被释放；提前结束遍历时必须调用Close
+type RowIterator interface {
+	// Next 前进到下一行，没有更多行或出错时返回false并自动关闭
+	Next() bool
+	// Row 以列名到值的映射返回当前行
+	Row() (map[string]interface{}, error)
+	// Scan 将当前行扫描到结构体指针，列名按gorm命名规则映射到字段
+	Scan(dest interface{}) error
+	// Each 对剩余每一行调用fn，fn返回错误时停止，返回前总会关闭游标
+	Each(fn func(row map[string]interface{}) error) error
+	// Err 遍历过程中的错误
+	Err() error
+	// Close 关闭游标并释放连接，可重复调用
+	Close() error
+}
+
+// SelectStream 执行查询并返回逐行读取的游标，结果不会整体缓冲到内存，也不经过请求级缓存
+// 执行后钩子在游标关闭时调用，result为已读取的行数
+func (s *defaultSession) SelectStream(ctx context.Context, sql string, args ...interface{}) (RowIterator, error) {
+	startTime := time.Now()
+
+	// 执行前钩子
+	for _, hook := range s.beforeHooks {
+		if err := hook(ctx, sql, args); err != nil {
+			return nil, fmt.Errorf("before hook error: %w", err)
+		}
+	}
+
+	stream := &rowStream{session: s, ctx: ctx, startTime: startTime}
+
+	if s.config.DryRun {
+		// DryRun模式：只打印SQL，返回空游标
+		s.logSQL("[DryRun STREAM]", sql, args)
+		stream.Close()
+		return stream, nil
+	}
+
+	if s.config.Debug {
+		s.logSQL("[Debug STREAM]", sql, args)
+	}
+
+	db := s.db.WithContext(ctx)
+	rows, err := db.Raw(sql, args...).Rows()
+	if err != nil {
+		s.logError("Stream query failed", err)
+		stream.err = err
+		stream.Close()
+		return nil, err
+	}
+
+	stream.db = db
+	stream.rows = rows
+	return stream, nil
+}
+
+// rowStream 基于*sql.Rows的游标实现
+type rowStream struct {
+	session   *defaultSession
+	ctx       context.Context
+	db        *gorm.DB
+	rows      *dbsql.Rows
+	columns   []string
+	current   map[string]interface{}
+	count     int64
+	err       error
+	startTime time.Time
+	closeOnce sync.Once
+	closed    bool
+}
+
+// Next 前进到下一行
+func (r *rowStream) Next() bool {
+	if r.closed || r.rows == nil {
+		return false
+	}
+
+	r.current = nil
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			r.err = err
+		}
+		r.Close()
+		return false
+	}
+
+	r.count++
+	return true
+}
+
+// Row 以列名到值的映射返回当前行
+func (r *rowStream) Row() (map[string]interface{}, error) {
+	if r.current != nil {
+		return r.current, nil
+	}
+	if r.closed || r.rows == nil {
+		return nil, fmt.Errorf("mybatis: row iterator is closed")
+	}
+
+	if r.columns == nil {
+		columns, err := r.rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		r.columns = columns
+	}
+
+	values := make([]interface{}, len(r.columns))
+	pointers := make([]interface{}, len(r.columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := r.rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]interface{}, len(r.columns))
+	for i, column := range r.columns {
+		row[column] = values[i]
+	}
+	r.current = row
+	return row, nil
+}
+
+// Scan 将当前行扫描到结构体指针
+func (r *rowStream) Scan(dest interface{}) error {
+	if r.closed || r.rows == nil {
+		return fmt.Errorf("mybatis: row iterator is closed")
+	}
+	return r.db.ScanRows(r.rows, dest)
+}
+
+// Each 对剩余每一行调用fn，返回前关闭游标
+func (r *rowStream) Each(fn func(row map[string]interface{}) error) error {
+	defer r.Close()
+
+	for r.Next() {
+		row, err := r.Row()
+		if err != nil {
+			r.err = err
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
+
+// Err 遍历过程中的错误
+func (r *rowStream) Err() error {
+	return r.err
+}
+
+// Close 关闭游标并调用执行后钩子
+func (r *rowStream) Close() error {
+	var closeErr error
+	r.closeOnce.Do(func() {
+		r.closed = true
+		r.current = nil
+		if r.rows != nil {
+			closeErr = r.rows.Close()
+		}
+
+		duration := time.Since(r.startTime)
+		for _, hook := range r.session.afterHooks {
+			hook(r.ctx, r.count, duration, r.err)
+		}
+	})
+	return closeErr
+}
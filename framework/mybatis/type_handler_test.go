@@ -0,0 +1,147 @@
+package mybatis
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zsy619/yyhertz/framework/mybatis/config"
+)
+
+// preferences 以JSON文本存储的用户偏好
+type preferences struct {
+	Theme string   `json:"theme"`
+	Tags  []string `json:"tags"`
+}
+
+type userProfile struct {
+	ID       int64
+	Name     string
+	Prefs    preferences            `gorm:"column:prefs_json"`
+	Metadata map[string]interface{} `gorm:"column:meta_json"`
+}
+
+// jsonTypeHandler 将JSON文本列解码为目标类型
+type jsonTypeHandler struct {
+	target reflect.Type
+}
+
+func (h jsonTypeHandler) SetParameter(stmt any, i int, parameter any, jdbcType string) error {
+	return nil
+}
+
+func (h jsonTypeHandler) GetResult(rs any, columnName string) (any, error) {
+	var data []byte
+	switch raw := rs.(map[string]interface{})[columnName].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		data = []byte(raw)
+	case []byte:
+		data = raw
+	default:
+		return nil, fmt.Errorf("unexpected JSON column type %T", raw)
+	}
+	value := reflect.New(h.target)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+func (h jsonTypeHandler) GetResultByIndex(rs any, columnIndex int) (any, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func newProfileMyBatis(t *testing.T, cfg *GormConfig) *MyBatisGorm {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "profiles.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Exec("CREATE TABLE profiles (id INTEGER PRIMARY KEY, name TEXT, prefs_json TEXT, meta_json TEXT)")
+	db.Exec(`INSERT INTO profiles VALUES (1, 'Alice', '{"theme":"dark","tags":["go","sql"]}', '{"plan":"pro","seats":3}'), (2, 'Bob', NULL, NULL)`)
+
+	mb := NewMyBatisGorm(db, cfg)
+	mb.RegisterMapper("ProfileMapper", map[string]*Statement{
+		"selectAll": {
+			ID:            "selectAll",
+			SQL:           "SELECT id, name, prefs_json, meta_json FROM profiles ORDER BY id",
+			StatementType: StatementTypeSelect,
+			ResultType:    reflect.TypeOf(&userProfile{}),
+		},
+	})
+	return mb
+}
+
+func assertProfiles(t *testing.T, mb *MyBatisGorm) {
+	t.Helper()
+	session := mb.OpenSession()
+	defer session.Close()
+
+	results, err := session.SelectList("ProfileMapper.selectAll", nil)
+	if err != nil {
+		t.Fatalf("SelectList failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 profiles, got %d", len(results))
+	}
+
+	alice, ok := results[0].(*userProfile)
+	if !ok {
+		t.Fatalf("Expected *userProfile, got %T", results[0])
+	}
+	if alice.ID != 1 || alice.Name != "Alice" {
+		t.Errorf("Expected Alice with id 1, got %+v", *alice)
+	}
+	expectedPrefs := preferences{Theme: "dark", Tags: []string{"go", "sql"}}
+	if !reflect.DeepEqual(alice.Prefs, expectedPrefs) {
+		t.Errorf("Expected prefs %+v, got %+v", expectedPrefs, alice.Prefs)
+	}
+	if alice.Metadata["plan"] != "pro" || alice.Metadata["seats"] != float64(3) {
+		t.Errorf("Expected decoded metadata, got %v", alice.Metadata)
+	}
+
+	bob := results[1].(*userProfile)
+	if !reflect.DeepEqual(bob.Prefs, preferences{}) || bob.Metadata != nil {
+		t.Errorf("Expected NULL JSON columns to yield zero values, got %+v", *bob)
+	}
+}
+
+func TestTypeHandler_DecodesJSONColumns(t *testing.T) {
+	mb := newProfileMyBatis(t, nil)
+	prefsType := reflect.TypeOf(preferences{})
+	metaType := reflect.TypeOf(map[string]interface{}{})
+	mb.RegisterTypeHandler(prefsType, jsonTypeHandler{target: prefsType})
+	mb.RegisterTypeHandler(metaType, jsonTypeHandler{target: metaType})
+
+	assertProfiles(t, mb)
+}
+
+func TestTypeHandler_SharedRegistryFromConfig(t *testing.T) {
+	registry := config.NewTypeHandlerRegistry()
+	prefsType := reflect.TypeOf(preferences{})
+	metaType := reflect.TypeOf(map[string]interface{}{})
+	registry.RegisterTypeHandler(prefsType, jsonTypeHandler{target: prefsType})
+	registry.RegisterTypeHandler(metaType, jsonTypeHandler{target: metaType})
+
+	cfg := DefaultGormConfig()
+	cfg.TypeHandlers = registry
+	assertProfiles(t, newProfileMyBatis(t, cfg))
+}
+
+func TestTypeHandler_WithoutHandlerFailsConversion(t *testing.T) {
+	mb := newProfileMyBatis(t, nil)
+	session := mb.OpenSession()
+	defer session.Close()
+
+	if _, err := session.SelectList("ProfileMapper.selectAll", nil); err == nil {
+		t.Fatal("Expected error mapping JSON text to struct without a type handler")
+	}
+}
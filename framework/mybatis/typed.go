@@ -0,0 +1,58 @@
+package mybatis
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SelectOneTyped 执行查询语句并将结果转换为T类型，无记录时返回nil，多条记录时返回错误
+func SelectOneTyped[T any](session SqlSession, statement string, parameter interface{}) (*T, error) {
+	results, err := SelectListTyped[T](session, statement, parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	if len(results) > 1 {
+		return nil, fmt.Errorf("expected one result but got %d", len(results))
+	}
+
+	return results[0], nil
+}
+
+// SelectListTyped 执行查询语句并将结果转换为T类型列表
+// 语句配置了 ResultMap 或 ResultType 时直接使用映射结果，否则按列名映射到T的字段（应用已注册的类型处理器）
+func SelectListTyped[T any](session SqlSession, statement string, parameter interface{}) ([]*T, error) {
+	results, err := session.SelectList(statement, parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	mb := &MyBatisGorm{}
+	if defaultSession, ok := session.(*DefaultSqlSession); ok && defaultSession.mybatis != nil {
+		mb = defaultSession.mybatis
+	}
+	resultMap := &ResultMap{ID: statement, Type: reflect.TypeOf((*T)(nil))}
+
+	typed := make([]*T, 0, len(results))
+	for _, result := range results {
+		switch value := result.(type) {
+		case *T:
+			typed = append(typed, value)
+		case T:
+			typed = append(typed, &value)
+		case map[string]interface{}:
+			mapped, err := mb.mapResult(value, resultMap)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map result for %s: %w", statement, err)
+			}
+			typed = append(typed, mapped.(*T))
+		default:
+			return nil, fmt.Errorf("cannot convert result %T to %s", result, resultMap.Type.Elem())
+		}
+	}
+	return typed, nil
+}
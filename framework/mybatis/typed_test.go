@@ -0,0 +1,115 @@
+package mybatis
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTypedMyBatis(t *testing.T, cfg *GormConfig) *MyBatisGorm {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "users.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT, create_at DATETIME)")
+	db.Exec("INSERT INTO users VALUES (1, 'Alice', 'alice@example.com', '2024-01-02 03:04:05'), (2, 'Bob', 'bob@example.com', '2024-02-03 04:05:06')")
+
+	mb := NewMyBatisGorm(db, cfg)
+	mb.RegisterMapper("UserMapper", map[string]*Statement{
+		"selectAll": {
+			ID:            "selectAll",
+			SQL:           "SELECT id, name, email, create_at FROM users ORDER BY id",
+			StatementType: StatementTypeSelect,
+		},
+		"selectByID": {
+			ID:            "selectByID",
+			SQL:           "SELECT id, name, email, create_at FROM users WHERE id = ?",
+			StatementType: StatementTypeSelect,
+		},
+		"selectTyped": {
+			ID:            "selectTyped",
+			SQL:           "SELECT id, name, email, create_at FROM users ORDER BY id",
+			StatementType: StatementTypeSelect,
+			ResultType:    reflect.TypeOf(User{}),
+		},
+	})
+	return mb
+}
+
+func TestSelectListTyped_ScansIntoStruct(t *testing.T) {
+	session := newTypedMyBatis(t, nil).OpenSession()
+	defer session.Close()
+
+	users, err := SelectListTyped[User](session, "UserMapper.selectAll", nil)
+	if err != nil {
+		t.Fatalf("SelectListTyped failed: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	if users[0].ID != 1 || users[0].Name != "Alice" || users[0].Email != "alice@example.com" {
+		t.Errorf("Unexpected first user: %+v", *users[0])
+	}
+	if expected := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !users[0].CreateAt.Equal(expected) {
+		t.Errorf("Expected create_at %v, got %v", expected, users[0].CreateAt)
+	}
+	if users[1].ID != 2 || users[1].Name != "Bob" {
+		t.Errorf("Unexpected second user: %+v", *users[1])
+	}
+}
+
+func TestSelectListTyped_UsesStatementResultType(t *testing.T) {
+	session := newTypedMyBatis(t, nil).OpenSession()
+	defer session.Close()
+
+	users, err := SelectListTyped[User](session, "UserMapper.selectTyped", nil)
+	if err != nil {
+		t.Fatalf("SelectListTyped failed: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "Alice" || users[1].Email != "bob@example.com" {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+}
+
+func TestSelectListTyped_CamelCaseKeys(t *testing.T) {
+	cfg := DefaultGormConfig()
+	cfg.MapUnderscoreToCamelCase = true
+	session := newTypedMyBatis(t, cfg).OpenSession()
+	defer session.Close()
+
+	users, err := SelectListTyped[User](session, "UserMapper.selectAll", nil)
+	if err != nil {
+		t.Fatalf("SelectListTyped failed: %v", err)
+	}
+	if len(users) != 2 || users[0].CreateAt.IsZero() {
+		t.Errorf("Expected create_at mapped from camel case key, got %+v", users)
+	}
+}
+
+func TestSelectOneTyped(t *testing.T) {
+	session := newTypedMyBatis(t, nil).OpenSession()
+	defer session.Close()
+
+	user, err := SelectOneTyped[User](session, "UserMapper.selectByID", 2)
+	if err != nil {
+		t.Fatalf("SelectOneTyped failed: %v", err)
+	}
+	if user == nil || user.ID != 2 || user.Name != "Bob" || user.Email != "bob@example.com" {
+		t.Errorf("Unexpected user: %+v", user)
+	}
+
+	missing, err := SelectOneTyped[User](session, "UserMapper.selectByID", 99)
+	if err != nil || missing != nil {
+		t.Errorf("Expected nil user without error, got %+v, %v", missing, err)
+	}
+
+	if _, err := SelectOneTyped[User](session, "UserMapper.selectAll", nil); err == nil {
+		t.Error("Expected error for multiple rows")
+	}
+}
@@ -0,0 +1,188 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// ExplainPlanLogger 查询计划日志插件，用于开发模式
+// 在Find/First/Scan/Rows等查询执行前于同一连接（含事务）上执行EXPLAIN，并通过全局日志以INFO级别输出SQL与查询计划
+type ExplainPlanLogger struct {
+	enabled atomic.Bool
+}
+
+// NewExplainPlanLogger 创建查询计划日志插件
+func NewExplainPlanLogger() *ExplainPlanLogger {
+	l := &ExplainPlanLogger{}
+	l.enabled.Store(true)
+	return l
+}
+
+// NewExplainPlanLoggerFromConfig 根据数据库配置创建查询计划日志插件
+// development.enable 与 development.explain_plan 同时为true时启用
+func NewExplainPlanLoggerFromConfig(cfg *config.DatabaseConfig) *ExplainPlanLogger {
+	l := NewExplainPlanLogger()
+	l.SetEnabled(false)
+	l.Reload(cfg)
+	return l
+}
+
+// Reload 重新加载配置
+func (l *ExplainPlanLogger) Reload(cfg *config.DatabaseConfig) {
+	if cfg == nil {
+		return
+	}
+	l.SetEnabled(cfg.Development.Enable && cfg.Development.ExplainPlan)
+}
+
+// SetEnabled 启用或禁用查询计划日志
+func (l *ExplainPlanLogger) SetEnabled(enabled bool) {
+	l.enabled.Store(enabled)
+}
+
+// Enabled 是否启用
+func (l *ExplainPlanLogger) Enabled() bool {
+	return l.enabled.Load()
+}
+
+// Name 实现gorm.Plugin接口
+func (l *ExplainPlanLogger) Name() string {
+	return "yyhertz:explain_plan_logger"
+}
+
+// Initialize 实现gorm.Plugin接口，在query与row回调前注册
+// 查询执行前EXPLAIN，避免Row/Rows返回后游标仍占用连接
+func (l *ExplainPlanLogger) Initialize(db *gorm.DB) error {
+	for _, operation := range []string{"query", "row"} {
+		registerBefore, _ := dbCallbackRegistrars(db, operation)
+		if err := registerBefore(fmt.Sprintf("explain_plan:before_%s", operation), l.before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before 查询执行前输出查询计划
+func (l *ExplainPlanLogger) before(tx *gorm.DB) {
+	if !l.enabled.Load() || tx.Error != nil || tx.DryRun || tx.Statement.ConnPool == nil {
+		return
+	}
+
+	// 链式查询的SQL在gorm:query/gorm:row中构建，提前构建后这两个回调不会重复构建
+	callbacks.BuildQuerySQL(tx)
+	if tx.Error != nil {
+		return
+	}
+
+	sql := strings.TrimSpace(tx.Statement.SQL.String())
+	if !isExplainableQuery(sql) {
+		return
+	}
+
+	prefix, ok := explainPrefix(tx.Dialector.Name())
+	if !ok {
+		return
+	}
+
+	plan, err := l.explain(tx, prefix+sql)
+	fields := map[string]any{
+		"sql":  sql,
+		"args": tx.Statement.Vars,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		config.WithFields(fields).Warn("Query plan unavailable")
+		return
+	}
+	fields["plan"] = plan
+	config.WithFields(fields).Info("Query plan")
+}
+
+// explain 在当前连接（含事务）上执行EXPLAIN，绕过GORM回调避免递归
+func (l *ExplainPlanLogger) explain(tx *gorm.DB, sql string) (string, error) {
+	rows, err := tx.Statement.ConnPool.QueryContext(tx.Statement.Context, sql, tx.Statement.Vars...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	detail := -1
+	for i, column := range columns {
+		if strings.EqualFold(column, "detail") {
+			detail = i
+		}
+	}
+
+	lines := make([]string, 0)
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return "", err
+		}
+
+		switch {
+		case detail >= 0:
+			// SQLite: id | parent | notused | detail
+			lines = append(lines, planValue(values[detail]))
+		case len(columns) == 1:
+			// PostgreSQL: QUERY PLAN
+			lines = append(lines, planValue(values[0]))
+		default:
+			// MySQL: 每行输出 列=值
+			pairs := make([]string, 0, len(columns))
+			for i, column := range columns {
+				if values[i] != nil {
+					pairs = append(pairs, column+"="+planValue(values[i]))
+				}
+			}
+			lines = append(lines, strings.Join(pairs, " "))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// explainPrefix 按数据库方言返回EXPLAIN前缀，不支持的方言返回false
+func explainPrefix(dialect string) (string, bool) {
+	switch dialect {
+	case "sqlite", "sqlite3":
+		return "EXPLAIN QUERY PLAN ", true
+	case "mysql", "postgres":
+		return "EXPLAIN ", true
+	default:
+		return "", false
+	}
+}
+
+// isExplainableQuery 是否为可EXPLAIN的SELECT查询
+func isExplainableQuery(sql string) bool {
+	if sql == "" {
+		return false
+	}
+	keyword := strings.ToUpper(strings.Fields(sql)[0])
+	return keyword == "SELECT" || keyword == "WITH"
+}
+
+// planValue 将查询计划列值转换为字符串
+func planValue(value any) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,115 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+func explainPlanEntries(hook *logrustest.Hook) []*logrus.Entry {
+	var entries []*logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Query plan" || entry.Message == "Query plan unavailable" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestExplainPlanLogger_LogsPlanForSelect(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	db := openMetricsTestDB(t)
+	require.NoError(t, db.Use(NewExplainPlanLogger()))
+
+	require.NoError(t, db.Create(&metricsUser{Name: "alice"}).Error)
+	assert.Empty(t, explainPlanEntries(hook), "insert should not be explained")
+
+	var users []metricsUser
+	require.NoError(t, db.Where("name = ?", "alice").Find(&users).Error)
+	require.Len(t, users, 1)
+
+	entries := explainPlanEntries(hook)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Query plan", entries[0].Message)
+	assert.Equal(t, logrus.InfoLevel, entries[0].Level)
+	assert.Contains(t, entries[0].Data["sql"], "SELECT * FROM `metrics_users`")
+	assert.Equal(t, []interface{}{"alice"}, entries[0].Data["args"])
+	assert.Contains(t, entries[0].Data["plan"], "SCAN")
+
+	// 主键查询走索引
+	hook.Reset()
+	var user metricsUser
+	require.NoError(t, db.First(&user, users[0].ID).Error)
+	entries = explainPlanEntries(hook)
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Data["plan"], "SEARCH")
+
+	// 在事务中同样可用
+	hook.Reset()
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Raw("SELECT name FROM metrics_users WHERE id = ?", user.ID).Scan(&user.Name).Error
+	}))
+	assert.Len(t, explainPlanEntries(hook), 1)
+
+	// Rows在单连接池下不会因EXPLAIN阻塞
+	hook.Reset()
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	rows, err := db.Model(&metricsUser{}).Where("id > ?", 0).Rows()
+	require.NoError(t, err)
+	for rows.Next() {
+		require.NoError(t, db.ScanRows(rows, &user))
+	}
+	require.NoError(t, rows.Close())
+	entries = explainPlanEntries(hook)
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Data["sql"], "WHERE id > ?")
+}
+
+func TestExplainPlanLogger_Disabled(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	db := openMetricsTestDB(t)
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Development.ExplainPlan = true
+	plugin := NewExplainPlanLoggerFromConfig(cfg)
+	assert.False(t, plugin.Enabled(), "requires development.enable")
+	require.NoError(t, db.Use(plugin))
+
+	var users []metricsUser
+	require.NoError(t, db.Find(&users).Error)
+	assert.Empty(t, explainPlanEntries(hook))
+
+	cfg.Development.Enable = true
+	plugin.Reload(cfg)
+	assert.True(t, plugin.Enabled())
+	require.NoError(t, db.Find(&users).Error)
+	assert.Len(t, explainPlanEntries(hook), 1)
+}
+
+func TestExplainPrefix(t *testing.T) {
+	prefix, ok := explainPrefix("sqlite")
+	assert.True(t, ok)
+	assert.Equal(t, "EXPLAIN QUERY PLAN ", prefix)
+
+	for _, dialect := range []string{"mysql", "postgres"} {
+		prefix, ok = explainPrefix(dialect)
+		assert.True(t, ok)
+		assert.Equal(t, "EXPLAIN ", prefix)
+	}
+
+	_, ok = explainPrefix("sqlserver")
+	assert.False(t, ok)
+
+	assert.True(t, isExplainableQuery("select * from users"))
+	assert.True(t, isExplainableQuery("WITH t AS (SELECT 1) SELECT * FROM t"))
+	assert.False(t, isExplainableQuery("UPDATE users SET name = 'x'"))
+	assert.False(t, isExplainableQuery(""))
+}
@@ -90,10 +90,12 @@ func GetDefaultORM() *ORM {
 
 		// 从配置管理器获取数据库配置
 		dbConfig := DefaultDatabaseConfig()
+		var appConfig *config.DatabaseConfig
 
 		// 尝试从全局配置获取数据库配置
 		if configManager := config.GetDatabaseConfigManager(); configManager != nil {
-			if appConfig, err := configManager.GetConfig(); err == nil {
+			if loaded, err := configManager.GetConfig(); err == nil {
+				appConfig = loaded
 				// 映射配置字段
 				if appConfig.Primary.Driver != "" {
 					dbConfig.Type = appConfig.Primary.Driver
@@ -133,6 +135,11 @@ func GetDefaultORM() *ORM {
 		if err != nil {
 			config.Fatalf("Failed to initialize default ORM: %v", err)
 		}
+		if appConfig != nil {
+			if err := ApplyPoolConfig(defaultORM.db, appConfig); err != nil {
+				config.Warnf("Failed to apply database pool config: %v", err)
+			}
+		}
 	})
 	return defaultORM
 }
@@ -180,6 +180,16 @@ func (mc *MetricsCollector) collectMetrics() {
 	}
 }
 
+// SetSlowQueryThreshold 设置慢查询阈值，需在记录查询前调用
+func (mc *MetricsCollector) SetSlowQueryThreshold(threshold time.Duration) {
+	mc.slowQueryThreshold = threshold
+}
+
+// SlowQueryThreshold 获取慢查询阈值
+func (mc *MetricsCollector) SlowQueryThreshold() time.Duration {
+	return mc.slowQueryThreshold
+}
+
 // RecordConnection 记录连接
 func (mc *MetricsCollector) RecordConnection(nodeID, nodeType string) {
 	atomic.AddInt64(&mc.totalConnections, 1)
@@ -18,11 +18,12 @@ type Migration interface {
 	GetDescription() string // 获取描述
 }
 
-// MigrationInfo 迁移信息
+// MigrationInfo 迁移信息，同一次Migrate执行的迁移属于同一批次
 type MigrationInfo struct {
 	ID          uint      `gorm:"primarykey" json:"id"`
 	Version     string    `gorm:"uniqueIndex;size:255" json:"version"`
 	Description string    `gorm:"size:500" json:"description"`
+	Batch       int       `gorm:"index;default:0" json:"batch"`
 	ExecutedAt  time.Time `json:"executed_at"`
 	CreatedAt   time.Time `json:"created_at"`
 }
@@ -121,6 +122,7 @@ type SQLMigration struct {
 	*BaseMigration
 	upSQL   string
 	downSQL string
+	split   bool // 按分号拆分为多条语句逐条执行（SQL文件迁移）
 }
 
 // NewSQLMigration 创建SQL迁移
@@ -140,7 +142,7 @@ func (sm *SQLMigration) Up(db *gorm.DB) error {
 		return fmt.Errorf("no up SQL provided for migration %s", sm.version)
 	}
 
-	if err := db.Exec(sm.upSQL).Error; err != nil {
+	if err := sm.exec(db, sm.upSQL); err != nil {
 		return fmt.Errorf("failed to execute up SQL for migration %s: %w", sm.version, err)
 	}
 
@@ -156,7 +158,7 @@ func (sm *SQLMigration) Down(db *gorm.DB) error {
 		return fmt.Errorf("no down SQL provided for migration %s", sm.version)
 	}
 
-	if err := db.Exec(sm.downSQL).Error; err != nil {
+	if err := sm.exec(db, sm.downSQL); err != nil {
 		return fmt.Errorf("failed to execute down SQL for migration %s: %w", sm.version, err)
 	}
 
@@ -164,23 +166,75 @@ func (sm *SQLMigration) Down(db *gorm.DB) error {
 	return nil
 }
 
+// exec 执行SQL，SQL文件迁移逐条执行拆分后的语句
+func (sm *SQLMigration) exec(db *gorm.DB, sql string) error {
+	if !sm.split {
+		return db.Exec(sql).Error
+	}
+	for _, statement := range splitSQLStatements(sql) {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // MigrationManager 迁移管理器
 type MigrationManager struct {
 	db         *gorm.DB
+	table      string
 	migrations []Migration
 }
 
-// NewMigrationManager 创建迁移管理器
+// NewMigrationManager 创建迁移管理器，迁移记录保存在 migrations 表
 func NewMigrationManager(db *gorm.DB) *MigrationManager {
 	if db == nil {
 		db = GetDefaultORM().DB()
 	}
 	return &MigrationManager{
 		db:         db,
+		table:      MigrationInfo{}.TableName(),
 		migrations: make([]Migration, 0),
 	}
 }
 
+// NewMigrationManagerFromConfig 根据数据库配置创建迁移管理器，使用 migration.table_name 作为迁移记录表
+// 并加载 migration.path 目录中的SQL文件迁移；cfg为nil时等同于 NewMigrationManager
+func NewMigrationManagerFromConfig(db *gorm.DB, cfg *config.DatabaseConfig) (*MigrationManager, error) {
+	mm := NewMigrationManager(db)
+	if cfg == nil {
+		return mm, nil
+	}
+	if cfg.Migration.TableName != "" {
+		mm.SetTableName(cfg.Migration.TableName)
+	}
+
+	path := cfg.Migration.Path
+	if path == "" {
+		path = DefaultMigrationPath
+	}
+	if err := mm.LoadDir(path); err != nil {
+		return nil, err
+	}
+	return mm, nil
+}
+
+// SetTableName 设置迁移记录表名
+func (mm *MigrationManager) SetTableName(table string) *MigrationManager {
+	mm.table = table
+	return mm
+}
+
+// TableName 迁移记录表名
+func (mm *MigrationManager) TableName() string {
+	return mm.table
+}
+
+// records 迁移记录表查询
+func (mm *MigrationManager) records(db *gorm.DB) *gorm.DB {
+	return db.Table(mm.table)
+}
+
 // GetDefaultMigrationManager 获取默认迁移管理器
 func GetDefaultMigrationManager() *MigrationManager {
 	return NewMigrationManager(GetDefaultORM().DB())
@@ -207,8 +261,8 @@ func (mm *MigrationManager) Initialize() error {
 	config.Info("Initializing migration system...")
 
 	// 创建迁移信息表
-	if err := mm.db.AutoMigrate(&MigrationInfo{}); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+	if err := mm.records(mm.db).AutoMigrate(&MigrationInfo{}); err != nil {
+		return fmt.Errorf("failed to create migrations table %s: %w", mm.table, err)
 	}
 
 	config.Info("Migration system initialized successfully")
@@ -218,7 +272,7 @@ func (mm *MigrationManager) Initialize() error {
 // GetExecutedMigrations 获取已执行的迁移
 func (mm *MigrationManager) GetExecutedMigrations() (map[string]*MigrationInfo, error) {
 	var migrations []*MigrationInfo
-	if err := mm.db.Find(&migrations).Error; err != nil {
+	if err := mm.records(mm.db).Find(&migrations).Error; err != nil {
 		return nil, fmt.Errorf("failed to get executed migrations: %w", err)
 	}
 
@@ -230,72 +284,86 @@ func (mm *MigrationManager) GetExecutedMigrations() (map[string]*MigrationInfo,
 	return result, nil
 }
 
-// Migrate 执行所有未执行的迁移
+// Migrate 在一个事务中按注册顺序执行所有未执行的迁移，并记为新的批次
+// 注意：MySQL等数据库的DDL会隐式提交，失败时无法完整回滚
 func (mm *MigrationManager) Migrate() error {
-	if err := mm.Initialize(); err != nil {
-		return err
-	}
-
-	config.Info("Starting database migration...")
+	_, err := mm.MigrateBatch()
+	return err
+}
 
-	executed, err := mm.GetExecutedMigrations()
+// MigrateBatch 执行所有未执行的迁移，返回执行的数量
+func (mm *MigrationManager) MigrateBatch() (int, error) {
+	pending, err := mm.Pending()
 	if err != nil {
-		return err
-	}
-
-	pendingCount := 0
-	for _, migration := range mm.migrations {
-		if _, exists := executed[migration.GetVersion()]; !exists {
-			pendingCount++
-		}
+		return 0, err
 	}
 
-	if pendingCount == 0 {
+	if len(pending) == 0 {
 		config.Info("No pending migrations found")
-		return nil
+		return 0, nil
 	}
 
-	config.Infof("Found %d pending migrations", pendingCount)
-
-	for _, migration := range mm.migrations {
-		version := migration.GetVersion()
+	config.Infof("Found %d pending migrations", len(pending))
 
-		// 检查是否已执行
-		if _, exists := executed[version]; exists {
-			config.Debugf("Migration %s already executed, skipping", version)
-			continue
+	err = mm.db.Transaction(func(tx *gorm.DB) error {
+		batch, err := mm.lastBatch(tx)
+		if err != nil {
+			return err
 		}
+		batch++
 
-		config.Infof("Executing migration %s: %s", version, migration.GetDescription())
+		for _, migration := range pending {
+			version := migration.GetVersion()
+			config.Infof("Executing migration %s: %s", version, migration.GetDescription())
 
-		// 在事务中执行迁移
-		err := mm.db.Transaction(func(tx *gorm.DB) error {
 			// 执行迁移
 			if err := migration.Up(tx); err != nil {
-				return err
+				return fmt.Errorf("migration %s failed: %w", version, err)
 			}
 
 			// 记录迁移信息
 			migrationInfo := &MigrationInfo{
 				Version:     version,
 				Description: migration.GetDescription(),
+				Batch:       batch,
 				ExecutedAt:  time.Now(),
 				CreatedAt:   time.Now(),
 			}
+			if err := mm.records(tx).Create(migrationInfo).Error; err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", version, err)
+			}
 
-			return tx.Create(migrationInfo).Error
-		})
-
-		if err != nil {
-			config.Errorf("Migration %s failed: %v", version, err)
-			return fmt.Errorf("migration %s failed: %w", version, err)
+			config.Infof("Migration %s completed successfully", version)
 		}
-
-		config.Infof("Migration %s completed successfully", version)
+		return nil
+	})
+	if err != nil {
+		config.Errorf("Migration failed, transaction rolled back: %v", err)
+		return 0, err
 	}
 
 	config.Info("All migrations completed successfully")
-	return nil
+	return len(pending), nil
+}
+
+// Pending 获取尚未执行的迁移，按注册顺序
+func (mm *MigrationManager) Pending() ([]Migration, error) {
+	if err := mm.Initialize(); err != nil {
+		return nil, err
+	}
+
+	executed, err := mm.GetExecutedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, migration := range mm.migrations {
+		if _, exists := executed[migration.GetVersion()]; !exists {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
 }
 
 // Rollback 回滚指定数量的迁移
@@ -308,7 +376,7 @@ func (mm *MigrationManager) Rollback(steps int) error {
 
 	// 获取已执行的迁移，按执行时间倒序
 	var executedMigrations []*MigrationInfo
-	if err := mm.db.Order("executed_at DESC").Limit(steps).Find(&executedMigrations).Error; err != nil {
+	if err := mm.records(mm.db).Order("executed_at DESC, id DESC").Limit(steps).Find(&executedMigrations).Error; err != nil {
 		return fmt.Errorf("failed to get executed migrations for rollback: %w", err)
 	}
 
@@ -342,7 +410,7 @@ func (mm *MigrationManager) Rollback(steps int) error {
 			}
 
 			// 删除迁移记录
-			return tx.Delete(&MigrationInfo{}, "version = ?", version).Error
+			return mm.records(tx).Delete(&MigrationInfo{}, "version = ?", version).Error
 		})
 
 		if err != nil {
@@ -367,7 +435,7 @@ func (mm *MigrationManager) RollbackTo(targetVersion string) error {
 
 	// 获取目标版本之后的所有迁移
 	var migrationsToRollback []*MigrationInfo
-	if err := mm.db.Where("version > ?", targetVersion).Order("executed_at DESC").Find(&migrationsToRollback).Error; err != nil {
+	if err := mm.records(mm.db).Where("version > ?", targetVersion).Order("executed_at DESC, id DESC").Find(&migrationsToRollback).Error; err != nil {
 		return fmt.Errorf("failed to get migrations to rollback: %w", err)
 	}
 
@@ -401,7 +469,7 @@ func (mm *MigrationManager) RollbackTo(targetVersion string) error {
 			}
 
 			// 删除迁移记录
-			return tx.Delete(&MigrationInfo{}, "version = ?", version).Error
+			return mm.records(tx).Delete(&MigrationInfo{}, "version = ?", version).Error
 		})
 
 		if err != nil {
@@ -416,6 +484,70 @@ func (mm *MigrationManager) RollbackTo(targetVersion string) error {
 	return nil
 }
 
+// RollbackBatch 在一个事务中按执行顺序倒序回滚最后一个批次的迁移，返回回滚的数量
+func (mm *MigrationManager) RollbackBatch() (int, error) {
+	if err := mm.Initialize(); err != nil {
+		return 0, err
+	}
+
+	batch, err := mm.lastBatch(mm.db)
+	if err != nil {
+		return 0, err
+	}
+	if batch == 0 {
+		config.Info("No migrations to rollback")
+		return 0, nil
+	}
+
+	var records []*MigrationInfo
+	if err := mm.records(mm.db).Where("batch = ?", batch).Order("id DESC").Find(&records).Error; err != nil {
+		return 0, fmt.Errorf("failed to get migrations of batch %d: %w", batch, err)
+	}
+
+	// 创建迁移映射
+	migrationMap := make(map[string]Migration)
+	for _, migration := range mm.migrations {
+		migrationMap[migration.GetVersion()] = migration
+	}
+
+	config.Infof("Rolling back batch %d (%d migrations)", batch, len(records))
+
+	err = mm.db.Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			migration, exists := migrationMap[record.Version]
+			if !exists {
+				return fmt.Errorf("migration %s not found in registered migrations", record.Version)
+			}
+
+			config.Infof("Rolling back migration %s: %s", record.Version, migration.GetDescription())
+
+			if err := migration.Down(tx); err != nil {
+				return fmt.Errorf("rollback of migration %s failed: %w", record.Version, err)
+			}
+			if err := mm.records(tx).Delete(&MigrationInfo{}, "version = ?", record.Version).Error; err != nil {
+				return fmt.Errorf("failed to delete migration record %s: %w", record.Version, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		config.Errorf("Rollback failed, transaction rolled back: %v", err)
+		return 0, err
+	}
+
+	config.Infof("Rolled back %d migrations successfully", len(records))
+	return len(records), nil
+}
+
+// lastBatch 获取最大批次号，没有记录时返回0
+func (mm *MigrationManager) lastBatch(db *gorm.DB) (int, error) {
+	var batch int
+	if err := mm.records(db).Select("COALESCE(MAX(batch), 0)").Scan(&batch).Error; err != nil {
+		return 0, fmt.Errorf("failed to get last migration batch: %w", err)
+	}
+	return batch, nil
+}
+
 // Status 获取迁移状态
 func (mm *MigrationManager) Status() (*MigrationStatus, error) {
 	if err := mm.Initialize(); err != nil {
@@ -0,0 +1,181 @@
+package orm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// DefaultMigrationPath 默认迁移文件目录
+const DefaultMigrationPath = "./migrations"
+
+// migrationFilePattern 迁移文件名格式：<版本号>_<名称>.up.sql / <版本号>_<名称>.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_\-]+)\.(up|down)\.sql$`)
+
+// sqlMigrationFile 迁移目录中的一个版本
+type sqlMigrationFile struct {
+	number   uint64
+	name     string
+	upPath   string
+	downPath string
+}
+
+// LoadDir 读取目录中的SQL文件迁移，按版本号数值升序注册为SQLMigration
+// 版本号为文件名前缀的数值（如 2_create_users.up.sql 的版本号为 2），down文件可省略
+func (mm *MigrationManager) LoadDir(path string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to read migration path %s: %w", path, err)
+	}
+
+	files := make(map[uint64]*sqlMigrationFile)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		number, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		file, exists := files[number]
+		if !exists {
+			file = &sqlMigrationFile{number: number, name: matches[2]}
+			files[number] = file
+		} else if file.name != matches[2] {
+			return fmt.Errorf("duplicate migration version %d: %s and %s", number, file.name, matches[2])
+		}
+
+		fullPath := filepath.Join(path, entry.Name())
+		if matches[3] == "up" {
+			file.upPath = fullPath
+		} else {
+			file.downPath = fullPath
+		}
+	}
+
+	sorted := make([]*sqlMigrationFile, 0, len(files))
+	for _, file := range files {
+		if file.upPath == "" {
+			return fmt.Errorf("migration %d_%s has no up file", file.number, file.name)
+		}
+		sorted = append(sorted, file)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].number < sorted[j].number
+	})
+
+	for _, file := range sorted {
+		migration, err := file.load()
+		if err != nil {
+			return err
+		}
+		mm.Add(migration)
+	}
+	return nil
+}
+
+// load 读取SQL文件内容并创建SQLMigration
+func (f *sqlMigrationFile) load() (*SQLMigration, error) {
+	upSQL, err := os.ReadFile(f.upPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration file %s: %w", f.upPath, err)
+	}
+
+	var downSQL []byte
+	if f.downPath != "" {
+		if downSQL, err = os.ReadFile(f.downPath); err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", f.downPath, err)
+		}
+	}
+
+	migration := NewSQLMigration(strconv.FormatUint(f.number, 10), f.name, string(upSQL), string(downSQL))
+	migration.split = true
+	return migration, nil
+}
+
+// splitSQLStatements 按分号拆分SQL语句，忽略引号和注释中的分号
+// 不支持需要自定义分隔符的存储过程或触发器定义
+func splitSQLStatements(content string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	var quote byte
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			current.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			current.WriteByte(c)
+		case c == '-' && i+1 < len(content) && content[i+1] == '-':
+			// 单行注释
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			// 多行注释
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				i = len(content)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// ============= 便捷函数 =============
+
+// RunConfiguredMigrations 按全局数据库配置执行 migration.path 目录中的SQL文件迁移，未启用 migration.enable 时不执行
+func RunConfiguredMigrations(db *gorm.DB) error {
+	configManager := config.GetDatabaseConfigManager()
+	if configManager == nil {
+		return fmt.Errorf("database config manager is not available")
+	}
+	cfg, err := configManager.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database config: %w", err)
+	}
+	if !cfg.Migration.Enable {
+		return nil
+	}
+
+	mm, err := NewMigrationManagerFromConfig(db, cfg)
+	if err != nil {
+		return err
+	}
+	return mm.Migrate()
+}
@@ -0,0 +1,176 @@
+package orm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// writeMigration 在迁移目录中写入一个迁移文件
+func writeMigration(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+// setupMigrationManager 创建包含两个迁移的目录和独立的SQLite数据库
+func setupMigrationManager(t *testing.T) (*MigrationManager, *gorm.DB, string) {
+	dir := t.TempDir()
+	migrationDir := filepath.Join(dir, "migrations")
+	require.NoError(t, os.Mkdir(migrationDir, 0o755))
+
+	// 第二个迁移依赖第一个迁移创建的表，版本号按数值排序（2 < 10）
+	writeMigration(t, migrationDir, "2_create_users.up.sql", `
+		-- 用户表; 注释中的分号不拆分语句
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+		INSERT INTO users (name) VALUES ('admin;root');
+	`)
+	writeMigration(t, migrationDir, "2_create_users.down.sql", "DROP TABLE users;")
+	writeMigration(t, migrationDir, "10_add_user_email.up.sql", `
+		ALTER TABLE users ADD COLUMN email TEXT;
+		CREATE INDEX idx_users_email ON users (email);
+	`)
+	writeMigration(t, migrationDir, "10_add_user_email.down.sql", `
+		DROP INDEX idx_users_email;
+		ALTER TABLE users DROP COLUMN email;
+	`)
+	writeMigration(t, migrationDir, "README.md", "not a migration")
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "migrate.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Migration.Path = migrationDir
+	cfg.Migration.TableName = "app_migrations"
+	mm, err := NewMigrationManagerFromConfig(db, cfg)
+	require.NoError(t, err)
+	return mm, db, migrationDir
+}
+
+// reloadMigrationManager 重新加载迁移目录，模拟新增迁移文件后重启应用
+func reloadMigrationManager(t *testing.T, db *gorm.DB, migrationDir string) *MigrationManager {
+	mm := NewMigrationManager(db).SetTableName("app_migrations")
+	require.NoError(t, mm.LoadDir(migrationDir))
+	return mm
+}
+
+// appliedMigrations 按执行顺序获取迁移记录
+func appliedMigrations(t *testing.T, db *gorm.DB) []*MigrationInfo {
+	var records []*MigrationInfo
+	require.NoError(t, db.Table("app_migrations").Order("id").Find(&records).Error)
+	return records
+}
+
+func TestMigrationManager_LoadDirAppliesInOrder(t *testing.T) {
+	mm, db, _ := setupMigrationManager(t)
+	assert.Equal(t, "app_migrations", mm.TableName())
+
+	pending, err := mm.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "2", pending[0].GetVersion())
+	assert.Equal(t, "10", pending[1].GetVersion())
+
+	applied, err := mm.MigrateBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+
+	assert.True(t, db.Migrator().HasColumn("users", "email"))
+	var name string
+	require.NoError(t, db.Raw("SELECT name FROM users").Scan(&name).Error)
+	assert.Equal(t, "admin;root", name)
+
+	records := appliedMigrations(t, db)
+	require.Len(t, records, 2)
+	assert.Equal(t, "create_users", records[0].Description)
+	assert.Equal(t, "add_user_email", records[1].Description)
+	assert.Equal(t, 1, records[0].Batch)
+	assert.Equal(t, 1, records[1].Batch)
+	assert.False(t, db.Migrator().HasTable("migrations"), "records use the configured table")
+}
+
+func TestMigrationManager_RerunIsIdempotent(t *testing.T) {
+	mm, db, migrationDir := setupMigrationManager(t)
+
+	require.NoError(t, mm.Migrate())
+
+	applied, err := reloadMigrationManager(t, db, migrationDir).MigrateBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	var count int64
+	require.NoError(t, db.Table("app_migrations").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+	require.NoError(t, db.Table("users").Count(&count).Error)
+	assert.Equal(t, int64(1), count, "seed data inserted once")
+}
+
+func TestMigrationManager_RollbackBatch(t *testing.T) {
+	mm, db, migrationDir := setupMigrationManager(t)
+	require.NoError(t, mm.Migrate())
+
+	// 新增迁移作为第二批次
+	writeMigration(t, migrationDir, "11_create_posts.up.sql", "CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT);")
+	writeMigration(t, migrationDir, "11_create_posts.down.sql", "DROP TABLE posts;")
+	mm = reloadMigrationManager(t, db, migrationDir)
+	applied, err := mm.MigrateBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, 2, appliedMigrations(t, db)[2].Batch)
+
+	rolledBack, err := mm.RollbackBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 1, rolledBack)
+	assert.False(t, db.Migrator().HasTable("posts"))
+	assert.True(t, db.Migrator().HasColumn("users", "email"), "earlier batch untouched")
+
+	rolledBack, err = mm.RollbackBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 2, rolledBack)
+	assert.False(t, db.Migrator().HasTable("users"))
+
+	rolledBack, err = mm.RollbackBatch()
+	require.NoError(t, err)
+	assert.Equal(t, 0, rolledBack)
+
+	pending, err := mm.Pending()
+	require.NoError(t, err)
+	assert.Len(t, pending, 3)
+}
+
+func TestMigrationManager_FailedMigrationRollsBackBatch(t *testing.T) {
+	_, db, migrationDir := setupMigrationManager(t)
+	writeMigration(t, migrationDir, "12_broken.up.sql", "CREATE TABLE tags (id INTEGER PRIMARY KEY); INSERT INTO missing_table VALUES (1);")
+
+	err := reloadMigrationManager(t, db, migrationDir).Migrate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration 12 failed")
+
+	assert.False(t, db.Migrator().HasTable("users"))
+	assert.False(t, db.Migrator().HasTable("tags"))
+	assert.Empty(t, appliedMigrations(t, db))
+}
+
+func TestMigrationManager_LoadDirErrors(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "defaults.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	assert.Error(t, NewMigrationManager(db).LoadDir(filepath.Join(t.TempDir(), "missing")))
+
+	dir := t.TempDir()
+	writeMigration(t, dir, "3_only_down.down.sql", "DROP TABLE users;")
+	assert.ErrorContains(t, NewMigrationManager(db).LoadDir(dir), "has no up file")
+
+	assert.Equal(t, "migrations", NewMigrationManager(db).TableName())
+}
@@ -74,8 +74,11 @@ func (r *BaseRepository[T]) Create(model *T) error {
 	return r.db.Create(model).Error
 }
 
-// Update 更新记录
+// Update 更新记录，声明了版本字段（`gorm:"version"`）的模型使用乐观锁更新
 func (r *BaseRepository[T]) Update(model *T) error {
+	if HasVersionField(r.db, model) {
+		return UpdateWithVersion(r.db, model)
+	}
 	return r.db.Save(model).Error
 }
 
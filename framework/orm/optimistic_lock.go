@@ -0,0 +1,86 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	frameworkErrors "github.com/zsy619/yyhertz/framework/errors"
+)
+
+// VersionTagSetting 乐观锁版本字段的gorm标签设置，如 Version int `gorm:"version"`
+const VersionTagSetting = "VERSION"
+
+// ErrOptimisticLock 乐观锁冲突：记录已被其他请求修改或已删除，可通过 errors.Is 判断
+// 同时匹配 errors.DataUpdateError
+var ErrOptimisticLock = fmt.Errorf("orm: optimistic lock conflict: %w", frameworkErrors.DataUpdateError)
+
+// UpdateWithVersion 按乐观锁更新实体的全部字段
+// 更新条件附加 version = 当前版本号，并将版本号加1；未更新任何记录时恢复实体的版本号并返回 ErrOptimisticLock
+func UpdateWithVersion(db *gorm.DB, model any) error {
+	versionField, err := versionFieldOf(db, model)
+	if err != nil {
+		return err
+	}
+	if versionField == nil {
+		return fmt.Errorf("orm: %T has no version field", model)
+	}
+
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("orm: optimistic lock requires a struct pointer, got %T", model)
+	}
+	for _, primaryField := range versionField.Schema.PrimaryFields {
+		if _, isZero := primaryField.ValueOf(db.Statement.Context, value.Elem()); isZero {
+			return fmt.Errorf("orm: optimistic lock requires primary key %s", primaryField.Name)
+		}
+	}
+
+	field := value.Elem().FieldByIndex(versionField.StructField.Index)
+	current := reflect.New(field.Type()).Elem()
+	current.Set(field)
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(field.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(field.Uint() + 1)
+	default:
+		return fmt.Errorf("orm: version field %s must be an integer, got %s", versionField.Name, field.Type())
+	}
+
+	result := db.Model(model).
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: versionField.DBName}, Value: current.Interface()}).
+		Select("*").
+		Updates(model)
+	if result.Error != nil || result.RowsAffected == 0 {
+		field.Set(current)
+		if result.Error != nil {
+			return result.Error
+		}
+		return ErrOptimisticLock
+	}
+	return nil
+}
+
+// HasVersionField 模型是否声明了乐观锁版本字段
+func HasVersionField(db *gorm.DB, model any) bool {
+	versionField, err := versionFieldOf(db, model)
+	return err == nil && versionField != nil
+}
+
+// versionFieldOf 查找模型中 `gorm:"version"` 标记的字段，未声明时返回nil
+func versionFieldOf(db *gorm.DB, model any) (*schema.Field, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if _, ok := field.TagSettings[VersionTagSetting]; ok {
+			return field, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+package orm
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	frameworkErrors "github.com/zsy619/yyhertz/framework/errors"
+)
+
+type versionedDoc struct {
+	ID      uint `gorm:"primarykey"`
+	Title   string
+	Version int `gorm:"version;not null;default:0"`
+}
+
+func openVersionTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "lock.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&versionedDoc{}, &poolUser{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestUpdateWithVersion_StaleUpdateFails(t *testing.T) {
+	db := openVersionTestDB(t)
+	require.NoError(t, db.Create(&versionedDoc{Title: "draft"}).Error)
+
+	// 两个请求读取到同一版本
+	var first, second versionedDoc
+	require.NoError(t, db.First(&first).Error)
+	require.NoError(t, db.First(&second).Error)
+
+	first.Title = "first edit"
+	require.NoError(t, UpdateWithVersion(db, &first))
+	assert.Equal(t, 1, first.Version)
+
+	second.Title = "second edit"
+	err := UpdateWithVersion(db, &second)
+	assert.ErrorIs(t, err, ErrOptimisticLock)
+	assert.True(t, errors.Is(err, frameworkErrors.DataUpdateError))
+	assert.Equal(t, 0, second.Version, "version restored after conflict")
+
+	var stored versionedDoc
+	require.NoError(t, db.First(&stored).Error)
+	assert.Equal(t, "first edit", stored.Title)
+	assert.Equal(t, 1, stored.Version)
+
+	// 重新读取后更新成功
+	require.NoError(t, db.First(&second).Error)
+	second.Title = "second edit"
+	require.NoError(t, UpdateWithVersion(db, &second))
+	require.NoError(t, db.First(&stored).Error)
+	assert.Equal(t, "second edit", stored.Title)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestBaseRepository_UpdateUsesOptimisticLock(t *testing.T) {
+	db := openVersionTestDB(t)
+	repo := NewBaseRepository[versionedDoc](db)
+	doc := &versionedDoc{Title: "draft"}
+	require.NoError(t, repo.Create(doc))
+
+	stale := *doc
+	doc.Title = "published"
+	require.NoError(t, repo.Update(doc))
+	assert.Equal(t, 1, doc.Version)
+
+	stale.Title = "stale"
+	assert.ErrorIs(t, repo.Update(&stale), ErrOptimisticLock)
+
+	// 已删除的记录同样视为冲突
+	require.NoError(t, db.Delete(&versionedDoc{}, doc.ID).Error)
+	doc.Title = "gone"
+	assert.ErrorIs(t, repo.Update(doc), ErrOptimisticLock)
+}
+
+func TestUpdateWithVersion_InvalidModels(t *testing.T) {
+	db := openVersionTestDB(t)
+
+	assert.Error(t, UpdateWithVersion(db, &poolUser{ID: 1}), "model without version field")
+	assert.False(t, HasVersionField(db, &poolUser{}))
+	assert.Error(t, UpdateWithVersion(db, &versionedDoc{Title: "no id"}), "missing primary key")
+}
@@ -0,0 +1,166 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// maxBorrowAttempts 借用连接验证失败时的最大重试次数
+const maxBorrowAttempts = 3
+
+// ApplyPoolConfig 将数据库配置中的连接池参数应用到GORM底层的 *sql.DB
+//
+// 启用 Pool 时优先使用 Pool 配置：max_active_conns → SetMaxOpenConns，max_idle_conns → SetMaxIdleConns，
+// min_evictable_time → SetConnMaxIdleTime；未配置的项回退到 Primary 中的同名配置。
+// conn_max_lifetime 取自 Primary。test_on_borrow 为true且配置了 validation_query 时，
+// 每次借用连接执行验证查询，失败的连接被丢弃。
+// database/sql 不支持等待超时，max_wait_time 需通过请求上下文的超时控制。
+func ApplyPoolConfig(db *gorm.DB, cfg *config.DatabaseConfig) error {
+	if db == nil || cfg == nil {
+		return fmt.Errorf("database and config are required")
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("get sql.DB: %w", err)
+	}
+
+	pool := cfg.Pool
+	maxOpen, maxIdle := cfg.Primary.MaxOpenConns, cfg.Primary.MaxIdleConns
+	idleTime := cfg.Primary.ConnMaxIdleTime
+	if pool.Enable {
+		if pool.MaxActiveConns > 0 {
+			maxOpen = pool.MaxActiveConns
+		}
+		if pool.MaxIdleConns > 0 {
+			maxIdle = pool.MaxIdleConns
+		}
+		if pool.MinEvictableTime != "" {
+			idleTime = pool.MinEvictableTime
+		}
+	}
+
+	if maxOpen > 0 {
+		sqlDB.SetMaxOpenConns(maxOpen)
+	}
+	if maxIdle > 0 {
+		sqlDB.SetMaxIdleConns(maxIdle)
+	}
+	if lifetime, err := parsePoolDuration("conn_max_lifetime", cfg.Primary.ConnMaxLifetime); err != nil {
+		return err
+	} else if lifetime > 0 {
+		sqlDB.SetConnMaxLifetime(lifetime)
+	}
+	if idle, err := parsePoolDuration("conn_max_idle_time", idleTime); err != nil {
+		return err
+	} else if idle > 0 {
+		sqlDB.SetConnMaxIdleTime(idle)
+	}
+
+	if pool.Enable && pool.TestOnBorrow && pool.ValidationQuery != "" {
+		if db.ConnPool != sqlDB {
+			// 预编译语句等模式已包装连接池，无法在借用时验证
+			config.Warnf("test_on_borrow ignored: connection pool is already wrapped (%T)", db.ConnPool)
+			return nil
+		}
+		validating := &validatingConnPool{db: sqlDB, query: pool.ValidationQuery}
+		db.ConnPool = validating
+		db.Statement.ConnPool = validating
+	}
+
+	config.Infof("Database pool configured: max_open=%d max_idle=%d test_on_borrow=%v",
+		sqlDB.Stats().MaxOpenConnections, maxIdle, pool.Enable && pool.TestOnBorrow)
+	return nil
+}
+
+// parsePoolDuration 解析时长配置，空字符串返回0
+func parsePoolDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return d, nil
+}
+
+// validatingConnPool 借用连接时执行验证查询的连接池（test_on_borrow）
+// 事务与预编译语句直接使用底层连接池
+type validatingConnPool struct {
+	db    *sql.DB
+	query string
+}
+
+// GetDBConn 供 gorm.DB.DB() 获取底层 *sql.DB
+func (p *validatingConnPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+// borrow 借用一个通过验证查询的连接
+func (p *validatingConnPool) borrow(ctx context.Context) (*sql.Conn, error) {
+	var lastErr error
+	for i := 0; i < maxBorrowAttempts; i++ {
+		conn, err := p.db.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = conn.ExecContext(ctx, p.query); err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		// 返回 ErrBadConn 使连接从连接池中丢弃
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = conn.Close()
+	}
+	return nil, fmt.Errorf("connection validation query failed: %w", lastErr)
+}
+
+func (p *validatingConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *validatingConnPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	conn, err := p.borrow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.ExecContext(ctx, query, args...)
+}
+
+func (p *validatingConnPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	conn, err := p.borrow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// Conn.Close 等待结果集关闭后才归还连接
+	go conn.Close()
+	return rows, nil
+}
+
+func (p *validatingConnPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	conn, err := p.borrow(ctx)
+	if err != nil {
+		// sql.Row 无法直接携带错误，交由连接池执行以返回实际错误
+		return p.db.QueryRowContext(ctx, query, args...)
+	}
+	row := conn.QueryRowContext(ctx, query, args...)
+	go conn.Close()
+	return row
+}
+
+func (p *validatingConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return p.db.BeginTx(ctx, opts)
+}
@@ -0,0 +1,116 @@
+package orm
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+type poolUser struct {
+	ID   uint
+	Name string
+}
+
+func openPoolTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "pool.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&poolUser{}))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestApplyPoolConfig_Limits(t *testing.T) {
+	db := openPoolTestDB(t)
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Primary.MaxOpenConns = 50
+	cfg.Primary.ConnMaxLifetime = "1h"
+	cfg.Pool.Enable = true
+	cfg.Pool.MaxActiveConns = 4
+	cfg.Pool.MaxIdleConns = 2
+	cfg.Pool.MinEvictableTime = "10m"
+	require.NoError(t, ApplyPoolConfig(db, cfg))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Equal(t, 4, sqlDB.Stats().MaxOpenConnections, "pool max_active_conns overrides primary")
+
+	// 同时占用4个连接后归还，空闲连接数不超过 max_idle_conns
+	ctx := context.Background()
+	var conns []interface{ Close() error }
+	for i := 0; i < 4; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		require.NoError(t, err)
+		conns = append(conns, conn)
+	}
+	assert.Equal(t, 4, sqlDB.Stats().InUse)
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+	stats := sqlDB.Stats()
+	assert.Equal(t, 2, stats.Idle)
+	assert.Equal(t, int64(2), stats.MaxIdleClosed)
+}
+
+func TestApplyPoolConfig_InvalidDuration(t *testing.T) {
+	db := openPoolTestDB(t)
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Primary.ConnMaxLifetime = "forever"
+	assert.Error(t, ApplyPoolConfig(db, cfg))
+}
+
+func TestApplyPoolConfig_TestOnBorrow(t *testing.T) {
+	db := openPoolTestDB(t)
+
+	cfg := &config.DatabaseConfig{}
+	cfg.Pool.Enable = true
+	cfg.Pool.MaxActiveConns = 2
+	cfg.Pool.TestOnBorrow = true
+	cfg.Pool.ValidationQuery = "SELECT 1"
+	require.NoError(t, ApplyPoolConfig(db, cfg))
+
+	// 验证通过时正常读写，并发查询不会耗尽连接池
+	require.NoError(t, db.Create(&poolUser{Name: "alice"}).Error)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var users []poolUser
+			assert.NoError(t, db.Find(&users).Error)
+			assert.Len(t, users, 1)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&poolUser{Name: "bob"}).Error
+	}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return sqlDB.Stats().InUse == 0 }, time.Second, 10*time.Millisecond)
+
+	// 验证查询失败时拒绝使用连接
+	cfg.Pool.ValidationQuery = "SELECT * FROM missing_table"
+	broken := openPoolTestDB(t)
+	require.NoError(t, ApplyPoolConfig(broken, cfg))
+	var users []poolUser
+	err = broken.Find(&users).Error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation query failed")
+}
@@ -0,0 +1,262 @@
+package orm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// PrometheusContentType Prometheus文本格式的Content-Type
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// dbMetricsStartKey 查询开始时间在Statement中的键
+const dbMetricsStartKey = "prometheus:start_time"
+
+// dbMetricsOperations 需要统计的GORM回调
+var dbMetricsOperations = []string{"create", "query", "update", "delete", "row", "raw"}
+
+// DBMetricsExporter 数据库指标导出器
+// 通过GORM回调将查询耗时与结果记录到 MetricsCollector，并以Prometheus文本格式导出其节点指标与连接池状态
+type DBMetricsExporter struct {
+	collector *MetricsCollector
+	path      string
+	enabled   bool
+
+	mu  sync.Mutex
+	dbs map[string]*gorm.DB
+}
+
+// NewDBMetricsExporter 创建导出指定指标收集器的导出器，collector为nil时创建新的收集器
+func NewDBMetricsExporter(collector *MetricsCollector) *DBMetricsExporter {
+	if collector == nil {
+		collector = NewMetricsCollector()
+	}
+	return &DBMetricsExporter{
+		collector: collector,
+		path:      "/metrics",
+		enabled:   true,
+		dbs:       make(map[string]*gorm.DB),
+	}
+}
+
+// NewDBMetricsExporterFromConfig 根据数据库配置创建指标导出器
+// 使用 primary.slow_query_threshold 设置收集器的慢查询阈值，使用 monitoring.metrics_path 作为指标路径；
+// 仅当 monitoring.enable 为true且 export_format 为 prometheus 时 Mount 才会注册路由
+func NewDBMetricsExporterFromConfig(collector *MetricsCollector, cfg *config.DatabaseConfig) *DBMetricsExporter {
+	exporter := NewDBMetricsExporter(collector)
+	if cfg == nil {
+		return exporter
+	}
+
+	if threshold, err := time.ParseDuration(cfg.Primary.SlowQueryThreshold); err == nil {
+		exporter.collector.SetSlowQueryThreshold(threshold)
+	} else if cfg.Primary.SlowQueryThreshold != "" {
+		config.Warnf("Invalid slow_query_threshold %q: %v", cfg.Primary.SlowQueryThreshold, err)
+	}
+	if cfg.Monitoring.MetricsPath != "" {
+		exporter.path = cfg.Monitoring.MetricsPath
+	}
+	format := strings.ToLower(cfg.Monitoring.ExportFormat)
+	exporter.enabled = cfg.Monitoring.Enable && (format == "" || format == "prometheus")
+	return exporter
+}
+
+// Collector 导出的指标收集器
+func (e *DBMetricsExporter) Collector() *MetricsCollector {
+	return e.collector
+}
+
+// Path 指标路径
+func (e *DBMetricsExporter) Path() string {
+	return e.path
+}
+
+// Enabled 是否启用Prometheus导出
+func (e *DBMetricsExporter) Enabled() bool {
+	return e.enabled
+}
+
+// Instrument 为数据库注册指标回调，查询记录到收集器中以name为ID的节点，name同时作为指标的db标签
+func (e *DBMetricsExporter) Instrument(name string, db *gorm.DB) error {
+	if db == nil {
+		return errors.New("orm: nil database")
+	}
+
+	e.mu.Lock()
+	e.dbs[name] = db
+	e.mu.Unlock()
+
+	for _, operation := range dbMetricsOperations {
+		registerBefore, registerAfter := dbCallbackRegistrars(db, operation)
+
+		if err := registerBefore(fmt.Sprintf("prometheus:before_%s:%s", operation, name), func(tx *gorm.DB) {
+			tx.InstanceSet(dbMetricsStartKey, time.Now())
+		}); err != nil {
+			return err
+		}
+
+		if err := registerAfter(fmt.Sprintf("prometheus:after_%s:%s", operation, name), func(tx *gorm.DB) {
+			e.record(name, tx)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dbCallbackRegistrars 获取在 gorm:<operation> 前后注册回调的函数
+func dbCallbackRegistrars(db *gorm.DB, operation string) (before, after func(name string, fn func(*gorm.DB)) error) {
+	callbacks := db.Callback()
+	gormName := "gorm:" + operation
+	switch operation {
+	case "create":
+		return callbacks.Create().Before(gormName).Register, callbacks.Create().After(gormName).Register
+	case "update":
+		return callbacks.Update().Before(gormName).Register, callbacks.Update().After(gormName).Register
+	case "delete":
+		return callbacks.Delete().Before(gormName).Register, callbacks.Delete().After(gormName).Register
+	case "row":
+		return callbacks.Row().Before(gormName).Register, callbacks.Row().After(gormName).Register
+	case "raw":
+		return callbacks.Raw().Before(gormName).Register, callbacks.Raw().After(gormName).Register
+	default:
+		return callbacks.Query().Before(gormName).Register, callbacks.Query().After(gormName).Register
+	}
+}
+
+// record 将一次数据库操作记录到收集器
+func (e *DBMetricsExporter) record(name string, tx *gorm.DB) {
+	value, ok := tx.InstanceGet(dbMetricsStartKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	success := tx.Error == nil || errors.Is(tx.Error, gorm.ErrRecordNotFound)
+	e.collector.RecordQuery(name, time.Since(start), success)
+}
+
+// WritePrometheus 以Prometheus文本格式输出收集器的节点查询指标与已登记数据库的连接池状态
+func (e *DBMetricsExporter) WritePrometheus(w io.Writer) error {
+	snapshot := e.collector.GetMetrics()
+	nodes := make([]string, 0, len(snapshot.NodeMetrics))
+	for nodeID := range snapshot.NodeMetrics {
+		nodes = append(nodes, nodeID)
+	}
+	sort.Strings(nodes)
+
+	e.mu.Lock()
+	names := make([]string, 0, len(e.dbs))
+	for name := range e.dbs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	dbs := make([]*gorm.DB, len(names))
+	for i, name := range names {
+		dbs[i] = e.dbs[name]
+	}
+	e.mu.Unlock()
+
+	var buf bytes.Buffer
+	writeFamily := func(metric, metricType, help string, value func(*NodeMetrics) string) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, metricType)
+		for _, nodeID := range nodes {
+			fmt.Fprintf(&buf, "%s{db=\"%s\"} %s\n", metric, escapeLabelValue(nodeID), value(snapshot.NodeMetrics[nodeID]))
+		}
+	}
+
+	writeFamily("db_queries_total", "counter", "Total number of executed database operations.",
+		func(m *NodeMetrics) string { return fmt.Sprint(m.TotalQueries) })
+	writeFamily("db_query_errors_total", "counter", "Total number of failed database operations.",
+		func(m *NodeMetrics) string { return fmt.Sprint(m.FailedQueries) })
+	writeFamily("db_slow_queries_total", "counter", "Total number of database operations slower than the slow query threshold.",
+		func(m *NodeMetrics) string { return fmt.Sprint(m.SlowQueries) })
+
+	fmt.Fprintf(&buf, "# HELP db_query_duration_seconds Time spent executing database operations.\n# TYPE db_query_duration_seconds summary\n")
+	for _, nodeID := range nodes {
+		m := snapshot.NodeMetrics[nodeID]
+		labels := fmt.Sprintf(`db="%s"`, escapeLabelValue(nodeID))
+		fmt.Fprintf(&buf, "db_query_duration_seconds_sum{%s} %g\n", labels, m.AverageResponseTime.Seconds()*float64(m.TotalQueries))
+		fmt.Fprintf(&buf, "db_query_duration_seconds_count{%s} %d\n", labels, m.TotalQueries)
+	}
+
+	// 连接池状态
+	type poolGauge struct {
+		metric, metricType, help string
+	}
+	gauges := []poolGauge{
+		{"db_connections_open", "gauge", "Number of established connections both in use and idle."},
+		{"db_connections_in_use", "gauge", "Number of connections currently in use."},
+		{"db_connections_idle", "gauge", "Number of idle connections."},
+		{"db_connections_max_open", "gauge", "Maximum number of open connections to the database."},
+		{"db_connections_wait_total", "counter", "Total number of connections waited for."},
+		{"db_connections_wait_seconds_total", "counter", "Total time blocked waiting for a new connection."},
+	}
+	values := make([][]string, len(names))
+	for i, db := range dbs {
+		sqlDB, err := db.DB()
+		if err != nil {
+			continue
+		}
+		stats := sqlDB.Stats()
+		values[i] = []string{
+			fmt.Sprint(stats.OpenConnections),
+			fmt.Sprint(stats.InUse),
+			fmt.Sprint(stats.Idle),
+			fmt.Sprint(stats.MaxOpenConnections),
+			fmt.Sprint(stats.WaitCount),
+			fmt.Sprintf("%g", stats.WaitDuration.Seconds()),
+		}
+	}
+	for j, gauge := range gauges {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", gauge.metric, gauge.help, gauge.metric, gauge.metricType)
+		for i, name := range names {
+			if values[i] != nil {
+				fmt.Fprintf(&buf, "%s{db=\"%s\"} %s\n", gauge.metric, escapeLabelValue(name), values[i][j])
+			}
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Handler 指标导出处理器
+func (e *DBMetricsExporter) Handler() app.HandlerFunc {
+	return func(c context.Context, ctx *app.RequestContext) {
+		var buf bytes.Buffer
+		if err := e.WritePrometheus(&buf); err != nil {
+			ctx.String(500, err.Error())
+			return
+		}
+		ctx.Data(200, PrometheusContentType, buf.Bytes())
+	}
+}
+
+// Mount 在指标路径注册导出路由，未启用时不注册
+func (e *DBMetricsExporter) Mount(r route.IRoutes) {
+	if !e.enabled {
+		return
+	}
+	r.GET(e.path, e.Handler())
+}
+
+// escapeLabelValue 转义Prometheus标签值
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
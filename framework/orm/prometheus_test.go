@@ -0,0 +1,103 @@
+package orm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+type metricsUser struct {
+	ID   uint
+	Name string
+}
+
+func openMetricsTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&metricsUser{}))
+	return db
+}
+
+func TestDBMetricsExporter_ScrapeEndpoint(t *testing.T) {
+	db := openMetricsTestDB(t)
+	collector := NewMetricsCollector()
+	collector.SetSlowQueryThreshold(time.Nanosecond)
+	exporter := NewDBMetricsExporter(collector)
+	require.NoError(t, exporter.Instrument("primary", db))
+
+	require.NoError(t, db.Create(&metricsUser{Name: "alice"}).Error)
+	var users []metricsUser
+	require.NoError(t, db.Find(&users).Error)
+	require.Error(t, db.Raw("SELECT * FROM missing_table").Scan(&users).Error)
+
+	engine := route.NewEngine(hertzconfig.NewOptions(nil))
+	exporter.Mount(engine)
+
+	resp := ut.PerformRequest(engine, "GET", "/metrics", nil).Result()
+	require.Equal(t, 200, resp.StatusCode())
+	assert.True(t, strings.HasPrefix(string(resp.Header.ContentType()), "text/plain"))
+
+	body := string(resp.Body())
+	for _, family := range []string{
+		"db_queries_total",
+		"db_query_errors_total",
+		"db_slow_queries_total",
+		"db_query_duration_seconds",
+		"db_connections_open",
+		"db_connections_in_use",
+		"db_connections_idle",
+		"db_connections_max_open",
+		"db_connections_wait_total",
+	} {
+		assert.Contains(t, body, "# TYPE "+family+" ", "missing metric family %s", family)
+	}
+
+	assert.Contains(t, body, `db_queries_total{db="primary"} 3`)
+	assert.Contains(t, body, `db_query_errors_total{db="primary"} 1`)
+	assert.Contains(t, body, `db_slow_queries_total{db="primary"} 3`)
+	assert.Contains(t, body, `db_query_duration_seconds_count{db="primary"} 3`)
+
+	// 导出的是收集器中的统计，而不是导出器自身维护的计数
+	snapshot := collector.GetMetrics()
+	assert.Equal(t, int64(3), snapshot.TotalQueries)
+	assert.Equal(t, int64(1), snapshot.FailedQueries)
+	assert.Equal(t, int64(3), snapshot.NodeMetrics["primary"].TotalQueries)
+}
+
+func TestDBMetricsExporter_FromConfig(t *testing.T) {
+	cfg := &config.DatabaseConfig{}
+	cfg.Primary.SlowQueryThreshold = "200ms"
+	cfg.Monitoring.Enable = true
+	cfg.Monitoring.MetricsPath = "/db/metrics"
+	cfg.Monitoring.ExportFormat = "prometheus"
+
+	collector := NewMetricsCollector()
+	exporter := NewDBMetricsExporterFromConfig(collector, cfg)
+	assert.True(t, exporter.Enabled())
+	assert.Equal(t, "/db/metrics", exporter.Path())
+	assert.Same(t, collector, exporter.Collector())
+	assert.Equal(t, 200*time.Millisecond, collector.SlowQueryThreshold())
+
+	engine := route.NewEngine(hertzconfig.NewOptions(nil))
+	exporter.Mount(engine)
+	assert.Equal(t, 200, ut.PerformRequest(engine, "GET", "/db/metrics", nil).Result().StatusCode())
+
+	// 非Prometheus导出格式不注册路由
+	cfg.Monitoring.ExportFormat = "json"
+	disabled := NewDBMetricsExporterFromConfig(nil, cfg)
+	assert.False(t, disabled.Enabled())
+	other := route.NewEngine(hertzconfig.NewOptions(nil))
+	disabled.Mount(other)
+	assert.Equal(t, 404, ut.PerformRequest(other, "GET", "/db/metrics", nil).Result().StatusCode())
+}
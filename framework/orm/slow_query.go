@@ -170,7 +170,12 @@ func (m *SlowQueryMonitor) RecordQuery(sql string, duration time.Duration, param
 	}
 
 	// 记录慢查询日志
-	config.Warnf("检测到慢查询: %s [%v]", sql, duration)
+	config.WithFields(map[string]any{
+		"sql":       sql,
+		"args":      params,
+		"duration":  duration.String(),
+		"threshold": m.threshold.String(),
+	}).Warnf("检测到慢查询: %s [%v]", sql, duration)
 }
 
 // GetSlowQueries 获取慢查询记录
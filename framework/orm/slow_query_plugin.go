@@ -0,0 +1,94 @@
+package orm
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// slowQueryStartKey 慢查询计时开始时间在Statement中的键
+const slowQueryStartKey = "slow_query:start_time"
+
+// SlowQueryPlugin 慢查询GORM插件
+// 统计每条语句的执行耗时并交给 SlowQueryMonitor 记录，超过监控器阈值的语句进入慢查询记录并以WARN级别输出日志
+type SlowQueryPlugin struct {
+	monitor *SlowQueryMonitor
+}
+
+// NewSlowQueryPlugin 创建慢查询插件，monitor为nil时使用全局慢查询监控器
+func NewSlowQueryPlugin(monitor *SlowQueryMonitor) *SlowQueryPlugin {
+	if monitor == nil {
+		monitor = GetGlobalSlowQueryMonitor()
+	}
+	return &SlowQueryPlugin{monitor: monitor}
+}
+
+// NewSlowQueryPluginFromConfig 根据数据库配置创建使用全局慢查询监控器的插件
+// 使用 primary.slow_query_threshold 与 monitoring.slow_query_log
+func NewSlowQueryPluginFromConfig(cfg *config.DatabaseConfig) *SlowQueryPlugin {
+	p := NewSlowQueryPlugin(nil)
+	p.Reload(cfg)
+	return p
+}
+
+// Monitor 插件记录慢查询的监控器
+func (p *SlowQueryPlugin) Monitor() *SlowQueryMonitor {
+	return p.monitor
+}
+
+// Reload 重新加载配置，更新监控器的阈值与启停状态，阈值无法解析时保留原阈值
+func (p *SlowQueryPlugin) Reload(cfg *config.DatabaseConfig) {
+	if cfg == nil {
+		return
+	}
+	if threshold, err := time.ParseDuration(cfg.Primary.SlowQueryThreshold); err == nil {
+		p.monitor.SetThreshold(threshold)
+	} else if cfg.Primary.SlowQueryThreshold != "" {
+		config.Warnf("Invalid slow_query_threshold %q: %v", cfg.Primary.SlowQueryThreshold, err)
+	}
+
+	if cfg.Monitoring.SlowQueryLog {
+		p.monitor.Start()
+	} else {
+		p.monitor.Stop()
+	}
+}
+
+// Name 实现gorm.Plugin接口
+func (p *SlowQueryPlugin) Name() string {
+	return "yyhertz:slow_query"
+}
+
+// Initialize 实现gorm.Plugin接口，在各类操作前后注册计时回调
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	for _, operation := range dbMetricsOperations {
+		registerBefore, registerAfter := dbCallbackRegistrars(db, operation)
+
+		if err := registerBefore(fmt.Sprintf("slow_query:before_%s", operation), func(tx *gorm.DB) {
+			tx.InstanceSet(slowQueryStartKey, time.Now())
+		}); err != nil {
+			return err
+		}
+		if err := registerAfter(fmt.Sprintf("slow_query:after_%s", operation), p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// after 语句执行后将耗时交给监控器，由监控器按阈值判断是否为慢查询
+func (p *SlowQueryPlugin) after(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	p.monitor.RecordQuery(tx.Statement.SQL.String(), time.Since(start), tx.Statement.Vars...)
+}
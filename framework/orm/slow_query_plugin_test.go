@@ -0,0 +1,85 @@
+package orm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zsy619/yyhertz/framework/config"
+)
+
+// slowTestQuery 通过递归CTE人为制造的慢查询
+const slowTestQuery = "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < ?) SELECT count(*) FROM c"
+
+func slowQueryEntries(hook *logrustest.Hook) []*logrus.Entry {
+	var entries []*logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if strings.HasPrefix(entry.Message, "检测到慢查询") {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestSlowQueryPlugin_RecordsSlowStatements(t *testing.T) {
+	hook := logrustest.NewLocal(config.GetGlobalLogger().GetRawLogger())
+	db := openMetricsTestDB(t)
+	monitor := NewSlowQueryMonitor(5*time.Millisecond, 10)
+	require.NoError(t, db.Use(NewSlowQueryPlugin(monitor)))
+
+	var count int64
+	require.NoError(t, db.Raw("SELECT 1").Scan(&count).Error)
+	assert.Empty(t, slowQueryEntries(hook), "fast query should not be logged")
+	assert.Zero(t, monitor.GetRecordCount())
+
+	require.NoError(t, db.Exec(slowTestQuery, 500000).Error)
+	entries := slowQueryEntries(hook)
+	require.Len(t, entries, 1)
+	assert.Equal(t, logrus.WarnLevel, entries[0].Level)
+	assert.Contains(t, entries[0].Data["sql"], "WITH RECURSIVE")
+	assert.Equal(t, []interface{}{500000}, entries[0].Data["args"])
+	assert.NotEmpty(t, entries[0].Data["duration"])
+
+	// 慢查询进入监控器的记录，可供统计与分析
+	records := monitor.GetSlowQueries()
+	require.Len(t, records, 1)
+	assert.Contains(t, records[0].SQL, "WITH RECURSIVE")
+	assert.Equal(t, []interface{}{500000}, records[0].Params)
+
+	// 重新加载阈值后不再记录
+	hook.Reset()
+	monitor.SetThreshold(time.Hour)
+	require.NoError(t, db.Exec(slowTestQuery, 500000).Error)
+	assert.Empty(t, slowQueryEntries(hook))
+	assert.Equal(t, 1, monitor.GetRecordCount())
+}
+
+func TestSlowQueryPlugin_Reload(t *testing.T) {
+	cfg := &config.DatabaseConfig{}
+	cfg.Primary.SlowQueryThreshold = "200ms"
+	cfg.Monitoring.SlowQueryLog = true
+
+	plugin := NewSlowQueryPlugin(NewSlowQueryMonitor(0, 0))
+	plugin.Reload(cfg)
+	assert.Equal(t, 200*time.Millisecond, plugin.Monitor().GetThreshold())
+	assert.True(t, plugin.Monitor().IsEnabled())
+
+	cfg.Primary.SlowQueryThreshold = "1s"
+	plugin.Reload(cfg)
+	assert.Equal(t, time.Second, plugin.Monitor().GetThreshold())
+
+	// 无法解析的阈值保留原值
+	cfg.Primary.SlowQueryThreshold = "fast"
+	plugin.Reload(cfg)
+	assert.Equal(t, time.Second, plugin.Monitor().GetThreshold())
+
+	// 关闭慢查询日志时停止监控
+	cfg.Monitoring.SlowQueryLog = false
+	plugin.Reload(cfg)
+	assert.False(t, plugin.Monitor().IsEnabled())
+}
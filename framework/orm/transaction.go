@@ -99,6 +99,9 @@ func SafeTransaction(fn TransactionFunc) (err error) {
 
 type transactionContextKey struct{}
 
+// RequestTransactionKey 请求上下文（RequestContext）中存储请求级事务的键
+const RequestTransactionKey = "orm_transaction"
+
 // TransactionContext 事务上下文
 type TransactionContext struct {
 	TX *gorm.DB
@@ -0,0 +1,248 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// CSV CSV导出渲染器
+// Data 支持结构体切片、map切片（键为字符串）与 [][]string（原样输出）
+// Headers 指定输出的列及顺序：结构体按字段列名（csv/json标签）选择，map按键选择；
+// 为空时结构体输出全部导出字段，map输出首行的全部键（按字母序）
+type CSV struct {
+	Filename string
+	Headers  []string
+	Data     any
+}
+
+// CSV渲染实现
+func (r CSV) Render(c *app.RequestContext) error {
+	content, err := MarshalCSV(r.Data, r.Headers)
+	if err != nil {
+		return err
+	}
+	r.WriteContentType(c)
+	if r.Filename != "" {
+		c.Header("Content-Disposition", ContentDisposition(r.Filename))
+	}
+	c.Write(content)
+	return nil
+}
+
+func (r CSV) WriteContentType(c *app.RequestContext) {
+	writeContentType(c, []string{"text/csv; charset=utf-8"})
+}
+
+// WriteCSV 便捷函数
+func WriteCSV(c *app.RequestContext, filename string, data any) error {
+	return CSV{Filename: filename, Data: data}.Render(c)
+}
+
+// MarshalCSV 将数据序列化为带表头的CSV，字段中的逗号、引号与换行按RFC 4180转义
+func MarshalCSV(data any, headers []string) ([]byte, error) {
+	var records [][]string
+
+	if rows, ok := data.([][]string); ok {
+		records = rows
+	} else {
+		v := reflect.ValueOf(data)
+		for v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fmt.Errorf("csv: unsupported data type %T, expected slice", data)
+		}
+
+		var err error
+		records, err = csvRecords(v, headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvRecords 将切片转换为CSV记录（首行为表头）
+func csvRecords(rows reflect.Value, headers []string) ([][]string, error) {
+	elemType := rows.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	switch elemType.Kind() {
+	case reflect.Struct:
+		return csvStructRecords(rows, elemType, headers)
+	case reflect.Map:
+		if elemType.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("csv: map key must be string, got %s", elemType.Key())
+		}
+		return csvMapRecords(rows, headers), nil
+	case reflect.Interface:
+		// []any 按首个元素的实际类型处理
+		if rows.Len() == 0 {
+			return [][]string{headers}, nil
+		}
+		first := reflect.Indirect(reflect.ValueOf(rows.Index(0).Interface()))
+		if !first.IsValid() {
+			return nil, fmt.Errorf("csv: nil element in data")
+		}
+		typed := reflect.MakeSlice(reflect.SliceOf(first.Type()), 0, rows.Len())
+		for i := 0; i < rows.Len(); i++ {
+			item := reflect.Indirect(reflect.ValueOf(rows.Index(i).Interface()))
+			if !item.IsValid() || item.Type() != first.Type() {
+				return nil, fmt.Errorf("csv: mixed element types in data")
+			}
+			typed = reflect.Append(typed, item)
+		}
+		return csvRecords(typed, headers)
+	default:
+		return nil, fmt.Errorf("csv: unsupported element type %s", elemType)
+	}
+}
+
+// csvField 结构体字段与列名的对应关系
+type csvField struct {
+	name  string
+	index []int
+}
+
+// csvStructRecords 结构体切片转CSV记录
+func csvStructRecords(rows reflect.Value, elemType reflect.Type, headers []string) ([][]string, error) {
+	fields := csvStructFields(elemType)
+	if len(headers) > 0 {
+		byName := make(map[string]csvField, len(fields))
+		for _, f := range fields {
+			byName[f.name] = f
+		}
+		selected := make([]csvField, 0, len(headers))
+		for _, h := range headers {
+			f, ok := byName[h]
+			if !ok {
+				return nil, fmt.Errorf("csv: unknown column %q for %s", h, elemType)
+			}
+			selected = append(selected, f)
+		}
+		fields = selected
+	}
+
+	records := make([][]string, 0, rows.Len()+1)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	records = append(records, header)
+
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		record := make([]string, len(fields))
+		if row.IsValid() {
+			for j, f := range fields {
+				record[j] = csvValue(row.FieldByIndex(f.index))
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// csvStructFields 获取结构体的导出字段，列名优先取csv标签，其次json标签
+func csvStructFields(t reflect.Type) []csvField {
+	fields := make([]csvField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Name
+		tag := sf.Tag.Get("csv")
+		if tag == "" {
+			tag = sf.Tag.Get("json")
+		}
+		if tag != "" {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields = append(fields, csvField{name: name, index: sf.Index})
+	}
+	return fields
+}
+
+// csvMapRecords map切片转CSV记录
+func csvMapRecords(rows reflect.Value, headers []string) [][]string {
+	if len(headers) == 0 && rows.Len() > 0 {
+		first := reflect.Indirect(rows.Index(0))
+		for _, key := range first.MapKeys() {
+			headers = append(headers, key.String())
+		}
+		sort.Strings(headers)
+	}
+
+	records := make([][]string, 0, rows.Len()+1)
+	records = append(records, headers)
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		record := make([]string, len(headers))
+		for j, h := range headers {
+			if value := row.MapIndex(reflect.ValueOf(h).Convert(row.Type().Key())); value.IsValid() {
+				record[j] = csvValue(value)
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// csvValue 将单个值格式化为CSV字段
+func csvValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	case fmt.Stringer:
+		return value.String()
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// ContentDisposition 构建附件下载头，非ASCII文件名额外提供RFC 5987编码
+func ContentDisposition(filename string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", "").Replace(filename)
+	for _, r := range filename {
+		if r > 127 {
+			return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, escaped, url.PathEscape(filename))
+		}
+	}
+	return fmt.Sprintf(`attachment; filename="%s"`, escaped)
+}
@@ -0,0 +1,67 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvTestUser struct {
+	ID        int        `csv:"id"`
+	Name      string     `json:"name"`
+	Remark    string     `csv:"remark"`
+	Password  string     `csv:"-"`
+	CreatedAt time.Time  `csv:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	internal  string
+}
+
+func TestCSV_RenderStructs(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := []csvTestUser{
+		{ID: 1, Name: "Alice", Remark: "plain", Password: "secret", CreatedAt: created},
+		{ID: 2, Name: "Smith, Bob", Remark: "line1\nline2", CreatedAt: created},
+		{ID: 3, Name: `Carol "CJ"`, Remark: "", internal: "x"},
+	}
+
+	c := ut.CreateUtRequestContext("GET", "/export", nil)
+	require.NoError(t, CSV{Filename: "users.csv", Data: users}.Render(c))
+
+	assert.Equal(t, "text/csv; charset=utf-8", string(c.Response.Header.ContentType()))
+	assert.Equal(t, `attachment; filename="users.csv"`, string(c.Response.Header.Peek("Content-Disposition")))
+
+	expected := "id,name,remark,created_at,deleted_at\n" +
+		"1,Alice,plain,2024-01-02T03:04:05Z,\n" +
+		"2,\"Smith, Bob\",\"line1\nline2\",2024-01-02T03:04:05Z,\n" +
+		"3,\"Carol \"\"CJ\"\"\",,,\n"
+	assert.Equal(t, expected, string(c.Response.Body()))
+	assert.NotContains(t, string(c.Response.Body()), "secret")
+}
+
+func TestMarshalCSV_HeadersSelectColumns(t *testing.T) {
+	users := []*csvTestUser{{ID: 1, Name: "Alice"}, nil}
+	content, err := MarshalCSV(users, []string{"name", "id"})
+	require.NoError(t, err)
+	assert.Equal(t, "name,id\nAlice,1\n,\n", string(content))
+
+	_, err = MarshalCSV(users, []string{"unknown"})
+	assert.Error(t, err)
+}
+
+func TestMarshalCSV_Maps(t *testing.T) {
+	rows := []map[string]any{
+		{"name": "Alice", "age": 30},
+		{"name": "Bob"},
+	}
+	content, err := MarshalCSV(rows, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "age,name\n30,Alice\n,Bob\n", string(content))
+}
+
+func TestContentDisposition_NonASCII(t *testing.T) {
+	assert.Equal(t, `attachment; filename="用户.csv"; filename*=UTF-8''%E7%94%A8%E6%88%B7.csv`, ContentDisposition("用户.csv"))
+	assert.Equal(t, `attachment; filename="a\"b.csv"`, ContentDisposition(`a"b.csv`))
+}
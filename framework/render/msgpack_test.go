@@ -0,0 +1,33 @@
+package render
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgPack_Render(t *testing.T) {
+	c := ut.CreateUtRequestContext("GET", "/users/1", nil)
+	require.NoError(t, MsgPack{Data: struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}{1, "alice"}}.Render(c))
+
+	assert.Equal(t, "application/x-msgpack", string(c.Response.Header.ContentType()))
+	// {"id":1,"name":"alice"}
+	assert.Equal(t, "82a2696401a46e616d65a5616c696365", hex.EncodeToString(c.Response.Body()))
+}
+
+func TestMsgPack_RenderKeepsExplicitContentType(t *testing.T) {
+	c := ut.CreateUtRequestContext("GET", "/users/1", nil)
+	c.Response.Header.SetContentType("application/vnd.example+msgpack")
+	require.NoError(t, WriteMsgPack(c, []int{1, 2}))
+
+	assert.Equal(t, "application/vnd.example+msgpack", string(c.Response.Header.ContentType()))
+	assert.Equal(t, []byte{0x92, 0x01, 0x02}, c.Response.Body())
+
+	assert.Error(t, WriteMsgPack(ut.CreateUtRequestContext("GET", "/", nil), func() {}))
+}
@@ -0,0 +1,28 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoBuf_Render(t *testing.T) {
+	c := ut.CreateUtRequestContext("GET", "/greeting", nil)
+	require.NoError(t, ProtoBuf{Data: wrapperspb.String("你好")}.Render(c))
+
+	assert.Equal(t, "application/x-protobuf", string(c.Response.Header.ContentType()))
+
+	var decoded wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(c.Response.Body(), &decoded))
+	assert.Equal(t, "你好", decoded.GetValue())
+}
+
+func TestProtoBuf_RenderRequiresMessage(t *testing.T) {
+	c := ut.CreateUtRequestContext("GET", "/greeting", nil)
+	assert.Error(t, ProtoBuf{Data: map[string]any{"value": "hi"}}.Render(c))
+	assert.Empty(t, c.Response.Body())
+}
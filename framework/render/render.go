@@ -3,13 +3,18 @@
 package render
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"html/template"
 	"net/http"
+	"sort"
+	"sync"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
 )
 
@@ -29,7 +34,32 @@ type IndentedJSON struct {
 	Data any
 }
 
-// SecureJSON 安全的JSON渲染器（防止JSON劫持）
+// DefaultSecureJSONPrefix SecureJSON默认的防JSON劫持前缀
+const DefaultSecureJSONPrefix = "while(1);"
+
+var (
+	secureJSONPrefixMu sync.RWMutex
+	secureJSONPrefix   = DefaultSecureJSONPrefix
+)
+
+// SetSecureJSONPrefix 设置全局默认的SecureJSON前缀，空字符串恢复为 DefaultSecureJSONPrefix
+func SetSecureJSONPrefix(prefix string) {
+	if prefix == "" {
+		prefix = DefaultSecureJSONPrefix
+	}
+	secureJSONPrefixMu.Lock()
+	secureJSONPrefix = prefix
+	secureJSONPrefixMu.Unlock()
+}
+
+// SecureJSONPrefix 获取全局默认的SecureJSON前缀
+func SecureJSONPrefix() string {
+	secureJSONPrefixMu.RLock()
+	defer secureJSONPrefixMu.RUnlock()
+	return secureJSONPrefix
+}
+
+// SecureJSON 安全的JSON渲染器（防止JSON劫持），Prefix 为空时使用全局默认前缀
 type SecureJSON struct {
 	Prefix string
 	Data   any
@@ -46,11 +76,51 @@ type XML struct {
 	Data any
 }
 
+// H 通用键值映射，支持直接进行XML序列化
+type H map[string]any
+
+// MarshalXML 将映射序列化为XML，键名作为元素名（按字母序输出）
+func (h H) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "" || start.Name.Local == "H" {
+		start.Name = xml.Name{Local: "response"}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := h[key]
+		if nested, ok := value.(map[string]any); ok {
+			value = H(nested)
+		}
+		if err := e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
 // YAML YAML渲染器
 type YAML struct {
 	Data any
 }
 
+// ProtoBuf protobuf渲染器，Data 必须实现 proto.Message
+type ProtoBuf struct {
+	Data any
+}
+
+// MsgPack MessagePack渲染器
+type MsgPack struct {
+	Data any
+}
+
 // String 字符串渲染器
 type String struct {
 	Format string
@@ -136,10 +206,11 @@ func (r SecureJSON) Render(c *app.RequestContext) error {
 	if err != nil {
 		return err
 	}
-	if string(jsonBytes) == "null" && r.Prefix != "" {
-		jsonBytes = []byte(r.Prefix + "null")
+	prefix := r.Prefix
+	if prefix == "" {
+		prefix = SecureJSONPrefix()
 	}
-	c.Write(jsonBytes)
+	c.Write(append([]byte(prefix), jsonBytes...))
 	return nil
 }
 
@@ -174,7 +245,12 @@ func (r JsonpJSON) WriteContentType(c *app.RequestContext) {
 // XML渲染实现
 func (r XML) Render(c *app.RequestContext) error {
 	r.WriteContentType(c)
-	xmlBytes, err := xml.Marshal(r.Data)
+	data := r.Data
+	if m, ok := data.(map[string]any); ok {
+		// encoding/xml 不支持直接序列化map
+		data = H(m)
+	}
+	xmlBytes, err := xml.Marshal(data)
 	if err != nil {
 		return err
 	}
@@ -201,6 +277,43 @@ func (r YAML) WriteContentType(c *app.RequestContext) {
 	writeContentType(c, []string{"application/x-yaml; charset=utf-8"})
 }
 
+// ProtoBuf渲染实现
+func (r ProtoBuf) Render(c *app.RequestContext) error {
+	msg, ok := r.Data.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf render: %T does not implement proto.Message", r.Data)
+	}
+	r.WriteContentType(c)
+	protoBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.Write(protoBytes)
+	return nil
+}
+
+func (r ProtoBuf) WriteContentType(c *app.RequestContext) {
+	writeContentType(c, []string{"application/x-protobuf"})
+}
+
+// MsgPack渲染实现，字段名取自 msgpack 标签，未设置时使用 json 标签；整数使用最短编码
+func (r MsgPack) Render(c *app.RequestContext) error {
+	r.WriteContentType(c)
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	if err := enc.Encode(r.Data); err != nil {
+		return err
+	}
+	c.Write(buf.Bytes())
+	return nil
+}
+
+func (r MsgPack) WriteContentType(c *app.RequestContext) {
+	writeContentType(c, []string{"application/x-msgpack"})
+}
+
 // String渲染实现
 func (r String) Render(c *app.RequestContext) error {
 	r.WriteContentType(c)
@@ -312,10 +425,14 @@ func (r Reader) writeHeaders(c *app.RequestContext, headers map[string]string) {
 	}
 }
 
+// defaultContentType Hertz未设置Content-Type时返回的默认值
+const defaultContentType = "text/plain; charset=utf-8"
+
 // 辅助函数
+// writeContentType 未设置Content-Type时写入，Hertz的默认值视为未设置
 func writeContentType(c *app.RequestContext, value []string) {
-	header := c.Response.Header.Peek("Content-Type")
-	if len(header) == 0 {
+	header := c.Response.Header.ContentType()
+	if len(header) == 0 || string(header) == defaultContentType {
 		c.Header("Content-Type", value[0])
 	}
 }
@@ -345,6 +462,14 @@ func WriteYAML(c *app.RequestContext, obj any) error {
 	return YAML{Data: obj}.Render(c)
 }
 
+func WriteProtoBuf(c *app.RequestContext, obj any) error {
+	return ProtoBuf{Data: obj}.Render(c)
+}
+
+func WriteMsgPack(c *app.RequestContext, obj any) error {
+	return MsgPack{Data: obj}.Render(c)
+}
+
 func WriteString(c *app.RequestContext, format string, values ...any) error {
 	return String{Format: format, Data: values}.Render(c)
 }
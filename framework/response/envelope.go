@@ -0,0 +1,90 @@
+package response
+
+import (
+	"github.com/zsy619/yyhertz/framework/constant"
+)
+
+// EnvelopeConfig 统一响应包装 {code, message, data} 的配置
+type EnvelopeConfig struct {
+	CodeField      string // 业务码字段名，默认 code
+	MessageField   string // 消息字段名，默认 message
+	DataField      string // 数据字段名，默认 data
+	SuccessCode    int    // 成功业务码，默认 constant.CodeSuccess
+	SuccessMessage string // 成功消息，默认 success
+	FailStatus     int    // 失败业务码不是HTTP错误码（400-599）时使用的HTTP状态码，默认200
+}
+
+// DefaultEnvelopeConfig 默认响应包装配置
+func DefaultEnvelopeConfig() EnvelopeConfig {
+	return EnvelopeConfig{
+		CodeField:      "code",
+		MessageField:   "message",
+		DataField:      "data",
+		SuccessCode:    int(constant.CodeSuccess),
+		SuccessMessage: "success",
+		FailStatus:     200,
+	}
+}
+
+// EnvelopeContextKey 请求上下文中响应包装配置的键，由应用（App）按请求写入
+const EnvelopeContextKey = "response.envelope"
+
+// NormalizeEnvelopeConfig 补全响应包装配置，未设置的字段使用默认值
+func NormalizeEnvelopeConfig(cfg EnvelopeConfig) EnvelopeConfig {
+	defaults := DefaultEnvelopeConfig()
+	if cfg.CodeField == "" {
+		cfg.CodeField = defaults.CodeField
+	}
+	if cfg.MessageField == "" {
+		cfg.MessageField = defaults.MessageField
+	}
+	if cfg.DataField == "" {
+		cfg.DataField = defaults.DataField
+	}
+	if cfg.SuccessMessage == "" {
+		cfg.SuccessMessage = defaults.SuccessMessage
+	}
+	if cfg.FailStatus == 0 {
+		cfg.FailStatus = defaults.FailStatus
+	}
+	return cfg
+}
+
+// Envelope 按配置的字段名构建响应包装
+func (cfg EnvelopeConfig) Envelope(code int, message string, data any) map[string]any {
+	return map[string]any{
+		cfg.CodeField:    code,
+		cfg.MessageField: message,
+		cfg.DataField:    data,
+	}
+}
+
+// SuccessEnvelope 构建成功响应包装，返回HTTP状态码与响应体
+func (cfg EnvelopeConfig) SuccessEnvelope(data any) (int, map[string]any) {
+	return 200, cfg.Envelope(cfg.SuccessCode, cfg.SuccessMessage, data)
+}
+
+// FailEnvelope 构建失败响应包装，返回HTTP状态码与响应体
+// code 为HTTP错误码（400-599）时同时作为HTTP状态码，否则使用 FailStatus
+func (cfg EnvelopeConfig) FailEnvelope(code int, message string) (int, map[string]any) {
+	status := cfg.FailStatus
+	if code >= 400 && code < 600 {
+		status = code
+	}
+	return status, cfg.Envelope(code, message, nil)
+}
+
+// Envelope 按默认配置构建响应包装
+func Envelope(code int, message string, data any) map[string]any {
+	return DefaultEnvelopeConfig().Envelope(code, message, data)
+}
+
+// SuccessEnvelope 按默认配置构建成功响应包装
+func SuccessEnvelope(data any) (int, map[string]any) {
+	return DefaultEnvelopeConfig().SuccessEnvelope(data)
+}
+
+// FailEnvelope 按默认配置构建失败响应包装
+func FailEnvelope(code int, message string) (int, map[string]any) {
+	return DefaultEnvelopeConfig().FailEnvelope(code, message)
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	t.Run("默认字段", func(t *testing.T) {
+		status, body := SuccessEnvelope(map[string]any{"id": 1})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, map[string]any{"code": 0, "message": "success", "data": map[string]any{"id": 1}}, body)
+
+		status, body = FailEnvelope(404, "not found")
+		assert.Equal(t, 404, status)
+		assert.Equal(t, map[string]any{"code": 404, "message": "not found", "data": nil}, body)
+
+		// 业务错误码不是HTTP错误码
+		status, body = FailEnvelope(10001, "余额不足")
+		assert.Equal(t, 200, status)
+		assert.Equal(t, 10001, body["code"])
+	})
+
+	t.Run("自定义字段", func(t *testing.T) {
+		cfg := NormalizeEnvelopeConfig(EnvelopeConfig{CodeField: "errno", MessageField: "msg", SuccessCode: 200, FailStatus: 400})
+
+		status, body := cfg.SuccessEnvelope("ok")
+		assert.Equal(t, 200, status)
+		assert.Equal(t, map[string]any{"errno": 200, "msg": "success", "data": "ok"}, body)
+
+		status, body = cfg.FailEnvelope(10001, "余额不足")
+		assert.Equal(t, 400, status)
+		assert.Equal(t, map[string]any{"errno": 10001, "msg": "余额不足", "data": nil}, body)
+	})
+}
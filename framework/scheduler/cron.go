@@ -471,17 +471,6 @@ func GetNextCronTime(cronExpr string, from time.Time) (time.Time, error) {
 
 // ============= Cron调度器增强 =============
 
-// 扩展原有的parseSchedule方法以支持Cron表达式
-func (s *Scheduler) parseScheduleWithCron(schedule string) (time.Time, error) {
-	// 首先尝试解析为Cron表达式
-	if err := ValidateCronExpression(schedule); err == nil {
-		return GetNextCronTime(schedule, time.Now())
-	}
-
-	// 如果不是Cron表达式，使用原有的解析逻辑
-	return s.parseSchedule(schedule)
-}
-
 // CronTask Cron任务的便捷包装
 type CronTask struct {
 	*Task
@@ -551,7 +540,7 @@ func ScheduleAfter(id, name, description string, delay time.Duration, jobFunc fu
 // ScheduleEvery 按间隔调度任务
 func ScheduleEvery(id, name, description string, interval time.Duration, jobFunc func(ctx context.Context) error) error {
 	job := NewJobFunc(name, description, jobFunc)
-	task := NewTask(id, name, description, "@every_"+interval.String(), job)
+	task := NewTask(id, name, description, "@every "+interval.String(), job)
 
 	return GetGlobalScheduler().AddTask(task)
 }
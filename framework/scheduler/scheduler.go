@@ -1,7 +1,9 @@
 // Package scheduler 提供任务调度功能
 //
 // 这个包提供了一个强大的任务调度系统，支持：
-// - Cron表达式调度
+// - Cron表达式调度（5/6/7字段）与固定间隔调度（@every 30s）
+// - 防止重叠执行：上一次执行未结束时跳过本次调度
+// - 任务panic恢复与优雅停止
 // - 一次性任务
 // - 延时任务
 // - 任务持久化
@@ -13,8 +15,10 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -109,6 +113,7 @@ type Task struct {
 	NextRunTime *time.Time        `json:"next_run_time,omitempty"`
 	RunCount    int64             `json:"run_count"`
 	FailCount   int64             `json:"fail_count"`
+	SkipCount   int64             `json:"skip_count"` // 因上一次执行未结束而跳过的次数
 	MaxRetries  int               `json:"max_retries"`
 	Timeout     time.Duration     `json:"timeout"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
@@ -153,6 +158,18 @@ func (t *Task) IncrementFailCount() {
 	atomic.AddInt64(&t.FailCount, 1)
 }
 
+// IncrementSkipCount 增加跳过次数
+func (t *Task) IncrementSkipCount() {
+	atomic.AddInt64(&t.SkipCount, 1)
+}
+
+// GetStatus 获取任务状态
+func (t *Task) GetStatus() TaskStatus {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.Status
+}
+
 // SetLastRunTime 设置最后运行时间
 func (t *Task) SetLastRunTime(t2 time.Time) {
 	t.mutex.Lock()
@@ -189,6 +206,8 @@ type Scheduler struct {
 	tasks    map[string]*Task
 	running  int32
 	stopChan chan struct{}
+	loopDone chan struct{}  // 调度循环退出信号
+	inflight sync.WaitGroup // 正在执行的任务
 	workers  int
 	
 	// 事件回调
@@ -391,11 +410,13 @@ func (s *Scheduler) ResumeTask(taskID string) error {
 
 // Start 启动调度器
 func (s *Scheduler) Start() error {
-	if atomic.LoadInt32(&s.running) == 1 {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
 		return fmt.Errorf("scheduler is already running")
 	}
-	
-	atomic.StoreInt32(&s.running, 1)
+
+	// 支持停止后重新启动
+	s.stopChan = make(chan struct{})
+	s.loopDone = make(chan struct{})
 	
 	// 从存储中加载任务
 	if s.config.EnablePersistent && s.storage != nil {
@@ -405,37 +426,71 @@ func (s *Scheduler) Start() error {
 	}
 	
 	// 启动调度循环
-	go s.scheduleLoop()
+	go s.scheduleLoop(s.stopChan, s.loopDone)
 	
 	// 启动工作协程
 	for i := 0; i < s.workers; i++ {
-		go s.workerLoop(i)
+		go s.workerLoop(i, s.stopChan)
 	}
 	
 	config.Infof("Scheduler started with %d workers", s.workers)
 	return nil
 }
 
-// Stop 停止调度器
+// Stop 停止调度器，并取消正在执行的任务
 func (s *Scheduler) Stop() error {
-	if atomic.LoadInt32(&s.running) == 0 {
+	if !s.IsRunning() {
 		return fmt.Errorf("scheduler is not running")
 	}
-	
-	atomic.StoreInt32(&s.running, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown 优雅停止调度器：不再调度新的执行，并等待正在执行的任务完成
+// ctx 结束时取消仍在执行的任务并返回 ctx.Err()；调度器未运行时直接返回nil
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&s.running, 1, 0) {
+		return nil
+	}
 	close(s.stopChan)
-	
-	// 取消所有正在运行的任务
+	// 等待调度循环退出，之后不会再有新的执行
+	<-s.loopDone
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		config.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.cancelRunningTasks()
+		config.Warnf("Scheduler stopped before running tasks finished: %v", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// cancelRunningTasks 取消所有正在执行的任务
+func (s *Scheduler) cancelRunningTasks() {
 	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	for _, task := range s.tasks {
-		if task.Status == TaskStatusRunning && task.cancel != nil {
-			task.cancel()
+		task.mutex.RLock()
+		cancel := task.cancel
+		task.mutex.RUnlock()
+		if cancel != nil {
+			cancel()
 		}
 	}
-	s.mutex.RUnlock()
-	
-	config.Info("Scheduler stopped")
-	return nil
 }
 
 // IsRunning 检查调度器是否运行中
@@ -444,13 +499,15 @@ func (s *Scheduler) IsRunning() bool {
 }
 
 // scheduleLoop 调度循环
-func (s *Scheduler) scheduleLoop() {
+func (s *Scheduler) scheduleLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
 	ticker := time.NewTicker(s.config.TickInterval)
 	defer ticker.Stop()
 	
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
 			return
 		case <-ticker.C:
 			s.checkAndScheduleTasks()
@@ -458,7 +515,7 @@ func (s *Scheduler) scheduleLoop() {
 	}
 }
 
-// checkAndScheduleTasks 检查并调度任务
+// checkAndScheduleTasks 检查并调度到期任务
 func (s *Scheduler) checkAndScheduleTasks() {
 	now := time.Now()
 	
@@ -466,33 +523,73 @@ func (s *Scheduler) checkAndScheduleTasks() {
 	defer s.mutex.RUnlock()
 	
 	for _, task := range s.tasks {
-		if task.Status != TaskStatusPending {
-			continue
+		switch claimTask(task, now) {
+		case claimStarted:
+			s.inflight.Add(1)
+			go func(task *Task) {
+				defer s.inflight.Done()
+				s.executeTask(task)
+			}(task)
+		case claimSkipped:
+			if s.config.EnableLogging {
+				config.Warnf("Task %s (%s) skipped: previous run still executing", task.Name, task.ID)
+			}
 		}
-		
-		if task.NextRunTime != nil && now.After(*task.NextRunTime) {
-			// 提交任务到工作队列
-			go s.executeTask(task)
+	}
+}
+
+// claimResult 到期检查结果
+type claimResult int
+
+const (
+	claimNone    claimResult = iota // 未到期或不可执行
+	claimStarted                    // 已标记为运行中，应立即执行
+	claimSkipped                    // 上一次执行未结束，跳过本次
+)
+
+// claimTask 检查任务是否到期，到期的等待中任务原子地标记为运行中
+// 上一次执行仍未结束的周期任务跳过本次调度，下次运行时间推迟到下一个周期（防止重叠执行）
+func claimTask(task *Task, now time.Time) claimResult {
+	task.mutex.Lock()
+	defer task.mutex.Unlock()
+
+	if task.NextRunTime == nil || now.Before(*task.NextRunTime) {
+		return claimNone
+	}
+
+	switch task.Status {
+	case TaskStatusPending:
+		task.Status = TaskStatusRunning
+		task.UpdatedAt = now
+		return claimStarted
+	case TaskStatusRunning:
+		if next, err := nextScheduleTime(task.Schedule, now); err == nil && isRecurringSchedule(task.Schedule) {
+			task.NextRunTime = &next
+		} else {
+			task.NextRunTime = nil
 		}
+		task.IncrementSkipCount()
+		return claimSkipped
 	}
+	return claimNone
 }
 
-// executeTask 执行任务
+// executeTask 执行任务，调用前任务已由 claimTask 标记为运行中
 func (s *Scheduler) executeTask(task *Task) {
-	// 检查任务状态
-	if task.Status != TaskStatusPending {
-		return
-	}
-	
-	// 设置任务状态为运行中
-	task.SetStatus(TaskStatusRunning)
 	task.SetLastRunTime(time.Now())
 	task.IncrementRunCount()
 	
 	// 创建上下文
 	ctx, cancel := context.WithTimeout(context.Background(), task.Timeout)
+	task.mutex.Lock()
 	task.cancel = cancel
-	defer cancel()
+	task.mutex.Unlock()
+	defer func() {
+		task.mutex.Lock()
+		task.cancel = nil
+		task.mutex.Unlock()
+		cancel()
+	}()
 	
 	// 触发开始回调
 	if s.onTaskStart != nil {
@@ -510,7 +607,12 @@ func (s *Scheduler) executeTask(task *Task) {
 		
 		err = task.Job.Execute(ctx)
 	}()
-	
+
+	// 执行期间被暂停的任务保持暂停状态
+	if task.GetStatus() == TaskStatusPaused {
+		return
+	}
+
 	// 处理执行结果
 	if err != nil {
 		task.IncrementFailCount()
@@ -550,8 +652,8 @@ func (s *Scheduler) executeTask(task *Task) {
 		}
 	}
 	
-	// 计算下次运行时间
-	if task.Status == TaskStatusCompleted {
+	// 计算下次运行时间：失败重试优先，其余情况周期任务进入下一个周期，一次性任务保持结束状态
+	if task.GetStatus() != TaskStatusPending && isRecurringSchedule(task.Schedule) {
 		nextRun, parseErr := s.parseSchedule(task.Schedule)
 		if parseErr == nil {
 			task.SetNextRunTime(nextRun)
@@ -568,12 +670,12 @@ func (s *Scheduler) executeTask(task *Task) {
 }
 
 // workerLoop 工作协程循环
-func (s *Scheduler) workerLoop(workerID int) {
+func (s *Scheduler) workerLoop(workerID int, stop <-chan struct{}) {
 	config.Infof("Worker %d started", workerID)
 	
 	for {
 		select {
-		case <-s.stopChan:
+		case <-stop:
 			config.Infof("Worker %d stopped", workerID)
 			return
 		default:
@@ -582,31 +684,78 @@ func (s *Scheduler) workerLoop(workerID int) {
 	}
 }
 
-// parseSchedule 解析调度表达式
+// parseSchedule 解析调度表达式，返回下次运行时间
 func (s *Scheduler) parseSchedule(schedule string) (time.Time, error) {
-	// 简化实现，支持几种常见格式
+	return nextScheduleTime(schedule, time.Now())
+}
+
+// nextScheduleTime 计算from之后的下次运行时间
+// 支持：@every_minute/@every_hour/@every_day、@every <间隔>（如 @every 30s）、Cron表达式、
+// @once、时间间隔（如 5m，延时执行一次）与绝对时间（2006-01-02 15:04:05）
+func nextScheduleTime(schedule string, from time.Time) (time.Time, error) {
 	switch schedule {
 	case "@every_minute":
-		return time.Now().Add(time.Minute), nil
+		return from.Add(time.Minute), nil
 	case "@every_hour":
-		return time.Now().Add(time.Hour), nil
+		return from.Add(time.Hour), nil
 	case "@every_day":
-		return time.Now().Add(24 * time.Hour), nil
+		return from.Add(24 * time.Hour), nil
 	case "@once":
-		return time.Now(), nil
-	default:
-		// 尝试解析为时间间隔
-		if duration, err := time.ParseDuration(schedule); err == nil {
-			return time.Now().Add(duration), nil
+		return from, nil
+	}
+
+	if interval, ok := everyInterval(schedule); ok {
+		if interval <= 0 {
+			return time.Time{}, fmt.Errorf("invalid interval: %s", schedule)
 		}
-		
-		// 尝试解析为绝对时间
-		if t, err := time.Parse("2006-01-02 15:04:05", schedule); err == nil {
-			return t, nil
+		return from.Add(interval), nil
+	}
+
+	if expr, err := ParseCronExpression(schedule); err == nil {
+		next := expr.NextTime(from)
+		if next.IsZero() {
+			return time.Time{}, fmt.Errorf("no next execution time found for cron expression: %s", schedule)
 		}
-		
-		return time.Time{}, fmt.Errorf("unsupported schedule format: %s", schedule)
+		return next, nil
+	}
+
+	// 尝试解析为时间间隔
+	if duration, err := time.ParseDuration(schedule); err == nil {
+		return from.Add(duration), nil
+	}
+
+	// 尝试解析为绝对时间
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", schedule, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported schedule format: %s", schedule)
+}
+
+// everyInterval 解析 "@every 30s" 或 "@every_30s" 形式的固定间隔
+func everyInterval(schedule string) (time.Duration, bool) {
+	for _, prefix := range []string{"@every ", "@every_"} {
+		if value, ok := strings.CutPrefix(schedule, prefix); ok {
+			interval, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return 0, false
+			}
+			return interval, true
+		}
+	}
+	return 0, false
+}
+
+// isRecurringSchedule 判断是否为周期调度（固定间隔或Cron表达式）
+func isRecurringSchedule(schedule string) bool {
+	switch schedule {
+	case "@every_minute", "@every_hour", "@every_day":
+		return true
+	}
+	if _, ok := everyInterval(schedule); ok {
+		return true
 	}
+	return ValidateCronExpression(schedule) == nil
 }
 
 // loadTasksFromStorage 从存储中加载任务
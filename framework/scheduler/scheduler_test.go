@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestScheduler 创建快速检查到期任务的调度器，测试结束时停止
+func newTestScheduler(t *testing.T) *Scheduler {
+	s := NewScheduler(&SchedulerConfig{MaxWorkers: 1, TickInterval: 5 * time.Millisecond})
+	require.NoError(t, s.Start())
+	t.Cleanup(func() { _ = s.Stop() })
+	return s
+}
+
+func TestScheduler_IntervalJobFires(t *testing.T) {
+	s := newTestScheduler(t)
+
+	var runs int32
+	task := NewTask("interval", "interval", "", "@every 20ms", NewJobFunc("interval", "", func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}))
+	require.NoError(t, s.AddTask(task))
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, TaskStatusPending, task.GetStatus(), "recurring task should wait for its next run")
+}
+
+func TestScheduler_CronJobFires(t *testing.T) {
+	s := newTestScheduler(t)
+
+	fired := make(chan time.Time, 1)
+	require.NoError(t, s.AddTask(NewTask("cron", "cron", "", "* * * * * *", NewJobFunc("cron", "", func(ctx context.Context) error {
+		select {
+		case fired <- time.Now():
+		default:
+		}
+		return nil
+	}))))
+
+	select {
+	case at := <-fired:
+		// 每秒执行的Cron任务在整秒触发
+		assert.Less(t, at.Sub(at.Truncate(time.Second)), 500*time.Millisecond)
+	case <-time.After(3 * time.Second):
+		t.Fatal("cron job did not fire")
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s := newTestScheduler(t)
+
+	var running, maxRunning, runs int32
+	task := NewTask("slow", "slow", "", "@every 10ms", NewJobFunc("slow", "", func(ctx context.Context) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if current <= old || atomic.CompareAndSwapInt32(&maxRunning, old, current) {
+				break
+			}
+		}
+		atomic.AddInt32(&runs, 1)
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}))
+	require.NoError(t, s.AddTask(task))
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 2 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning), "runs must not overlap")
+	assert.Greater(t, atomic.LoadInt64(&task.SkipCount), int64(0), "due runs during execution should be skipped")
+}
+
+func TestScheduler_RecoversFromPanic(t *testing.T) {
+	s := newTestScheduler(t)
+
+	var runs int32
+	task := NewTask("panic", "panic", "", "@every 10ms", NewJobFunc("panic", "", func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		panic("boom")
+	}))
+	task.MaxRetries = 0
+	require.NoError(t, s.AddTask(task))
+
+	// panic 记为失败，周期任务继续调度
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 2 }, 2*time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, atomic.LoadInt64(&task.FailCount), int64(1))
+	assert.True(t, s.IsRunning())
+}
+
+func TestScheduler_ShutdownWaitsForRunningJobs(t *testing.T) {
+	s := NewScheduler(&SchedulerConfig{MaxWorkers: 1, TickInterval: 5 * time.Millisecond})
+	require.NoError(t, s.Start())
+
+	started := make(chan struct{})
+	var finished int32
+	require.NoError(t, s.AddTask(NewTask("drain", "drain", "", "@once", NewJobFunc("drain", "", func(ctx context.Context) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+		return nil
+	}))))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&finished), "shutdown should wait for the running job")
+	assert.False(t, s.IsRunning())
+	assert.NoError(t, s.Shutdown(ctx), "shutting down a stopped scheduler is a no-op")
+}
+
+func TestScheduler_ShutdownCancelsJobsAfterDeadline(t *testing.T) {
+	s := NewScheduler(&SchedulerConfig{MaxWorkers: 1, TickInterval: 5 * time.Millisecond})
+	require.NoError(t, s.Start())
+
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	require.NoError(t, s.AddTask(NewTask("stuck", "stuck", "", "@once", NewJobFunc("stuck", "", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	}))))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Shutdown(ctx), context.DeadlineExceeded)
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("running job was not canceled")
+	}
+}
+
+func TestNextScheduleTime(t *testing.T) {
+	from := time.Date(2024, 5, 1, 12, 0, 30, 0, time.Local)
+
+	cases := map[string]time.Time{
+		"@every 30s":          from.Add(30 * time.Second),
+		"@every_5m":           from.Add(5 * time.Minute),
+		"@every_hour":         from.Add(time.Hour),
+		"0 */15 * * * *":      time.Date(2024, 5, 1, 12, 15, 0, 0, time.Local),
+		"0 9 * * *":           time.Date(2024, 5, 2, 9, 0, 0, 0, time.Local),
+		"2024-06-01 08:00:00": time.Date(2024, 6, 1, 8, 0, 0, 0, time.Local),
+	}
+	for schedule, want := range cases {
+		got, err := nextScheduleTime(schedule, from)
+		if assert.NoError(t, err, schedule) {
+			assert.True(t, want.Equal(got), "%s: want %v, got %v", schedule, want, got)
+		}
+	}
+
+	for _, schedule := range []string{"", "@every", "@every -1s", "not a schedule", "* * *"} {
+		_, err := nextScheduleTime(schedule, from)
+		assert.Error(t, err, schedule)
+	}
+
+	assert.True(t, isRecurringSchedule("@every 1s"))
+	assert.True(t, isRecurringSchedule("*/5 * * * *"))
+	assert.False(t, isRecurringSchedule("@once"))
+	assert.False(t, isRecurringSchedule("5m"))
+}
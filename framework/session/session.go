@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"sync"
 	"time"
+
+	"github.com/zsy619/yyhertz/framework/cache"
 )
 
 // SessionData Session数据结构
@@ -239,6 +241,13 @@ func (sm *SessionManager) GetLifetime() time.Duration {
 	return sm.lifetime
 }
 
+// ClearAll 清除所有Session
+func (sm *SessionManager) ClearAll() {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.sessions = make(map[string]*SessionData)
+}
+
 // GetSessionCount 获取Session数量
 func (sm *SessionManager) GetSessionCount() int {
 	sm.mutex.RLock()
@@ -272,6 +281,11 @@ func (sm *SessionManager) gc() {
 // 全局Session管理器
 var DefaultSessionManager = NewSessionManager(30*time.Minute, "HERTZ_SESSION_ID")
 
+func init() {
+	// 登记全局Session存储，供缓存管理接口查看与清空
+	cache.Register(cache.NewInspector(cache.SessionCacheName, "会话缓存", DefaultSessionManager.GetSessionCount, DefaultSessionManager.ClearAll))
+}
+
 // 便捷函数
 func CreateSession() (string, *SessionData) {
 	return DefaultSessionManager.CreateSession()
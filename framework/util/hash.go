@@ -249,6 +249,8 @@ func Uniqid(prefix string, moreEntropy ...bool) string {
 }
 
 // PasswordHash creates a password hash
+//
+// Deprecated: this is an unsalted SHA-256 placeholder; use HashPassword (bcrypt/argon2id) instead.
 func PasswordHash(password string, algo int, options ...map[string]any) string {
 	// Simplified implementation - in production use bcrypt or similar
 	salt := "defaultsalt" // Should be random
@@ -263,6 +265,8 @@ func PasswordHash(password string, algo int, options ...map[string]any) string {
 }
 
 // PasswordVerify verifies a password against a hash
+//
+// Deprecated: use VerifyPassword, which checks bcrypt/argon2id hashes in constant time.
 func PasswordVerify(password, hash string) bool {
 	// Simplified implementation
 	return PasswordHash(password, 0) == hash
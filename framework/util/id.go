@@ -125,10 +125,12 @@ func GenerateCategoryID() CategoryID {
 	return CategoryID(GenerateID("category"))
 }
 
-// UUID 生成简单的UUID
+// UUID 生成随机UUID（版本4）
 func UUID() string {
 	b := make([]byte, 16)
 	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40 // 版本4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 变体
 	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
 }
 
@@ -0,0 +1,205 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordAlgorithm 密码哈希算法
+type PasswordAlgorithm string
+
+const (
+	// PasswordBcrypt bcrypt算法
+	PasswordBcrypt PasswordAlgorithm = "bcrypt"
+	// PasswordArgon2id argon2id算法
+	PasswordArgon2id PasswordAlgorithm = "argon2id"
+)
+
+// ErrInvalidPasswordHash 密码哈希格式无效
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// PasswordHasher 密码哈希器，支持bcrypt与argon2id
+// 哈希结果自描述算法与参数，Verify会根据哈希前缀自动选择算法
+type PasswordHasher struct {
+	Algorithm PasswordAlgorithm
+
+	// bcrypt参数
+	BcryptCost int
+
+	// argon2id参数
+	Argon2Time    uint32 // 迭代次数
+	Argon2Memory  uint32 // 内存（KiB）
+	Argon2Threads uint8  // 并行度
+	Argon2KeyLen  uint32 // 派生密钥长度
+	Argon2SaltLen uint32 // 盐长度
+}
+
+// DefaultPasswordHasher 默认密码哈希器（bcrypt，默认成本）
+func DefaultPasswordHasher() *PasswordHasher {
+	return &PasswordHasher{
+		Algorithm:     PasswordBcrypt,
+		BcryptCost:    bcrypt.DefaultCost,
+		Argon2Time:    3,
+		Argon2Memory:  64 * 1024,
+		Argon2Threads: 2,
+		Argon2KeyLen:  32,
+		Argon2SaltLen: 16,
+	}
+}
+
+// NewBcryptHasher 创建指定成本的bcrypt哈希器
+func NewBcryptHasher(cost int) *PasswordHasher {
+	h := DefaultPasswordHasher()
+	h.BcryptCost = cost
+	return h
+}
+
+// NewArgon2idHasher 创建指定参数的argon2id哈希器
+func NewArgon2idHasher(time, memory uint32, threads uint8) *PasswordHasher {
+	h := DefaultPasswordHasher()
+	h.Algorithm = PasswordArgon2id
+	h.Argon2Time = time
+	h.Argon2Memory = memory
+	h.Argon2Threads = threads
+	return h
+}
+
+// Hash 计算密码哈希
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.Algorithm {
+	case PasswordArgon2id:
+		return h.hashArgon2id(password)
+	case PasswordBcrypt, "":
+		cost := h.BcryptCost
+		if cost == 0 {
+			cost = bcrypt.DefaultCost
+		}
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return "", fmt.Errorf("bcrypt cost %d out of range [%d, %d]", cost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	default:
+		return "", fmt.Errorf("unsupported password algorithm: %s", h.Algorithm)
+	}
+}
+
+// Verify 以常量时间校验密码与哈希是否匹配
+func (h *PasswordHasher) Verify(password, encoded string) bool {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return false
+		}
+		derived := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+		return subtle.ConstantTimeCompare(derived, key) == 1
+	case strings.HasPrefix(encoded, "$2"):
+		return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+	default:
+		return false
+	}
+}
+
+// NeedsRehash 判断哈希的算法或参数是否与当前配置不一致（如提高成本后需要重新哈希）
+func (h *PasswordHasher) NeedsRehash(encoded string) bool {
+	switch h.Algorithm {
+	case PasswordArgon2id:
+		params, _, _, err := decodeArgon2id(encoded)
+		if err != nil {
+			return true
+		}
+		return params.time != h.Argon2Time || params.memory != h.Argon2Memory || params.threads != h.Argon2Threads
+	default:
+		cost, err := bcrypt.Cost([]byte(encoded))
+		if err != nil {
+			return true
+		}
+		expected := h.BcryptCost
+		if expected == 0 {
+			expected = bcrypt.DefaultCost
+		}
+		return cost != expected
+	}
+}
+
+// hashArgon2id 计算argon2id哈希，输出PHC格式：$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+func (h *PasswordHasher) hashArgon2id(password string) (string, error) {
+	saltLen := h.Argon2SaltLen
+	if saltLen == 0 {
+		saltLen = 16
+	}
+	keyLen := h.Argon2KeyLen
+	if keyLen == 0 {
+		keyLen = 32
+	}
+	if h.Argon2Time == 0 || h.Argon2Memory == 0 || h.Argon2Threads == 0 {
+		return "", errors.New("argon2id time, memory and threads must be positive")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Argon2Time, h.Argon2Memory, h.Argon2Threads, keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Argon2Memory, h.Argon2Time, h.Argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// argon2Params argon2id哈希参数
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// decodeArgon2id 解析PHC格式的argon2id哈希
+func decodeArgon2id(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	return params, salt, key, nil
+}
+
+// HashPassword 使用默认哈希器（bcrypt）计算密码哈希
+func HashPassword(password string) (string, error) {
+	return DefaultPasswordHasher().Hash(password)
+}
+
+// VerifyPassword 校验密码与哈希（自动识别bcrypt/argon2id）
+func VerifyPassword(password, hash string) bool {
+	return DefaultPasswordHasher().Verify(password, hash)
+}
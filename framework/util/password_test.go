@@ -0,0 +1,62 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher_Bcrypt(t *testing.T) {
+	hasher := NewBcryptHasher(bcrypt.MinCost + 1)
+
+	hash, err := hasher.Hash("s3cret!")
+	require.NoError(t, err)
+	assert.NotContains(t, hash, "s3cret!")
+
+	assert.True(t, hasher.Verify("s3cret!", hash))
+	assert.False(t, hasher.Verify("wrong", hash))
+
+	// 成本参数生效
+	cost, err := bcrypt.Cost([]byte(hash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, NewBcryptHasher(bcrypt.MinCost+2).NeedsRehash(hash))
+
+	_, err = NewBcryptHasher(bcrypt.MaxCost + 1).Hash("s3cret!")
+	assert.Error(t, err)
+}
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	hasher := NewArgon2idHasher(1, 8*1024, 1)
+
+	hash, err := hasher.Hash("s3cret!")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$"))
+
+	assert.True(t, hasher.Verify("s3cret!", hash))
+	assert.False(t, hasher.Verify("wrong", hash))
+
+	// 相同密码每次使用不同的盐
+	other, err := hasher.Hash("s3cret!")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, NewArgon2idHasher(2, 8*1024, 1).NeedsRehash(hash))
+}
+
+func TestVerifyPassword_DetectsAlgorithm(t *testing.T) {
+	argonHash, err := NewArgon2idHasher(1, 8*1024, 1).Hash("pass")
+	require.NoError(t, err)
+	bcryptHash, err := HashPassword("pass")
+	require.NoError(t, err)
+
+	assert.True(t, VerifyPassword("pass", argonHash))
+	assert.True(t, VerifyPassword("pass", bcryptHash))
+	assert.False(t, VerifyPassword("pass", "plain-text"))
+	assert.False(t, VerifyPassword("pass", "$argon2id$v=19$broken"))
+}